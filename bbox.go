@@ -0,0 +1,316 @@
+package geodesy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/internal/geomath"
+)
+
+// BoundingBox represents a region bounded by two parallels and two meridians, in decimal degrees.
+// The box spans eastwards from West to East, so that a box with West > East crosses the antimeridian.
+// A box spanning all longitudes has West = LonLowerBound and East = LonUpperBound
+type BoundingBox struct {
+	South, West, North, East float64
+}
+
+// expansionRadius is the radius of the sphere used to convert distances to angles when expanding
+// a bounding box. It is the smallest radius of curvature of the WGS84 ellipsoid, so that expanded
+// boxes are never smaller than the exact ones on the ellipsoid
+const expansionRadius = ellipsoids.WGS84_MERIDIAN_CURVATURE_EQUATORIAL_RADIUS
+
+// emptyBoundingBox returns a box with math.NaN() bounds, which contains no points
+func emptyBoundingBox() BoundingBox {
+	nan := math.NaN()
+	return BoundingBox{South: nan, West: nan, North: nan, East: nan}
+}
+
+// NewBoundingBox returns the smallest bounding box containing all the given points, whose
+// longitudes span the shortest interval containing all of them (which may cross the antimeridian).
+// If no points are given or any of them does not constitute a valid geographic coordinate,
+// the returned box is empty
+func NewBoundingBox(points ...Point) BoundingBox {
+	if len(points) == 0 {
+		return emptyBoundingBox()
+	}
+
+	b := BoundingBox{South: LatUpperBound, North: LatLowerBound}
+	lons := make([]float64, len(points))
+	for i, p := range points {
+		if !p.Valid() {
+			return emptyBoundingBox()
+		}
+		b.South = math.Min(b.South, p.Lat())
+		b.North = math.Max(b.North, p.Lat())
+		lons[i] = p.Lon()
+		if lons[i] == LonUpperBound {
+			lons[i] = LonLowerBound
+		}
+	}
+
+	// The shortest interval containing all longitudes is the complement of the largest gap between them
+	sort.Float64s(lons)
+	gap := lons[0] + 360 - lons[len(lons)-1]
+	b.West, b.East = lons[0], lons[len(lons)-1]
+	for i := 1; i < len(lons); i++ {
+		if d := lons[i] - lons[i-1]; d > gap {
+			gap = d
+			b.West, b.East = lons[i], lons[i-1]
+		}
+	}
+
+	return b
+}
+
+// IsEmpty returns whether b contains no points, as is the case for the intersection of disjoint boxes
+func (b BoundingBox) IsEmpty() bool {
+	return !(b.South <= b.North) || math.IsNaN(b.West) || math.IsNaN(b.East)
+}
+
+// Valid returns whether b is a non-empty box whose bounds are contained within the valid range of
+// geographic coordinates
+func (b BoundingBox) Valid() bool {
+	return !b.IsEmpty() &&
+		Point{b.South, b.West}.Valid() && Point{b.North, b.East}.Valid()
+}
+
+// CrossesAntimeridian returns whether b spans across the ±180° meridian
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.West > b.East
+}
+
+// Width returns the longitudinal extent of b, in degrees within [0, 360]
+func (b BoundingBox) Width() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+
+	return lonSpan(b.West, b.East)
+}
+
+// Height returns the latitudinal extent of b, in degrees within [0, 180]
+func (b BoundingBox) Height() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+
+	return b.North - b.South
+}
+
+// Contains returns whether p lies within b, including its boundary
+func (b BoundingBox) Contains(p Point) bool {
+	if b.IsEmpty() || !p.Valid() {
+		return false
+	}
+
+	return p.Lat() >= b.South && p.Lat() <= b.North && lonContains(b.West, b.East, p.Lon())
+}
+
+// ContainsBox returns whether o lies entirely within b
+func (b BoundingBox) ContainsBox(o BoundingBox) bool {
+	if b.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+
+	return o.South >= b.South && o.North <= b.North && lonContainsInterval(b.West, b.East, o.West, o.East)
+}
+
+// Intersects returns whether b and o have at least one point in common
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	return !b.Intersection(o).IsEmpty()
+}
+
+// Union returns the smallest bounding box containing both b and o
+func (b BoundingBox) Union(o BoundingBox) BoundingBox {
+	switch {
+	case b.IsEmpty():
+		return o
+	case o.IsEmpty():
+		return b
+	}
+
+	u := BoundingBox{South: math.Min(b.South, o.South), North: math.Max(b.North, o.North)}
+	switch {
+	case lonContainsInterval(b.West, b.East, o.West, o.East):
+		u.West, u.East = b.West, b.East
+	case lonContainsInterval(o.West, o.East, b.West, b.East):
+		u.West, u.East = o.West, o.East
+	default:
+		// Either interval may be extended eastwards to reach the other one, or both overlap at each
+		// end so that together they cover all longitudes
+		ok1 := lonContainsInterval(b.West, o.East, o.West, o.East) && lonContainsInterval(b.West, o.East, b.West, b.East)
+		ok2 := lonContainsInterval(o.West, b.East, b.West, b.East) && lonContainsInterval(o.West, b.East, o.West, o.East)
+		switch {
+		case ok1 && (!ok2 || lonSpan(b.West, o.East) <= lonSpan(o.West, b.East)):
+			u.West, u.East = b.West, o.East
+		case ok2:
+			u.West, u.East = o.West, b.East
+		default:
+			u.West, u.East = LonLowerBound, LonUpperBound
+		}
+	}
+
+	return u
+}
+
+// Intersection returns the intersection of b and o, which is empty if they are disjoint.
+// When the longitudes of b and o overlap at both ends (which requires their widths to add up to
+// more than 360°) their intersection consists of two boxes, and the narrower of b and o is returned
+func (b BoundingBox) Intersection(o BoundingBox) BoundingBox {
+	if b.IsEmpty() || o.IsEmpty() {
+		return emptyBoundingBox()
+	}
+
+	i := BoundingBox{South: math.Max(b.South, o.South), North: math.Min(b.North, o.North)}
+	if i.South > i.North {
+		return emptyBoundingBox()
+	}
+
+	switch {
+	case lonContains(b.West, b.East, o.West):
+		if lonContains(b.West, b.East, o.East) {
+			if lonSpan(o.West, o.East) <= lonSpan(b.West, b.East) {
+				i.West, i.East = o.West, o.East
+			} else {
+				i.West, i.East = b.West, b.East
+			}
+		} else {
+			i.West, i.East = o.West, b.East
+		}
+	case lonContains(o.West, o.East, b.West):
+		if lonContains(o.West, o.East, b.East) {
+			i.West, i.East = b.West, b.East
+		} else {
+			i.West, i.East = b.West, o.East
+		}
+	default:
+		return emptyBoundingBox()
+	}
+
+	return i
+}
+
+// Expand returns a box containing every point within a distance d (in meters) of b.
+// Distances are converted to angles on a sphere whose radius is the smallest radius of curvature
+// of the WGS84 ellipsoid, so that the returned box may be up to 1% larger than the exact one.
+// The longitudinal expansion accounts for the convergence of the meridians: it grows with the
+// latitude, and the box spans all longitudes once it reaches a pole.
+// If d is negative or b is empty, the returned box is empty
+func (b BoundingBox) Expand(d float64) BoundingBox {
+	if b.IsEmpty() || !(d >= 0) {
+		return emptyBoundingBox()
+	}
+
+	r := d / expansionRadius
+	if r >= math.Pi {
+		return BoundingBox{South: LatLowerBound, West: LonLowerBound, North: LatUpperBound, East: LonUpperBound}
+	}
+
+	e := BoundingBox{
+		South: math.Max(b.South-r*180/math.Pi, LatLowerBound),
+		North: math.Min(b.North+r*180/math.Pi, LatUpperBound),
+	}
+
+	// The longitudinal extent of a spherical cap of radius r centered at latitude φ is
+	// ±asin(sin r / cos φ), which is largest at the latitude of b closest to a pole
+	φ := math.Max(math.Abs(b.South), math.Abs(b.North)) * math.Pi / 180
+	sinr := math.Sin(r)
+	if r >= math.Pi/2 || e.South == LatLowerBound || e.North == LatUpperBound || sinr >= math.Cos(φ) {
+		e.West, e.East = LonLowerBound, LonUpperBound
+		return e
+	}
+	dλ := math.Asin(sinr/math.Cos(φ)) * 180 / math.Pi
+	if lonSpan(b.West, b.East)+2*dλ >= 360 {
+		e.West, e.East = LonLowerBound, LonUpperBound
+		return e
+	}
+	e.West, e.East = geomath.NormalizeLon(b.West-dλ), geomath.NormalizeLon(b.East+dλ)
+
+	return e
+}
+
+// Polygon returns the vertices of b in counter-clockwise order, for use with the polygon package.
+// As polygon edges are geodesics, intermediate vertices are inserted along the parallels so that
+// no edge spans more than 90° of longitude, and bounds at a pole are collapsed into a single vertex.
+// Boxes spanning all longitudes are returned as a single ring when they reach a pole, and they
+// cannot be represented as a polygon otherwise, in which case (as for empty boxes) nil is returned
+func (b BoundingBox) Polygon() []Point {
+	if b.IsEmpty() {
+		return nil
+	}
+
+	width := lonSpan(b.West, b.East)
+	if width == 360 {
+		switch {
+		case b.North == LatUpperBound && b.South == LatLowerBound:
+			return nil
+		case b.North == LatUpperBound:
+			return parallel(b.South, LonLowerBound, 360)[:4]
+		case b.South == LatLowerBound:
+			return parallel(b.North, LonLowerBound, -360)[:4]
+		}
+		return nil
+	}
+
+	var vertices []Point
+	if b.South == LatLowerBound {
+		vertices = append(vertices, Point{LatLowerBound, b.West})
+	} else {
+		vertices = append(vertices, parallel(b.South, b.West, width)...)
+	}
+	if b.North == LatUpperBound {
+		vertices = append(vertices, Point{LatUpperBound, b.East})
+	} else {
+		vertices = append(vertices, parallel(b.North, b.East, -width)...)
+	}
+
+	return vertices
+}
+
+// parallel returns the points along the parallel at latitude lat from longitude lon to lon+span,
+// spaced by no more than 90° of longitude
+func parallel(lat, lon, span float64) []Point {
+	n := int(math.Ceil(math.Abs(span) / 90))
+	if n == 0 {
+		n = 1
+	}
+	points := make([]Point, n+1)
+	for i := range points {
+		points[i] = Point{lat, geomath.NormalizeLon(lon + span*float64(i)/float64(n))}
+	}
+
+	return points
+}
+
+// lonSpan returns the length of the longitude interval from west eastwards to east, in degrees.
+// An interval between -180° and 180° spans all longitudes
+func lonSpan(west, east float64) float64 {
+	d := lonOffset(west, east)
+	if d == 0 && west != east {
+		return 360
+	}
+
+	return d
+}
+
+// lonOffset returns the eastward angle from longitude from to longitude to, in degrees within [0, 360)
+func lonOffset(from, to float64) float64 {
+	return math.Mod(math.Mod(to-from, 360)+360, 360)
+}
+
+// lonContains returns whether the longitude interval from west to east contains lon
+func lonContains(west, east, lon float64) bool {
+	return lonOffset(west, lon) <= lonSpan(west, east)
+}
+
+// lonContainsInterval returns whether the longitude interval from west to east contains
+// the interval from w to e
+func lonContainsInterval(west, east, w, e float64) bool {
+	span := lonSpan(west, east)
+	if span == 360 {
+		return true
+	}
+
+	return lonOffset(west, w)+lonSpan(w, e) <= span
+}
@@ -0,0 +1,297 @@
+package geodesy_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []geodesy.Point
+		expected geodesy.BoundingBox
+		empty    bool
+	}{
+		{
+			name:     "OK/single_point",
+			points:   []geodesy.Point{{10, 20}},
+			expected: geodesy.BoundingBox{South: 10, West: 20, North: 10, East: 20},
+		},
+		{
+			name:     "OK/regular",
+			points:   []geodesy.Point{{-34.6, -58.4}, {40.4, -3.7}, {51.5, -0.1}},
+			expected: geodesy.BoundingBox{South: -34.6, West: -58.4, North: 51.5, East: -0.1},
+		},
+		{
+			name:     "OK/antimeridian",
+			points:   []geodesy.Point{{-41.3, 174.8}, {21.3, -157.9}, {-17.7, 178.4}},
+			expected: geodesy.BoundingBox{South: -41.3, West: 174.8, North: 21.3, East: -157.9},
+		},
+		{
+			name:     "OK/antimeridian_180",
+			points:   []geodesy.Point{{0, 180}, {0, -170}},
+			expected: geodesy.BoundingBox{South: 0, West: -180, North: 0, East: -170},
+		},
+		{
+			name:   "FAIL/no_points",
+			points: nil,
+			empty:  true,
+		},
+		{
+			name:   "FAIL/invalid_point",
+			points: []geodesy.Point{{0, 0}, {91, 0}},
+			empty:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := geodesy.NewBoundingBox(tt.points...)
+			if tt.empty {
+				assert.True(t, b.IsEmpty())
+				assert.False(t, b.Valid())
+				return
+			}
+			assert.Equal(t, tt.expected, b)
+			assert.True(t, b.Valid())
+			for _, p := range tt.points {
+				assert.True(t, b.Contains(p), p)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	antimeridian := geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170}
+	assert.True(t, antimeridian.CrossesAntimeridian())
+	assert.Equal(t, 20.0, antimeridian.Width())
+	assert.Equal(t, 20.0, antimeridian.Height())
+	assert.True(t, antimeridian.Contains(geodesy.Point{0, 180}))
+	assert.True(t, antimeridian.Contains(geodesy.Point{0, -180}))
+	assert.True(t, antimeridian.Contains(geodesy.Point{10, 175}))
+	assert.True(t, antimeridian.Contains(geodesy.Point{-5, -170}))
+	assert.False(t, antimeridian.Contains(geodesy.Point{0, 0}))
+	assert.False(t, antimeridian.Contains(geodesy.Point{11, 180}))
+
+	west := geodesy.BoundingBox{South: -10, West: -180, North: 10, East: -170}
+	assert.False(t, west.CrossesAntimeridian())
+	assert.True(t, west.Contains(geodesy.Point{0, 180}))
+
+	world := geodesy.BoundingBox{South: -90, West: -180, North: 90, East: 180}
+	assert.Equal(t, 360.0, world.Width())
+	assert.True(t, world.Contains(geodesy.Point{12, 34}))
+	assert.True(t, world.ContainsBox(antimeridian))
+	assert.False(t, antimeridian.ContainsBox(world))
+
+	assert.True(t, antimeridian.ContainsBox(geodesy.BoundingBox{South: 0, West: 175, North: 5, East: -175}))
+	assert.True(t, antimeridian.ContainsBox(geodesy.BoundingBox{South: 0, West: -180, North: 5, East: -175}))
+	assert.False(t, antimeridian.ContainsBox(geodesy.BoundingBox{South: 0, West: -175, North: 5, East: 175}))
+}
+
+func TestBoundingBoxUnion(t *testing.T) {
+	tests := []struct {
+		name     string
+		b, o     geodesy.BoundingBox
+		expected geodesy.BoundingBox
+	}{
+		{
+			name:     "OK/disjoint",
+			b:        geodesy.BoundingBox{South: 0, West: 0, North: 10, East: 10},
+			o:        geodesy.BoundingBox{South: -5, West: 20, North: 5, East: 30},
+			expected: geodesy.BoundingBox{South: -5, West: 0, North: 10, East: 30},
+		},
+		{
+			name:     "OK/disjoint_across_antimeridian",
+			b:        geodesy.BoundingBox{South: 0, West: 160, North: 10, East: 170},
+			o:        geodesy.BoundingBox{South: 0, West: -170, North: 10, East: -160},
+			expected: geodesy.BoundingBox{South: 0, West: 160, North: 10, East: -160},
+		},
+		{
+			name:     "OK/overlapping",
+			b:        geodesy.BoundingBox{South: 0, West: 170, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 0, West: -175, North: 10, East: -100},
+			expected: geodesy.BoundingBox{South: 0, West: 170, North: 10, East: -100},
+		},
+		{
+			name:     "OK/contained",
+			b:        geodesy.BoundingBox{South: 0, West: 170, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 1, West: 175, North: 2, East: 179},
+			expected: geodesy.BoundingBox{South: 0, West: 170, North: 10, East: -170},
+		},
+		{
+			name:     "OK/covering_all_longitudes",
+			b:        geodesy.BoundingBox{South: 0, West: 0, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 0, West: 180, North: 10, East: 10},
+			expected: geodesy.BoundingBox{South: 0, West: -180, North: 10, East: 180},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.b.Union(tt.o))
+			assert.Equal(t, tt.expected, tt.o.Union(tt.b))
+		})
+	}
+
+	b := geodesy.BoundingBox{South: 0, West: 0, North: 10, East: 10}
+	assert.Equal(t, b, b.Union(geodesy.NewBoundingBox()))
+	assert.Equal(t, b, geodesy.NewBoundingBox().Union(b))
+}
+
+func TestBoundingBoxIntersection(t *testing.T) {
+	tests := []struct {
+		name     string
+		b, o     geodesy.BoundingBox
+		expected geodesy.BoundingBox
+		empty    bool
+	}{
+		{
+			name:     "OK/overlapping",
+			b:        geodesy.BoundingBox{South: 0, West: 0, North: 10, East: 10},
+			o:        geodesy.BoundingBox{South: 5, West: 5, North: 15, East: 15},
+			expected: geodesy.BoundingBox{South: 5, West: 5, North: 10, East: 10},
+		},
+		{
+			name:     "OK/across_antimeridian",
+			b:        geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 0, West: -175, North: 20, East: -100},
+			expected: geodesy.BoundingBox{South: 0, West: -175, North: 10, East: -170},
+		},
+		{
+			name:     "OK/contained",
+			b:        geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 0, West: 175, North: 5, East: -175},
+			expected: geodesy.BoundingBox{South: 0, West: 175, North: 5, East: -175},
+		},
+		{
+			name:     "OK/two_pieces",
+			b:        geodesy.BoundingBox{South: 0, West: 0, North: 10, East: -170},
+			o:        geodesy.BoundingBox{South: 0, West: 180, North: 10, East: 20},
+			expected: geodesy.BoundingBox{South: 0, West: 0, North: 10, East: -170},
+		},
+		{
+			name:  "FAIL/disjoint_longitudes",
+			b:     geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170},
+			o:     geodesy.BoundingBox{South: -10, West: -160, North: 10, East: 160},
+			empty: true,
+		},
+		{
+			name:  "FAIL/disjoint_latitudes",
+			b:     geodesy.BoundingBox{South: -10, West: 0, North: 10, East: 10},
+			o:     geodesy.BoundingBox{South: 11, West: 0, North: 20, East: 10},
+			empty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, i := range []geodesy.BoundingBox{tt.b.Intersection(tt.o), tt.o.Intersection(tt.b)} {
+				if tt.empty {
+					assert.True(t, i.IsEmpty())
+				} else {
+					assert.Equal(t, tt.expected, i)
+				}
+			}
+			assert.Equal(t, !tt.empty, tt.b.Intersects(tt.o))
+		})
+	}
+}
+
+func TestBoundingBoxExpand(t *testing.T) {
+	tests := []struct {
+		name string
+		b    geodesy.BoundingBox
+		d    float64
+	}{
+		{name: "OK/equator", b: geodesy.BoundingBox{South: -1, West: -1, North: 1, East: 1}, d: 100_000},
+		{name: "OK/high_latitude", b: geodesy.BoundingBox{South: 70, West: 10, North: 80, East: 20}, d: 50_000},
+		{name: "OK/antimeridian", b: geodesy.BoundingBox{South: -50, West: 179, North: -45, East: -179}, d: 200_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.b.Expand(tt.d)
+			assert.True(t, e.Valid())
+			assert.True(t, e.ContainsBox(tt.b))
+
+			// Points at distance d from the corners of the box lie within the expanded box, which
+			// is not much larger than needed along the meridians
+			for _, c := range []geodesy.Point{{tt.b.South, tt.b.West}, {tt.b.South, tt.b.East}, {tt.b.North, tt.b.West}, {tt.b.North, tt.b.East}} {
+				for azi := -180.0; azi < 180; azi += 15 {
+					p := geodesic.WGS84.Direct(c, azi, tt.d).P2
+					assert.True(t, e.Contains(p), p)
+				}
+			}
+			south := geodesic.WGS84.Direct(geodesy.Point{tt.b.South, tt.b.West}, 180, 1.02*tt.d).P2
+			assert.False(t, e.Contains(south), south)
+			north := geodesic.WGS84.Direct(geodesy.Point{tt.b.North, tt.b.West}, 0, 1.02*tt.d).P2
+			assert.False(t, e.Contains(north), north)
+		})
+	}
+
+	polar := geodesy.BoundingBox{South: 85, West: 0, North: 89, East: 10}.Expand(200_000)
+	assert.Equal(t, 90.0, polar.North)
+	assert.Equal(t, 360.0, polar.Width())
+
+	// The longitudinal expansion at 80° is about 1/cos(80°) times the one at the equator
+	equator := geodesy.BoundingBox{South: 0, West: 0, North: 0, East: 0}.Expand(10_000)
+	north := geodesy.BoundingBox{South: 80, West: 0, North: 80, East: 0}.Expand(10_000)
+	assert.InDelta(t, equator.East/0.17364817766693033, north.East, 1e-4)
+
+	assert.True(t, polar.Expand(-1).IsEmpty())
+	assert.True(t, geodesy.NewBoundingBox().Expand(1).IsEmpty())
+}
+
+func TestBoundingBoxPolygon(t *testing.T) {
+	tests := []struct {
+		name     string
+		b        geodesy.BoundingBox
+		expected []geodesy.Point
+	}{
+		{
+			name:     "OK/regular",
+			b:        geodesy.BoundingBox{South: 0, West: 0, North: 10, East: 10},
+			expected: []geodesy.Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+		},
+		{
+			name:     "OK/antimeridian",
+			b:        geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170},
+			expected: []geodesy.Point{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}},
+		},
+		{
+			name: "OK/wide",
+			b:    geodesy.BoundingBox{South: 0, West: -80, North: 10, East: 80},
+			expected: []geodesy.Point{
+				{0, -80}, {0, 0}, {0, 80},
+				{10, 80}, {10, 0}, {10, -80},
+			},
+		},
+		{
+			name:     "OK/north_pole",
+			b:        geodesy.BoundingBox{South: 80, West: 0, North: 90, East: 10},
+			expected: []geodesy.Point{{80, 0}, {80, 10}, {90, 10}},
+		},
+		{
+			name:     "OK/north_cap",
+			b:        geodesy.BoundingBox{South: 80, West: -180, North: 90, East: 180},
+			expected: []geodesy.Point{{80, -180}, {80, -90}, {80, 0}, {80, 90}},
+		},
+		{
+			name:     "OK/south_cap",
+			b:        geodesy.BoundingBox{South: -90, West: -180, North: -80, East: 180},
+			expected: []geodesy.Point{{-80, -180}, {-80, 90}, {-80, 0}, {-80, -90}},
+		},
+		{
+			name: "FAIL/band",
+			b:    geodesy.BoundingBox{South: -10, West: -180, North: 10, East: 180},
+		},
+		{
+			name: "FAIL/empty",
+			b:    geodesy.NewBoundingBox(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.b.Polygon())
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Package cellgrid implements a hierarchical discrete global grid of quadrilateral cells, laid out as
+// in the S2 geometry library: the sphere is projected onto the 6 faces of a cube, and each face is
+// recursively divided into 4 cells down to level 30, whose cells measure about 1 cm. Cells are
+// numbered along a Hilbert curve, so that 64-bit cell IDs which are close tend to belong to nearby
+// cells, and the IDs of the descendants of a cell span a contiguous range.
+//
+// Points are placed on the sphere by their latitude and longitude, as in S2
+package cellgrid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+// ErrSyntax is returned when a string is not a cell token
+var ErrSyntax = errors.New("cellgrid: invalid token")
+
+const (
+	// MaxLevel is the level of the smallest cells, which are leaves of the hierarchy
+	MaxLevel = 30
+
+	numFaces = 6
+	faceBits = 3
+	posBits  = 2*MaxLevel + 1
+	// maxSize is the amount of leaf cells along the side of a face
+	maxSize = 1 << MaxLevel
+
+	// swapMask and invertMask are the bits of the orientations of the Hilbert curve, which swap the
+	// i and j axes and invert the direction of its traversal respectively
+	swapMask   = 1
+	invertMask = 2
+)
+
+var (
+	// ijToPos holds the position along the Hilbert curve of each quadrant (i<<1 | j), by orientation
+	ijToPos = [4][4]uint64{
+		{0, 1, 3, 2},
+		{0, 3, 1, 2},
+		{2, 3, 1, 0},
+		{2, 1, 3, 0},
+	}
+	// posToIJ holds the quadrant (i<<1 | j) at each position along the Hilbert curve, by orientation
+	posToIJ = [4][4]int{
+		{0, 1, 3, 2},
+		{0, 2, 3, 1},
+		{3, 2, 0, 1},
+		{3, 1, 0, 2},
+	}
+	// posToOrientation holds the change of orientation of the Hilbert curve in each position
+	posToOrientation = [4]int{swapMask, 0, 0, invertMask | swapMask}
+)
+
+// CellID identifies a cell of the grid. Its 3 most significant bits hold the face of the cell and the
+// rest its position along the Hilbert curve of the face: 2 bits per level below the face, followed by
+// a set bit and as many zeros as needed to fill the remaining levels. The zero CellID is invalid
+type CellID uint64
+
+// FromFace returns the cell of the given face, from 0 to 5. Faces 0, 1 and 2 are centered on the
+// positive x, y and z axes (0°E, 90°E and the north pole), and faces 3, 4 and 5 on the negative ones
+func FromFace(face int) CellID {
+	return CellID(uint64(face)<<posBits | lsbForLevel(0))
+}
+
+// FromPoint returns the cell of the given level, from 0 to MaxLevel, containing p. If p is invalid
+// or level is out of range, the returned CellID is invalid
+func FromPoint(p geodesy.Point, level int) CellID {
+	if !p.Valid() || level < 0 || level > MaxLevel {
+		return 0
+	}
+	face, u, v := xyzToFaceUV(sphere.FromPoint(p))
+
+	return fromFaceIJ(face, stToIJ(uvToST(u)), stToIJ(uvToST(v))).Parent(level)
+}
+
+// FromToken returns the cell of a token returned by Token, which is case-insensitive
+func FromToken(token string) (CellID, error) {
+	if len(token) == 0 || len(token) > 16 {
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, token)
+	}
+	id, err := strconv.ParseUint(token, 16, 64)
+	if err != nil || token[0] == '+' {
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, token)
+	}
+	c := CellID(id << (4 * uint(16-len(token))))
+	if !c.Valid() {
+		return 0, fmt.Errorf("%w: %q is not a cell", ErrSyntax, token)
+	}
+
+	return c, nil
+}
+
+// fromFaceIJ returns the leaf cell of the given face at coordinates i and j, from 0 to maxSize-1
+func fromFaceIJ(face, i, j int) CellID {
+	n := uint64(face) << (posBits - 1)
+	orientation := face & swapMask
+	for k := MaxLevel - 1; k >= 0; k-- {
+		ij := (i>>uint(k)&1)<<1 | j>>uint(k)&1
+		pos := ijToPos[orientation][ij]
+		n |= pos << uint(2*k)
+		orientation ^= posToOrientation[pos]
+	}
+
+	return CellID(n<<1 | 1)
+}
+
+// fromFaceIJWrap is like fromFaceIJ, but coordinates i and j may lie just beyond the face, in which
+// case the returned leaf cell is the one of the adjacent face
+func fromFaceIJWrap(face, i, j int) CellID {
+	i = clampInt(i, -1, maxSize)
+	j = clampInt(j, -1, maxSize)
+
+	// Coordinates beyond the face are projected linearly onto its plane, which is exact along the
+	// edges of the face, and then onto the face of the resulting point
+	const scale = 1.0 / maxSize
+	limit := math.Nextafter(1, 2)
+	u := math.Max(-limit, math.Min(limit, scale*float64(2*(i-maxSize/2)+1)))
+	v := math.Max(-limit, math.Min(limit, scale*float64(2*(j-maxSize/2)+1)))
+	face, u, v = xyzToFaceUV(faceUVToXYZ(face, u, v))
+
+	return fromFaceIJ(face, stToIJ(0.5*(u+1)), stToIJ(0.5*(v+1)))
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func lsbForLevel(level int) uint64 {
+	return 1 << uint(2*(MaxLevel-level))
+}
+
+func (c CellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// Valid returns whether c identifies a cell
+func (c CellID) Valid() bool {
+	return c.Face() < numFaces && c.lsb()&0x1555555555555555 != 0
+}
+
+// Face returns the face of c, from 0 to 5
+func (c CellID) Face() int {
+	return int(uint64(c) >> posBits)
+}
+
+// Level returns the level of c, from 0 for faces to MaxLevel for leaves
+func (c CellID) Level() int {
+	return MaxLevel - bits.TrailingZeros64(uint64(c))/2
+}
+
+// IsLeaf returns whether c is a cell of MaxLevel
+func (c CellID) IsLeaf() bool {
+	return uint64(c)&1 != 0
+}
+
+// Parent returns the cell of the given level containing c, which must not be greater than the level
+// of c
+func (c CellID) Parent(level int) CellID {
+	lsb := lsbForLevel(level)
+	return CellID(uint64(c)&-lsb | lsb)
+}
+
+// Children returns the 4 cells of the next level contained by c, in Hilbert curve order. c must not
+// be a leaf
+func (c CellID) Children() [4]CellID {
+	lsb := c.lsb() >> 2
+	first := uint64(c) - c.lsb() + lsb
+
+	var children [4]CellID
+	for k := range children {
+		children[k] = CellID(first + uint64(k)*lsb<<1)
+	}
+
+	return children
+}
+
+// RangeMin returns the first leaf cell contained by c
+func (c CellID) RangeMin() CellID {
+	return CellID(uint64(c) - (c.lsb() - 1))
+}
+
+// RangeMax returns the last leaf cell contained by c
+func (c CellID) RangeMax() CellID {
+	return CellID(uint64(c) + (c.lsb() - 1))
+}
+
+// Contains returns whether o is c or one of its descendants
+func (c CellID) Contains(o CellID) bool {
+	return c.RangeMin() <= o && o <= c.RangeMax()
+}
+
+// Intersects returns whether c and o share any leaf cell, that is, whether either contains the other
+func (c CellID) Intersects(o CellID) bool {
+	return o.RangeMin() <= c.RangeMax() && o.RangeMax() >= c.RangeMin()
+}
+
+// Next returns the following cell of the same level along the Hilbert curve, which may wrap to the
+// next face. The successor of the last cell of face 5 is invalid
+func (c CellID) Next() CellID {
+	return CellID(uint64(c) + c.lsb()<<1)
+}
+
+// Prev returns the preceding cell of the same level along the Hilbert curve, which may wrap to the
+// previous face. The predecessor of the first cell of face 0 is invalid
+func (c CellID) Prev() CellID {
+	return CellID(uint64(c) - c.lsb()<<1)
+}
+
+// faceIJ returns the face of c, and the coordinates i and j of its leaf cell of lowest coordinates
+func (c CellID) faceIJ() (face, i, j int) {
+	face = c.Face()
+	orientation := face & swapMask
+	for k := MaxLevel - 1; k >= MaxLevel-c.Level(); k-- {
+		pos := uint64(c) >> uint(2*k+1) & 3
+		ij := posToIJ[orientation][pos]
+		i |= ij >> 1 << uint(k)
+		j |= ij & 1 << uint(k)
+		orientation ^= posToOrientation[pos]
+	}
+
+	return face, i, j
+}
+
+// EdgeNeighbors returns the 4 cells of the same level sharing an edge with c, which are the ones
+// below, to the right, above and to the left of it in the (u, v) coordinates of its face. Neighbors
+// across the edges of a face belong to the adjacent face
+func (c CellID) EdgeNeighbors() [4]CellID {
+	level := c.Level()
+	size := 1 << uint(MaxLevel-level)
+	face, i, j := c.faceIJ()
+
+	return [4]CellID{
+		fromFaceIJWrap(face, i, j-size).Parent(level),
+		fromFaceIJWrap(face, i+size, j).Parent(level),
+		fromFaceIJWrap(face, i, j+size).Parent(level),
+		fromFaceIJWrap(face, i-size, j).Parent(level),
+	}
+}
+
+// bounds returns the face of c and the bounds of its (u, v) coordinates
+func (c CellID) bounds() (face int, u0, u1, v0, v1 float64) {
+	face, i, j := c.faceIJ()
+	size := 1 << uint(MaxLevel-c.Level())
+
+	return face, stToUV(ijToST(i)), stToUV(ijToST(i + size)), stToUV(ijToST(j)), stToUV(ijToST(j + size))
+}
+
+// vertices returns the unit vectors of the vertices of c, counter-clockwise
+func (c CellID) vertices() [4]sphere.Vector {
+	face, u0, u1, v0, v1 := c.bounds()
+
+	return [4]sphere.Vector{
+		faceUVToXYZ(face, u0, v0).Normalize(),
+		faceUVToXYZ(face, u1, v0).Normalize(),
+		faceUVToXYZ(face, u1, v1).Normalize(),
+		faceUVToXYZ(face, u0, v1).Normalize(),
+	}
+}
+
+// Vertices returns the vertices of c in counter-clockwise order. The edges of cells are great circle
+// arcs
+func (c CellID) Vertices() [4]geodesy.Point {
+	var points [4]geodesy.Point
+	for k, v := range c.vertices() {
+		points[k] = v.Point()
+	}
+
+	return points
+}
+
+// Center returns the center of c, as the point at the center of its coordinates on the cube face
+func (c CellID) Center() geodesy.Point {
+	face, i, j := c.faceIJ()
+	size := 1 << uint(MaxLevel-c.Level())
+	s := ijToST(2*i+size) / 2
+	t := ijToST(2*j+size) / 2
+
+	return faceUVToXYZ(face, stToUV(s), stToUV(t)).Normalize().Point()
+}
+
+// Area returns the area of c in square meters, on the sphere with the authalic radius of the WGS84
+// ellipsoid, whose area equals that of the ellipsoid
+func (c CellID) Area() float64 {
+	vs := c.vertices()
+	r := ellipsoids.WGS84_AUTHALIC_MEAN_RADIUS
+
+	return (triangleArea(vs[0], vs[1], vs[2]) + triangleArea(vs[0], vs[2], vs[3])) * r * r
+}
+
+// triangleArea returns the area of the spherical triangle abc on the unit sphere.
+// See Van Oosterom and Strackee (1983), "The Solid Angle of a Plane Triangle"
+func triangleArea(a, b, c sphere.Vector) float64 {
+	return 2 * math.Atan2(math.Abs(a.Dot(b.Cross(c))), 1+a.Dot(b)+b.Dot(c)+c.Dot(a))
+}
+
+// Bounds returns a bounding box containing c, which spans all longitudes if c contains a pole
+func (c CellID) Bounds() geodesy.BoundingBox {
+	vs := c.vertices()
+	points := c.Vertices()
+	b := geodesy.NewBoundingBox(points[:]...)
+
+	// Edges bulge towards the poles, and their latitudes may exceed the ones of their vertices
+	for k := range vs {
+		a, e := vs[k], vs[(k+1)%4]
+		n := a.Cross(e)
+		pole := sphere.Vector{0, 0, 1}
+		m := pole.Sub(n.Scale(pole.Dot(n) / n.Dot(n)))
+		if m.Norm() == 0 {
+			continue
+		}
+		m = m.Normalize()
+		for _, extreme := range []sphere.Vector{m, m.Scale(-1)} {
+			if a.Cross(extreme).Dot(n) > 0 && extreme.Cross(e).Dot(n) > 0 {
+				φ := extreme.Point().Lat()
+				b.South, b.North = math.Min(b.South, φ), math.Max(b.North, φ)
+			}
+		}
+	}
+
+	face, u0, u1, v0, v1 := c.bounds()
+	if (face == 2 || face == 5) && u0 <= 0 && 0 <= u1 && v0 <= 0 && 0 <= v1 {
+		b.West, b.East = geodesy.LonLowerBound, geodesy.LonUpperBound
+		if face == 2 {
+			b.North = geodesy.LatUpperBound
+		} else {
+			b.South = geodesy.LatLowerBound
+		}
+	}
+
+	return b
+}
+
+// Token returns a compact representation of c as the hexadecimal digits of its ID, without trailing
+// zeros
+func (c CellID) Token() string {
+	if c == 0 {
+		return "X"
+	}
+	s := fmt.Sprintf("%016x", uint64(c))
+
+	return strings.TrimRight(s, "0")
+}
+
+// String returns the face of c followed by the positions of its ancestors along the Hilbert curve,
+// such as "3/0312"
+func (c CellID) String() string {
+	if !c.Valid() {
+		return "Invalid: " + strconv.FormatUint(uint64(c), 16)
+	}
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(c.Face()) + "/")
+	for level := 1; level <= c.Level(); level++ {
+		b.WriteByte(byte('0' + uint64(c)>>uint(posBits-2*level)&3))
+	}
+
+	return b.String()
+}
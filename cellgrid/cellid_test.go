@@ -0,0 +1,232 @@
+package cellgrid_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/cellgrid"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+var samples = []geodesy.Point{
+	{0, 0}, {51.5074, -0.1278}, {-33.8688, 151.2093}, {89.9, 10}, {-89.9, -170}, {0.5, 179.9}, {35.6762, 139.6503},
+	{-45, 45}, {45, -135}, {12.34, -98.76},
+}
+
+func TestFromFace(t *testing.T) {
+	for face := 0; face < 6; face++ {
+		c := cellgrid.FromFace(face)
+		assert.True(t, c.Valid())
+		assert.Equal(t, face, c.Face())
+		assert.Equal(t, 0, c.Level())
+		assert.Equal(t, cellgrid.CellID(uint64(face)<<61|1<<60), c)
+	}
+	assert.False(t, cellgrid.FromFace(6).Valid())
+	assert.False(t, cellgrid.CellID(0).Valid())
+
+	centers := []geodesy.Point{{0, 0}, {0, 90}, {90, 0}, {0, 180}, {0, -90}, {-90, 0}}
+	for face, want := range centers {
+		got := cellgrid.FromFace(face).Center()
+		assert.InDelta(t, want.Lat(), got.Lat(), 1e-12)
+		if math.Abs(want.Lat()) != 90 {
+			assert.InDelta(t, 0, math.Remainder(want.Lon()-got.Lon(), 360), 1e-12)
+		}
+	}
+}
+
+func TestFromPoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     geodesy.Point
+		level int
+		want  cellgrid.CellID
+	}{
+		{name: "OK/face", p: geodesy.Point{0, 0}, level: 0, want: 0x1000000000000000},
+		{name: "OK/first_level", p: geodesy.Point{0, 0}, level: 1, want: 0x1400000000000000},
+		{name: "OK/north_pole_face", p: geodesy.Point{89, 0}, level: 0, want: 0x5000000000000000},
+		{name: "FAIL/invalid_point", p: geodesy.Point{91, 0}, level: 10, want: 0},
+		{name: "FAIL/level_out_of_range", p: geodesy.Point{0, 0}, level: 31, want: 0},
+		{name: "FAIL/negative_level", p: geodesy.Point{0, 0}, level: -1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cellgrid.FromPoint(tt.p, tt.level))
+		})
+	}
+
+	t.Run("OK/hierarchy", func(t *testing.T) {
+		for _, p := range samples {
+			leaf := cellgrid.FromPoint(p, cellgrid.MaxLevel)
+			assert.True(t, leaf.Valid())
+			assert.True(t, leaf.IsLeaf())
+			for level := 0; level <= cellgrid.MaxLevel; level++ {
+				c := cellgrid.FromPoint(p, level)
+				assert.Equal(t, level, c.Level())
+				assert.Equal(t, leaf.Parent(level), c)
+				assert.True(t, c.Contains(leaf))
+				assert.True(t, c.Bounds().Contains(p), "%v in %v", p, c)
+			}
+			center := leaf.Center()
+			assert.InDelta(t, p.Lat(), center.Lat(), 1e-6)
+			assert.InDelta(t, 0, math.Remainder(p.Lon()-center.Lon(), 360), 1e-5)
+		}
+	})
+}
+
+func TestCellID_Children(t *testing.T) {
+	c := cellgrid.FromPoint(geodesy.Point{48.8566, 2.3522}, 12)
+	children := c.Children()
+	for k, child := range children {
+		assert.Equal(t, 13, child.Level())
+		assert.Equal(t, c, child.Parent(12))
+		assert.True(t, c.Contains(child))
+		assert.True(t, child.Intersects(c))
+		assert.False(t, child.Contains(c))
+		if k > 0 {
+			assert.Equal(t, children[k-1].Next(), child)
+			assert.Equal(t, children[k-1], child.Prev())
+			assert.False(t, child.Intersects(children[k-1]))
+		}
+	}
+	assert.Equal(t, children[0].RangeMin(), c.RangeMin())
+	assert.Equal(t, children[3].RangeMax(), c.RangeMax())
+
+	assert.Equal(t, cellgrid.FromFace(1), cellgrid.FromFace(0).Next())
+	assert.Equal(t, cellgrid.FromFace(4), cellgrid.FromFace(5).Prev())
+}
+
+func TestCellID_EdgeNeighbors(t *testing.T) {
+	t.Run("OK/faces", func(t *testing.T) {
+		want := [4]cellgrid.CellID{cellgrid.FromFace(5), cellgrid.FromFace(1), cellgrid.FromFace(2), cellgrid.FromFace(4)}
+		assert.Equal(t, want, cellgrid.FromFace(0).EdgeNeighbors())
+	})
+
+	t.Run("OK/symmetric", func(t *testing.T) {
+		for _, p := range samples {
+			for _, level := range []int{1, 5, 17, cellgrid.MaxLevel} {
+				c := cellgrid.FromPoint(p, level)
+				for _, n := range c.EdgeNeighbors() {
+					assert.Equal(t, level, n.Level())
+					assert.NotEqual(t, c, n)
+					assert.Contains(t, n.EdgeNeighbors(), c, "%v and %v", c, n)
+
+					// Neighbors share two vertices
+					shared := 0
+					for _, v := range c.Vertices() {
+						for _, w := range n.Vertices() {
+							if math.Abs(v.Lat()-w.Lat()) < 1e-9 && math.Abs(math.Remainder(v.Lon()-w.Lon(), 360)) < 1e-9 ||
+								math.Abs(v.Lat()) > 90-1e-9 && math.Abs(v.Lat()-w.Lat()) < 1e-9 {
+								shared++
+							}
+						}
+					}
+					assert.Equal(t, 2, shared, "%v and %v", c, n)
+				}
+			}
+		}
+	})
+}
+
+func TestCellID_Vertices(t *testing.T) {
+	for _, p := range samples {
+		for _, level := range []int{0, 3, 12, 18} {
+			c := cellgrid.FromPoint(p, level)
+			vertices := c.Vertices()
+			assert.Equal(t, polygon.CounterClockwise, polygon.Orientation(vertices[:]), "%v", c)
+			assert.True(t, polygon.Contains(vertices[:], c.Center()))
+		}
+	}
+}
+
+func TestCellID_Area(t *testing.T) {
+	r := ellipsoids.WGS84_AUTHALIC_MEAN_RADIUS
+	total := 0.0
+	for face := 0; face < 6; face++ {
+		area := cellgrid.FromFace(face).Area()
+		assert.InDelta(t, 4*math.Pi*r*r/6, area, 1e3)
+		total += area
+	}
+	assert.InDelta(t, 4*math.Pi*r*r, total, 1e3)
+
+	for _, p := range samples {
+		c := cellgrid.FromPoint(p, 10)
+		sum := 0.0
+		for _, child := range c.Children() {
+			sum += child.Area()
+		}
+		assert.InDelta(t, c.Area(), sum, c.Area()*1e-9)
+		// The quadratic transform keeps the areas of a level within a factor of 2.1
+		assert.InDelta(t, 4*math.Pi*r*r/6/math.Pow(4, 10), c.Area(), 4*math.Pi*r*r/6/math.Pow(4, 10))
+	}
+}
+
+func TestCellID_Bounds(t *testing.T) {
+	b := cellgrid.FromFace(2).Bounds()
+	assert.Equal(t, 90.0, b.North)
+	assert.Equal(t, -180.0, b.West)
+	assert.Equal(t, 180.0, b.East)
+	assert.InDelta(t, math.Atan(1/math.Sqrt2)*180/math.Pi, b.South, 1e-9)
+
+	// The edges of face 0 bulge up to 45° at the prime meridian, beyond their vertices at 35.26°
+	b = cellgrid.FromFace(0).Bounds()
+	assert.InDelta(t, 45, b.North, 1e-9)
+	assert.InDelta(t, -45, b.South, 1e-9)
+	assert.InDelta(t, -45, b.West, 1e-9)
+	assert.InDelta(t, 45, b.East, 1e-9)
+
+	b = cellgrid.FromFace(3).Bounds()
+	assert.True(t, b.CrossesAntimeridian())
+	assert.True(t, b.Contains(geodesy.Point{44.9, 180}))
+}
+
+func TestToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    cellgrid.CellID
+		wantErr error
+	}{
+		{name: "OK/face", token: "1", want: cellgrid.FromFace(0)},
+		{name: "OK/last_face", token: "b", want: cellgrid.FromFace(5)},
+		{name: "OK/upper_case", token: "1C", want: 0x1c00000000000000},
+		{name: "FAIL/empty", token: "", wantErr: cellgrid.ErrSyntax},
+		{name: "FAIL/invalid", token: "X", wantErr: cellgrid.ErrSyntax},
+		{name: "FAIL/sign", token: "+1", wantErr: cellgrid.ErrSyntax},
+		{name: "FAIL/not_a_cell", token: "2", wantErr: cellgrid.ErrSyntax},
+		{name: "FAIL/face_out_of_range", token: "d", wantErr: cellgrid.ErrSyntax},
+		{name: "FAIL/too_long", token: "10000000000000001", wantErr: cellgrid.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cellgrid.FromToken(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range samples {
+			for level := 0; level <= cellgrid.MaxLevel; level += 3 {
+				c := cellgrid.FromPoint(p, level)
+				got, err := cellgrid.FromToken(c.Token())
+				assert.NoError(t, err)
+				assert.Equal(t, c, got)
+			}
+		}
+		assert.Equal(t, "X", cellgrid.CellID(0).Token())
+	})
+}
+
+func TestCellID_String(t *testing.T) {
+	assert.Equal(t, "0/", cellgrid.FromFace(0).String())
+	assert.Equal(t, "0/2", cellgrid.FromPoint(geodesy.Point{0, 0}, 1).String())
+	assert.Equal(t, "0/20", cellgrid.FromPoint(geodesy.Point{0, 0}, 1).Children()[0].String())
+	assert.Equal(t, "Invalid: 0", cellgrid.CellID(0).String())
+}
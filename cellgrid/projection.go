@@ -0,0 +1,86 @@
+package cellgrid
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+// xyzToFaceUV returns the face whose axis is closest to v, and the coordinates of the projection of v
+// onto the face
+func xyzToFaceUV(v sphere.Vector) (face int, u, w float64) {
+	face = 0
+	if math.Abs(v[1]) > math.Abs(v[face]) {
+		face = 1
+	}
+	if math.Abs(v[2]) > math.Abs(v[face]) {
+		face = 2
+	}
+	if v[face] < 0 {
+		face += 3
+	}
+	u, w = faceXYZToUV(face, v)
+
+	return face, u, w
+}
+
+// faceXYZToUV returns the coordinates of the projection of v onto the plane of the given face, which
+// is only meaningful when v lies on the side of the face
+func faceXYZToUV(face int, v sphere.Vector) (u, w float64) {
+	switch face {
+	case 0:
+		return v[1] / v[0], v[2] / v[0]
+	case 1:
+		return -v[0] / v[1], v[2] / v[1]
+	case 2:
+		return -v[0] / v[2], -v[1] / v[2]
+	case 3:
+		return v[2] / v[0], v[1] / v[0]
+	case 4:
+		return v[2] / v[1], -v[0] / v[1]
+	}
+	return -v[1] / v[2], -v[0] / v[2]
+}
+
+// faceUVToXYZ returns the point of the given face at coordinates u and v, which is not unit length
+func faceUVToXYZ(face int, u, v float64) sphere.Vector {
+	switch face {
+	case 0:
+		return sphere.Vector{1, u, v}
+	case 1:
+		return sphere.Vector{-u, 1, v}
+	case 2:
+		return sphere.Vector{-u, -v, 1}
+	case 3:
+		return sphere.Vector{-1, -v, -u}
+	case 4:
+		return sphere.Vector{v, -1, -u}
+	}
+	return sphere.Vector{v, u, -1}
+}
+
+// uvToST applies the quadratic transform of S2 to a face coordinate, from [-1, 1] to [0, 1], which
+// makes the areas of the cells of a level more uniform than the gnomonic projection alone
+func uvToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+// stToUV is the inverse of uvToST
+func stToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (4*s*s - 1) / 3
+	}
+	return (1 - 4*(1-s)*(1-s)) / 3
+}
+
+// stToIJ returns the leaf coordinate containing the face coordinate s
+func stToIJ(s float64) int {
+	return clampInt(int(math.Floor(maxSize*s)), 0, maxSize-1)
+}
+
+func ijToST(i int) float64 {
+	return float64(i) / maxSize
+}
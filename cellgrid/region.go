@@ -0,0 +1,271 @@
+package cellgrid
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+	"github.com/lggomez/go-geodesy/polygon"
+)
+
+// Region is the interface implemented by the regions covered by a Coverer. Tests may be conservative:
+// IntersectsCell may return true for cells which do not intersect the region, and ContainsCell may
+// return false for cells contained by it, at the cost of larger coverings
+type Region interface {
+	// ContainsCell returns whether the region contains c entirely
+	ContainsCell(c CellID) bool
+	// IntersectsCell returns whether the region and c may have any point in common
+	IntersectsCell(c CellID) bool
+}
+
+// containsVector returns whether c contains the unit vector p, including its boundary
+func (c CellID) containsVector(p sphere.Vector) bool {
+	face, u0, u1, v0, v1 := c.bounds()
+	if axis := p[face%3]; face < 3 && axis <= 0 || face >= 3 && axis >= 0 {
+		return false
+	}
+	u, v := faceXYZToUV(face, p)
+
+	return u0 <= u && u <= u1 && v0 <= v && v <= v1
+}
+
+// distance returns the angular distance from the unit vector p to the nearest point of c, in radians
+func (c CellID) distance(p sphere.Vector) float64 {
+	if c.containsVector(p) {
+		return 0
+	}
+	vs := c.vertices()
+	d := math.Inf(1)
+	for k := range vs {
+		d = math.Min(d, arcDistance(p, vs[k], vs[(k+1)%4]))
+	}
+
+	return d
+}
+
+// arcDistance returns the angular distance from p to the great circle arc ab, in radians
+func arcDistance(p, a, b sphere.Vector) float64 {
+	n := a.Cross(b)
+	// The nearest point of the great circle through a and b is the projection of p onto its plane,
+	// which is the nearest point of the arc if it lies between a and b
+	q := p.Sub(n.Scale(p.Dot(n) / n.Dot(n)))
+	if a.Cross(q).Dot(n) > 0 && q.Cross(b).Dot(n) > 0 {
+		return math.Atan2(math.Abs(p.Dot(n))/n.Norm(), q.Norm())
+	}
+
+	return math.Min(p.Angle(a), p.Angle(b))
+}
+
+// Cap is the region within a distance of a point, measured on the sphere used by distance.Haversine
+type Cap struct {
+	Center geodesy.Point
+	// Radius is the distance from Center in meters
+	Radius float64
+}
+
+// angle returns the radius of c in radians
+func (c Cap) angle() float64 {
+	return c.Radius / ellipsoids.WGS84_MEAN_RADIUS
+}
+
+// ContainsCell returns whether c contains the cell, which is the case when the cell does not
+// intersect the complement of c, a cap centered at its antipode
+func (c Cap) ContainsCell(cell CellID) bool {
+	if !c.Center.Valid() || !(c.Radius >= 0) {
+		return false
+	}
+	if c.angle() >= math.Pi {
+		return true
+	}
+
+	return cell.distance(sphere.FromPoint(c.Center).Scale(-1)) > math.Pi-c.angle()
+}
+
+// IntersectsCell returns whether c and the cell have any point in common
+func (c Cap) IntersectsCell(cell CellID) bool {
+	if !c.Center.Valid() || !(c.Radius >= 0) {
+		return false
+	}
+
+	return cell.distance(sphere.FromPoint(c.Center)) <= c.angle()
+}
+
+// Rect is the region of a bounding box, which may cross the antimeridian. Its tests are made against
+// the bounding boxes of cells, so they are conservative
+type Rect geodesy.BoundingBox
+
+// ContainsCell returns whether r contains the bounding box of the cell
+func (r Rect) ContainsCell(c CellID) bool {
+	return geodesy.BoundingBox(r).ContainsBox(c.Bounds())
+}
+
+// IntersectsCell returns whether r intersects the bounding box of the cell
+func (r Rect) IntersectsCell(c CellID) bool {
+	return geodesy.BoundingBox(r).Intersects(c.Bounds())
+}
+
+// Polygon is the region of a simple polygon whose edges are great circle arcs, as in package polygon:
+// its interior is the smaller of the two regions it bounds
+type Polygon struct {
+	points []geodesy.Point
+	vs     []sphere.Vector
+}
+
+// NewPolygon returns the region of the polygon with the given vertices, or an error from
+// polygon.Validate if it is not simple
+func NewPolygon(points []geodesy.Point) (*Polygon, error) {
+	if err := polygon.Validate(points); err != nil {
+		return nil, err
+	}
+	p := &Polygon{points: append([]geodesy.Point(nil), points...)}
+	for _, point := range points {
+		p.vs = append(p.vs, sphere.FromPoint(point))
+	}
+
+	return p, nil
+}
+
+// ContainsCell returns whether p contains the cell, which is the case when p contains its vertices
+// and neither the vertices nor the edges of p enter it
+func (p *Polygon) ContainsCell(c CellID) bool {
+	for _, v := range c.Vertices() {
+		if !polygon.Contains(p.points, v) {
+			return false
+		}
+	}
+
+	return !p.touches(c)
+}
+
+// IntersectsCell returns whether p and the cell have any point in common
+func (p *Polygon) IntersectsCell(c CellID) bool {
+	for _, v := range c.Vertices() {
+		if polygon.Contains(p.points, v) {
+			return true
+		}
+	}
+
+	return p.touches(c)
+}
+
+// touches returns whether a vertex of p lies within c, or an edge of p crosses an edge of c
+func (p *Polygon) touches(c CellID) bool {
+	cvs := c.vertices()
+	for i, a := range p.vs {
+		if c.containsVector(a) {
+			return true
+		}
+		b := p.vs[(i+1)%len(p.vs)]
+		for k := range cvs {
+			if crossing(a, b, cvs[k], cvs[(k+1)%4]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// crossing returns whether the great circle arcs ab and cd cross or touch each other.
+// See Rubin (2012), "S2 Geometry", S2EdgeCrossings::SimpleCrossing
+func crossing(a, b, c, d sphere.Vector) bool {
+	ab := a.Cross(b)
+	acb := -ab.Dot(c)
+	bda := ab.Dot(d)
+	if acb*bda < 0 {
+		return false
+	}
+
+	cd := c.Cross(d)
+	cbd := -cd.Dot(b)
+	dac := cd.Dot(a)
+
+	return acb*cbd >= 0 && acb*dac >= 0 && cbd*dac >= 0
+}
+
+// DefaultMaxCells is the amount of cells of the coverings of a Coverer with no MaxCells
+const DefaultMaxCells = 8
+
+// Coverer computes coverings of regions, which are sets of cells whose union contains the region.
+// Coverings are made of cells from MinLevel to MaxLevel, and they hold up to MaxCells cells unless the
+// region intersects more cells of MinLevel, in which case all of them are returned
+type Coverer struct {
+	MinLevel int
+	MaxLevel int
+	// MaxCells is the maximum amount of cells of coverings, which is DefaultMaxCells if not positive
+	MaxCells int
+}
+
+// Covering returns the covering of r, sorted by ID. Starting from the faces, the cells intersecting
+// r are subdivided level by level until they are contained by r, they reach MaxLevel or subdividing
+// them would exceed MaxCells. Groups of 4 sibling cells are then replaced by their parent, as long as
+// it is not below MinLevel
+func (cv Coverer) Covering(r Region) []CellID {
+	maxLevel := clampInt(cv.MaxLevel, 0, MaxLevel)
+	minLevel := clampInt(cv.MinLevel, 0, maxLevel)
+	maxCells := cv.MaxCells
+	if maxCells <= 0 {
+		maxCells = DefaultMaxCells
+	}
+
+	var covering, candidates []CellID
+	for face := 0; face < numFaces; face++ {
+		if c := FromFace(face); r.IntersectsCell(c) {
+			candidates = append(candidates, c)
+		}
+	}
+	for len(candidates) > 0 {
+		var next []CellID
+		for k, c := range candidates {
+			level := c.Level()
+			if level >= minLevel && (level == maxLevel || r.ContainsCell(c)) {
+				covering = append(covering, c)
+				continue
+			}
+			var children []CellID
+			for _, child := range c.Children() {
+				if r.IntersectsCell(child) {
+					children = append(children, child)
+				}
+			}
+			remaining := len(candidates) - k - 1
+			if level >= minLevel && len(covering)+len(next)+remaining+len(children) > maxCells {
+				covering = append(covering, c)
+				continue
+			}
+			next = append(next, children...)
+		}
+		candidates = next
+	}
+
+	return normalize(covering, minLevel)
+}
+
+// normalize sorts the disjoint cells of a covering, and replaces groups of 4 siblings by their parent
+// as long as it is not below minLevel
+func normalize(cells []CellID, minLevel int) []CellID {
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+
+	var out []CellID
+	for _, c := range cells {
+		out = append(out, c)
+		// Cells are sorted, so the siblings of the last cell are the ones preceding it
+		for len(out) >= 4 {
+			last := out[len(out)-1]
+			level := last.Level()
+			if level == 0 || level <= minLevel {
+				break
+			}
+			parent := last.Parent(level - 1)
+			if out[len(out)-4] != parent.Children()[0] || parent.Children()[3] != last ||
+				out[len(out)-3] != parent.Children()[1] || out[len(out)-2] != parent.Children()[2] {
+				break
+			}
+			out = append(out[:len(out)-4], parent)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,164 @@
+package cellgrid_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/cellgrid"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+// covers returns whether p lies in a cell of covering
+func covers(covering []cellgrid.CellID, p geodesy.Point) bool {
+	leaf := cellgrid.FromPoint(p, cellgrid.MaxLevel)
+	for _, c := range covering {
+		if c.Contains(leaf) {
+			return true
+		}
+	}
+	return false
+}
+
+func assertNormalized(t *testing.T, covering []cellgrid.CellID) {
+	for i := 1; i < len(covering); i++ {
+		assert.Less(t, uint64(covering[i-1]), uint64(covering[i]))
+		assert.False(t, covering[i-1].Intersects(covering[i]))
+	}
+}
+
+func TestCap(t *testing.T) {
+	london := cellgrid.Cap{Center: geodesy.Point{51.5074, -0.1278}, Radius: 10000}
+	c := cellgrid.FromPoint(london.Center, 12)
+	assert.True(t, london.ContainsCell(c))
+	assert.True(t, london.IntersectsCell(c))
+	assert.False(t, london.ContainsCell(cellgrid.FromPoint(london.Center, 5)))
+	assert.True(t, london.IntersectsCell(cellgrid.FromPoint(london.Center, 5)))
+	assert.False(t, london.IntersectsCell(cellgrid.FromPoint(geodesy.Point{48.8566, 2.3522}, 12)))
+
+	world := cellgrid.Cap{Center: geodesy.Point{0, 0}, Radius: 30000000}
+	assert.True(t, world.ContainsCell(cellgrid.FromFace(3)))
+
+	// A cap larger than a hemisphere contains the cells away from the antipode of its center
+	large := cellgrid.Cap{Center: geodesy.Point{0, 0}, Radius: 15000000}
+	assert.True(t, large.ContainsCell(cellgrid.FromFace(0)))
+	assert.False(t, large.ContainsCell(cellgrid.FromFace(3)))
+	assert.True(t, large.IntersectsCell(cellgrid.FromFace(3)))
+
+	invalid := cellgrid.Cap{Center: geodesy.Point{91, 0}, Radius: 100}
+	assert.False(t, invalid.IntersectsCell(cellgrid.FromFace(2)))
+	assert.False(t, invalid.ContainsCell(cellgrid.FromFace(2)))
+}
+
+func TestRect(t *testing.T) {
+	r := cellgrid.Rect(geodesy.BoundingBox{South: 10, West: 170, North: 20, East: -170})
+	assert.True(t, r.IntersectsCell(cellgrid.FromFace(3)))
+	assert.False(t, r.ContainsCell(cellgrid.FromFace(3)))
+	assert.True(t, r.ContainsCell(cellgrid.FromPoint(geodesy.Point{15, 180}, 10)))
+	assert.False(t, r.IntersectsCell(cellgrid.FromPoint(geodesy.Point{15, 0}, 10)))
+}
+
+func TestPolygon(t *testing.T) {
+	square, err := cellgrid.NewPolygon([]geodesy.Point{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}})
+	assert.NoError(t, err)
+	assert.True(t, square.ContainsCell(cellgrid.FromPoint(geodesy.Point{0, 0}, 5)))
+	assert.True(t, square.IntersectsCell(cellgrid.FromFace(0)))
+	assert.False(t, square.ContainsCell(cellgrid.FromFace(0)))
+	assert.False(t, square.IntersectsCell(cellgrid.FromFace(3)))
+	// The polygon lies within the cell, which therefore intersects it without any vertex inside it
+	assert.True(t, square.IntersectsCell(cellgrid.FromPoint(geodesy.Point{1, 1}, 1)))
+
+	_, err = cellgrid.NewPolygon([]geodesy.Point{{0, 0}, {1, 1}})
+	assert.ErrorIs(t, err, polygon.ErrTooFewVertices)
+}
+
+func TestCoverer_Covering(t *testing.T) {
+	t.Run("OK/cap", func(t *testing.T) {
+		for _, center := range []geodesy.Point{{51.5074, -0.1278}, {89.99, 0}, {0, 180}, {-45, 45}} {
+			region := cellgrid.Cap{Center: center, Radius: 50000}
+			for _, maxCells := range []int{1, 4, 8, 20, 100} {
+				covering := cellgrid.Coverer{MaxLevel: 20, MaxCells: maxCells}.Covering(region)
+				assert.NotEmpty(t, covering)
+				assert.LessOrEqual(t, len(covering), maxCells)
+				assertNormalized(t, covering)
+				for bearing := 0.0; bearing < 360; bearing += 10 {
+					// Geodesic distances, which are up to 0.6% shorter than those on the sphere of the cap
+					for _, d := range []float64{0, 25000, 49500} {
+						p := geodesic.WGS84.Direct(center, bearing, d).P2
+						assert.True(t, covers(covering, p), "%v at %v", p, center)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("OK/tighter_with_more_cells", func(t *testing.T) {
+		region := cellgrid.Cap{Center: geodesy.Point{40.4168, -3.7038}, Radius: 2000}
+		area := func(covering []cellgrid.CellID) float64 {
+			sum := 0.0
+			for _, c := range covering {
+				sum += c.Area()
+			}
+			return sum
+		}
+		coarse := area(cellgrid.Coverer{MaxLevel: 30, MaxCells: 4}.Covering(region))
+		fine := area(cellgrid.Coverer{MaxLevel: 30, MaxCells: 64}.Covering(region))
+		assert.Less(t, fine, coarse)
+		assert.Greater(t, fine, math.Pi*2000*2000)
+	})
+
+	t.Run("OK/levels", func(t *testing.T) {
+		region := cellgrid.Cap{Center: geodesy.Point{-33.8688, 151.2093}, Radius: 1000}
+		covering := cellgrid.Coverer{MinLevel: 10, MaxLevel: 12, MaxCells: 1}.Covering(region)
+		for _, c := range covering {
+			assert.GreaterOrEqual(t, c.Level(), 10)
+			assert.LessOrEqual(t, c.Level(), 12)
+		}
+		assert.True(t, covers(covering, region.Center))
+	})
+
+	t.Run("OK/whole_sphere", func(t *testing.T) {
+		covering := cellgrid.Coverer{MaxLevel: 10}.Covering(cellgrid.Cap{Center: geodesy.Point{0, 0}, Radius: 3e7})
+		assert.Len(t, covering, 6)
+		covering = cellgrid.Coverer{MinLevel: 1, MaxLevel: 10}.Covering(cellgrid.Cap{Center: geodesy.Point{0, 0}, Radius: 3e7})
+		assert.Len(t, covering, 24)
+	})
+
+	t.Run("OK/rect", func(t *testing.T) {
+		box := geodesy.BoundingBox{South: -5, West: 175, North: 5, East: -175}
+		covering := cellgrid.Coverer{MaxLevel: 15, MaxCells: 16}.Covering(cellgrid.Rect(box))
+		assert.LessOrEqual(t, len(covering), 16)
+		assertNormalized(t, covering)
+		for lat := -5.0; lat <= 5; lat++ {
+			for lon := 175.0; lon <= 185; lon++ {
+				p := geodesy.Point{lat, math.Remainder(lon, 360)}
+				assert.True(t, covers(covering, p), "%v", p)
+			}
+		}
+	})
+
+	t.Run("OK/polygon", func(t *testing.T) {
+		vertices := []geodesy.Point{{80, 0}, {80, 120}, {80, -120}}
+		region, err := cellgrid.NewPolygon(vertices)
+		assert.NoError(t, err)
+		covering := cellgrid.Coverer{MaxLevel: 12, MaxCells: 30}.Covering(region)
+		assert.LessOrEqual(t, len(covering), 30)
+		assertNormalized(t, covering)
+		assert.True(t, covers(covering, geodesy.Point{90, 0}))
+		for lon := -180.0; lon < 180; lon += 15 {
+			for lat := 80.0; lat <= 90; lat += 2 {
+				if p := (geodesy.Point{lat, lon}); polygon.Contains(vertices, p) {
+					assert.True(t, covers(covering, p), "%v", p)
+				}
+			}
+		}
+		assert.False(t, covers(covering, geodesy.Point{0, 0}))
+	})
+
+	t.Run("OK/empty", func(t *testing.T) {
+		covering := cellgrid.Coverer{MaxLevel: 10}.Covering(cellgrid.Cap{Center: geodesy.Point{91, 0}, Radius: 10})
+		assert.Empty(t, covering)
+	})
+}
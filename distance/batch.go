@@ -0,0 +1,170 @@
+package distance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// chunkSize is the amount of distances computed by a worker at a time, between checks for cancellation
+const chunkSize = 1024
+
+var (
+	// ErrDimension is returned when the slices given to a batch computation have mismatched lengths
+	ErrDimension = errors.New("distance: mismatched dimensions")
+	// ErrPair is wrapped by the errors reporting the pairs of a batch computation whose distances could
+	// not be computed
+	ErrPair = errors.New("distance: distance could not be computed")
+)
+
+// Method calculates the distance in meters between 2 points, which is math.NaN() if it cannot be computed
+type Method func(p1, p2 geodesy.Point) float64
+
+// Vincenty calculates the ellipsoidal distance in meters between 2 points using VincentyInverse with its
+// default accuracy, for use as the Method of batch computations
+func Vincenty(p1, p2 geodesy.Point) float64 {
+	d, _, _ := VincentyInverse(p1, p2, -1, false)
+	return d
+}
+
+// Pair identifies a pair of points of a batch computation by their indexes in its origins and destinations
+type Pair struct {
+	I, J int
+}
+
+// PairError reports the pairs of a batch computation whose distances could not be computed, such as those
+// of invalid points or of nearly antipodal points with VincentyInverse, which are math.NaN() in its results
+type PairError struct {
+	// Pairs are the failed pairs, sorted by their origins and then by their destinations
+	Pairs []Pair
+}
+
+func (e *PairError) Error() string {
+	return fmt.Sprintf("%v for %d pairs, starting at (%d, %d)", ErrPair, len(e.Pairs), e.Pairs[0].I, e.Pairs[0].J)
+}
+
+func (e *PairError) Unwrap() error {
+	return ErrPair
+}
+
+// Batch computes many distances across a pool of goroutines, writing them to slices provided by the caller
+// so that no memory is allocated for them. The zero value computes Haversine distances with as many
+// workers as runtime.GOMAXPROCS
+type Batch struct {
+	// Method calculates the distance of each pair, which is Haversine if nil
+	Method Method
+	// Workers is the amount of goroutines computing distances, which is runtime.GOMAXPROCS(0) if not
+	// positive
+	Workers int
+}
+
+// Matrix writes to dst the distances from each of origins to each of destinations in row-major order, so
+// that the distance from origins[i] to destinations[j] is dst[i*len(destinations)+j]. dst must have room
+// for all of them, or ErrDimension is returned.
+// If ctx is done before all distances are computed, its error is returned and dst is left partially
+// written. Otherwise, if the distances of some pairs could not be computed, a *PairError reporting them
+// is returned
+func (b Batch) Matrix(ctx context.Context, dst []float64, origins, destinations []geodesy.Point) error {
+	m, n := len(destinations), len(origins)*len(destinations)
+	if len(dst) < n {
+		return fmt.Errorf("%w: %d×%d distances do not fit in %d", ErrDimension, len(origins), m, len(dst))
+	}
+
+	method := b.method()
+	return b.run(ctx, n, func(lo, hi int, failed []Pair) []Pair {
+		for k := lo; k < hi; k++ {
+			i, j := k/m, k%m
+			if dst[k] = method(origins[i], destinations[j]); math.IsNaN(dst[k]) {
+				failed = append(failed, Pair{I: i, J: j})
+			}
+		}
+		return failed
+	})
+}
+
+// Pairwise writes to dst[k] the distance from origins[k] to destinations[k]. origins and destinations must
+// have the same length and dst at least as much, or ErrDimension is returned. Errors are reported as in
+// Matrix, with pairs whose indexes are both k
+func (b Batch) Pairwise(ctx context.Context, dst []float64, origins, destinations []geodesy.Point) error {
+	n := len(origins)
+	if len(destinations) != n || len(dst) < n {
+		return fmt.Errorf("%w: %d origins and %d destinations for %d distances", ErrDimension, n, len(destinations), len(dst))
+	}
+
+	method := b.method()
+	return b.run(ctx, n, func(lo, hi int, failed []Pair) []Pair {
+		for k := lo; k < hi; k++ {
+			if dst[k] = method(origins[k], destinations[k]); math.IsNaN(dst[k]) {
+				failed = append(failed, Pair{I: k, J: k})
+			}
+		}
+		return failed
+	})
+}
+
+func (b Batch) method() Method {
+	if b.Method == nil {
+		return Haversine
+	}
+	return b.Method
+}
+
+// run computes n distances across the workers of b, which take chunks of them in turn until all of them
+// are computed or ctx is done. compute computes the distances in [lo, hi), appending the failed pairs to
+// failed
+func (b Batch) run(ctx context.Context, n int, compute func(lo, hi int, failed []Pair) []Pair) error {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunks := (n + chunkSize - 1) / chunkSize
+	if workers > chunks {
+		workers = chunks
+	}
+
+	var next int64
+	failed := make([][]Pair, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				c := int(atomic.AddInt64(&next, 1) - 1)
+				if c >= chunks {
+					return
+				}
+				lo := c * chunkSize
+				hi := lo + chunkSize
+				if hi > n {
+					hi = n
+				}
+				failed[w] = compute(lo, hi, failed[w])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var pairs []Pair
+	for _, f := range failed {
+		pairs = append(pairs, f...)
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].I < pairs[j].I || pairs[i].I == pairs[j].I && pairs[i].J < pairs[j].J
+	})
+
+	return &PairError{Pairs: pairs}
+}
@@ -0,0 +1,117 @@
+package distance_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomPoints(r *rand.Rand, n int) []geodesy.Point {
+	points := make([]geodesy.Point, n)
+	for k := range points {
+		points[k] = geodesy.Point{180*r.Float64() - 90, 360*r.Float64() - 180}
+	}
+	return points
+}
+
+func TestBatch_Matrix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	origins, destinations := randomPoints(r, 150), randomPoints(r, 70)
+
+	tests := []struct {
+		name  string
+		batch distance.Batch
+		want  distance.Method
+	}{
+		{name: "OK/default", batch: distance.Batch{}, want: distance.Haversine},
+		{name: "OK/single_worker", batch: distance.Batch{Workers: 1}, want: distance.Haversine},
+		{name: "OK/vincenty", batch: distance.Batch{Method: distance.Vincenty, Workers: 4}, want: distance.Vincenty},
+		{name: "OK/more_workers_than_chunks", batch: distance.Batch{Workers: 1000}, want: distance.Haversine},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := make([]float64, len(origins)*len(destinations))
+			assert.NoError(t, tt.batch.Matrix(context.Background(), dst, origins, destinations))
+			for i, o := range origins {
+				for j, d := range destinations {
+					assert.Equal(t, tt.want(o, d), dst[i*len(destinations)+j])
+				}
+			}
+		})
+	}
+
+	t.Run("OK/empty", func(t *testing.T) {
+		assert.NoError(t, distance.Batch{}.Matrix(context.Background(), nil, origins, nil))
+	})
+
+	t.Run("FAIL/pairs", func(t *testing.T) {
+		origins := []geodesy.Point{{0, 0}, {91, 0}, {10, 10}}
+		destinations := []geodesy.Point{{0, 181}, {10, 10}}
+		dst := make([]float64, 6)
+		err := distance.Batch{}.Matrix(context.Background(), dst, origins, destinations)
+		assert.ErrorIs(t, err, distance.ErrPair)
+		var pairErr *distance.PairError
+		assert.ErrorAs(t, err, &pairErr)
+		assert.Equal(t, []distance.Pair{{0, 0}, {1, 0}, {1, 1}, {2, 0}}, pairErr.Pairs)
+		assert.True(t, math.IsNaN(dst[2]))
+		assert.Equal(t, 0.0, dst[5])
+	})
+
+	t.Run("FAIL/antipodes", func(t *testing.T) {
+		p := geodesy.Point{40.698470, -73.951442}
+		dst := make([]float64, 1)
+		err := distance.Batch{Method: distance.Vincenty}.Matrix(context.Background(), dst, []geodesy.Point{p}, []geodesy.Point{p.Antipode()})
+		assert.ErrorIs(t, err, distance.ErrPair)
+	})
+
+	t.Run("FAIL/dimension", func(t *testing.T) {
+		dst := make([]float64, len(origins)*len(destinations)-1)
+		assert.ErrorIs(t, distance.Batch{}.Matrix(context.Background(), dst, origins, destinations), distance.ErrDimension)
+	})
+
+	t.Run("FAIL/cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		dst := make([]float64, len(origins)*len(destinations))
+		assert.ErrorIs(t, distance.Batch{}.Matrix(ctx, dst, origins, destinations), context.Canceled)
+		assert.Equal(t, make([]float64, len(dst)), dst)
+	})
+}
+
+func TestBatch_Pairwise(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	origins, destinations := randomPoints(r, 5000), randomPoints(r, 5000)
+
+	dst := make([]float64, len(origins)+1)
+	assert.NoError(t, distance.Batch{Method: distance.Vincenty}.Pairwise(context.Background(), dst, origins, destinations))
+	for k := range origins {
+		want, _, _ := distance.VincentyInverse(origins[k], destinations[k], -1, false)
+		assert.Equal(t, want, dst[k])
+	}
+	assert.Equal(t, 0.0, dst[len(origins)])
+
+	origins[4000] = geodesy.Point{0, -181}
+	err := distance.Batch{Workers: 3}.Pairwise(context.Background(), dst, origins, destinations)
+	var pairErr *distance.PairError
+	assert.ErrorAs(t, err, &pairErr)
+	assert.Equal(t, []distance.Pair{{4000, 4000}}, pairErr.Pairs)
+	assert.Equal(t, "distance: distance could not be computed for 1 pairs, starting at (4000, 4000)", err.Error())
+
+	assert.ErrorIs(t, distance.Batch{}.Pairwise(context.Background(), dst, origins, destinations[1:]), distance.ErrDimension)
+	assert.ErrorIs(t, distance.Batch{}.Pairwise(context.Background(), dst[:10], origins, destinations), distance.ErrDimension)
+}
+
+func BenchmarkBatch_Matrix(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	origins, destinations := randomPoints(r, 1000), randomPoints(r, 1000)
+	dst := make([]float64, len(origins)*len(destinations))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = distance.Batch{}.Matrix(context.Background(), dst, origins, destinations)
+	}
+}
@@ -1,6 +1,7 @@
 package distance
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 	"time"
@@ -116,3 +117,57 @@ func Benchmark_DistanceFar(b *testing.B) {
 		}
 	})
 }
+
+func Benchmark_DistanceMatrix(b *testing.B) {
+	const n = 500
+	origins, destinations := make([]geodesy.Point, n), make([]geodesy.Point, n)
+	originLats, originLons := make([]float64, n), make([]float64, n)
+	destinationLats, destinationLons := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		origins[i], destinations[i] = nearPoints[i][0], nearPoints[i][1]
+		originLats[i], originLons[i] = origins[i].Lat(), origins[i].Lon()
+		destinationLats[i], destinationLons[i] = destinations[i].Lat(), destinations[i].Lon()
+	}
+	dst := make([]float64, n*n)
+	serial := Batch{Workers: 1}
+	newPoints := func() (*Points, *Points) {
+		o, _ := NewPoints(originLats, originLons)
+		d, _ := NewPoints(destinationLats, destinationLons)
+		return o, d
+	}
+
+	b.Run("Haversine_per_pair", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for k, o := range origins {
+				for j, d := range destinations {
+					dst[k*n+j] = Haversine(o, d)
+				}
+			}
+		}
+	})
+	b.Run("Haversine_kernel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			o, d := newPoints()
+			_ = serial.HaversineMatrix(context.Background(), dst, o, d)
+		}
+	})
+	b.Run("Vincenty_per_pair", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for k, o := range origins {
+				for j, d := range destinations {
+					dst[k*n+j], _, _ = VincentyInverse(o, d, -1, false)
+				}
+			}
+		}
+	})
+	b.Run("Vincenty_kernel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			o, d := newPoints()
+			_ = serial.VincentyMatrix(context.Background(), dst, o, d)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package distance
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+// Points holds many points as a structure of arrays, along with the trigonometric functions of their
+// coordinates, which are computed once per point and reused across the pairs of the distance kernels
+// of Batch. The coordinates of invalid points are kept, and their distances are math.NaN()
+type Points struct {
+	lats, lons []float64
+	valid      []bool
+	// x, y, z are the coordinates of the unit vectors of the points, for the Haversine kernel
+	x, y, z []float64
+	// sinu, cosu are the sine and cosine of the reduced latitudes of the points, and λ their longitudes
+	// in radians, for the Vincenty kernel
+	sinu, cosu, λ []float64
+}
+
+// NewPoints returns the points with the given latitudes and longitudes, in decimal degrees, which must
+// have the same length or ErrDimension is returned
+func NewPoints(lats, lons []float64) (*Points, error) {
+	n := len(lats)
+	if len(lons) != n {
+		return nil, fmt.Errorf("%w: %d latitudes and %d longitudes", ErrDimension, n, len(lons))
+	}
+
+	p := &Points{
+		lats: make([]float64, n), lons: make([]float64, n), valid: make([]bool, n),
+		x: make([]float64, n), y: make([]float64, n), z: make([]float64, n),
+		sinu: make([]float64, n), cosu: make([]float64, n), λ: make([]float64, n),
+	}
+	copy(p.lats, lats)
+	copy(p.lons, lons)
+	for k := 0; k < n; k++ {
+		point := geodesy.Point{lats[k], lons[k]}
+		if !point.Valid() {
+			nan := math.NaN()
+			p.x[k], p.y[k], p.z[k] = nan, nan, nan
+			p.sinu[k], p.cosu[k], p.λ[k] = nan, nan, nan
+			continue
+		}
+		p.valid[k] = true
+		sinφ, cosφ := math.Sincos(point.LatRadians())
+		sinλ, cosλ := math.Sincos(point.LonRadians())
+		p.x[k], p.y[k], p.z[k] = cosφ*cosλ, cosφ*sinλ, sinφ
+		p.sinu[k], p.cosu[k] = reducedLatitude(point.LatRadians())
+		p.λ[k] = point.LonRadians()
+	}
+
+	return p, nil
+}
+
+// Len returns the amount of points of p
+func (p *Points) Len() int {
+	return len(p.lats)
+}
+
+// Point returns the k-th point of p
+func (p *Points) Point(k int) geodesy.Point {
+	return geodesy.Point{p.lats[k], p.lons[k]}
+}
+
+// HaversineMatrix is Matrix for Points, computing Haversine distances regardless of b.Method. Distances are
+// computed from the chords between the unit vectors of the points, which needs no trigonometric functions
+// other than an arc sine per pair, and which agree with Haversine up to rounding errors below a micrometer
+func (b Batch) HaversineMatrix(ctx context.Context, dst []float64, origins, destinations *Points) error {
+	return b.pointsMatrix(ctx, dst, origins, destinations, haversineKernel)
+}
+
+// VincentyMatrix is Matrix for Points, computing Vincenty distances regardless of b.Method, which are equal
+// to those of VincentyInverse with its default accuracy
+func (b Batch) VincentyMatrix(ctx context.Context, dst []float64, origins, destinations *Points) error {
+	return b.pointsMatrix(ctx, dst, origins, destinations, vincentyRow)
+}
+
+// kernel computes the distances from the i-th point of origins to the destinations from the j-th one
+// onwards, writing them to dst
+type kernel func(dst []float64, origins *Points, i int, destinations *Points, j int)
+
+// pointsMatrix computes the distance matrix of origins and destinations with k, across runs of consecutive
+// distances within the rows of dst
+func (b Batch) pointsMatrix(ctx context.Context, dst []float64, origins, destinations *Points, k kernel) error {
+	m, n := destinations.Len(), origins.Len()*destinations.Len()
+	if len(dst) < n {
+		return fmt.Errorf("%w: %d×%d distances do not fit in %d", ErrDimension, origins.Len(), m, len(dst))
+	}
+
+	return b.run(ctx, n, func(lo, hi int, failed []Pair) []Pair {
+		for lo < hi {
+			i, j := lo/m, lo%m
+			end := (i + 1) * m
+			if end > hi {
+				end = hi
+			}
+			row := dst[lo:end]
+			k(row, origins, i, destinations, j)
+			for c, d := range row {
+				if math.IsNaN(d) {
+					failed = append(failed, Pair{I: i, J: j + c})
+				}
+			}
+			lo = end
+		}
+		return failed
+	})
+}
+
+// haversineKernel is the kernel of HaversineMatrix, which is haversineRow unless the processor supports
+// a faster one
+var haversineKernel kernel = haversineRow
+
+// haversineRow is the portable kernel of HaversineMatrix. Its first loop only involves arithmetic on slices of
+// the same length, so that bounds checks are eliminated
+func haversineRow(dst []float64, origins *Points, i int, destinations *Points, j int) {
+	x, y, z := origins.x[i], origins.y[i], origins.z[i]
+	xs, ys, zs := destinations.x[j:j+len(dst)], destinations.y[j:j+len(dst)], destinations.z[j:j+len(dst)]
+
+	// Squared chords between the unit vectors, which are 4 times the haversines of the central angles
+	for c := range dst {
+		dx, dy, dz := xs[c]-x, ys[c]-y, zs[c]-z
+		dst[c] = dx*dx + dy*dy + dz*dz
+	}
+	for c, chord2 := range dst {
+		h := math.Sqrt(chord2) / 2
+		if h > 1 {
+			h = 1
+		}
+		dst[c] = 2 * ellipsoids.WGS84_MEAN_RADIUS * asin(h)
+	}
+}
+
+// asin returns the arc sine of x in [0, 1], or math.NaN() if x is NaN, from the rational approximations
+// of asin.c in the Cephes Math Library by Stephen L. Moshier. It is within a few ulps of the exact arc
+// sine, which is more accurate than math.Asin near 1, and about twice as fast, as math.Asin evaluates
+// an arc tangent after a division and a square root
+func asin(x float64) float64 {
+	const (
+		pio4     = math.Pi / 4
+		moreBits = 6.123233995736765886130e-17 // the rounding error of pio4
+	)
+
+	if x > 0.625 {
+		// asin(x) = π/2 - 2 asin(sqrt((1-x)/2))
+		zz := 1 - x
+		r := (((2.967721961301243206100e-3*zz-5.634242780008963776856e-1)*zz+6.968710824104713396794e0)*zz-2.556901049652824852289e1)*zz + 2.853665548261061424989e1
+		s := (((zz-2.194779531642920639778e1)*zz+1.470656354026814941758e2)*zz-3.838770957603691357202e2)*zz + 3.424398657913078477438e2
+		p := zz * r / s
+		zz = math.Sqrt(zz + zz)
+		z := pio4 - zz
+		zz = zz*p - moreBits
+		z -= zz
+		return z + pio4
+	}
+
+	zz := x * x
+	p := ((((4.253011369004428248960e-3*zz-6.019598008014123785661e-1)*zz+5.444622390564711410273e0)*zz-1.626247967210700244449e1)*zz+1.956261983317594739197e1)*zz - 8.198089802484824371615e0
+	q := ((((zz-1.474091372988853791896e1)*zz+7.049610280856842141659e1)*zz-1.471791292232726029859e2)*zz+1.395105614657485689735e2)*zz - 4.918853881490881290097e1
+	return x*(zz*p/q) + x
+}
+
+// vincentyRow is the kernel of VincentyMatrix, which handles the special cases of VincentyInverse before
+// evaluating its formulae from the reduced latitudes of the points. The checks of VincentyInverse are
+// made on the coordinates of the points, with the terms depending on the origin computed once per row
+func vincentyRow(dst []float64, origins *Points, i int, destinations *Points, j int) {
+	if !origins.valid[i] {
+		for c := range dst {
+			dst[c] = math.NaN()
+		}
+		return
+	}
+
+	lat1, lon1 := origins.lats[i], origins.lons[i]
+	sinu1, cosu1, λ1 := origins.sinu[i], origins.cosu[i], origins.λ[i]
+	antipodeLon1 := 180 - math.Abs(lon1)
+	lats, lons, valid := destinations.lats[j:j+len(dst)], destinations.lons[j:j+len(dst)], destinations.valid[j:j+len(dst)]
+	sinu, cosu, λ := destinations.sinu[j:j+len(dst)], destinations.cosu[j:j+len(dst)], destinations.λ[j:j+len(dst)]
+	for c := range dst {
+		lat2, lon2 := lats[c], lons[c]
+		switch {
+		case !valid[c] || lat1 == -lat2 && (lon1 == 180-math.Abs(lon2) || lon2 == antipodeLon1):
+			// Invalid points and antipodes, as in Point.IsAntipodeOf
+			dst[c] = math.NaN()
+		case lat1 == lat2 && lon1 == lon2:
+			dst[c] = 0
+		default:
+			dst[c], _, _ = vincentyInverse(sinu1, cosu1, sinu[c], cosu[c], λ[c]-λ1, lat1 == 0 || lat2 == 0,
+				defaultAccuracy, false)
+		}
+	}
+}
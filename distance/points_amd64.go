@@ -0,0 +1,27 @@
+package distance
+
+func init() {
+	if hasAVXFMA() {
+		haversineKernel = haversineRowAVX
+	}
+}
+
+// haversineRowAVX is haversineRow for amd64 processors with the AVX and FMA instruction sets, which
+// computes 4 distances at a time and leaves the remaining ones of dst to haversineRow
+func haversineRowAVX(dst []float64, origins *Points, i int, destinations *Points, j int) {
+	n := len(dst) &^ 3
+	haversineAVX(dst[:n], destinations.x[j:j+n], destinations.y[j:j+n], destinations.z[j:j+n],
+		origins.x[i], origins.y[i], origins.z[i])
+	haversineRow(dst[n:], origins, i, destinations, j+n)
+}
+
+// haversineAVX writes to dst the Haversine distances from the unit vector (x, y, z) to those of xs, ys and
+// zs, as haversineRow does. The length of dst must be a multiple of 4, and that of the others at least as
+// much
+//
+//go:noescape
+func haversineAVX(dst, xs, ys, zs []float64, x, y, z float64)
+
+// hasAVXFMA returns whether the processor supports the AVX and FMA instruction sets, and the operating
+// system saves the registers they use
+func hasAVXFMA() bool
@@ -0,0 +1,153 @@
+#include "textflag.h"
+
+// SPLAT defines the 32-byte constant name holding 4 copies of the float64 value, for packed operands
+#define SPLAT(name, value) \
+	DATA name<>+0(SB)/8, value; \
+	DATA name<>+8(SB)/8, value; \
+	DATA name<>+16(SB)/8, value; \
+	DATA name<>+24(SB)/8, value; \
+	GLOBL name<>(SB), RODATA|NOPTR, $32
+
+SPLAT(half, $0.5)
+SPLAT(one, $1.0)
+SPLAT(asinSplit, $0.625)
+SPLAT(diameter, $12742017.542830118) // 2 * ellipsoids.WGS84_MEAN_RADIUS
+SPLAT(pio4, $0.7853981633974483)
+SPLAT(moreBits, $6.123233995736765886130e-17)
+
+// Coefficients of asin, as in the Go code
+SPLAT(asinP0, $4.253011369004428248960e-3)
+SPLAT(asinP1, $-6.019598008014123785661e-1)
+SPLAT(asinP2, $5.444622390564711410273e0)
+SPLAT(asinP3, $-1.626247967210700244449e1)
+SPLAT(asinP4, $1.956261983317594739197e1)
+SPLAT(asinP5, $-8.198089802484824371615e0)
+SPLAT(asinQ1, $-1.474091372988853791896e1)
+SPLAT(asinQ2, $7.049610280856842141659e1)
+SPLAT(asinQ3, $-1.471791292232726029859e2)
+SPLAT(asinQ4, $1.395105614657485689735e2)
+SPLAT(asinQ5, $-4.918853881490881290097e1)
+SPLAT(asinR0, $2.967721961301243206100e-3)
+SPLAT(asinR1, $-5.634242780008963776856e-1)
+SPLAT(asinR2, $6.968710824104713396794e0)
+SPLAT(asinR3, $-2.556901049652824852289e1)
+SPLAT(asinR4, $2.853665548261061424989e1)
+SPLAT(asinS1, $-2.194779531642920639778e1)
+SPLAT(asinS2, $1.470656354026814941758e2)
+SPLAT(asinS3, $-3.838770957603691357202e2)
+SPLAT(asinS4, $3.424398657913078477438e2)
+
+// func haversineAVX(dst, xs, ys, zs []float64, x, y, z float64)
+TEXT ·haversineAVX(SB), NOSPLIT, $0-120
+	MOVQ dst_base+0(FP), DI
+	MOVQ dst_len+8(FP), CX
+	MOVQ xs_base+24(FP), R8
+	MOVQ ys_base+48(FP), R9
+	MOVQ zs_base+72(FP), R10
+	VBROADCASTSD x+96(FP), Y0
+	VBROADCASTSD y+104(FP), Y1
+	VBROADCASTSD z+112(FP), Y2
+	VMOVUPD one<>(SB), Y15
+	XORQ AX, AX
+
+loop:
+	CMPQ AX, CX
+	JAE  done
+
+	// Squared chords between the unit vectors, and h = min(chord/2, 1), which is NaN for invalid points
+	VMOVUPD (R8)(AX*8), Y3
+	VSUBPD  Y0, Y3, Y3
+	VMOVUPD (R9)(AX*8), Y4
+	VSUBPD  Y1, Y4, Y4
+	VMOVUPD (R10)(AX*8), Y5
+	VSUBPD  Y2, Y5, Y5
+	VMULPD  Y3, Y3, Y3
+	VMULPD  Y4, Y4, Y4
+	VMULPD  Y5, Y5, Y5
+	VADDPD  Y4, Y3, Y3
+	VADDPD  Y5, Y3, Y3
+	VSQRTPD Y3, Y3
+	VMULPD  half<>(SB), Y3, Y3
+	VCMPPD  $0x1e, Y15, Y3, Y4 // h > 1
+	VBLENDVPD Y4, Y15, Y3, Y3
+
+	// Each branch of asin is only evaluated if some of the 4 values take it
+	VCMPPD    $0x1e, asinSplit<>(SB), Y3, Y4 // h > 0.625
+	VMOVMSKPD Y4, BX
+	CMPQ      BX, $0x0f
+	JEQ       large
+
+	// asin(h) = h + h zz P(zz)/Q(zz), with zz = h²
+	VMULPD      Y3, Y3, Y8
+	VMOVUPD     asinP0<>(SB), Y9
+	VFMADD213PD asinP1<>(SB), Y8, Y9
+	VFMADD213PD asinP2<>(SB), Y8, Y9
+	VFMADD213PD asinP3<>(SB), Y8, Y9
+	VFMADD213PD asinP4<>(SB), Y8, Y9
+	VFMADD213PD asinP5<>(SB), Y8, Y9
+	VADDPD      asinQ1<>(SB), Y8, Y10
+	VFMADD213PD asinQ2<>(SB), Y8, Y10
+	VFMADD213PD asinQ3<>(SB), Y8, Y10
+	VFMADD213PD asinQ4<>(SB), Y8, Y10
+	VFMADD213PD asinQ5<>(SB), Y8, Y10
+	VMULPD      Y9, Y8, Y9
+	VDIVPD      Y10, Y9, Y9
+	VFMADD213PD Y3, Y3, Y9
+	VMOVAPD     Y9, Y6
+	TESTQ       BX, BX
+	JEQ         store
+
+large:
+	// asin(h) = π/2 - 2 asin(sqrt((1-h)/2)), with zz = 1 - h
+	VSUBPD      Y3, Y15, Y8
+	VMOVUPD     asinR0<>(SB), Y9
+	VFMADD213PD asinR1<>(SB), Y8, Y9
+	VFMADD213PD asinR2<>(SB), Y8, Y9
+	VFMADD213PD asinR3<>(SB), Y8, Y9
+	VFMADD213PD asinR4<>(SB), Y8, Y9
+	VADDPD      asinS1<>(SB), Y8, Y10
+	VFMADD213PD asinS2<>(SB), Y8, Y10
+	VFMADD213PD asinS3<>(SB), Y8, Y10
+	VFMADD213PD asinS4<>(SB), Y8, Y10
+	VMULPD      Y9, Y8, Y9
+	VDIVPD      Y10, Y9, Y9
+	VADDPD      Y8, Y8, Y10
+	VSQRTPD     Y10, Y10
+	VMOVUPD     pio4<>(SB), Y11
+	VSUBPD      Y10, Y11, Y7
+	VFMSUB213PD moreBits<>(SB), Y10, Y9
+	VSUBPD      Y9, Y7, Y7
+	VADDPD      Y11, Y7, Y7
+	VBLENDVPD   Y4, Y7, Y6, Y6
+
+store:
+	VMULPD  diameter<>(SB), Y6, Y6
+	VMOVUPD Y6, (DI)(AX*8)
+	ADDQ    $4, AX
+	JMP     loop
+
+done:
+	VZEROUPPER
+	RET
+
+// func hasAVXFMA() bool
+TEXT ·hasAVXFMA(SB), NOSPLIT, $0-1
+	MOVB $0, ret+0(FP)
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	// FMA, OSXSAVE and AVX
+	ANDL $0x18001000, CX
+	CMPL CX, $0x18001000
+	JNE  unsupported
+
+	// The operating system saves the XMM and YMM registers
+	XORL CX, CX
+	XGETBV
+	ANDL $6, AX
+	CMPL AX, $6
+	JNE  unsupported
+	MOVB $1, ret+0(FP)
+
+unsupported:
+	RET
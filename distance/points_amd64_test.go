@@ -0,0 +1,46 @@
+package distance
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaversineRowAVX(t *testing.T) {
+	if !hasAVXFMA() {
+		t.Skip("the processor does not support AVX and FMA")
+	}
+
+	r := rand.New(rand.NewSource(6))
+	lats, lons := make([]float64, 203), make([]float64, 203)
+	for k := range lats {
+		lats[k], lons[k] = r.Float64()*180-90, r.Float64()*360-180
+		if k%50 < 10 {
+			// Runs of nearby points, whose arc sines only take the branch for small values
+			lats[k], lons[k] = lats[k]/1e3, lons[k]/1e3
+		}
+	}
+	lats[17], lats[120] = 91, math.NaN()
+	points, err := NewPoints(lats, lons)
+	assert.NoError(t, err)
+	// Chords longer than the diameter, as rounding errors may yield for antipodes, are clamped
+	points.x[30], points.y[30], points.z[30] = -1.2, 0, 0
+	points.x[31], points.y[31], points.z[31] = 1.2, 0, 0
+
+	for _, i := range []int{0, 5, 17, 30, 31, 150} {
+		for _, j := range []int{0, 1, 3} {
+			got, want := make([]float64, len(lats)-j), make([]float64, len(lats)-j)
+			haversineRowAVX(got, points, i, points, j)
+			haversineRow(want, points, i, points, j)
+			for c := range want {
+				if math.IsNaN(want[c]) {
+					assert.True(t, math.IsNaN(got[c]), "%d %d", i, j+c)
+					continue
+				}
+				assert.InDelta(t, want[c], got[c], 1e-8, "%d %d", i, j+c)
+			}
+		}
+	}
+}
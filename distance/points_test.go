@@ -0,0 +1,75 @@
+package distance_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPoints(t *testing.T, points []geodesy.Point) *distance.Points {
+	lats, lons := make([]float64, len(points)), make([]float64, len(points))
+	for k, p := range points {
+		lats[k], lons[k] = p.Lat(), p.Lon()
+	}
+	p, err := distance.NewPoints(lats, lons)
+	assert.NoError(t, err)
+
+	return p
+}
+
+func TestNewPoints(t *testing.T) {
+	p, err := distance.NewPoints([]float64{10, 95}, []float64{20, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, p.Len())
+	assert.Equal(t, geodesy.Point{95, 0}, p.Point(1))
+
+	_, err = distance.NewPoints([]float64{10, 20}, []float64{20})
+	assert.ErrorIs(t, err, distance.ErrDimension)
+}
+
+func TestBatch_HaversineMatrix(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	origins := append(randomPoints(r, 120), geodesy.Point{0, 180}, geodesy.Point{90, 0}, geodesy.Point{-34.5, -58.4})
+	destinations := append(randomPoints(r, 90), geodesy.Point{0, -180}, geodesy.Point{-90, 10}, geodesy.Point{-34.5, -58.4},
+		geodesy.Point{34.5, 121.6}, geodesy.Point{-34.50001, -58.40001})
+	o, d := newPoints(t, origins), newPoints(t, destinations)
+
+	dst := make([]float64, len(origins)*len(destinations))
+	assert.NoError(t, distance.Batch{Workers: 3}.HaversineMatrix(context.Background(), dst, o, d))
+	for i, p1 := range origins {
+		for j, p2 := range destinations {
+			want := distance.Haversine(p1, p2)
+			assert.InDelta(t, want, dst[i*len(destinations)+j], 1e-6+want*1e-14, "%v %v", p1, p2)
+		}
+	}
+}
+
+func TestBatch_VincentyMatrix(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	origins := append(randomPoints(r, 40), geodesy.Point{0, -71.3}, geodesy.Point{40.698470, -73.951442}, geodesy.Point{91, 0})
+	destinations := append(randomPoints(r, 30), geodesy.Point{0, -73.1}, geodesy.Point{-40.698470, 106.048558}, geodesy.Point{40.698470, -73.951442})
+	o, d := newPoints(t, origins), newPoints(t, destinations)
+
+	dst := make([]float64, len(origins)*len(destinations))
+	err := distance.Batch{}.VincentyMatrix(context.Background(), dst, o, d)
+	var pairErr *distance.PairError
+	assert.ErrorAs(t, err, &pairErr)
+	for i, p1 := range origins {
+		for j, p2 := range destinations {
+			want, _, _ := distance.VincentyInverse(p1, p2, -1, false)
+			if math.IsNaN(want) {
+				assert.Contains(t, pairErr.Pairs, distance.Pair{I: i, J: j})
+				assert.True(t, math.IsNaN(dst[i*len(destinations)+j]))
+				continue
+			}
+			assert.Equal(t, want, dst[i*len(destinations)+j], "%v %v", p1, p2)
+		}
+	}
+
+	assert.ErrorIs(t, distance.Batch{}.VincentyMatrix(context.Background(), dst[:10], o, d), distance.ErrDimension)
+}
@@ -3,6 +3,7 @@ package distance
 import (
 	"github.com/lggomez/go-geodesy"
 	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/internal/geomath"
 	"math"
 )
 
@@ -11,7 +12,6 @@ const (
 	maxIterations   int     = 50
 
 	fullAngleRad = 2 * math.Pi
-	radConversionFactor = 180/ math.Pi
 )
 
 /*
@@ -56,18 +56,30 @@ func VincentyInverse(p1, p2 geodesy.Point, accuracy float64, calculateAzimuth bo
 		ε = accuracy
 	}
 
+	sinu1, cosu1 := reducedLatitude(p1.LatRadians())
+	sinu2, cosu2 := reducedLatitude(p2.LatRadians())
+	L := p2.LonRadians() - p1.LonRadians() // Difference in longitude
+
+	return vincentyInverse(sinu1, cosu1, sinu2, cosu2, L, p1.Lat() == 0 || p2.Lat() == 0, ε, calculateAzimuth)
+}
+
+// reducedLatitude returns the sine and cosine of the reduced latitude (latitude on the auxiliary sphere)
+// of the latitude φ, in radians
+func reducedLatitude(φ float64) (float64, float64) {
+	return math.Sincos(math.Atan((1 - ellipsoids.WGS84_FLATTENING) * math.Tan(φ)))
+}
+
+// vincentyInverse evaluates the inverse Vincenty formulae from the sines and cosines of the reduced
+// latitudes of 2 points and their difference in longitude L, in radians, converging to the accuracy ε.
+// If any of the points lies on the equator, the distance is computed through it
+func vincentyInverse(sinu1, cosu1, sinu2, cosu2, L float64, equator bool, ε float64, calculateAzimuth bool) (float64, float64, float64) {
 	// Initial conditions setup
 	a := ellipsoids.WGS84_SEMI_MAJOR_AXIS
 	b := ellipsoids.WGS84_SEMI_MINOR_AXIS
 	ƒ := ellipsoids.WGS84_FLATTENING
-	u1 := math.Atan((1 - ƒ) * math.Tan(p1.LatRadians())) // Reduced latitude for p1
-	u2 := math.Atan((1 - ƒ) * math.Tan(p2.LatRadians())) // Reduced latitude for p2
-	L := p2.LonRadians() - p1.LonRadians()               // Difference in longitude
-	λ := L                                               // Difference in longitude of the points on the auxiliary sphere
+	λ := L // Difference in longitude of the points on the auxiliary sphere
 	λ_prev := float64(0)
 	f16Frac := ƒ / 16
-	sinu1, cosu1 := math.Sincos(u1)
-	sinu2, cosu2 := math.Sincos(u2)
 
 	// Loop variables
 	cos2α := float64(0)
@@ -100,7 +112,7 @@ func VincentyInverse(p1, p2 geodesy.Point, accuracy float64, calculateAzimuth bo
 		C := float64(0)
 		// Distances through the equator yield C = 0 and cos2σₘ is not used,
 		// so calculate if points do not fall on it
-		if (p1.Lat() != 0) && (p2.Lat() != 0) {
+		if !equator {
 			cos2α = 1 - (sinα*sinα)
 			cos2σₘ = cosσ - ((2 * sinu1 * sinu2) / cos2α)
 			C = f16Frac * cos2α * (4 + ƒ*(4-3*cos2α))
@@ -146,8 +158,8 @@ func VincentyInverse(p1, p2 geodesy.Point, accuracy float64, calculateAzimuth bo
 
 func quadrantRadToDegree(rad float64) float64 {
 	if rad < 0 {
-		return (rad + fullAngleRad) * radConversionFactor
+		return (rad + fullAngleRad) * geomath.RadToDeg
 	}
 
-	return rad * radConversionFactor
+	return rad * geomath.RadToDeg
 }
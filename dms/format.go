@@ -0,0 +1,164 @@
+package dms
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// Style represents the notation in which coordinates are formatted
+type Style int
+
+const (
+	// Decimal formats signed decimal degrees, such as 40.446, -79.982
+	Decimal Style = iota
+	// DecimalHemisphere formats decimal degrees followed by hemisphere letters, such as 40.446°N 79.982°W
+	DecimalHemisphere
+	// DegreesMinutes formats degrees and decimal minutes (DDM), such as 40°26.767'N 79°58.933'W
+	DegreesMinutes
+	// DegreesMinutesSeconds formats degrees, minutes and seconds (DMS), such as 40°26'46"N 79°58'56"W
+	DegreesMinutesSeconds
+)
+
+// Formatter formats coordinates in a Style, with options beyond those of Format. The zero value formats
+// signed decimal degrees without decimal places
+type Formatter struct {
+	Style Style
+	// Precision is the amount of decimal places of the last component (degrees, minutes or seconds)
+	Precision int
+	// HemispherePrefix places hemisphere letters before the coordinates rather than after them, as in
+	// N40°26'46" W79°58'56". Decimal degrees omit the degree symbol then, as in N40.446 W79.982. It has
+	// no effect on the Decimal style
+	HemispherePrefix bool
+	// DecimalComma writes decimal commas rather than decimal points, as in 40,446°N 79,982°W. Coordinates
+	// in the Decimal style are separated by a semicolon then, as in 40,446; -79,982
+	DecimalComma bool
+}
+
+// Format returns the coordinates of p in the given style, with precision decimal places in its last
+// component (degrees, minutes or seconds). Values are rounded as a whole, so that minutes and seconds
+// never reach 60. The output is accepted by Parse. If p does not constitute a valid geographic
+// coordinate, it returns an empty string
+func Format(p geodesy.Point, style Style, precision int) string {
+	return Formatter{Style: style, Precision: precision}.Format(p)
+}
+
+// FormatLatitude returns the latitude φ in the given style, with precision decimal places in its
+// last component. If φ is not a valid latitude, it returns an empty string
+func FormatLatitude(φ float64, style Style, precision int) string {
+	return Formatter{Style: style, Precision: precision}.FormatLatitude(φ)
+}
+
+// FormatLongitude returns the longitude λ in the given style, with precision decimal places in its
+// last component. If λ is not a valid longitude, it returns an empty string
+func FormatLongitude(λ float64, style Style, precision int) string {
+	return Formatter{Style: style, Precision: precision}.FormatLongitude(λ)
+}
+
+// Format is the package level Format with the options of f
+func (f Formatter) Format(p geodesy.Point) string {
+	if !p.Valid() {
+		return ""
+	}
+
+	separator := " "
+	if f.Style == Decimal {
+		separator = ", "
+		if f.DecimalComma {
+			separator = "; "
+		}
+	}
+
+	return f.FormatLatitude(p.Lat()) + separator + f.FormatLongitude(p.Lon())
+}
+
+// FormatLatitude is the package level FormatLatitude with the options of f
+func (f Formatter) FormatLatitude(φ float64) string {
+	if !(φ >= geodesy.LatLowerBound && φ <= geodesy.LatUpperBound) {
+		return ""
+	}
+	return f.format(φ, 'N', 'S')
+}
+
+// FormatLongitude is the package level FormatLongitude with the options of f
+func (f Formatter) FormatLongitude(λ float64) string {
+	if !(λ >= geodesy.LonLowerBound && λ <= geodesy.LonUpperBound) {
+		return ""
+	}
+	return f.format(λ, 'E', 'W')
+}
+
+func (f Formatter) format(v float64, positive, negative byte) string {
+	style, precision := f.Style, f.Precision
+	if precision < 0 {
+		precision = 0
+	}
+
+	// The magnitude is rounded to an integer amount of the last unit, scaled by the precision,
+	// and then split into its components
+	perDegree := 1.0
+	switch style {
+	case DegreesMinutes:
+		perDegree = 60
+	case DegreesMinutesSeconds:
+		perDegree = 3600
+	}
+	scale := math.Pow(10, float64(precision))
+	units := math.Round(math.Abs(v) * perDegree * scale)
+
+	// Values rounding to zero carry no sign, nor a southern or western hemisphere
+	hemisphere := positive
+	if v < 0 && units > 0 {
+		hemisphere = negative
+	}
+
+	decimal := func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', precision, 64)
+		if f.DecimalComma {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+
+	var b strings.Builder
+	if style == Decimal {
+		if hemisphere == negative {
+			b.WriteByte('-')
+		}
+		b.WriteString(decimal(units / scale))
+		return b.String()
+	}
+	if f.HemispherePrefix {
+		b.WriteByte(hemisphere)
+	}
+	switch style {
+	case DecimalHemisphere:
+		b.WriteString(decimal(units / scale))
+		if !f.HemispherePrefix {
+			b.WriteString("°")
+		}
+	case DegreesMinutes:
+		whole := math.Floor(units / scale / 60)
+		b.WriteString(strconv.FormatFloat(whole, 'f', 0, 64))
+		b.WriteString("°")
+		b.WriteString(decimal((units - whole*60*scale) / scale))
+		b.WriteString("'")
+	case DegreesMinutesSeconds:
+		whole := math.Floor(units / scale / 3600)
+		rest := units - whole*3600*scale
+		minutes := math.Floor(rest / scale / 60)
+		b.WriteString(strconv.FormatFloat(whole, 'f', 0, 64))
+		b.WriteString("°")
+		b.WriteString(strconv.FormatFloat(minutes, 'f', 0, 64))
+		b.WriteString("'")
+		b.WriteString(decimal((rest - minutes*60*scale) / scale))
+		b.WriteString(`"`)
+	}
+	if !f.HemispherePrefix {
+		b.WriteByte(hemisphere)
+	}
+
+	return b.String()
+}
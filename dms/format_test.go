@@ -0,0 +1,104 @@
+package dms_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/dms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	pittsburgh := geodesy.Point{40.446111, -79.982222}
+	tests := []struct {
+		name      string
+		p         geodesy.Point
+		style     dms.Style
+		precision int
+		want      string
+	}{
+		{name: "OK/decimal", p: pittsburgh, style: dms.Decimal, precision: 3, want: "40.446, -79.982"},
+		{name: "OK/decimal_hemisphere", p: pittsburgh, style: dms.DecimalHemisphere, precision: 3, want: "40.446°N 79.982°W"},
+		{name: "OK/ddm", p: pittsburgh, style: dms.DegreesMinutes, precision: 3, want: "40°26.767'N 79°58.933'W"},
+		{name: "OK/dms", p: pittsburgh, style: dms.DegreesMinutesSeconds, precision: 0, want: `40°26'46"N 79°58'56"W`},
+		{name: "OK/dms_precision", p: pittsburgh, style: dms.DegreesMinutesSeconds, precision: 2, want: `40°26'46.00"N 79°58'56.00"W`},
+		{name: "OK/south_east", p: geodesy.Point{-33.925, 18.4239}, style: dms.DegreesMinutes, precision: 1, want: "33°55.5'S 18°25.4'E"},
+		{name: "OK/seconds_carry", p: geodesy.Point{10.9999999, 20}, style: dms.DegreesMinutesSeconds, precision: 1, want: `11°0'0.0"N 20°0'0.0"E`},
+		{name: "OK/minutes_carry", p: geodesy.Point{10.99999, -20.99999}, style: dms.DegreesMinutes, precision: 2, want: "11°0.00'N 21°0.00'W"},
+		{name: "OK/negative_zero", p: geodesy.Point{-0.0000001, -0.0000001}, style: dms.Decimal, precision: 4, want: "0.0000, 0.0000"},
+		{name: "OK/negative_zero_hemisphere", p: geodesy.Point{-0.0000001, 0}, style: dms.DecimalHemisphere, precision: 2, want: "0.00°N 0.00°E"},
+		{name: "OK/negative_precision", p: geodesy.Point{1.4, 2.6}, style: dms.Decimal, precision: -1, want: "1, 3"},
+		{name: "FAIL/invalid_point", p: geodesy.Point{91, 0}, style: dms.Decimal, precision: 2, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dms.Format(tt.p, tt.style, tt.precision))
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		points := []geodesy.Point{{0, 0}, {-90, 180}, {45.123456789, -120.987654321}, {-12.000001, 0.5}}
+		for _, style := range []dms.Style{dms.Decimal, dms.DecimalHemisphere, dms.DegreesMinutes, dms.DegreesMinutesSeconds} {
+			for _, p := range points {
+				got, err := dms.Parse(dms.Format(p, style, 6))
+				assert.NoError(t, err)
+				assert.InDelta(t, p.Lat(), got.Lat(), 1e-6)
+				assert.InDelta(t, p.Lon(), got.Lon(), 1e-6)
+			}
+		}
+	})
+
+	t.Run("OK/latitude_longitude", func(t *testing.T) {
+		assert.Equal(t, `51°28'40"N`, dms.FormatLatitude(51.4778, dms.DegreesMinutesSeconds, 0))
+		assert.Equal(t, "0.0015°W", dms.FormatLongitude(-0.0015, dms.DecimalHemisphere, 4))
+		assert.Equal(t, "", dms.FormatLatitude(-90.5, dms.Decimal, 1))
+		assert.Equal(t, "", dms.FormatLongitude(181, dms.Decimal, 1))
+	})
+}
+
+func TestFormatter_Format(t *testing.T) {
+	pittsburgh := geodesy.Point{40.446111, -79.982222}
+	tests := []struct {
+		name      string
+		formatter dms.Formatter
+		want      string
+	}{
+		{name: "OK/zero_value", formatter: dms.Formatter{}, want: "40, -80"},
+		{name: "OK/decimal_prefix", formatter: dms.Formatter{Style: dms.DecimalHemisphere, Precision: 3, HemispherePrefix: true}, want: "N40.446 W79.982"},
+		{name: "OK/ddm_prefix", formatter: dms.Formatter{Style: dms.DegreesMinutes, Precision: 3, HemispherePrefix: true}, want: "N40°26.767' W79°58.933'"},
+		{name: "OK/dms_prefix", formatter: dms.Formatter{Style: dms.DegreesMinutesSeconds, HemispherePrefix: true}, want: `N40°26'46" W79°58'56"`},
+		{name: "OK/signed_prefix", formatter: dms.Formatter{Style: dms.Decimal, Precision: 1, HemispherePrefix: true}, want: "40.4, -80.0"},
+		{name: "OK/decimal_comma", formatter: dms.Formatter{Style: dms.Decimal, Precision: 3, DecimalComma: true}, want: "40,446; -79,982"},
+		{name: "OK/decimal_comma_hemisphere", formatter: dms.Formatter{Style: dms.DecimalHemisphere, Precision: 3, DecimalComma: true}, want: "40,446°N 79,982°W"},
+		{name: "OK/decimal_comma_dms", formatter: dms.Formatter{Style: dms.DegreesMinutesSeconds, Precision: 1, DecimalComma: true}, want: `40°26'46,0"N 79°58'56,0"W`},
+		{name: "OK/decimal_comma_integer", formatter: dms.Formatter{Style: dms.Decimal, DecimalComma: true}, want: "40; -80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.formatter.Format(pittsburgh))
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		points := []geodesy.Point{{0, 0}, {-90, 180}, {45.123456789, -120.987654321}, {-12.000001, 0.5}}
+		for _, style := range []dms.Style{dms.Decimal, dms.DecimalHemisphere, dms.DegreesMinutes, dms.DegreesMinutesSeconds} {
+			for _, formatter := range []dms.Formatter{
+				{Style: style, Precision: 6, HemispherePrefix: true},
+				{Style: style, Precision: 6, DecimalComma: true},
+				{Style: style, Precision: 6, HemispherePrefix: true, DecimalComma: true},
+			} {
+				for _, p := range points {
+					got, err := dms.Parse(formatter.Format(p))
+					assert.NoError(t, err, formatter.Format(p))
+					assert.InDelta(t, p.Lat(), got.Lat(), 1e-6)
+					assert.InDelta(t, p.Lon(), got.Lon(), 1e-6)
+				}
+			}
+		}
+	})
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.Equal(t, "", dms.Formatter{HemispherePrefix: true}.Format(geodesy.Point{0, 181}))
+		assert.Equal(t, "", dms.Formatter{DecimalComma: true}.FormatLatitude(-91))
+	})
+}
@@ -0,0 +1,478 @@
+// Package dms parses and formats geographic coordinates as strings in degrees, minutes and seconds
+// (DMS), degrees and decimal minutes (DDM) or decimal degrees, with or without hemisphere letters
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+var (
+	// ErrSyntax is returned when a string does not hold coordinates in a supported format
+	ErrSyntax = errors.New("dms: invalid syntax")
+	// ErrRange is returned when a coordinate, or any of its minutes or seconds, is out of range
+	ErrRange = errors.New("dms: value out of range")
+)
+
+// unit identifies the component of an angle a number represents
+type unit int
+
+const (
+	none unit = iota
+	degrees
+	minutes
+	seconds
+)
+
+func (u unit) String() string {
+	switch u {
+	case degrees:
+		return "degrees"
+	case minutes:
+		return "minutes"
+	case seconds:
+		return "seconds"
+	}
+
+	return "number"
+}
+
+// Symbols accepted for each unit, including the typographic and the ASCII variants in common use
+var symbols = map[rune]unit{
+	'°': degrees, 'º': degrees, '˚': degrees,
+	'\'': minutes, '′': minutes, '’': minutes, '‘': minutes, 'ʹ': minutes, '´': minutes, '`': minutes,
+	'"': seconds, '″': seconds, '”': seconds, '“': seconds, 'ʺ': seconds,
+}
+
+type tokenKind int
+
+const (
+	number tokenKind = iota
+	symbol
+	sign
+	hemisphere
+	separator
+)
+
+type token struct {
+	kind tokenKind
+	// offset holds the position of the token in the input, in bytes
+	offset int
+	text   string
+	value  float64
+	// fractional reports whether a number has a decimal part
+	fractional bool
+	unit       unit
+	negative   bool
+	hemisphere rune
+}
+
+// tokenize splits s into tokens, skipping whitespace and colons. Commas between two digits are taken
+// as decimal separators when decimalComma is set, and as separators otherwise
+func tokenize(s string, decimalComma bool) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r) || r == ':':
+			i += size
+		case r >= '0' && r <= '9' || r == '.':
+			t, err := scanNumber(s, i, decimalComma)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i += len(t.text)
+		case r == '\'' && strings.HasPrefix(s[i+1:], "'"), r == '′' && strings.HasPrefix(s[i+size:], "′"):
+			// Two primes stand for a double prime
+			tokens = append(tokens, token{kind: symbol, offset: i, text: s[i : i+2*size], unit: seconds})
+			i += 2 * size
+		case symbols[r] != none:
+			tokens = append(tokens, token{kind: symbol, offset: i, text: s[i : i+size], unit: symbols[r]})
+			i += size
+		case r == '+' || r == '-' || r == '−' || r == '–':
+			tokens = append(tokens, token{kind: sign, offset: i, text: s[i : i+size], negative: r != '+'})
+			i += size
+		case strings.ContainsRune("NSEWnsew", r):
+			if next, _ := utf8.DecodeRuneInString(s[i+size:]); unicode.IsLetter(next) {
+				return nil, fmt.Errorf("%w: unexpected word at offset %d in %q", ErrSyntax, i, s)
+			}
+			// A letter between digits is not a hemisphere, but rather an exponent as in 1e5
+			if i > 0 && isDigit(s[i-1]) && i+size < len(s) && isDigit(s[i+size]) {
+				return nil, fmt.Errorf("%w: unexpected %q between digits at offset %d in %q", ErrSyntax, r, i, s)
+			}
+			tokens = append(tokens, token{kind: hemisphere, offset: i, text: s[i : i+size], hemisphere: unicode.ToUpper(r)})
+			i += size
+		case r == ',' || r == ';' || r == '/':
+			tokens = append(tokens, token{kind: separator, offset: i, text: s[i : i+size]})
+			i += size
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q at offset %d in %q", ErrSyntax, r, i, s)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// scanNumber scans the decimal number starting at offset i of s
+func scanNumber(s string, i int, decimalComma bool) (token, error) {
+	end := i
+	point := -1
+	for end < len(s) {
+		c := s[end]
+		isDecimal := c == '.' || decimalComma && c == ','
+		switch {
+		case c >= '0' && c <= '9':
+		case isDecimal && point < 0 && (c == '.' || end > i && end+1 < len(s) && s[end+1] >= '0' && s[end+1] <= '9'):
+			point = end
+		default:
+			return newNumber(s, i, end, point)
+		}
+		end++
+	}
+
+	return newNumber(s, i, end, point)
+}
+
+func newNumber(s string, start, end, point int) (token, error) {
+	text := s[start:end]
+	digits := text
+	if point >= 0 {
+		digits = text[:point-start] + "." + text[point-start+1:]
+	}
+	if digits == "." {
+		return token{}, fmt.Errorf("%w: misplaced decimal point at offset %d in %q", ErrSyntax, start, s)
+	}
+	v, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return token{}, fmt.Errorf("%w: invalid number %q at offset %d in %q", ErrSyntax, text, start, s)
+	}
+
+	return token{kind: number, offset: start, text: text, value: v, fractional: point >= 0}, nil
+}
+
+// component represents a number along with the unit it stands for
+type component struct {
+	token
+	unit unit
+}
+
+// coordinate represents the tokens making up a single latitude or longitude
+type coordinate struct {
+	components []component
+	signed     *token
+	hemisphere *token
+}
+
+func (c *coordinate) empty() bool {
+	return len(c.components) == 0 && c.signed == nil && c.hemisphere == nil
+}
+
+// lastUnit returns the unit of the last component of c, inferring the one of unmarked numbers
+// from their position
+func (c *coordinate) lastUnit() unit {
+	if len(c.components) == 0 {
+		return none
+	}
+	return c.components[len(c.components)-1].unit
+}
+
+// group splits tokens into coordinates. A coordinate ends at a separator, at a hemisphere letter
+// following its numbers, and before a sign, a prefixed hemisphere letter or a number which cannot
+// belong to it, that is, a number following its seconds or a decimal number, or marked with a unit
+// not greater than the one of its last number. An even amount of unmarked numbers alone, as in
+// "40 26 79 58", is split evenly when two coordinates are expected
+func group(s string, tokens []token, expected int) ([]*coordinate, error) {
+	if expected == 2 && unmarked(tokens) {
+		half := len(tokens) / 2
+		coordinates := []*coordinate{{}, {}}
+		for i, t := range tokens {
+			c := coordinates[i/half]
+			c.components = append(c.components, component{token: t, unit: c.lastUnit() + 1})
+		}
+		return coordinates, nil
+	}
+
+	coordinates := []*coordinate{{}}
+	current := coordinates[0]
+	next := func() {
+		if !current.empty() {
+			current = &coordinate{}
+			coordinates = append(coordinates, current)
+		}
+	}
+
+	// closed reports whether the previous token was a hemisphere letter ending a coordinate
+	closed := false
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		wasClosed := closed
+		closed = false
+		switch t.kind {
+		case separator:
+			if current.empty() && !wasClosed {
+				return nil, fmt.Errorf("%w: unexpected %q at offset %d in %q", ErrSyntax, t.text, t.offset, s)
+			}
+			next()
+		case sign:
+			if len(current.components) > 0 || current.signed != nil {
+				next()
+			}
+			current.signed = &tokens[i]
+		case hemisphere:
+			switch {
+			case len(current.components) == 0 && current.hemisphere == nil:
+				current.hemisphere = &tokens[i]
+			case len(current.components) > 0 && current.hemisphere == nil:
+				current.hemisphere = &tokens[i]
+				next()
+				closed = true
+			default:
+				next()
+				current.hemisphere = &tokens[i]
+			}
+		case symbol:
+			return nil, fmt.Errorf("%w: unexpected %q at offset %d in %q", ErrSyntax, t.text, t.offset, s)
+		case number:
+			u := none
+			if i+1 < len(tokens) && tokens[i+1].kind == symbol {
+				u = tokens[i+1].unit
+				i++
+			}
+
+			last := current.lastUnit()
+			if len(current.components) > 0 {
+				previous := current.components[len(current.components)-1]
+				if previous.fractional || last == seconds || u != none && u <= last {
+					next()
+					last = none
+				}
+			}
+			if u == none {
+				u = last + 1
+			}
+			current.components = append(current.components, component{token: t, unit: u})
+		}
+	}
+	if current.empty() {
+		coordinates = coordinates[:len(coordinates)-1]
+	}
+
+	return coordinates, nil
+}
+
+// unmarked reports whether tokens are made of an even amount of up to 6 numbers, without any
+// symbols, signs, hemisphere letters or separators
+func unmarked(tokens []token) bool {
+	if len(tokens) == 0 || len(tokens)%2 != 0 || len(tokens) > 6 {
+		return false
+	}
+	for _, t := range tokens {
+		if t.kind != number {
+			return false
+		}
+	}
+
+	return true
+}
+
+// value returns the angle represented by c in decimal degrees, signed by its sign or hemisphere
+func (c *coordinate) value(s string) (float64, error) {
+	if len(c.components) == 0 {
+		return 0, fmt.Errorf("%w: missing degrees in %q", ErrSyntax, s)
+	}
+
+	v := 0.0
+	previous := none
+	for i, comp := range c.components {
+		if comp.unit <= previous {
+			return 0, fmt.Errorf("%w: %s after %s at offset %d in %q", ErrSyntax, comp.unit, previous, comp.offset, s)
+		}
+		if comp.fractional && i < len(c.components)-1 {
+			return 0, fmt.Errorf("%w: decimal %s followed by more components at offset %d in %q", ErrSyntax, comp.unit, comp.offset, s)
+		}
+		if i == 0 && comp.unit != degrees {
+			return 0, fmt.Errorf("%w: %s without degrees at offset %d in %q", ErrSyntax, comp.unit, comp.offset, s)
+		}
+		if comp.unit == seconds && previous != minutes {
+			return 0, fmt.Errorf("%w: seconds without minutes at offset %d in %q", ErrSyntax, comp.offset, s)
+		}
+
+		switch comp.unit {
+		case degrees:
+			v += comp.value
+		case minutes, seconds:
+			if comp.value >= 60 {
+				return 0, fmt.Errorf("%w: %s %s must be less than 60 at offset %d in %q", ErrRange, comp.unit, comp.text, comp.offset, s)
+			}
+			if comp.unit == minutes {
+				v += comp.value / 60
+			} else {
+				v += comp.value / 3600
+			}
+		}
+		previous = comp.unit
+	}
+
+	negative := c.signed != nil && c.signed.negative
+	if c.hemisphere != nil {
+		if c.signed != nil {
+			return 0, fmt.Errorf("%w: both a sign and a hemisphere at offset %d in %q", ErrSyntax, c.signed.offset, s)
+		}
+		negative = c.hemisphere.hemisphere == 'S' || c.hemisphere.hemisphere == 'W'
+	}
+	if negative {
+		v = -v
+	}
+
+	return v, nil
+}
+
+// isLongitude reports whether the hemisphere of c is east or west
+func (c *coordinate) isLongitude() bool {
+	return c.hemisphere != nil && (c.hemisphere.hemisphere == 'E' || c.hemisphere.hemisphere == 'W')
+}
+
+// isLatitude reports whether the hemisphere of c is north or south
+func (c *coordinate) isLatitude() bool {
+	return c.hemisphere != nil && (c.hemisphere.hemisphere == 'N' || c.hemisphere.hemisphere == 'S')
+}
+
+// split tokenizes and groups s into coordinates. Decimal commas are attempted first, and commas are
+// taken as separators if that does not result in the expected amount of coordinates
+func split(s string, expected int) ([]*coordinate, error) {
+	var err error
+	for _, decimalComma := range []bool{true, false} {
+		var tokens []token
+		tokens, err = tokenize(s, decimalComma)
+		if err != nil {
+			continue
+		}
+		var coordinates []*coordinate
+		coordinates, err = group(s, tokens, expected)
+		if err != nil {
+			continue
+		}
+		if len(coordinates) == expected {
+			return coordinates, nil
+		}
+		if len(coordinates) == 0 {
+			err = fmt.Errorf("%w: no coordinates in %q", ErrSyntax, s)
+		} else {
+			err = fmt.Errorf("%w: found %d coordinates instead of %d in %q", ErrSyntax, len(coordinates), expected, s)
+		}
+	}
+
+	return nil, err
+}
+
+// Parse parses a latitude-longitude pair such as any of
+//
+//	40°26'46"N 79°58'56"W
+//	40° 26.767′ N, 79° 58.933′ W
+//	N40.446 W79.982
+//	40.446, -79.982
+//	40,446; -79,982
+//	40:26:46 -79:58:56
+//
+// Each coordinate is made of degrees optionally followed by minutes and seconds, where only the last
+// component may have a decimal part, and signed either with a sign or a hemisphere letter (preceding
+// or following it). Components may be marked with degree, minute and second symbols (°, ′ and ″, along
+// with the common ASCII and typographic variants), or separated by whitespace or colons.
+// Coordinates may be separated by whitespace, commas, semicolons or slashes, and decimal commas are
+// accepted. Coordinates are given latitude first, unless their hemisphere letters state otherwise.
+// The returned errors wrap either ErrSyntax or ErrRange, and locate the offending part of s by its
+// offset in bytes. A single coordinate, such as "40 26.767 N", is rejected with an error wrapping
+// ErrSyntax that refers to ParseLatitude and ParseLongitude, which parse it
+func Parse(s string) (geodesy.Point, error) {
+	coordinates, err := split(s, 2)
+	if err != nil {
+		if _, single := split(s, 1); single == nil {
+			return geodesy.Point{}, fmt.Errorf("%w: found a single coordinate in %q, which ParseLatitude or "+
+				"ParseLongitude parse", ErrSyntax, s)
+		}
+		return geodesy.Point{}, err
+	}
+
+	lat, lon := coordinates[0], coordinates[1]
+	if lat.isLongitude() || lon.isLatitude() {
+		lat, lon = lon, lat
+	}
+	if lat.isLongitude() || lon.isLatitude() {
+		return geodesy.Point{}, fmt.Errorf("%w: both coordinates have the same axis in %q", ErrSyntax, s)
+	}
+
+	φ, err := lat.value(s)
+	if err != nil {
+		return geodesy.Point{}, err
+	}
+	λ, err := lon.value(s)
+	if err != nil {
+		return geodesy.Point{}, err
+	}
+	if err := checkRange(s, φ, λ); err != nil {
+		return geodesy.Point{}, err
+	}
+
+	return geodesy.Point{φ, λ}, nil
+}
+
+// ParseLatitude parses a single latitude in any of the formats accepted by Parse.
+// Its hemisphere letter, if any, must be N or S
+func ParseLatitude(s string) (float64, error) {
+	return parseAngle(s, (*coordinate).isLongitude, "latitude")
+}
+
+// ParseLongitude parses a single longitude in any of the formats accepted by Parse.
+// Its hemisphere letter, if any, must be E or W
+func ParseLongitude(s string) (float64, error) {
+	return parseAngle(s, (*coordinate).isLatitude, "longitude")
+}
+
+func parseAngle(s string, wrongAxis func(*coordinate) bool, axis string) (float64, error) {
+	coordinates, err := split(s, 1)
+	if err != nil {
+		return math.NaN(), err
+	}
+	c := coordinates[0]
+	if wrongAxis(c) {
+		return math.NaN(), fmt.Errorf("%w: hemisphere %q at offset %d is not valid for a %s in %q",
+			ErrSyntax, c.hemisphere.text, c.hemisphere.offset, axis, s)
+	}
+
+	v, err := c.value(s)
+	if err != nil {
+		return math.NaN(), err
+	}
+	φ, λ := 0.0, v
+	if axis == "latitude" {
+		φ, λ = v, 0
+	}
+	if err := checkRange(s, φ, λ); err != nil {
+		return math.NaN(), err
+	}
+
+	return v, nil
+}
+
+func checkRange(s string, φ, λ float64) error {
+	if φ < geodesy.LatLowerBound || φ > geodesy.LatUpperBound {
+		return fmt.Errorf("%w: latitude %v in %q", ErrRange, φ, s)
+	}
+	if λ < geodesy.LonLowerBound || λ > geodesy.LonUpperBound {
+		return fmt.Errorf("%w: longitude %v in %q", ErrRange, λ, s)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package dms_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/dms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	pittsburgh := geodesy.Point{40 + 26.0/60 + 46.0/3600, -(79 + 58.0/60 + 56.0/3600)}
+	tests := []struct {
+		name  string
+		input string
+		want  geodesy.Point
+	}{
+		{name: "OK/dms", input: `40°26'46"N 79°58'56"W`, want: pittsburgh},
+		{name: "OK/dms_unicode_primes", input: "40° 26′ 46″ N, 79° 58′ 56″ W", want: pittsburgh},
+		{name: "OK/dms_double_apostrophe", input: "40°26'46''N 79°58'56''W", want: pittsburgh},
+		{name: "OK/dms_ordinal_indicator", input: "40º26’46”N 79º58’56”W", want: pittsburgh},
+		{name: "OK/dms_colons", input: "40:26:46 -79:58:56", want: pittsburgh},
+		{name: "OK/dms_unmarked", input: "40 26 46 N 79 58 56 W", want: pittsburgh},
+		{name: "OK/dms_prefixed_hemisphere", input: `N 40°26'46" W 79°58'56"`, want: pittsburgh},
+		{name: "OK/dms_longitude_first", input: `79°58'56"W 40°26'46"N`, want: pittsburgh},
+		{name: "OK/ddm", input: "40 26.767 N 79 58.933 W", want: geodesy.Point{40 + 26.767/60, -(79 + 58.933/60)}},
+		{name: "OK/dm_unmarked", input: "40 26 79 58", want: geodesy.Point{40 + 26.0/60, 79 + 58.0/60}},
+		{name: "OK/ddm_symbols", input: "40°26.767'N, 79°58.933'W", want: geodesy.Point{40 + 26.767/60, -(79 + 58.933/60)}},
+		{name: "OK/decimal_prefixed_hemisphere", input: "N40.446 W79.982", want: geodesy.Point{40.446, -79.982}},
+		{name: "OK/decimal_suffixed_hemisphere", input: "40.446°n 79.982°w", want: geodesy.Point{40.446, -79.982}},
+		{name: "OK/decimal_signed", input: "40.446, -79.982", want: geodesy.Point{40.446, -79.982}},
+		{name: "OK/decimal_minus_sign", input: "−40.446 +79.982", want: geodesy.Point{-40.446, 79.982}},
+		{name: "OK/decimal_unsigned", input: "40.446 79.982", want: geodesy.Point{40.446, 79.982}},
+		{name: "OK/decimal_comma", input: "40,446; -79,982", want: geodesy.Point{40.446, -79.982}},
+		{name: "OK/decimal_comma_separated", input: "40,446,-79,982", want: geodesy.Point{40.446, -79.982}},
+		{name: "OK/integer_comma_separated", input: "40,5", want: geodesy.Point{40, 5}},
+		{name: "OK/slash", input: "-33.9/18.4", want: geodesy.Point{-33.9, 18.4}},
+		{name: "OK/south_east", input: `33°55'S 18°25'E`, want: geodesy.Point{-(33 + 55.0/60), 18 + 25.0/60}},
+		{name: "OK/bounds", input: "90S 180E", want: geodesy.Point{-90, 180}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dms.Parse(tt.input)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.Lat(), got.Lat(), 1e-12)
+			assert.InDelta(t, tt.want.Lon(), got.Lon(), 1e-12)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		err     error
+		message string
+	}{
+		{name: "FAIL/empty", input: "", err: dms.ErrSyntax, message: `dms: invalid syntax: no coordinates in ""`},
+		{name: "FAIL/single", input: "40.446", err: dms.ErrSyntax, message: `dms: invalid syntax: found a single coordinate in "40.446", which ParseLatitude or ParseLongitude parse`},
+		{name: "FAIL/single_ddm", input: "40 26.767 N", err: dms.ErrSyntax, message: `dms: invalid syntax: found a single coordinate in "40 26.767 N", which ParseLatitude or ParseLongitude parse`},
+		{name: "FAIL/unexpected_character", input: "40.446 x79", err: dms.ErrSyntax, message: `dms: invalid syntax: unexpected character 'x' at offset 7 in "40.446 x79"`},
+		{name: "FAIL/word", input: "40 North 79 West", err: dms.ErrSyntax, message: `dms: invalid syntax: unexpected word at offset 3 in "40 North 79 West"`},
+		{name: "FAIL/exponent", input: "1e5 3", err: dms.ErrSyntax, message: `dms: invalid syntax: unexpected 'e' between digits at offset 1 in "1e5 3"`},
+		{name: "FAIL/hemisphere_between_digits", input: "40.5N79.5W", err: dms.ErrSyntax},
+		{name: "FAIL/minutes_range", input: "40°61'N 79°58'W", err: dms.ErrRange, message: `dms: value out of range: minutes 61 must be less than 60 at offset 4 in "40°61'N 79°58'W"`},
+		{name: "FAIL/seconds_range", input: `40°26'60"N 79°58'56"W`, err: dms.ErrRange},
+		{name: "FAIL/latitude_range", input: "91 0", err: dms.ErrRange, message: `dms: value out of range: latitude 91 in "91 0"`},
+		{name: "FAIL/longitude_range", input: "10S 180.5W", err: dms.ErrRange},
+		{name: "FAIL/same_axis", input: "40N 79N", err: dms.ErrSyntax},
+		{name: "FAIL/sign_and_hemisphere", input: "-40N 79W", err: dms.ErrSyntax},
+		{name: "FAIL/seconds_without_minutes", input: `40°46"N 79°W`, err: dms.ErrSyntax},
+		{name: "FAIL/decimal_degrees_and_minutes", input: "40.5°26'N 79°W", err: dms.ErrSyntax},
+		{name: "FAIL/dangling_symbol", input: "40 ° 26 79", err: dms.ErrSyntax},
+		{name: "FAIL/leading_separator", input: ", 40 79", err: dms.ErrSyntax},
+		{name: "FAIL/lone_point", input: ". 40 79", err: dms.ErrSyntax},
+		{name: "FAIL/three_coordinates", input: "1.5 2.5 3.5", err: dms.ErrSyntax},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dms.Parse(tt.input)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.message != "" {
+				assert.EqualError(t, err, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseLatitudeLongitude(t *testing.T) {
+	t.Run("OK/latitude", func(t *testing.T) {
+		φ, err := dms.ParseLatitude(`33°55'30"S`)
+		assert.NoError(t, err)
+		assert.InDelta(t, -(33 + 55.5/60), φ, 1e-12)
+	})
+
+	t.Run("OK/ddm", func(t *testing.T) {
+		φ, err := dms.ParseLatitude("40 26.767 N")
+		assert.NoError(t, err)
+		assert.InDelta(t, 40+26.767/60, φ, 1e-12)
+	})
+
+	t.Run("OK/unmarked_minutes", func(t *testing.T) {
+		φ, err := dms.ParseLatitude("-12 30")
+		assert.NoError(t, err)
+		assert.InDelta(t, -12.5, φ, 1e-12)
+	})
+
+	t.Run("OK/longitude", func(t *testing.T) {
+		λ, err := dms.ParseLongitude("E 151,2093")
+		assert.NoError(t, err)
+		assert.InDelta(t, 151.2093, λ, 1e-12)
+	})
+
+	t.Run("FAIL/latitude_hemisphere", func(t *testing.T) {
+		_, err := dms.ParseLatitude("33.9E")
+		assert.EqualError(t, err, `dms: invalid syntax: hemisphere "E" at offset 4 is not valid for a latitude in "33.9E"`)
+	})
+
+	t.Run("FAIL/longitude_hemisphere", func(t *testing.T) {
+		_, err := dms.ParseLongitude("N151")
+		assert.ErrorIs(t, err, dms.ErrSyntax)
+	})
+
+	t.Run("FAIL/latitude_range", func(t *testing.T) {
+		_, err := dms.ParseLatitude("120")
+		assert.ErrorIs(t, err, dms.ErrRange)
+	})
+
+	t.Run("FAIL/pair", func(t *testing.T) {
+		_, err := dms.ParseLongitude("40N 79W")
+		assert.ErrorIs(t, err, dms.ErrSyntax)
+	})
+}
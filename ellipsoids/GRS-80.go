@@ -1,4 +1,5 @@
 package ellipsoids
+
 /*
 	This file contains the constant definitions for the GRS-80 ellipsoid
 
@@ -16,13 +17,13 @@ const (
 // Defining physical constants
 const (
 	// Geocentric gravitational constant GM, defined in (m^3)/(s^2)
-	GRS80_GEOCENTRIC_GRAVITATIONAL_CONSTANT float64 = 3_986_005_000_000_000
+	GRS80_GEOCENTRIC_GRAVITATIONAL_CONSTANT float64 = 398_600_500_000_000
 
 	// Dynamical form factor J2; adimensional
-	GRS80_DYNAMICAL_FORM_FACTOR float64 = 0.0108263
+	GRS80_DYNAMICAL_FORM_FACTOR float64 = 0.00108263
 
-	// Dynamical form factor ω; defined in s^-1
-	GRS80_ANGULAR_VELOCITY float64 = 0.0007292115
+	// Angular velocity ω; defined in s^-1
+	GRS80_ANGULAR_VELOCITY float64 = 0.00007292115
 )
 
 // Derived geometrical constants (all rounded)
@@ -56,11 +57,20 @@ const (
 	// Flattening f; adimensional
 	GRS80_FLATTENING float64 = 0.003352810681183637418
 	// Flattening inverse (1/f); adimensional
-	GRS80_FLATTENING_INVERSE float64 = 1/0.003352810681183637418
+	GRS80_FLATTENING_INVERSE float64 = 1 / 0.003352810681183637418
 )
 
 // Derived physical constants (all rounded)
 const (
 	// Period of rotation (sidereal day) = 2π/ω; defined in seconds (s)
-	GRS80_ROTATION_PERIOD float64 = 8_616.4100637
-)
\ No newline at end of file
+	GRS80_ROTATION_PERIOD float64 = 86_164.100637
+
+	// Normal potential U0 on the surface of the ellipsoid; defined in (m^2)/(s^2)
+	GRS80_NORMAL_POTENTIAL float64 = 62_636_860.850
+	// Geodetic constant m = (ω^2)(a^2)b/GM; adimensional
+	GRS80_M float64 = 0.00344978600308
+	// Normal gravity at the equator γe; defined in (m)/(s^2)
+	GRS80_EQUATORIAL_GRAVITY float64 = 9.7803267715
+	// Normal gravity at the poles γp; defined in (m)/(s^2)
+	GRS80_POLAR_GRAVITY float64 = 9.8321863685
+)
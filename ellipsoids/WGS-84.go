@@ -1,4 +1,5 @@
 package ellipsoids
+
 /*
 	This file contains the constant definitions for the WGS-84 ellipsoid
 
@@ -16,14 +17,14 @@ const (
 // Defining physical constants
 const (
 	// WGS84_GEOCENTRIC_GRAVITATIONAL_CONSTANT Geocentric gravitational constant GM, defined in (m^3)/(s^2)
-	WGS84_GEOCENTRIC_GRAVITATIONAL_CONSTANT float64 = 3_986_005_000_000_000
+	WGS84_GEOCENTRIC_GRAVITATIONAL_CONSTANT float64 = 398_600_441_800_000
 
 	// WGS84_DYNAMICAL_FORM_FACTOR Dynamical form factor J2; adimensional
 	// See https://ahrs.readthedocs.io/en/latest/wgs84.html#ahrs.utils.wgs84.WGS.dynamical_form_factor
 	WGS84_DYNAMICAL_FORM_FACTOR float64 = 0.0010826298213129219
 
-	// WGS84_ANGULAR_VELOCITY Dynamical form factor ω; defined in s^-1
-	WGS84_ANGULAR_VELOCITY float64 = 0.0007292115
+	// WGS84_ANGULAR_VELOCITY Angular velocity ω; defined in s^-1
+	WGS84_ANGULAR_VELOCITY float64 = 0.00007292115
 )
 
 // Derived geometrical constants (all rounded)
@@ -55,7 +56,7 @@ const (
 	WGS84_LINEAR_ECCENTRICITY_POLES = 0.0818191918426205
 
 	// WGS84_FLATTENING Flattening f; adimensional
-	WGS84_FLATTENING float64 = 1/298.257223563
+	WGS84_FLATTENING float64 = 1 / 298.257223563
 	// WGS84_FLATTENING_INVERSE Flattening inverse (1/f); adimensional
 	WGS84_FLATTENING_INVERSE float64 = 298.257223563
 )
@@ -63,5 +64,14 @@ const (
 // Derived physical constants (all rounded)
 const (
 	// WGS84_ROTATION_PERIOD Period of rotation (sidereal day) = 2π/ω; defined in seconds (s)
-	WGS84_ROTATION_PERIOD float64 = 8_616.4100637
-)
\ No newline at end of file
+	WGS84_ROTATION_PERIOD float64 = 86_164.100637
+
+	// WGS84_NORMAL_POTENTIAL Normal potential U0 on the surface of the ellipsoid; defined in (m^2)/(s^2)
+	WGS84_NORMAL_POTENTIAL float64 = 62_636_851.7146
+	// WGS84_M Geodetic constant m = (ω^2)(a^2)b/GM; adimensional
+	WGS84_M float64 = 0.00344978650684
+	// WGS84_EQUATORIAL_GRAVITY Normal gravity at the equator γe; defined in (m)/(s^2)
+	WGS84_EQUATORIAL_GRAVITY float64 = 9.7803253359
+	// WGS84_POLAR_GRAVITY Normal gravity at the poles γp; defined in (m)/(s^2)
+	WGS84_POLAR_GRAVITY float64 = 9.8321849378
+)
@@ -0,0 +1,38 @@
+package ellipsoids
+
+// Ellipsoid represents a reference ellipsoid by its defining geometrical parameters
+type Ellipsoid struct {
+	// Semi major axis a, defined in meters (m)
+	SemiMajorAxis float64
+	// Flattening f; adimensional
+	Flattening float64
+}
+
+var (
+	// WGS84 is the reference ellipsoid of the World Geodetic System 1984
+	WGS84 = Ellipsoid{SemiMajorAxis: WGS84_SEMI_MAJOR_AXIS, Flattening: WGS84_FLATTENING}
+	// GRS80 is the reference ellipsoid of the Geodetic Reference System 1980
+	GRS80 = Ellipsoid{SemiMajorAxis: GRS80_SEMI_MAJOR_AXIS, Flattening: GRS80_FLATTENING}
+	// Airy1830 is the reference ellipsoid of the OSGB36 datum of Great Britain
+	Airy1830 = Ellipsoid{SemiMajorAxis: 6_377_563.396, Flattening: 1 - 6_356_256.909/6_377_563.396}
+	// AiryModified is the reference ellipsoid of the Ireland 1965 datum of the Irish Grid
+	AiryModified = Ellipsoid{SemiMajorAxis: 6_377_340.189, Flattening: 1 - 6_356_034.447/6_377_340.189}
+	// Bessel1841 is the reference ellipsoid of the CH1903 and CH1903+ datums of Switzerland
+	Bessel1841 = Ellipsoid{SemiMajorAxis: 6_377_397.155, Flattening: 1 / 299.1528128}
+)
+
+// SemiMinorAxis returns the semi minor axis b = (1 − ƒ) a of e, in meters (m)
+func (e Ellipsoid) SemiMinorAxis() float64 {
+	return e.SemiMajorAxis * (1 - e.Flattening)
+}
+
+// EccentricitySquared returns the squared first eccentricity e² = ƒ(2 − ƒ) of e
+func (e Ellipsoid) EccentricitySquared() float64 {
+	return e.Flattening * (2 - e.Flattening)
+}
+
+// SecondEccentricitySquared returns the squared second eccentricity e'² = e²/(1 − e²) of e
+func (e Ellipsoid) SecondEccentricitySquared() float64 {
+	e2 := e.EccentricitySquared()
+	return e2 / (1 - e2)
+}
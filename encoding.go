@@ -0,0 +1,229 @@
+package geodesy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy/internal/iso6709"
+)
+
+// Layout represents the representation of points in JSON
+type Layout int
+
+const (
+	// LatLonLayout represents points as [lat, lon] arrays, as Point.MarshalJSON encodes them
+	LatLonLayout Layout = iota
+	// GeoJSONLayout represents points as [lon, lat] arrays, as GeoJSON positions
+	GeoJSONLayout
+	// ObjectLayout represents points as {"lat": lat, "lon": lon} objects
+	ObjectLayout
+	// ISO6709Layout represents points as ISO 6709 strings in decimal degrees, such as "+40.20361-075.00417/"
+	ISO6709Layout
+)
+
+// ErrInvalidPoint is returned when decoding a point which does not constitute a valid geographic
+// coordinate. Points are encoded as they are, so that encoding never fails for values which were
+// encoded before they were validated
+var ErrInvalidPoint = errors.New("geodesy: invalid point")
+
+// pointBinaryVersion is the first byte of the binary encoding of points, which allows for its evolution
+const pointBinaryVersion = 1
+
+// jsonPoint is the representation of points in ObjectLayout
+type jsonPoint struct {
+	Lat *float64 `json:"lat"`
+	Lon *float64 `json:"lon"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding p in LatLonLayout
+func (p Point) MarshalJSON() ([]byte, error) {
+	return p.MarshalJSONLayout(LatLonLayout)
+}
+
+// MarshalJSONLayout encodes p as JSON in the given layout
+func (p Point) MarshalJSONLayout(layout Layout) ([]byte, error) {
+	switch layout {
+	case LatLonLayout:
+		return json.Marshal([2]float64(p))
+	case GeoJSONLayout:
+		return json.Marshal([2]float64{p.Lon(), p.Lat()})
+	case ObjectLayout:
+		lat, lon := p.Lat(), p.Lon()
+		return json.Marshal(jsonPoint{Lat: &lat, Lon: &lon})
+	case ISO6709Layout:
+		s, err := p.iso6709()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(s)
+	}
+
+	return nil, unknownLayout(layout)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding p in LatLonLayout
+func (p *Point) UnmarshalJSON(data []byte) error {
+	return p.UnmarshalJSONLayout(data, LatLonLayout)
+}
+
+// UnmarshalJSONLayout decodes p from JSON in any of the layouts, taking arrays in the order of the given
+// layout: [lon, lat] in GeoJSONLayout, and [lat, lon] in any other. A null value leaves p unchanged
+func (p *Point) UnmarshalJSONLayout(data []byte, layout Layout) error {
+	if layout < LatLonLayout || layout > ISO6709Layout {
+		return unknownLayout(layout)
+	}
+
+	data = bytes.TrimSpace(data)
+	var q Point
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case bytes.HasPrefix(data, []byte("[")):
+		var position []float64
+		if err := json.Unmarshal(data, &position); err != nil {
+			return err
+		}
+		if len(position) != 2 {
+			return fmt.Errorf("geodesy: point array has %d elements instead of 2", len(position))
+		}
+		q = Point{position[0], position[1]}
+		if layout == GeoJSONLayout {
+			q = Point{position[1], position[0]}
+		}
+	case bytes.HasPrefix(data, []byte("{")):
+		var o jsonPoint
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+		if o.Lat == nil || o.Lon == nil {
+			return errors.New(`geodesy: point object must have both "lat" and "lon"`)
+		}
+		q = Point{*o.Lat, *o.Lon}
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(s))
+	default:
+		return fmt.Errorf("geodesy: cannot decode point from %s", data)
+	}
+
+	if !q.Valid() {
+		return fmt.Errorf("%w: %v", ErrInvalidPoint, [2]float64(q))
+	}
+	*p = q
+
+	return nil
+}
+
+func unknownLayout(layout Layout) error {
+	return fmt.Errorf("geodesy: unknown layout %d", layout)
+}
+
+// GeoJSONPoint is a Point encoded and decoded in GeoJSONLayout, for use in the fields of structs
+type GeoJSONPoint Point
+
+// MarshalJSON implements json.Marshaler, encoding p in GeoJSONLayout
+func (p GeoJSONPoint) MarshalJSON() ([]byte, error) {
+	return Point(p).MarshalJSONLayout(GeoJSONLayout)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding p in GeoJSONLayout
+func (p *GeoJSONPoint) UnmarshalJSON(data []byte) error {
+	return (*Point)(p).UnmarshalJSONLayout(data, GeoJSONLayout)
+}
+
+// ObjectPoint is a Point encoded and decoded in ObjectLayout, for use in the fields of structs
+type ObjectPoint Point
+
+// MarshalJSON implements json.Marshaler, encoding p in ObjectLayout
+func (p ObjectPoint) MarshalJSON() ([]byte, error) {
+	return Point(p).MarshalJSONLayout(ObjectLayout)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding p in ObjectLayout
+func (p *ObjectPoint) UnmarshalJSON(data []byte) error {
+	return (*Point)(p).UnmarshalJSONLayout(data, ObjectLayout)
+}
+
+// ISO6709Point is a Point encoded and decoded in ISO6709Layout, for use in the fields of structs
+type ISO6709Point Point
+
+// MarshalJSON implements json.Marshaler, encoding p in ISO6709Layout
+func (p ISO6709Point) MarshalJSON() ([]byte, error) {
+	return Point(p).MarshalJSONLayout(ISO6709Layout)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding p in ISO6709Layout
+func (p *ISO6709Point) UnmarshalJSON(data []byte) error {
+	return (*Point)(p).UnmarshalJSONLayout(data, ISO6709Layout)
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding p as an ISO 6709 string in decimal degrees
+// with the shortest precision that represents it exactly
+func (p Point) MarshalText() ([]byte, error) {
+	s, err := p.iso6709()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding p from an ISO 6709 string in any of
+// its forms. Altitudes and CRS identifiers are accepted and discarded
+func (p *Point) UnmarshalText(text []byte) error {
+	l, err := iso6709.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = Point{l.Lat, l.Lon}
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding p as a version byte followed by its
+// latitude and longitude as big-endian IEEE 754 binary64 values
+func (p Point) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 17)
+	data[0] = pointBinaryVersion
+	binary.BigEndian.PutUint64(data[1:], math.Float64bits(p.Lat()))
+	binary.BigEndian.PutUint64(data[9:], math.Float64bits(p.Lon()))
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding p as encoded by MarshalBinary
+func (p *Point) UnmarshalBinary(data []byte) error {
+	if len(data) != 17 {
+		return fmt.Errorf("geodesy: point binary encoding has %d bytes instead of 17", len(data))
+	}
+	if data[0] != pointBinaryVersion {
+		return fmt.Errorf("geodesy: unsupported point binary encoding version %d", data[0])
+	}
+
+	q := Point{
+		math.Float64frombits(binary.BigEndian.Uint64(data[1:])),
+		math.Float64frombits(binary.BigEndian.Uint64(data[9:])),
+	}
+	if !q.Valid() {
+		return fmt.Errorf("%w: %v", ErrInvalidPoint, [2]float64(q))
+	}
+	*p = q
+
+	return nil
+}
+
+// iso6709 returns p as an ISO 6709 string in decimal degrees, with the shortest representation of
+// its latitude and longitude, which is decoded back exactly. Points out of range are encoded as well,
+// unless their coordinates are not finite or have more integer digits than ISO 6709 allows
+func (p Point) iso6709() (string, error) {
+	if math.IsNaN(p.Lat()) || math.IsNaN(p.Lon()) || math.Abs(p.Lat()) >= 100 || math.Abs(p.Lon()) >= 1000 {
+		return "", fmt.Errorf("geodesy: point %v cannot be encoded in ISO 6709", [2]float64(p))
+	}
+	return iso6709.FormatExact(iso6709.Location{Lat: p.Lat(), Lon: p.Lon(), Alt: math.NaN()}), nil
+}
@@ -0,0 +1,357 @@
+package geojson
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geometry"
+)
+
+// perimeter is the length of the boundary of the rectangle of geographic coordinates, in degrees
+const perimeter = 1080
+
+// normalize returns g as it should be written according to RFC 7946, with polygon rings following the
+// right-hand rule (exterior rings counterclockwise and holes clockwise) and the geometries crossing the
+// antimeridian cut in two or more parts, so that polylines become multi-polylines and polygons become
+// multi-polygons when cut
+func normalize(g geometry.Geometry) geometry.Geometry {
+	switch g := g.(type) {
+	case geometry.LineString:
+		lines := cutLine(g)
+		if len(lines) == 1 {
+			return lines[0]
+		}
+		return lines
+	case geometry.MultiLineString:
+		var lines geometry.MultiLineString
+		for _, l := range g {
+			lines = append(lines, cutLine(l)...)
+		}
+		return lines
+	case geometry.Polygon:
+		polygons := cutPolygon(g)
+		if len(polygons) == 1 {
+			return polygons[0]
+		}
+		return polygons
+	case geometry.MultiPolygon:
+		var polygons geometry.MultiPolygon
+		for _, p := range g {
+			polygons = append(polygons, cutPolygon(p)...)
+		}
+		return polygons
+	case geometry.Collection:
+		collection := make(geometry.Collection, len(g))
+		for i, member := range g {
+			collection[i] = normalize(member)
+		}
+		return collection
+	}
+
+	return g
+}
+
+// split splits the polyline points at its crossings of the antimeridian, which are the segments whose
+// longitudes differ by more than 180°. The crossing latitudes are interpolated linearly, as segments are
+// straight lines in the coordinate space of GeoJSON. Parts may be made of a single point when points
+// start or end on the antimeridian
+func split(points []geodesy.Point) [][]geodesy.Point {
+	parts := [][]geodesy.Point{{points[0]}}
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		d := b.Lon() - a.Lon()
+		if math.Abs(d) <= 180 || a.Lat() == b.Lat() && math.Abs(a.Lat()) == geodesy.LatUpperBound {
+			// Segments along a pole are degenerate, as the ones closing rings around it
+			parts[len(parts)-1] = append(parts[len(parts)-1], b)
+			continue
+		}
+
+		// The segment leaves through the meridian at edge and enters through the opposite one
+		edge := geodesy.LonUpperBound
+		if d > 180 {
+			edge = geodesy.LonLowerBound
+		}
+		t := 0.0
+		if span := b.Lon() - math.Copysign(360, d) - a.Lon(); span != 0 {
+			t = (edge - a.Lon()) / span
+		}
+		lat := a.Lat() + t*(b.Lat()-a.Lat())
+
+		last := &parts[len(parts)-1]
+		if exit := (geodesy.Point{lat, edge}); !(*last)[len(*last)-1].Equals(exit) {
+			*last = append(*last, exit)
+		}
+		next := []geodesy.Point{{lat, -edge}}
+		if !b.Equals(next[0]) {
+			next = append(next, b)
+		}
+		parts = append(parts, next)
+	}
+
+	return parts
+}
+
+// cutLine returns the parts of l on each side of the antimeridian
+func cutLine(l geometry.LineString) geometry.MultiLineString {
+	if len(l) < 2 {
+		return geometry.MultiLineString{l}
+	}
+
+	var lines geometry.MultiLineString
+	for _, part := range split(l) {
+		if len(part) > 1 {
+			lines = append(lines, part)
+		}
+	}
+
+	return lines
+}
+
+// cutPolygon rewinds the rings of p following the right-hand rule and returns the polygons resulting
+// from cutting it along the antimeridian.
+//
+// Rings crossing the antimeridian are split into chains, which enter and leave the rectangle of geographic
+// coordinates through its left and right edges. Polygons are then rebuilt by following each chain with the
+// next chain start found walking counterclockwise along the boundary of the rectangle, which keeps the
+// interior to the left. Rings encircling a pole cross the antimeridian an odd amount of times, and are
+// closed along the boundary through the pole they encircle
+func cutPolygon(p geometry.Polygon) geometry.MultiPolygon {
+	if len(p) == 0 || len(p[0]) < 3 {
+		return geometry.MultiPolygon{p}
+	}
+
+	var rings []geometry.Ring
+	var chains [][]geodesy.Point
+	for i, r := range p {
+		r = rewind(r, i == 0)
+		closed := append(append(make([]geodesy.Point, 0, len(r)+1), r...), r[0])
+		parts := split(closed)
+		if len(parts) == 1 {
+			rings = append(rings, r)
+			continue
+		}
+
+		// The last part ends at the first point of the ring, where the first part starts
+		first := append(parts[len(parts)-1], parts[0][1:]...)
+		chains = append(chains, first)
+		chains = append(chains, parts[1:len(parts)-1]...)
+	}
+	if len(chains) == 0 {
+		return geometry.MultiPolygon{rings}
+	}
+
+	rings = append(rings, join(chains)...)
+
+	// Exterior rings are the counterclockwise ones, and holes are assigned to the one containing them
+	var polygons geometry.MultiPolygon
+	var holes []geometry.Ring
+	for _, r := range rings {
+		if signedArea(planar(r)) >= 0 {
+			polygons = append(polygons, geometry.Polygon{r})
+		} else {
+			holes = append(holes, r)
+		}
+	}
+	for _, h := range holes {
+		for i := range polygons {
+			if i == len(polygons)-1 || containsRing(polygons[i][0], h) {
+				polygons[i] = append(polygons[i], h)
+				break
+			}
+		}
+	}
+
+	return polygons
+}
+
+// join closes the chains into rings along the boundary of the rectangle of geographic coordinates
+func join(chains [][]geodesy.Point) []geometry.Ring {
+	var rings []geometry.Ring
+	used := make([]bool, len(chains))
+	for first := range chains {
+		if used[first] {
+			continue
+		}
+
+		var ring geometry.Ring
+		current := first
+		for steps := 0; steps <= len(chains); steps++ {
+			used[current] = true
+			ring = append(ring, chains[current]...)
+
+			// The next chain is the one whose start is found first walking from the end of the
+			// current one, passing by the corners of the rectangle in between
+			end := boundaryPosition(chains[current][len(chains[current])-1])
+			next, gap := -1, math.Inf(1)
+			var passed []geodesy.Point
+			for j, c := range chains {
+				if used[j] && j != first {
+					continue
+				}
+				if d := math.Mod(boundaryPosition(c[0])-end+perimeter, perimeter); d < gap {
+					next, gap = j, d
+				}
+			}
+			for _, corner := range corners {
+				if d := math.Mod(boundaryPosition(corner)-end+perimeter, perimeter); d > 0 && d < gap {
+					passed = append(passed, corner)
+				}
+			}
+			sort.Slice(passed, func(i, j int) bool {
+				return math.Mod(boundaryPosition(passed[i])-end+perimeter, perimeter) <
+					math.Mod(boundaryPosition(passed[j])-end+perimeter, perimeter)
+			})
+			for _, corner := range passed {
+				ring = appendDistinct(ring, corner)
+			}
+			if next < 0 || next == first {
+				break
+			}
+			current = next
+		}
+
+		// The first point of the ring is implicitly repeated at its end
+		if len(ring) > 1 && ring[0].Equals(ring[len(ring)-1]) {
+			ring = ring[:len(ring)-1]
+		}
+		rings = append(rings, ring)
+	}
+
+	return rings
+}
+
+// corners holds the corners of the rectangle of geographic coordinates, counterclockwise from the
+// north-east one
+var corners = []geodesy.Point{
+	{geodesy.LatUpperBound, geodesy.LonUpperBound},
+	{geodesy.LatUpperBound, geodesy.LonLowerBound},
+	{geodesy.LatLowerBound, geodesy.LonLowerBound},
+	{geodesy.LatLowerBound, geodesy.LonUpperBound},
+}
+
+// boundaryPosition returns the position of p along the boundary of the rectangle of geographic
+// coordinates, walked counterclockwise from its south-east corner, where p lies on its left or right edge
+// or on one of its corners
+func boundaryPosition(p geodesy.Point) float64 {
+	switch {
+	case p.Lat() == geodesy.LatUpperBound:
+		// North edge, walked westwards
+		return 180 + (geodesy.LonUpperBound - p.Lon())
+	case p.Lat() == geodesy.LatLowerBound && p.Lon() == geodesy.LonLowerBound:
+		return 720
+	case p.Lon() == geodesy.LonUpperBound:
+		// East edge, walked northwards
+		return p.Lat() - geodesy.LatLowerBound
+	}
+
+	// West edge, walked southwards
+	return 540 + (geodesy.LatUpperBound - p.Lat())
+}
+
+func appendDistinct(ring geometry.Ring, p geodesy.Point) geometry.Ring {
+	if len(ring) > 0 && ring[len(ring)-1].Equals(p) {
+		return ring
+	}
+	return append(ring, p)
+}
+
+// rewind returns r counterclockwise if exterior is set, and clockwise otherwise
+func rewind(r geometry.Ring, exterior bool) geometry.Ring {
+	xy := unwrap(r)
+	if xy == nil {
+		// Rings encircling a pole have no planar orientation, and are kept as given
+		return r
+	}
+	if area := signedArea(xy); area == 0 || (area > 0) == exterior {
+		return r
+	}
+
+	reversed := make(geometry.Ring, len(r))
+	for i, p := range r {
+		reversed[len(r)-1-i] = p
+	}
+
+	return reversed
+}
+
+// unwrap returns the planar coordinates of r, as x (longitude) and y (latitude) pairs, with longitudes
+// made continuous across the antimeridian. It returns nil if r encircles a pole
+func unwrap(r geometry.Ring) [][2]float64 {
+	xy := make([][2]float64, len(r))
+	x := r[0].Lon()
+	for i, p := range r {
+		if i > 0 {
+			x += wrap(p.Lon() - r[i-1].Lon())
+		}
+		xy[i] = [2]float64{x, p.Lat()}
+	}
+	if math.Abs(x+wrap(r[0].Lon()-r[len(r)-1].Lon())-r[0].Lon()) > 180 {
+		return nil
+	}
+
+	return xy
+}
+
+// planar returns the planar coordinates of r, as x (longitude) and y (latitude) pairs
+func planar(r geometry.Ring) [][2]float64 {
+	xy := make([][2]float64, len(r))
+	for i, p := range r {
+		xy[i] = [2]float64{p.Lon(), p.Lat()}
+	}
+
+	return xy
+}
+
+// wrap reduces the longitude difference d to [-180, 180]
+func wrap(d float64) float64 {
+	switch {
+	case d > 180:
+		return d - 360
+	case d < -180:
+		return d + 360
+	}
+	return d
+}
+
+// signedArea returns twice the planar area enclosed by xy, which is positive for counterclockwise rings
+func signedArea(xy [][2]float64) float64 {
+	area := 0.0
+	for i := range xy {
+		a, b := xy[i], xy[(i+1)%len(xy)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+
+	return area
+}
+
+// containsRing returns whether the first point of h not lying on the boundary of r is inside r, as
+// planar polygons in geographic coordinates
+func containsRing(r, h geometry.Ring) bool {
+	for _, p := range h {
+		inside, boundary := planarContains(r, p)
+		if !boundary {
+			return inside
+		}
+	}
+
+	return true
+}
+
+// planarContains returns whether p is inside r, and whether it lies on its boundary instead
+func planarContains(r geometry.Ring, p geodesy.Point) (inside, boundary bool) {
+	x, y := p.Lon(), p.Lat()
+	for i := range r {
+		a, b := r[i], r[(i+1)%len(r)]
+		ax, ay, bx, by := a.Lon(), a.Lat(), b.Lon(), b.Lat()
+		cross := (bx-ax)*(y-ay) - (by-ay)*(x-ax)
+		if cross == 0 && math.Min(ax, bx) <= x && x <= math.Max(ax, bx) && math.Min(ay, by) <= y && y <= math.Max(ay, by) {
+			return false, true
+		}
+		if (ay > y) != (by > y) && x < ax+(y-ay)*(bx-ax)/(by-ay) {
+			inside = !inside
+		}
+	}
+
+	return inside, false
+}
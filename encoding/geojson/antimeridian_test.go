@@ -0,0 +1,105 @@
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy/encoding/geojson"
+	"github.com/lggomez/go-geodesy/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTrip marshals g and unmarshals the result back
+func roundTrip(t *testing.T, g geometry.Geometry) geometry.Geometry {
+	data, err := geojson.MarshalGeometry(g)
+	assert.NoError(t, err)
+	got, err := geojson.UnmarshalGeometry(data)
+	assert.NoError(t, err)
+
+	return got
+}
+
+func TestMarshalGeometry_Antimeridian(t *testing.T) {
+	t.Run("OK/linestring", func(t *testing.T) {
+		got := roundTrip(t, geometry.LineString{{0, 170}, {10, -170}, {20, 170}})
+		assert.Equal(t, geometry.MultiLineString{
+			{{0, 170}, {5, 180}},
+			{{5, -180}, {10, -170}, {15, -180}},
+			{{15, 180}, {20, 170}},
+		}, got)
+	})
+
+	t.Run("OK/linestring_westwards_from_antimeridian", func(t *testing.T) {
+		got := roundTrip(t, geometry.LineString{{0, -180}, {0, 179}, {1, 178}})
+		assert.Equal(t, geometry.LineString{{0, 180}, {0, 179}, {1, 178}}, got)
+	})
+
+	t.Run("OK/linestring_on_antimeridian", func(t *testing.T) {
+		l := geometry.LineString{{0, 180}, {10, 180}, {20, 179}}
+		assert.Equal(t, l, roundTrip(t, l))
+	})
+
+	t.Run("OK/polygon", func(t *testing.T) {
+		// A square spanning from 170°E to 170°W, given clockwise
+		got := roundTrip(t, geometry.Polygon{{{-10, 170}, {10, 170}, {10, -170}, {-10, -170}}})
+		assert.Equal(t, geometry.MultiPolygon{
+			{{{-10, -180}, {-10, -170}, {10, -170}, {10, -180}}},
+			{{{10, 180}, {10, 170}, {-10, 170}, {-10, 180}}},
+		}, got)
+	})
+
+	t.Run("OK/polygon_with_holes", func(t *testing.T) {
+		got := roundTrip(t, geometry.Polygon{
+			{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}},
+			// A hole on each side, and another one across the antimeridian
+			{{-1, 172}, {1, 172}, {1, 174}, {-1, 174}},
+			{{-1, -174}, {1, -174}, {1, -172}, {-1, -172}},
+			{{5, 178}, {7, 178}, {7, -178}, {5, -178}},
+		})
+
+		// Each half keeps its hole, and the crossing hole becomes a notch of the exterior rings
+		assert.Equal(t, geometry.MultiPolygon{
+			{
+				{{10, 180}, {10, 170}, {-10, 170}, {-10, 180}, {5, 180}, {5, 178}, {7, 178}, {7, 180}},
+				{{-1, 172}, {1, 172}, {1, 174}, {-1, 174}},
+			},
+			{
+				{{-10, -180}, {-10, -170}, {10, -170}, {10, -180}, {7, -180}, {7, -178}, {5, -178}, {5, -180}},
+				{{-1, -174}, {1, -174}, {1, -172}, {-1, -172}},
+			},
+		}, got)
+	})
+
+	t.Run("OK/polygon_around_north_pole", func(t *testing.T) {
+		// A ring at 80°N traversed eastwards, which has the pole to its left
+		got := roundTrip(t, geometry.Polygon{{{80, -120}, {80, 0}, {80, 120}}})
+		assert.Equal(t, geometry.Polygon{{{80, -180}, {80, -120}, {80, 0}, {80, 120}, {80, 180}, {90, 180}, {90, -180}}}, got)
+
+		// Writing the cut polygon again leaves it unchanged
+		assert.Equal(t, got, roundTrip(t, got))
+	})
+
+	t.Run("OK/polygon_around_south_pole", func(t *testing.T) {
+		got := roundTrip(t, geometry.Polygon{{{-80, 120}, {-80, 0}, {-80, -120}}})
+		assert.Equal(t, geometry.Polygon{{{-80, 180}, {-80, 120}, {-80, 0}, {-80, -120}, {-80, -180}, {-90, -180}, {-90, 180}}}, got)
+	})
+
+	t.Run("OK/concave_polygon", func(t *testing.T) {
+		// A C shape opening westwards across the antimeridian, whose western side is cut in two
+		got := roundTrip(t, geometry.Polygon{{
+			{0, 170}, {0, -170}, {10, -170}, {10, 170}, {8, 170}, {8, -175}, {2, -175}, {2, 170},
+		}})
+		multi, ok := got.(geometry.MultiPolygon)
+		assert.True(t, ok)
+		assert.Len(t, multi, 3)
+		east, west := 0, 0
+		for _, p := range multi {
+			if p[0][0].Lon() < 0 {
+				east++
+			} else {
+				west++
+			}
+		}
+		assert.Equal(t, 1, east)
+		assert.Equal(t, 2, west)
+	})
+}
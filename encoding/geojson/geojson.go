@@ -0,0 +1,459 @@
+// Package geojson reads and writes GeoJSON (RFC 7946) geometries, features and feature collections,
+// mapping them to the types of the geometry package.
+//
+// Geometries are written as the RFC mandates: polygon rings follow the right-hand rule, with exterior
+// rings counterclockwise and holes clockwise, and geometries crossing the antimeridian are cut in two
+// or more parts. Reading accepts any winding and does not merge cut geometries
+package geojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geometry"
+)
+
+// ErrInvalid is returned when a GeoJSON object is malformed or holds invalid coordinates
+var ErrInvalid = errors.New("geojson: invalid object")
+
+// object is the representation of any GeoJSON object
+type object struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates,omitempty"`
+	Geometries  []json.RawMessage `json:"geometries,omitempty"`
+	Geometry    json.RawMessage   `json:"geometry,omitempty"`
+	Properties  json.RawMessage   `json:"properties,omitempty"`
+	ID          json.RawMessage   `json:"id,omitempty"`
+	Features    []json.RawMessage `json:"features,omitempty"`
+	BBox        []float64         `json:"bbox,omitempty"`
+}
+
+// geometryObject is the representation of GeoJSON geometries being written
+type geometryObject struct {
+	Type        string            `json:"type"`
+	Coordinates interface{}       `json:"coordinates,omitempty"`
+	Geometries  *[]geometryObject `json:"geometries,omitempty"`
+}
+
+// MarshalGeometry returns the GeoJSON encoding of g, rewound and cut along the antimeridian as
+// required by RFC 7946. If g has invalid points, line strings of less than 2 positions or polygon rings
+// of less than 3, which the RFC forbids, it returns an error wrapping ErrInvalid
+func MarshalGeometry(g geometry.Geometry) ([]byte, error) {
+	o, err := toObject(g)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(o)
+}
+
+// UnmarshalGeometry parses the GeoJSON geometry in data. Altitudes and any further elements of
+// positions are discarded, and so are the repeated closing positions of polygon rings
+func UnmarshalGeometry(data []byte) (geometry.Geometry, error) {
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	return fromObject(o)
+}
+
+func toObject(g geometry.Geometry) (geometryObject, error) {
+	if !geometry.Valid(g) {
+		return geometryObject{}, fmt.Errorf("%w: invalid geometry %v", ErrInvalid, g)
+	}
+
+	o := geometryObject{Type: g.Type().String()}
+	if collection, ok := g.(geometry.Collection); ok {
+		geometries := make([]geometryObject, len(collection))
+		for i, member := range collection {
+			var err error
+			if geometries[i], err = toObject(member); err != nil {
+				return geometryObject{}, err
+			}
+		}
+		o.Geometries = &geometries
+		return o, nil
+	}
+
+	if err := checkPositions(g); err != nil {
+		return geometryObject{}, err
+	}
+	g = normalize(g)
+	o.Type = g.Type().String()
+	switch g := g.(type) {
+	case geometry.Point:
+		o.Coordinates = position(g.Point)
+	case geometry.MultiPoint:
+		o.Coordinates = positions(g)
+	case geometry.LineString:
+		o.Coordinates = positions(g)
+	case geometry.MultiLineString:
+		lines := make([][][2]float64, len(g))
+		for i, l := range g {
+			lines[i] = positions(l)
+		}
+		o.Coordinates = lines
+	case geometry.Polygon:
+		o.Coordinates = polygonPositions(g)
+	case geometry.MultiPolygon:
+		polygons := make([][][][2]float64, len(g))
+		for i, p := range g {
+			polygons[i] = polygonPositions(p)
+		}
+		o.Coordinates = polygons
+	default:
+		return geometryObject{}, fmt.Errorf("%w: unsupported geometry %T", ErrInvalid, g)
+	}
+
+	return o, nil
+}
+
+// checkPositions returns an error wrapping ErrInvalid if g has line strings of less than 2 positions or
+// rings of less than 3, so that UnmarshalGeometry reads back anything written by MarshalGeometry
+func checkPositions(g geometry.Geometry) error {
+	var lines []geometry.LineString
+	var rings []geometry.Ring
+	switch g := g.(type) {
+	case geometry.LineString:
+		lines = append(lines, g)
+	case geometry.MultiLineString:
+		lines = append(lines, g...)
+	case geometry.Polygon:
+		rings = append(rings, g...)
+	case geometry.MultiPolygon:
+		for _, p := range g {
+			rings = append(rings, p...)
+		}
+	}
+
+	for _, l := range lines {
+		if len(l) < 2 {
+			return fmt.Errorf("%w: line string with %d positions instead of at least 2", ErrInvalid, len(l))
+		}
+	}
+	for _, r := range rings {
+		if len(r) < 3 {
+			return fmt.Errorf("%w: ring with %d positions instead of at least 3", ErrInvalid, len(r))
+		}
+	}
+
+	return nil
+}
+
+func position(p geodesy.Point) [2]float64 {
+	return [2]float64{p.Lon(), p.Lat()}
+}
+
+func positions(points []geodesy.Point) [][2]float64 {
+	coordinates := make([][2]float64, len(points))
+	for i, p := range points {
+		coordinates[i] = position(p)
+	}
+
+	return coordinates
+}
+
+// polygonPositions returns the positions of the rings of p, explicitly closed
+func polygonPositions(p geometry.Polygon) [][][2]float64 {
+	rings := make([][][2]float64, len(p))
+	for i, r := range p {
+		rings[i] = positions(r)
+		if len(r) > 0 {
+			rings[i] = append(rings[i], position(r[0]))
+		}
+	}
+
+	return rings
+}
+
+func fromObject(o object) (geometry.Geometry, error) {
+	if o.Type == "GeometryCollection" {
+		collection := make(geometry.Collection, len(o.Geometries))
+		for i, data := range o.Geometries {
+			g, err := UnmarshalGeometry(data)
+			if err != nil {
+				return nil, err
+			}
+			collection[i] = g
+		}
+		return collection, nil
+	}
+
+	if len(o.Coordinates) == 0 {
+		return nil, fmt.Errorf("%w: %s without coordinates", ErrInvalid, o.Type)
+	}
+	switch o.Type {
+	case "Point":
+		var c []float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		p, err := toPoint(c)
+		if err != nil {
+			return nil, err
+		}
+		return geometry.Point{Point: p}, nil
+	case "MultiPoint":
+		var c [][]float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		points, err := toPoints(c, 0)
+		if err != nil {
+			return nil, err
+		}
+		return geometry.MultiPoint(points), nil
+	case "LineString":
+		var c [][]float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		l, err := toLineString(c)
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	case "MultiLineString":
+		var c [][][]float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		lines := make(geometry.MultiLineString, len(c))
+		for i := range c {
+			var err error
+			if lines[i], err = toLineString(c[i]); err != nil {
+				return nil, err
+			}
+		}
+		return lines, nil
+	case "Polygon":
+		var c [][][]float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		p, err := toPolygon(c)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "MultiPolygon":
+		var c [][][][]float64
+		if err := decode(o, &c); err != nil {
+			return nil, err
+		}
+		polygons := make(geometry.MultiPolygon, len(c))
+		for i := range c {
+			var err error
+			if polygons[i], err = toPolygon(c[i]); err != nil {
+				return nil, err
+			}
+		}
+		return polygons, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown geometry type %q", ErrInvalid, o.Type)
+}
+
+func decode(o object, v interface{}) error {
+	if err := json.Unmarshal(o.Coordinates, v); err != nil {
+		return fmt.Errorf("%w: %s coordinates %s", ErrInvalid, o.Type, o.Coordinates)
+	}
+	return nil
+}
+
+func toPoint(c []float64) (geodesy.Point, error) {
+	if len(c) < 2 {
+		return geodesy.Point{}, fmt.Errorf("%w: position %v has less than 2 elements", ErrInvalid, c)
+	}
+	p := geodesy.Point{c[1], c[0]}
+	if !p.Valid() {
+		return geodesy.Point{}, fmt.Errorf("%w: position %v out of range", ErrInvalid, c)
+	}
+
+	return p, nil
+}
+
+// toPoints converts the positions c into points, requiring at least min of them
+func toPoints(c [][]float64, min int) ([]geodesy.Point, error) {
+	if len(c) < min {
+		return nil, fmt.Errorf("%w: %d positions instead of at least %d", ErrInvalid, len(c), min)
+	}
+	points := make([]geodesy.Point, len(c))
+	for i := range c {
+		var err error
+		if points[i], err = toPoint(c[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return points, nil
+}
+
+func toLineString(c [][]float64) (geometry.LineString, error) {
+	points, err := toPoints(c, 2)
+	return geometry.LineString(points), err
+}
+
+func toPolygon(c [][][]float64) (geometry.Polygon, error) {
+	p := make(geometry.Polygon, len(c))
+	for i := range c {
+		points, err := toPoints(c[i], 4)
+		if err != nil {
+			return nil, err
+		}
+		if !points[0].Equals(points[len(points)-1]) {
+			return nil, fmt.Errorf("%w: ring %v is not closed", ErrInvalid, c[i])
+		}
+		p[i] = points[:len(points)-1]
+	}
+
+	return p, nil
+}
+
+// Feature represents a GeoJSON feature. Its geometry is nil for unlocated features, and its bounding
+// box is only written when set
+type Feature struct {
+	ID         interface{}
+	Geometry   geometry.Geometry
+	Properties map[string]interface{}
+	BBox       *geodesy.BoundingBox
+}
+
+// MarshalJSON implements json.Marshaler
+func (f Feature) MarshalJSON() ([]byte, error) {
+	o := struct {
+		Type       string                 `json:"type"`
+		ID         interface{}            `json:"id,omitempty"`
+		BBox       []float64              `json:"bbox,omitempty"`
+		Geometry   *geometryObject        `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}{Type: "Feature", ID: f.ID, BBox: bbox(f.BBox), Properties: f.Properties}
+	if f.Geometry != nil {
+		g, err := toObject(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		o.Geometry = &g
+	}
+
+	return json.Marshal(o)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if o.Type != "Feature" {
+		return fmt.Errorf("%w: type %q instead of \"Feature\"", ErrInvalid, o.Type)
+	}
+
+	var feature Feature
+	if len(o.ID) > 0 {
+		if err := json.Unmarshal(o.ID, &feature.ID); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch feature.ID.(type) {
+		case string, float64:
+		default:
+			return fmt.Errorf("%w: id %s is neither a string nor a number", ErrInvalid, o.ID)
+		}
+	}
+	if len(o.Geometry) > 0 && !bytes.Equal(o.Geometry, []byte("null")) {
+		g, err := UnmarshalGeometry(o.Geometry)
+		if err != nil {
+			return err
+		}
+		feature.Geometry = g
+	}
+	if len(o.Properties) > 0 {
+		if err := json.Unmarshal(o.Properties, &feature.Properties); err != nil {
+			return fmt.Errorf("%w: properties %s", ErrInvalid, o.Properties)
+		}
+	}
+	var err error
+	if feature.BBox, err = toBBox(o.BBox); err != nil {
+		return err
+	}
+	*f = feature
+
+	return nil
+}
+
+// FeatureCollection represents a GeoJSON feature collection, whose bounding box is only written when set
+type FeatureCollection struct {
+	Features []Feature
+	BBox     *geodesy.BoundingBox
+}
+
+// MarshalJSON implements json.Marshaler
+func (c FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := c.Features
+	if features == nil {
+		features = []Feature{}
+	}
+
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		BBox     []float64 `json:"bbox,omitempty"`
+		Features []Feature `json:"features"`
+	}{Type: "FeatureCollection", BBox: bbox(c.BBox), Features: features})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *FeatureCollection) UnmarshalJSON(data []byte) error {
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if o.Type != "FeatureCollection" {
+		return fmt.Errorf("%w: type %q instead of \"FeatureCollection\"", ErrInvalid, o.Type)
+	}
+
+	collection := FeatureCollection{Features: make([]Feature, len(o.Features))}
+	for i, data := range o.Features {
+		if err := collection.Features[i].UnmarshalJSON(data); err != nil {
+			return err
+		}
+	}
+	var err error
+	if collection.BBox, err = toBBox(o.BBox); err != nil {
+		return err
+	}
+	*c = collection
+
+	return nil
+}
+
+// bbox returns the GeoJSON bounding box of b, as west, south, east and north bounds
+func bbox(b *geodesy.BoundingBox) []float64 {
+	if b == nil || b.IsEmpty() {
+		return nil
+	}
+	return []float64{b.West, b.South, b.East, b.North}
+}
+
+// toBBox returns the bounding box represented by the GeoJSON one c, whose altitudes are discarded
+func toBBox(c []float64) (*geodesy.BoundingBox, error) {
+	switch len(c) {
+	case 0:
+		return nil, nil
+	case 4:
+		b := geodesy.BoundingBox{West: c[0], South: c[1], East: c[2], North: c[3]}
+		if b.Valid() {
+			return &b, nil
+		}
+	case 6:
+		b := geodesy.BoundingBox{West: c[0], South: c[1], East: c[3], North: c[4]}
+		if b.Valid() {
+			return &b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: bbox %v", ErrInvalid, c)
+}
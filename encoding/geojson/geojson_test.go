@@ -0,0 +1,223 @@
+package geojson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/encoding/geojson"
+	"github.com/lggomez/go-geodesy/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalGeometry(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  geometry.Geometry
+	}{
+		{name: "OK/point", input: `{"type": "Point", "coordinates": [-75.00417, 40.20361]}`, want: geometry.Point{Point: geodesy.Point{40.20361, -75.00417}}},
+		{name: "OK/point_altitude", input: `{"type": "Point", "coordinates": [1, 2, 350.5]}`, want: geometry.Point{Point: geodesy.Point{2, 1}}},
+		{name: "OK/multipoint", input: `{"type": "MultiPoint", "coordinates": [[1, 2], [3, 4]]}`, want: geometry.MultiPoint{{2, 1}, {4, 3}}},
+		{name: "OK/linestring", input: `{"type": "LineString", "coordinates": [[1, 2], [3, 4]], "bbox": [1, 2, 3, 4]}`, want: geometry.LineString{{2, 1}, {4, 3}}},
+		{name: "OK/multilinestring", input: `{"type": "MultiLineString", "coordinates": [[[1, 2], [3, 4]], [[5, 6], [7, 8]]]}`, want: geometry.MultiLineString{{{2, 1}, {4, 3}}, {{6, 5}, {8, 7}}}},
+		{
+			name:  "OK/polygon",
+			input: `{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]], [[2, 2], [2, 4], [4, 4], [2, 2]]]}`,
+			want:  geometry.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, {{2, 2}, {4, 2}, {4, 4}}},
+		},
+		{
+			name:  "OK/multipolygon",
+			input: `{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]], [[[5, 5], [6, 5], [6, 6], [5, 5]]]]}`,
+			want:  geometry.MultiPolygon{{{{0, 0}, {0, 1}, {1, 1}}}, {{{5, 5}, {5, 6}, {6, 6}}}},
+		},
+		{
+			name:  "OK/collection",
+			input: `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}, {"type": "GeometryCollection", "geometries": []}]}`,
+			want:  geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.Collection{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geojson.UnmarshalGeometry([]byte(tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/syntax", input: `{"type": "Point"`, message: "geojson: invalid object: unexpected end of JSON input"},
+		{name: "FAIL/unknown_type", input: `{"type": "Circle", "coordinates": [1, 2]}`, message: `geojson: invalid object: unknown geometry type "Circle"`},
+		{name: "FAIL/missing_coordinates", input: `{"type": "Point"}`, message: "geojson: invalid object: Point without coordinates"},
+		{name: "FAIL/coordinates_shape", input: `{"type": "LineString", "coordinates": [1, 2]}`, message: "geojson: invalid object: LineString coordinates [1, 2]"},
+		{name: "FAIL/short_position", input: `{"type": "Point", "coordinates": [1]}`, message: "geojson: invalid object: position [1] has less than 2 elements"},
+		{name: "FAIL/range", input: `{"type": "Point", "coordinates": [181, 0]}`, message: "geojson: invalid object: position [181 0] out of range"},
+		{name: "FAIL/short_linestring", input: `{"type": "LineString", "coordinates": [[1, 2]]}`, message: "geojson: invalid object: 1 positions instead of at least 2"},
+		{name: "FAIL/open_ring", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}`, message: "geojson: invalid object: ring [[0 0] [1 0] [1 1] [0 1]] is not closed"},
+		{name: "FAIL/short_ring", input: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}`, message: "geojson: invalid object: 3 positions instead of at least 4"},
+		{name: "FAIL/collection_member", input: `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [0, 95]}]}`, message: "geojson: invalid object: position [0 95] out of range"},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geojson.UnmarshalGeometry([]byte(tt.input))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, geojson.ErrInvalid)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestMarshalGeometry(t *testing.T) {
+	tests := []struct {
+		name string
+		g    geometry.Geometry
+		want string
+	}{
+		{name: "OK/point", g: geometry.Point{Point: geodesy.Point{40.20361, -75.00417}}, want: `{"type":"Point","coordinates":[-75.00417,40.20361]}`},
+		{name: "OK/multipoint", g: geometry.MultiPoint{{2, 1}, {4, 3}}, want: `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+		{name: "OK/linestring", g: geometry.LineString{{2, 1}, {4, 3}}, want: `{"type":"LineString","coordinates":[[1,2],[3,4]]}`},
+		{
+			// The exterior ring is rewound counterclockwise, and the hole clockwise
+			name: "OK/polygon_rewound",
+			g:    geometry.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, {{2, 2}, {2, 4}, {4, 4}}},
+			want: `{"type":"Polygon","coordinates":[[[10,0],[10,10],[0,10],[0,0],[10,0]],[[4,4],[4,2],[2,2],[4,4]]]}`,
+		},
+		{
+			name: "OK/polygon",
+			g:    geometry.Polygon{{{0, 0}, {0, 1}, {1, 1}}},
+			want: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			name: "OK/collection",
+			g:    geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.Collection{}},
+			want: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"GeometryCollection","geometries":[]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := geojson.MarshalGeometry(tt.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+
+			_, err = geojson.UnmarshalGeometry(data)
+			assert.NoError(t, err)
+		})
+	}
+
+	short := []struct {
+		name    string
+		g       geometry.Geometry
+		message string
+	}{
+		{name: "FAIL/empty_linestring", g: geometry.LineString{}, message: "geojson: invalid object: line string with 0 positions instead of at least 2"},
+		{name: "FAIL/short_linestring", g: geometry.LineString{{2, 1}}, message: "geojson: invalid object: line string with 1 positions instead of at least 2"},
+		{name: "FAIL/short_member", g: geometry.MultiLineString{{{2, 1}, {4, 3}}, {}}, message: "geojson: invalid object: line string with 0 positions instead of at least 2"},
+		{name: "FAIL/short_ring", g: geometry.Polygon{{{0, 0}, {0, 1}}}, message: "geojson: invalid object: ring with 2 positions instead of at least 3"},
+	}
+	for _, tt := range short {
+		t.Run(tt.name, func(t *testing.T) {
+			// Geometries which could not be read back are not written
+			_, err := geojson.MarshalGeometry(tt.g)
+			assert.EqualError(t, err, tt.message)
+			_, err = geojson.MarshalGeometry(geometry.Collection{tt.g})
+			assert.ErrorIs(t, err, geojson.ErrInvalid)
+		})
+	}
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		_, err := geojson.MarshalGeometry(geometry.LineString{{0, 0}, {-91, 0}})
+		assert.ErrorIs(t, err, geojson.ErrInvalid)
+		_, err = geojson.MarshalGeometry(nil)
+		assert.ErrorIs(t, err, geojson.ErrInvalid)
+		_, err = geojson.MarshalGeometry(geometry.Collection{nil})
+		assert.ErrorIs(t, err, geojson.ErrInvalid)
+	})
+}
+
+func TestFeature(t *testing.T) {
+	t.Run("OK/round_trip", func(t *testing.T) {
+		input := `{"type":"Feature","id":"depot-1","bbox":[1,2,1,2],"geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"Depot","capacity":12}}`
+		var f geojson.Feature
+		assert.NoError(t, json.Unmarshal([]byte(input), &f))
+		assert.Equal(t, "depot-1", f.ID)
+		assert.Equal(t, geometry.Point{Point: geodesy.Point{2, 1}}, f.Geometry)
+		assert.Equal(t, map[string]interface{}{"name": "Depot", "capacity": 12.0}, f.Properties)
+		assert.Equal(t, &geodesy.BoundingBox{South: 2, West: 1, North: 2, East: 1}, f.BBox)
+
+		data, err := json.Marshal(f)
+		assert.NoError(t, err)
+		assert.JSONEq(t, input, string(data))
+	})
+
+	t.Run("OK/unlocated", func(t *testing.T) {
+		var f geojson.Feature
+		assert.NoError(t, json.Unmarshal([]byte(`{"type":"Feature","id":7,"geometry":null,"properties":null}`), &f))
+		assert.Equal(t, 7.0, f.ID)
+		assert.Nil(t, f.Geometry)
+		assert.Nil(t, f.Properties)
+
+		data, err := json.Marshal(geojson.Feature{})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"Feature","geometry":null,"properties":null}`, string(data))
+	})
+
+	t.Run("FAIL/type", func(t *testing.T) {
+		var f geojson.Feature
+		assert.EqualError(t, json.Unmarshal([]byte(`{"type":"Point","coordinates":[1,2]}`), &f),
+			`geojson: invalid object: type "Point" instead of "Feature"`)
+	})
+
+	t.Run("FAIL/id", func(t *testing.T) {
+		var f geojson.Feature
+		assert.ErrorIs(t, json.Unmarshal([]byte(`{"type":"Feature","id":[1],"geometry":null}`), &f), geojson.ErrInvalid)
+	})
+
+	t.Run("FAIL/bbox", func(t *testing.T) {
+		var f geojson.Feature
+		assert.EqualError(t, json.Unmarshal([]byte(`{"type":"Feature","bbox":[0,0,1],"geometry":null}`), &f),
+			"geojson: invalid object: bbox [0 0 1]")
+	})
+
+	t.Run("FAIL/geometry", func(t *testing.T) {
+		_, err := json.Marshal(geojson.Feature{Geometry: geometry.Point{Point: geodesy.Point{0, 200}}})
+		assert.ErrorIs(t, err, geojson.ErrInvalid)
+	})
+}
+
+func TestFeatureCollection(t *testing.T) {
+	t.Run("OK/round_trip", func(t *testing.T) {
+		input := `{"type":"FeatureCollection","bbox":[170,-10,0,-170,10,100],"features":[
+			{"type":"Feature","geometry":{"type":"LineString","coordinates":[[170,-10],[-170,10]]},"properties":{}},
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":null}
+		]}`
+		var c geojson.FeatureCollection
+		assert.NoError(t, json.Unmarshal([]byte(input), &c))
+		assert.Len(t, c.Features, 2)
+		assert.Equal(t, &geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170}, c.BBox)
+		assert.Equal(t, geometry.LineString{{-10, 170}, {10, -170}}, c.Features[0].Geometry)
+
+		// The line crossing the antimeridian is cut on output
+		data, err := json.Marshal(c)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"type":"FeatureCollection","bbox":[170,-10,-170,10],"features":[
+			{"type":"Feature","geometry":{"type":"MultiLineString","coordinates":[[[170,-10],[180,0]],[[-180,0],[-170,10]]]},"properties":{}},
+			{"type":"Feature","geometry":{"type":"Point","coordinates":[0,0]},"properties":null}
+		]}`, string(data))
+	})
+
+	t.Run("OK/empty", func(t *testing.T) {
+		data, err := json.Marshal(geojson.FeatureCollection{})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"FeatureCollection","features":[]}`, string(data))
+	})
+
+	t.Run("FAIL/feature", func(t *testing.T) {
+		var c geojson.FeatureCollection
+		err := json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[0]}}]}`), &c)
+		assert.ErrorIs(t, err, geojson.ErrInvalid)
+	})
+}
@@ -0,0 +1,409 @@
+// Package wkb reads and writes the Well-known Binary representation of geometries defined by the OGC
+// Simple Features specification, along with its PostGIS extension (EWKB) carrying a spatial reference
+// identifier, mapping them to the types of the geometry package.
+//
+// Coordinates are read as longitude (x) and latitude (y) pairs in degrees. Geometries with Z, M or ZM
+// dimensions are accepted both in their ISO form (type codes offset by 1000, 2000 and 3000) and in their
+// EWKB one (type codes flagged by their high bits), and their altitudes and measures are discarded
+package wkb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geometry"
+)
+
+// ErrInvalid is returned when data is not a WKB or EWKB geometry, or it holds invalid coordinates
+var ErrInvalid = errors.New("wkb: invalid geometry")
+
+const (
+	bigEndian    byte = 0
+	littleEndian byte = 1
+
+	// EWKB flags of the geometry type codes
+	flagZ    uint32 = 0x80000000
+	flagM    uint32 = 0x40000000
+	flagSRID uint32 = 0x20000000
+
+	// maxDepth bounds the nesting of geometry collections read
+	maxDepth = 32
+)
+
+// codes maps the geometry types to their WKB type codes
+var codes = map[geometry.Type]uint32{
+	geometry.PointType:           1,
+	geometry.LineStringType:      2,
+	geometry.PolygonType:         3,
+	geometry.MultiPointType:      4,
+	geometry.MultiLineStringType: 5,
+	geometry.MultiPolygonType:    6,
+	geometry.CollectionType:      7,
+}
+
+// Marshal returns the WKB representation of g in the given byte order, which must be either
+// binary.LittleEndian or binary.BigEndian. Polygon rings are closed by repeating their first point.
+// If g has invalid points, it returns an error wrapping ErrInvalid
+func Marshal(g geometry.Geometry, order binary.ByteOrder) ([]byte, error) {
+	return MarshalEWKB(g, order, 0)
+}
+
+// MarshalEWKB returns the EWKB representation of g in the given byte order, which is its WKB
+// representation with the spatial reference identifier of its outermost geometry. The identifier is
+// omitted when srid is 0, which means an unknown reference system
+func MarshalEWKB(g geometry.Geometry, order binary.ByteOrder, srid int) ([]byte, error) {
+	if order != binary.LittleEndian && order != binary.BigEndian {
+		return nil, fmt.Errorf("%w: unsupported byte order %v", ErrInvalid, order)
+	}
+	if !geometry.Valid(g) {
+		return nil, fmt.Errorf("%w: invalid geometry %v", ErrInvalid, g)
+	}
+
+	w := writer{order: order}
+	w.geometry(g, uint32(srid))
+
+	return w.buf, nil
+}
+
+// writer appends the representation of geometries to buf
+type writer struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+func (w *writer) uint32(v uint32) {
+	var b [4]byte
+	w.order.PutUint32(b[:], v)
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *writer) point(p geodesy.Point) {
+	var b [8]byte
+	w.order.PutUint64(b[:], math.Float64bits(p.Lon()))
+	w.buf = append(w.buf, b[:]...)
+	w.order.PutUint64(b[:], math.Float64bits(p.Lat()))
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *writer) points(points []geodesy.Point, closed bool) {
+	if closed && len(points) > 0 {
+		w.uint32(uint32(len(points) + 1))
+	} else {
+		w.uint32(uint32(len(points)))
+	}
+	for _, p := range points {
+		w.point(p)
+	}
+	if closed && len(points) > 0 {
+		w.point(points[0])
+	}
+}
+
+// geometry writes g along with its header, where the spatial reference identifier srid is included
+// unless it is 0
+func (w *writer) geometry(g geometry.Geometry, srid uint32) {
+	if w.order == binary.BigEndian {
+		w.buf = append(w.buf, bigEndian)
+	} else {
+		w.buf = append(w.buf, littleEndian)
+	}
+	if srid != 0 {
+		w.uint32(codes[g.Type()] | flagSRID)
+		w.uint32(srid)
+	} else {
+		w.uint32(codes[g.Type()])
+	}
+
+	switch g := g.(type) {
+	case geometry.Point:
+		w.point(g.Point)
+	case geometry.MultiPoint:
+		w.uint32(uint32(len(g)))
+		for _, p := range g {
+			w.geometry(geometry.Point{Point: p}, 0)
+		}
+	case geometry.LineString:
+		w.points(g, false)
+	case geometry.MultiLineString:
+		w.uint32(uint32(len(g)))
+		for _, l := range g {
+			w.geometry(l, 0)
+		}
+	case geometry.Polygon:
+		w.uint32(uint32(len(g)))
+		for _, r := range g {
+			w.points(r, true)
+		}
+	case geometry.MultiPolygon:
+		w.uint32(uint32(len(g)))
+		for _, p := range g {
+			w.geometry(p, 0)
+		}
+	case geometry.Collection:
+		w.uint32(uint32(len(g)))
+		for _, member := range g {
+			w.geometry(member, 0)
+		}
+	}
+}
+
+// Unmarshal parses data as a WKB geometry in either byte order, which may be an EWKB one whose spatial
+// reference identifier is discarded.
+//
+// Polygon rings must be closed and have at least 4 points, and their closing points are dropped from
+// the returned rings. Line strings must have at least 2 points unless empty. Empty points, whose
+// coordinates are NaN, are not supported
+func Unmarshal(data []byte) (geometry.Geometry, error) {
+	g, _, err := UnmarshalEWKB(data)
+	return g, err
+}
+
+// UnmarshalEWKB parses data as an EWKB geometry and returns the spatial reference identifier of its
+// outermost geometry, which is 0 if data is a plain WKB geometry
+func UnmarshalEWKB(data []byte) (geometry.Geometry, int, error) {
+	r := reader{data: data}
+	g, err := r.geometry(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r.i < len(data) {
+		return nil, 0, r.errorf("%d unexpected trailing bytes", len(data)-r.i)
+	}
+
+	return g, int(r.srid), nil
+}
+
+// reader reads geometries from data, starting at the offset i in bytes
+type reader struct {
+	data  []byte
+	i     int
+	order binary.ByteOrder
+	srid  uint32
+}
+
+func (r *reader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalid, fmt.Sprintf(format, args...), r.i)
+}
+
+func (r *reader) need(n int) error {
+	if len(r.data)-r.i < n {
+		return r.errorf("unexpected end of data")
+	}
+	return nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	if err := r.need(4); err != nil {
+		return 0, err
+	}
+	v := r.order.Uint32(r.data[r.i:])
+	r.i += 4
+	return v, nil
+}
+
+// count reads an amount of elements, which may not exceed the ones fitting in the remaining data
+// given the minimum size of each one
+func (r *reader) count(size int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(size) > uint64(len(r.data)-r.i) {
+		r.i -= 4
+		return 0, r.errorf("%d elements exceed the remaining data", n)
+	}
+	return int(n), nil
+}
+
+// point reads a coordinate made of dims values
+func (r *reader) point(dims int) (geodesy.Point, error) {
+	if err := r.need(8 * dims); err != nil {
+		return geodesy.Point{}, err
+	}
+	x := math.Float64frombits(r.order.Uint64(r.data[r.i:]))
+	y := math.Float64frombits(r.order.Uint64(r.data[r.i+8:]))
+	p := geodesy.Point{y, x}
+	if math.IsNaN(x) && math.IsNaN(y) {
+		return geodesy.Point{}, r.errorf("empty point")
+	}
+	if !p.Valid() {
+		return geodesy.Point{}, r.errorf("coordinate [%v %v] out of range", x, y)
+	}
+	r.i += 8 * dims
+
+	return p, nil
+}
+
+func (r *reader) points(dims int) ([]geodesy.Point, error) {
+	n, err := r.count(8 * dims)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]geodesy.Point, n)
+	for i := range points {
+		if points[i], err = r.point(dims); err != nil {
+			return nil, err
+		}
+	}
+
+	return points, nil
+}
+
+// header reads the byte order and type of a geometry, and returns its WKB type code along with the
+// amount of values of its coordinates
+func (r *reader) header(depth int) (code uint32, dims int, err error) {
+	if err := r.need(1); err != nil {
+		return 0, 0, err
+	}
+	switch r.data[r.i] {
+	case bigEndian:
+		r.order = binary.BigEndian
+	case littleEndian:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, r.errorf("invalid byte order %d", r.data[r.i])
+	}
+	r.i++
+
+	start := r.i
+	t, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+	dims = 2
+	if t&flagZ != 0 {
+		dims++
+	}
+	if t&flagM != 0 {
+		dims++
+	}
+	code = t &^ (flagZ | flagM | flagSRID)
+	if code > 1000 && code < 4000 {
+		if dims > 2 {
+			r.i = start
+			return 0, 0, r.errorf("type %#x has both ISO and EWKB dimensions", t)
+		}
+		dims += map[uint32]int{1: 1, 2: 1, 3: 2}[code/1000]
+		code %= 1000
+	}
+	if code < 1 || code > 7 {
+		r.i = start
+		return 0, 0, r.errorf("unsupported geometry type %#x", t)
+	}
+	if t&flagSRID != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return 0, 0, err
+		}
+		if depth == 0 {
+			r.srid = srid
+		}
+	}
+
+	return code, dims, nil
+}
+
+// geometry reads a geometry nested in depth collections
+func (r *reader) geometry(depth int) (geometry.Geometry, error) {
+	if depth > maxDepth {
+		return nil, r.errorf("geometry nested more than %d levels", maxDepth)
+	}
+	code, dims, err := r.header(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	switch code {
+	case 1:
+		p, err := r.point(dims)
+		if err != nil {
+			return nil, err
+		}
+		return geometry.Point{Point: p}, nil
+	case 2:
+		start := r.i
+		points, err := r.points(dims)
+		if err != nil {
+			return nil, err
+		}
+		if len(points) == 1 {
+			r.i = start
+			return nil, r.errorf("line string with a single point")
+		}
+		return geometry.LineString(points), nil
+	case 3:
+		n, err := r.count(4)
+		if err != nil {
+			return nil, err
+		}
+		polygon := make(geometry.Polygon, n)
+		for i := range polygon {
+			start := r.i
+			points, err := r.points(dims)
+			if err != nil {
+				return nil, err
+			}
+			if len(points) < 4 {
+				r.i = start
+				return nil, r.errorf("ring with %d points instead of at least 4", len(points))
+			}
+			if !points[0].Equals(points[len(points)-1]) {
+				r.i = start
+				return nil, r.errorf("ring is not closed")
+			}
+			polygon[i] = points[:len(points)-1]
+		}
+		return polygon, nil
+	}
+
+	// Members of multi-geometries and collections are whole geometries, with their own headers
+	n, err := r.count(5)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]geometry.Geometry, n)
+	for i := range members {
+		memberStart := r.i
+		if members[i], err = r.geometry(depth + 1); err != nil {
+			return nil, err
+		}
+		if code != 7 && codes[members[i].Type()] != code-3 {
+			r.i = memberStart
+			return nil, r.errorf("%s member of a %s", members[i].Type(), typeOf(code))
+		}
+	}
+
+	switch code {
+	case 4:
+		points := make(geometry.MultiPoint, n)
+		for i, member := range members {
+			points[i] = member.(geometry.Point).Point
+		}
+		return points, nil
+	case 5:
+		lines := make(geometry.MultiLineString, n)
+		for i, member := range members {
+			lines[i] = member.(geometry.LineString)
+		}
+		return lines, nil
+	case 6:
+		polygons := make(geometry.MultiPolygon, n)
+		for i, member := range members {
+			polygons[i] = member.(geometry.Polygon)
+		}
+		return polygons, nil
+	}
+	return geometry.Collection(members), nil
+}
+
+func typeOf(code uint32) geometry.Type {
+	for t, c := range codes {
+		if c == code {
+			return t
+		}
+	}
+	return 0
+}
@@ -0,0 +1,211 @@
+package wkb_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/encoding/wkb"
+	"github.com/lggomez/go-geodesy/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	assert.NoError(t, err)
+	return data
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  geometry.Geometry
+	}{
+		{
+			name:  "OK/point_little_endian",
+			input: "01 01000000 000000000000F03F 0000000000000040",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+		{
+			name:  "OK/point_big_endian",
+			input: "00 00000001 3FF0000000000000 4000000000000000",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+		{
+			name:  "OK/point_iso_z",
+			input: "01 E9030000 000000000000F03F 0000000000000040 0000000000000840",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+		{
+			name:  "OK/point_iso_zm",
+			input: "01 B90B0000 000000000000F03F 0000000000000040 0000000000000840 0000000000001040",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+		{
+			name:  "OK/point_ewkb_m",
+			input: "01 01000040 000000000000F03F 0000000000000040 0000000000000840",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+		{
+			name:  "OK/linestring",
+			input: "01 02000000 02000000 000000000000F03F 0000000000000040 0000000000000840 0000000000001040",
+			want:  geometry.LineString{{2, 1}, {4, 3}},
+		},
+		{
+			name:  "OK/linestring_empty",
+			input: "01 02000000 00000000",
+			want:  geometry.LineString{},
+		},
+		{
+			name: "OK/polygon",
+			input: "01 03000000 01000000 04000000" +
+				"0000000000000000 0000000000000000 000000000000F03F 0000000000000000" +
+				"000000000000F03F 000000000000F03F 0000000000000000 0000000000000000",
+			want: geometry.Polygon{{{0, 0}, {0, 1}, {1, 1}}},
+		},
+		{
+			name: "OK/multipoint_mixed_endianness",
+			input: "01 04000000 02000000" +
+				"01 01000000 000000000000F03F 0000000000000040" +
+				"00 00000001 4008000000000000 4010000000000000",
+			want: geometry.MultiPoint{{2, 1}, {4, 3}},
+		},
+		{
+			name: "OK/collection",
+			input: "01 07000000 02000000" +
+				"01 01000000 000000000000F03F 0000000000000040" +
+				"01 07000000 00000000",
+			want: geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.Collection{}},
+		},
+		{
+			name:  "OK/ewkb",
+			input: "01 01000020 E6100000 000000000000F03F 0000000000000040",
+			want:  geometry.Point{Point: geodesy.Point{2, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wkb.Unmarshal(decodeHex(t, tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/empty", input: "", message: "wkb: invalid geometry: unexpected end of data at offset 0"},
+		{name: "FAIL/byte_order", input: "02 01000000", message: "wkb: invalid geometry: invalid byte order 2 at offset 0"},
+		{name: "FAIL/type", input: "01 08000000", message: "wkb: invalid geometry: unsupported geometry type 0x8 at offset 1"},
+		{name: "FAIL/mixed_dimensions", input: "01 E9030080", message: "wkb: invalid geometry: type 0x800003e9 has both ISO and EWKB dimensions at offset 1"},
+		{name: "FAIL/truncated", input: "01 01000000 000000000000F03F", message: "wkb: invalid geometry: unexpected end of data at offset 5"},
+		{name: "FAIL/count", input: "01 02000000 FFFFFFFF", message: "wkb: invalid geometry: 4294967295 elements exceed the remaining data at offset 5"},
+		{name: "FAIL/empty_point", input: "01 01000000 000000000000F87F 000000000000F87F", message: "wkb: invalid geometry: empty point at offset 5"},
+		{name: "FAIL/range", input: "01 01000000 0000000000906640 0000000000000000", message: "wkb: invalid geometry: coordinate [180.5 0] out of range at offset 5"},
+		{
+			name:    "FAIL/short_linestring",
+			input:   "01 02000000 01000000 000000000000F03F 0000000000000040",
+			message: "wkb: invalid geometry: line string with a single point at offset 5",
+		},
+		{
+			name: "FAIL/open_ring",
+			input: "01 03000000 01000000 04000000" +
+				"0000000000000000 0000000000000000 000000000000F03F 0000000000000000" +
+				"000000000000F03F 000000000000F03F 0000000000000000 000000000000F03F",
+			message: "wkb: invalid geometry: ring is not closed at offset 9",
+		},
+		{
+			name:    "FAIL/member_type",
+			input:   "01 04000000 01000000 01 02000000 00000000",
+			message: "wkb: invalid geometry: LineString member of a MultiPoint at offset 9",
+		},
+		{name: "FAIL/trailing", input: "01 07000000 00000000 00", message: "wkb: invalid geometry: 1 unexpected trailing bytes at offset 9"},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wkb.Unmarshal(decodeHex(t, tt.input))
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, wkb.ErrInvalid)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+
+	t.Run("FAIL/nesting", func(t *testing.T) {
+		data := decodeHex(t, strings.Repeat("01 07000000 01000000", 40))
+		_, err := wkb.Unmarshal(data)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+	})
+}
+
+func TestUnmarshalEWKB(t *testing.T) {
+	t.Run("OK/srid", func(t *testing.T) {
+		g, srid, err := wkb.UnmarshalEWKB(decodeHex(t, "00 20000001 000010E6 3FF0000000000000 4000000000000000"))
+		assert.NoError(t, err)
+		assert.Equal(t, 4326, srid)
+		assert.Equal(t, geometry.Point{Point: geodesy.Point{2, 1}}, g)
+	})
+
+	t.Run("OK/no_srid", func(t *testing.T) {
+		_, srid, err := wkb.UnmarshalEWKB(decodeHex(t, "01 01000000 000000000000F03F 0000000000000040"))
+		assert.NoError(t, err)
+		assert.Zero(t, srid)
+	})
+}
+
+func TestMarshal(t *testing.T) {
+	geometries := []struct {
+		name string
+		g    geometry.Geometry
+	}{
+		{name: "OK/point", g: geometry.Point{Point: geodesy.Point{40.20361, -75.00417}}},
+		{name: "OK/multipoint", g: geometry.MultiPoint{{2, 1}, {4, 3}}},
+		{name: "OK/linestring", g: geometry.LineString{{2, 1}, {4, 3}}},
+		{name: "OK/multilinestring", g: geometry.MultiLineString{{{2, 1}, {4, 3}}, {}}},
+		{name: "OK/polygon", g: geometry.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, {{2, 2}, {4, 2}, {4, 4}}}},
+		{name: "OK/multipolygon", g: geometry.MultiPolygon{{{{0, 0}, {0, 1}, {1, 1}}}}},
+		{name: "OK/collection", g: geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.Collection{}}},
+	}
+	for _, tt := range geometries {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+				data, err := wkb.Marshal(tt.g, order)
+				assert.NoError(t, err)
+				got, err := wkb.Unmarshal(data)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.g, got)
+			}
+		})
+	}
+
+	t.Run("OK/encoding", func(t *testing.T) {
+		data, err := wkb.Marshal(geometry.Polygon{{{0, 0}, {0, 1}, {1, 1}}}, binary.LittleEndian)
+		assert.NoError(t, err)
+		assert.Equal(t, decodeHex(t, "01 03000000 01000000 04000000"+
+			"0000000000000000 0000000000000000 000000000000F03F 0000000000000000"+
+			"000000000000F03F 000000000000F03F 0000000000000000 0000000000000000"), data)
+	})
+
+	t.Run("OK/ewkb", func(t *testing.T) {
+		data, err := wkb.MarshalEWKB(geometry.MultiPoint{{2, 1}}, binary.BigEndian, 4326)
+		assert.NoError(t, err)
+		assert.Equal(t, decodeHex(t, "00 20000004 000010E6 00000001 00 00000001 3FF0000000000000 4000000000000000"), data)
+	})
+
+	t.Run("FAIL/invalid", func(t *testing.T) {
+		_, err := wkb.Marshal(geometry.LineString{{0, 0}, {-91, 0}}, binary.LittleEndian)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+		_, err = wkb.Marshal(nil, binary.LittleEndian)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+		_, err = wkb.Marshal(geometry.Collection{nil}, binary.LittleEndian)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+		_, err = wkb.Marshal(geometry.Collection{geometry.Point{}, geometry.Collection{nil}}, binary.LittleEndian)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+		_, err = wkb.Marshal(geometry.Point{}, nil)
+		assert.ErrorIs(t, err, wkb.ErrInvalid)
+	})
+}
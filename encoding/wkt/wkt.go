@@ -0,0 +1,497 @@
+// Package wkt reads and writes the Well-known Text representation of geometries defined by the OGC
+// Simple Features specification, along with its PostGIS extension (EWKT) carrying a spatial reference
+// identifier, mapping them to the types of the geometry package.
+//
+// Coordinates are read as longitude (x) and latitude (y) pairs in degrees. Geometries with Z, M or ZM
+// dimensions are accepted, and their altitudes and measures are discarded
+package wkt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geometry"
+)
+
+var (
+	// ErrSyntax is returned when a string is not a WKT or EWKT geometry
+	ErrSyntax = errors.New("wkt: invalid syntax")
+	// ErrRange is returned when a coordinate is not a valid longitude or latitude, as the ones of empty
+	// points, which have no geometry.Point representation
+	ErrRange = errors.New("wkt: value out of range")
+)
+
+// Format returns the WKT representation of g, such as POINT(-75.00417 40.20361). Polygon rings are
+// closed by repeating their first point. If g has invalid points, it returns an error wrapping ErrRange
+func Format(g geometry.Geometry) (string, error) {
+	if !geometry.Valid(g) {
+		return "", fmt.Errorf("%w: invalid geometry %v", ErrRange, g)
+	}
+
+	var b strings.Builder
+	write(&b, g)
+
+	return b.String(), nil
+}
+
+// FormatEWKT returns the EWKT representation of g, which is its WKT representation prefixed with its
+// spatial reference identifier, such as SRID=4326;POINT(-75.00417 40.20361). The prefix is omitted when
+// srid is 0, which means an unknown reference system
+func FormatEWKT(g geometry.Geometry, srid int) (string, error) {
+	s, err := Format(g)
+	if err != nil || srid == 0 {
+		return s, err
+	}
+
+	return "SRID=" + strconv.Itoa(srid) + ";" + s, nil
+}
+
+func write(b *strings.Builder, g geometry.Geometry) {
+	b.WriteString(strings.ToUpper(g.Type().String()))
+	switch g := g.(type) {
+	case geometry.Point:
+		b.WriteByte('(')
+		writePoint(b, g.Point)
+		b.WriteByte(')')
+	case geometry.MultiPoint:
+		writePoints(b, g, false)
+	case geometry.LineString:
+		writePoints(b, g, false)
+	case geometry.MultiLineString:
+		if writeEmpty(b, len(g)) {
+			return
+		}
+		for i, l := range g {
+			writeSeparator(b, i)
+			writePoints(b, l, false)
+		}
+		b.WriteByte(')')
+	case geometry.Polygon:
+		writePolygon(b, g)
+	case geometry.MultiPolygon:
+		if writeEmpty(b, len(g)) {
+			return
+		}
+		for i, p := range g {
+			writeSeparator(b, i)
+			writePolygon(b, p)
+		}
+		b.WriteByte(')')
+	case geometry.Collection:
+		if writeEmpty(b, len(g)) {
+			return
+		}
+		for i, member := range g {
+			writeSeparator(b, i)
+			write(b, member)
+		}
+		b.WriteByte(')')
+	}
+}
+
+// writeEmpty writes the EMPTY keyword if n is 0, separated from a preceding geometry tag, and the
+// opening parenthesis otherwise
+func writeEmpty(b *strings.Builder, n int) bool {
+	if n == 0 {
+		if s := b.String(); s[len(s)-1] != ',' && s[len(s)-1] != '(' {
+			b.WriteByte(' ')
+		}
+		b.WriteString("EMPTY")
+		return true
+	}
+	b.WriteByte('(')
+	return false
+}
+
+func writeSeparator(b *strings.Builder, i int) {
+	if i > 0 {
+		b.WriteByte(',')
+	}
+}
+
+func writePoint(b *strings.Builder, p geodesy.Point) {
+	b.WriteString(strconv.FormatFloat(p.Lon(), 'f', -1, 64))
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(p.Lat(), 'f', -1, 64))
+}
+
+// writePoints writes a parenthesized list of points, repeating the first one at its end if closed is set
+func writePoints(b *strings.Builder, points []geodesy.Point, closed bool) {
+	if writeEmpty(b, len(points)) {
+		return
+	}
+	for i, p := range points {
+		writeSeparator(b, i)
+		writePoint(b, p)
+	}
+	if closed {
+		b.WriteByte(',')
+		writePoint(b, points[0])
+	}
+	b.WriteByte(')')
+}
+
+func writePolygon(b *strings.Builder, p geometry.Polygon) {
+	if writeEmpty(b, len(p)) {
+		return
+	}
+	for i, r := range p {
+		writeSeparator(b, i)
+		writePoints(b, r, true)
+	}
+	b.WriteByte(')')
+}
+
+// Parse parses s as a WKT geometry, such as POINT(-75.00417 40.20361) or POLYGON Z((0 0 1,1 0 1,1 1 1,
+// 0 0 1)). Keywords are case-insensitive, the dimension may also be appended to the geometry type as in
+// POINTM, and coordinates with 3 or 4 values are read as Z and ZM ones when it is not given. An EWKT
+// prefix is accepted and its spatial reference identifier discarded.
+//
+// Polygon rings must be closed and have at least 4 points, and their closing points are dropped from
+// the returned rings. Line strings must have at least 2 points unless empty
+func Parse(s string) (geometry.Geometry, error) {
+	g, _, err := ParseEWKT(s)
+	return g, err
+}
+
+// ParseEWKT parses s as an EWKT geometry, such as SRID=4326;POINT(-75.00417 40.20361), and returns its
+// spatial reference identifier, which is 0 if s is a plain WKT geometry
+func ParseEWKT(s string) (geometry.Geometry, int, error) {
+	p := parser{s: s}
+	srid := 0
+	p.skipSpace()
+	if word := p.peekWord(); strings.EqualFold(word, "SRID") {
+		p.i += len(word)
+		p.skipSpace()
+		if err := p.expect('='); err != nil {
+			return nil, 0, err
+		}
+		p.skipSpace()
+		start := p.i
+		for p.i < len(s) && s[p.i] >= '0' && s[p.i] <= '9' {
+			p.i++
+		}
+		n, err := strconv.Atoi(s[start:p.i])
+		if err != nil {
+			p.i = start
+			return nil, 0, p.errorf(ErrSyntax, "invalid SRID")
+		}
+		srid = n
+		p.skipSpace()
+		if err := p.expect(';'); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	g, err := p.geometry()
+	if err != nil {
+		return nil, 0, err
+	}
+	p.skipSpace()
+	if p.i < len(s) {
+		return nil, 0, p.errorf(ErrSyntax, "unexpected %q", s[p.i:])
+	}
+
+	return g, srid, nil
+}
+
+// types maps the WKT geometry tags to their types
+var types = map[string]geometry.Type{
+	"POINT":              geometry.PointType,
+	"MULTIPOINT":         geometry.MultiPointType,
+	"LINESTRING":         geometry.LineStringType,
+	"MULTILINESTRING":    geometry.MultiLineStringType,
+	"POLYGON":            geometry.PolygonType,
+	"MULTIPOLYGON":       geometry.MultiPolygonType,
+	"GEOMETRYCOLLECTION": geometry.CollectionType,
+}
+
+// parser reads a geometry from s, starting at the offset i in bytes
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) errorf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d in %q", err, fmt.Sprintf(format, args...), p.i, p.s)
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t' || p.s[p.i] == '\n' || p.s[p.i] == '\r') {
+		p.i++
+	}
+}
+
+// peekWord returns the letters starting at the current offset
+func (p *parser) peekWord() string {
+	end := p.i
+	for end < len(p.s) && (p.s[end] >= 'A' && p.s[end] <= 'Z' || p.s[end] >= 'a' && p.s[end] <= 'z') {
+		end++
+	}
+	return p.s[p.i:end]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return nil
+	}
+	if p.i == len(p.s) {
+		return p.errorf(ErrSyntax, "missing %q", c)
+	}
+	return p.errorf(ErrSyntax, "expected %q instead of %q", c, p.s[p.i])
+}
+
+// open reads the EMPTY keyword or an opening parenthesis, and returns whether the former was found
+func (p *parser) open() (empty bool, err error) {
+	p.skipSpace()
+	if word := p.peekWord(); strings.EqualFold(word, "EMPTY") {
+		p.i += len(word)
+		return true, nil
+	}
+	return false, p.expect('(')
+}
+
+// next reads the separator following an element of a list, and returns whether more elements follow
+func (p *parser) next() (bool, error) {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == ',' {
+		p.i++
+		return true, nil
+	}
+	return false, p.expect(')')
+}
+
+// geometry reads a tagged geometry along with its dimension
+func (p *parser) geometry() (geometry.Geometry, error) {
+	p.skipSpace()
+	start := p.i
+	word := strings.ToUpper(p.peekWord())
+	t, ok := types[word]
+	dims := 0
+	for _, suffix := range []string{"ZM", "Z", "M"} {
+		if !ok && strings.HasSuffix(word, suffix) {
+			if t, ok = types[strings.TrimSuffix(word, suffix)]; ok {
+				dims = 2 + len(suffix)
+			}
+		}
+	}
+	if !ok {
+		if word == "" {
+			return nil, p.errorf(ErrSyntax, "missing geometry type")
+		}
+		return nil, p.errorf(ErrSyntax, "unknown geometry type %q", p.s[start:start+len(word)])
+	}
+	p.i += len(word)
+	p.skipSpace()
+	if word := strings.ToUpper(p.peekWord()); dims == 0 && (word == "Z" || word == "M" || word == "ZM") {
+		p.i += len(word)
+		dims = 2 + len(word)
+	}
+
+	switch t {
+	case geometry.PointType:
+		empty, err := p.open()
+		if err != nil {
+			return nil, err
+		}
+		if empty {
+			p.i = start
+			return nil, p.errorf(ErrRange, "empty point")
+		}
+		point, err := p.point(&dims)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		return geometry.Point{Point: point}, p.expect(')')
+	case geometry.MultiPointType:
+		return p.multiPoint(&dims)
+	case geometry.LineStringType:
+		return p.lineString(&dims)
+	case geometry.MultiLineStringType:
+		lines := geometry.MultiLineString{}
+		err := p.list(func() error {
+			l, err := p.lineString(&dims)
+			lines = append(lines, l)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return lines, nil
+	case geometry.PolygonType:
+		return p.polygon(&dims)
+	case geometry.MultiPolygonType:
+		polygons := geometry.MultiPolygon{}
+		err := p.list(func() error {
+			polygon, err := p.polygon(&dims)
+			polygons = append(polygons, polygon)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return polygons, nil
+	}
+
+	collection := geometry.Collection{}
+	err := p.list(func() error {
+		member, err := p.geometry()
+		collection = append(collection, member)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// list reads an empty or parenthesized list whose elements are read by element
+func (p *parser) list(element func() error) error {
+	empty, err := p.open()
+	if err != nil || empty {
+		return err
+	}
+	for more := true; more; {
+		if err := element(); err != nil {
+			return err
+		}
+		if more, err = p.next(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// point reads a coordinate made of dims values, or of 2 to 4 values if dims is 0, in which case it is
+// set to the amount found
+func (p *parser) point(dims *int) (geodesy.Point, error) {
+	p.skipSpace()
+	start := p.i
+	var values []float64
+	for p.i < len(p.s) && p.s[p.i] != ',' && p.s[p.i] != ')' {
+		begin := p.i
+		for p.i < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.i]) >= 0 {
+			p.i++
+		}
+		v, err := strconv.ParseFloat(p.s[begin:p.i], 64)
+		if err != nil {
+			p.i = begin
+			return geodesy.Point{}, p.errorf(ErrSyntax, "invalid number")
+		}
+		values = append(values, v)
+		p.skipSpace()
+	}
+	if *dims == 0 {
+		if len(values) < 2 || len(values) > 4 {
+			p.i = start
+			return geodesy.Point{}, p.errorf(ErrSyntax, "coordinate with %d values instead of 2 to 4", len(values))
+		}
+		*dims = len(values)
+	}
+	if len(values) != *dims {
+		p.i = start
+		return geodesy.Point{}, p.errorf(ErrSyntax, "coordinate with %d values instead of %d", len(values), *dims)
+	}
+
+	point := geodesy.Point{values[1], values[0]}
+	if !point.Valid() {
+		p.i = start
+		return geodesy.Point{}, p.errorf(ErrRange, "coordinate %v", values[:2])
+	}
+
+	return point, nil
+}
+
+// points reads an empty or parenthesized list of coordinates
+func (p *parser) points(dims *int) ([]geodesy.Point, error) {
+	points := []geodesy.Point{}
+	err := p.list(func() error {
+		point, err := p.point(dims)
+		points = append(points, point)
+		return err
+	})
+
+	return points, err
+}
+
+// multiPoint reads a list of points, which may be parenthesized or not as in MULTIPOINT((1 2),(3 4))
+// and MULTIPOINT(1 2,3 4)
+func (p *parser) multiPoint(dims *int) (geometry.MultiPoint, error) {
+	points := geometry.MultiPoint{}
+	err := p.list(func() error {
+		p.skipSpace()
+		if p.i < len(p.s) && p.s[p.i] == '(' || strings.EqualFold(p.peekWord(), "EMPTY") {
+			start := p.i
+			empty, err := p.open()
+			if err != nil {
+				return err
+			}
+			if empty {
+				p.i = start
+				return p.errorf(ErrRange, "empty point")
+			}
+			point, err := p.point(dims)
+			if err != nil {
+				return err
+			}
+			points = append(points, point)
+			p.skipSpace()
+			return p.expect(')')
+		}
+		point, err := p.point(dims)
+		points = append(points, point)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (p *parser) lineString(dims *int) (geometry.LineString, error) {
+	start := p.i
+	points, err := p.points(dims)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 1 {
+		p.i = start
+		return nil, p.errorf(ErrSyntax, "line string with a single point")
+	}
+
+	return points, nil
+}
+
+func (p *parser) polygon(dims *int) (geometry.Polygon, error) {
+	polygon := geometry.Polygon{}
+	err := p.list(func() error {
+		p.skipSpace()
+		start := p.i
+		points, err := p.points(dims)
+		if err != nil {
+			return err
+		}
+		if len(points) < 4 {
+			p.i = start
+			return p.errorf(ErrSyntax, "ring with %d points instead of at least 4", len(points))
+		}
+		if !points[0].Equals(points[len(points)-1]) {
+			p.i = start
+			return p.errorf(ErrSyntax, "ring is not closed")
+		}
+		polygon = append(polygon, geometry.Ring(points[:len(points)-1]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return polygon, nil
+}
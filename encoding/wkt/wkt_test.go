@@ -0,0 +1,160 @@
+package wkt_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/encoding/wkt"
+	"github.com/lggomez/go-geodesy/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  geometry.Geometry
+	}{
+		{name: "OK/point", input: "POINT(-75.00417 40.20361)", want: geometry.Point{Point: geodesy.Point{40.20361, -75.00417}}},
+		{name: "OK/point_z", input: "point z (1 2 3)", want: geometry.Point{Point: geodesy.Point{2, 1}}},
+		{name: "OK/point_inferred_zm", input: "POINT(1 2 3 4)", want: geometry.Point{Point: geodesy.Point{2, 1}}},
+		{name: "OK/point_m_suffix", input: "POINTM(1 2 3)", want: geometry.Point{Point: geodesy.Point{2, 1}}},
+		{name: "OK/multipoint", input: "MULTIPOINT((1 2), (3 4))", want: geometry.MultiPoint{{2, 1}, {4, 3}}},
+		{name: "OK/multipoint_unparenthesized", input: "MULTIPOINT(1 2,3 4)", want: geometry.MultiPoint{{2, 1}, {4, 3}}},
+		{name: "OK/linestring", input: "LINESTRING (1 2, 3 4)", want: geometry.LineString{{2, 1}, {4, 3}}},
+		{name: "OK/linestring_empty", input: "LINESTRING EMPTY", want: geometry.LineString{}},
+		{name: "OK/multilinestring", input: "MULTILINESTRING ZM ((1 2 0 0,3 4 0 0),EMPTY)", want: geometry.MultiLineString{{{2, 1}, {4, 3}}, {}}},
+		{
+			name:  "OK/polygon",
+			input: "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,2 2))",
+			want:  geometry.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, {{2, 2}, {4, 2}, {4, 4}}},
+		},
+		{
+			name:  "OK/multipolygon",
+			input: "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))",
+			want:  geometry.MultiPolygon{{{{0, 0}, {0, 1}, {1, 1}}}, {{{5, 5}, {5, 6}, {6, 6}}}},
+		},
+		{
+			name:  "OK/collection",
+			input: "GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4),GEOMETRYCOLLECTION EMPTY)",
+			want:  geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.LineString{{2, 1}, {4, 3}}, geometry.Collection{}},
+		},
+		{name: "OK/ewkt", input: "SRID=4326;POINT(1 2)", want: geometry.Point{Point: geodesy.Point{2, 1}}},
+		{name: "OK/exponent", input: "POINT(1e1 -2.5E-1)", want: geometry.Point{Point: geodesy.Point{-0.25, 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wkt.Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		err     error
+		message string
+	}{
+		{name: "FAIL/empty", input: "", err: wkt.ErrSyntax, message: `wkt: invalid syntax: missing geometry type at offset 0 in ""`},
+		{name: "FAIL/unknown_type", input: "CIRCLE(1 2)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: unknown geometry type "CIRCLE" at offset 0 in "CIRCLE(1 2)"`},
+		{name: "FAIL/unclosed", input: "POINT(1 2", err: wkt.ErrSyntax, message: `wkt: invalid syntax: missing ')' at offset 9 in "POINT(1 2"`},
+		{name: "FAIL/number", input: "POINT(1 x)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: invalid number at offset 8 in "POINT(1 x)"`},
+		{name: "FAIL/dimension", input: "POINT Z(1 2)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 2 values instead of 3 at offset 8 in "POINT Z(1 2)"`},
+		{name: "FAIL/empty_coordinate", input: "POINT()", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 0 values instead of 2 to 4 at offset 6 in "POINT()"`},
+		{name: "FAIL/single_value", input: "POINT(1)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 1 values instead of 2 to 4 at offset 6 in "POINT(1)"`},
+		{name: "FAIL/five_values", input: "POINT(1 2 3 4 5)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 5 values instead of 2 to 4 at offset 6 in "POINT(1 2 3 4 5)"`},
+		{name: "FAIL/empty_multipoint_coordinate", input: "MULTIPOINT(())", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 0 values instead of 2 to 4 at offset 12 in "MULTIPOINT(())"`},
+		{name: "FAIL/empty_ring_coordinate", input: "POLYGON(())", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 0 values instead of 2 to 4 at offset 9 in "POLYGON(())"`},
+		{name: "FAIL/empty_collection_member_coordinate", input: "GEOMETRYCOLLECTION(POINT())", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 0 values instead of 2 to 4 at offset 25 in "GEOMETRYCOLLECTION(POINT())"`},
+		{name: "FAIL/mixed_dimensions", input: "LINESTRING(1 2,3 4 5)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: coordinate with 3 values instead of 2 at offset 15 in "LINESTRING(1 2,3 4 5)"`},
+		{name: "FAIL/short_linestring", input: "LINESTRING(1 2)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: line string with a single point at offset 10 in "LINESTRING(1 2)"`},
+		{name: "FAIL/open_ring", input: "POLYGON((0 0,1 0,1 1,0 1))", err: wkt.ErrSyntax, message: `wkt: invalid syntax: ring is not closed at offset 8 in "POLYGON((0 0,1 0,1 1,0 1))"`},
+		{name: "FAIL/short_ring", input: "POLYGON((0 0,1 0,0 0))", err: wkt.ErrSyntax, message: `wkt: invalid syntax: ring with 3 points instead of at least 4 at offset 8 in "POLYGON((0 0,1 0,0 0))"`},
+		{name: "FAIL/trailing", input: "POINT(1 2) x", err: wkt.ErrSyntax, message: `wkt: invalid syntax: unexpected "x" at offset 11 in "POINT(1 2) x"`},
+		{name: "FAIL/srid", input: "SRID=x;POINT(1 2)", err: wkt.ErrSyntax, message: `wkt: invalid syntax: invalid SRID at offset 5 in "SRID=x;POINT(1 2)"`},
+		{name: "FAIL/range", input: "POINT(181 0)", err: wkt.ErrRange, message: `wkt: value out of range: coordinate [181 0] at offset 6 in "POINT(181 0)"`},
+		{name: "FAIL/empty_point", input: "POINT EMPTY", err: wkt.ErrRange, message: `wkt: value out of range: empty point at offset 0 in "POINT EMPTY"`},
+		{name: "FAIL/empty_multipoint_member", input: "MULTIPOINT(EMPTY)", err: wkt.ErrRange, message: `wkt: value out of range: empty point at offset 11 in "MULTIPOINT(EMPTY)"`},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wkt.Parse(tt.input)
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, tt.err)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestParseEWKT(t *testing.T) {
+	t.Run("OK/srid", func(t *testing.T) {
+		g, srid, err := wkt.ParseEWKT("SRID=4258; LINESTRING(1 2,3 4)")
+		assert.NoError(t, err)
+		assert.Equal(t, 4258, srid)
+		assert.Equal(t, geometry.LineString{{2, 1}, {4, 3}}, g)
+	})
+
+	t.Run("OK/no_srid", func(t *testing.T) {
+		_, srid, err := wkt.ParseEWKT("POINT(1 2)")
+		assert.NoError(t, err)
+		assert.Zero(t, srid)
+	})
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		g    geometry.Geometry
+		want string
+	}{
+		{name: "OK/point", g: geometry.Point{Point: geodesy.Point{40.20361, -75.00417}}, want: "POINT(-75.00417 40.20361)"},
+		{name: "OK/multipoint", g: geometry.MultiPoint{{2, 1}, {4, 3}}, want: "MULTIPOINT(1 2,3 4)"},
+		{name: "OK/multipoint_empty", g: geometry.MultiPoint{}, want: "MULTIPOINT EMPTY"},
+		{name: "OK/linestring", g: geometry.LineString{{2, 1}, {4, 3}}, want: "LINESTRING(1 2,3 4)"},
+		{name: "OK/multilinestring", g: geometry.MultiLineString{{{2, 1}, {4, 3}}, {}}, want: "MULTILINESTRING((1 2,3 4),EMPTY)"},
+		{
+			name: "OK/polygon",
+			g:    geometry.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, {{2, 2}, {4, 2}, {4, 4}}},
+			want: "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,2 2))",
+		},
+		{name: "OK/multipolygon", g: geometry.MultiPolygon{{{{0, 0}, {0, 1}, {1, 1}}}}, want: "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))"},
+		{
+			name: "OK/collection",
+			g:    geometry.Collection{geometry.Point{Point: geodesy.Point{2, 1}}, geometry.Collection{}},
+			want: "GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION EMPTY)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wkt.Format(tt.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := wkt.Parse(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.g, parsed)
+		})
+	}
+
+	t.Run("OK/ewkt", func(t *testing.T) {
+		s, err := wkt.FormatEWKT(geometry.Point{Point: geodesy.Point{2, 1}}, 4326)
+		assert.NoError(t, err)
+		assert.Equal(t, "SRID=4326;POINT(1 2)", s)
+
+		s, err = wkt.FormatEWKT(geometry.Point{Point: geodesy.Point{2, 1}}, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "POINT(1 2)", s)
+	})
+
+	t.Run("FAIL/invalid", func(t *testing.T) {
+		_, err := wkt.Format(geometry.LineString{{0, 0}, {-91, 0}})
+		assert.ErrorIs(t, err, wkt.ErrRange)
+		_, err = wkt.FormatEWKT(nil, 4326)
+		assert.ErrorIs(t, err, wkt.ErrRange)
+		_, err = wkt.Format(geometry.Collection{nil})
+		assert.ErrorIs(t, err, wkt.ErrRange)
+		_, err = wkt.Format(geometry.Collection{geometry.Collection{nil}})
+		assert.ErrorIs(t, err, wkt.ErrRange)
+	})
+}
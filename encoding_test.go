@@ -0,0 +1,239 @@
+package geodesy_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoint_MarshalJSON(t *testing.T) {
+	p := geodesy.Point{40.20361, -75.00417}
+	tests := []struct {
+		name   string
+		layout geodesy.Layout
+		want   string
+	}{
+		{name: "OK/lat_lon", layout: geodesy.LatLonLayout, want: `[40.20361,-75.00417]`},
+		{name: "OK/geojson", layout: geodesy.GeoJSONLayout, want: `[-75.00417,40.20361]`},
+		{name: "OK/object", layout: geodesy.ObjectLayout, want: `{"lat":40.20361,"lon":-75.00417}`},
+		{name: "OK/iso6709", layout: geodesy.ISO6709Layout, want: `"+40.20361-075.00417/"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := p.MarshalJSONLayout(tt.layout)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+
+			var got geodesy.Point
+			assert.NoError(t, got.UnmarshalJSONLayout(data, tt.layout))
+			assert.Equal(t, p, got)
+		})
+	}
+
+	t.Run("OK/lat_lon_by_default", func(t *testing.T) {
+		data, err := json.Marshal(map[string]geodesy.Point{"p": p})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"p":[40.20361,-75.00417]}`, string(data))
+	})
+
+	t.Run("OK/wrapper_types", func(t *testing.T) {
+		v := struct {
+			GeoJSON geodesy.GeoJSONPoint `json:"geojson"`
+			Object  geodesy.ObjectPoint  `json:"object"`
+			ISO6709 geodesy.ISO6709Point `json:"iso6709"`
+		}{geodesy.GeoJSONPoint(p), geodesy.ObjectPoint(p), geodesy.ISO6709Point(p)}
+		data, err := json.Marshal(v)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"geojson":[-75.00417,40.20361],"object":{"lat":40.20361,"lon":-75.00417},"iso6709":"+40.20361-075.00417/"}`, string(data))
+
+		v.GeoJSON, v.Object, v.ISO6709 = geodesy.GeoJSONPoint{}, geodesy.ObjectPoint{}, geodesy.ISO6709Point{}
+		assert.NoError(t, json.Unmarshal(data, &v))
+		assert.Equal(t, p, geodesy.Point(v.GeoJSON))
+		assert.Equal(t, p, geodesy.Point(v.Object))
+		assert.Equal(t, p, geodesy.Point(v.ISO6709))
+	})
+
+	t.Run("OK/out_of_range", func(t *testing.T) {
+		// Points out of range are encoded as they are, and rejected on decoding
+		data, err := json.Marshal(struct{ P geodesy.Point }{geodesy.Point{91, 0}})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"P":[91,0]}`, string(data))
+		data, err = geodesy.Point{91, -181}.MarshalJSONLayout(geodesy.ISO6709Layout)
+		assert.NoError(t, err)
+		assert.Equal(t, `"+91-181/"`, string(data))
+
+		var got geodesy.Point
+		assert.Error(t, got.UnmarshalJSONLayout(data, geodesy.ISO6709Layout))
+		assert.ErrorIs(t, got.UnmarshalJSON([]byte(`[91, 0]`)), geodesy.ErrInvalidPoint)
+	})
+
+	t.Run("FAIL/not_finite", func(t *testing.T) {
+		_, err := geodesy.Point{0, math.NaN()}.MarshalJSONLayout(geodesy.ISO6709Layout)
+		assert.EqualError(t, err, "geodesy: point [0 NaN] cannot be encoded in ISO 6709")
+	})
+
+	t.Run("FAIL/unknown_layout", func(t *testing.T) {
+		_, err := p.MarshalJSONLayout(geodesy.Layout(42))
+		assert.EqualError(t, err, "geodesy: unknown layout 42")
+	})
+}
+
+func TestPoint_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  geodesy.Point
+	}{
+		{name: "OK/lat_lon", input: ` [ -89.5 , 179.5 ] `, want: geodesy.Point{-89.5, 179.5}},
+		{name: "OK/lat_lon_legacy", input: `[40.2,-75.0]`, want: geodesy.Point{40.2, -75}},
+		{name: "OK/object", input: `{"lon": 2.35, "lat": 48.85}`, want: geodesy.Point{48.85, 2.35}},
+		{name: "OK/iso6709_dms", input: `"+401213-0750015/"`, want: geodesy.Point{40 + 12.0/60 + 13.0/3600, -(75 + 15.0/3600)}},
+		{name: "OK/iso6709_altitude", input: `"-33.8688+151.2093+58CRSWGS_84/"`, want: geodesy.Point{-33.8688, 151.2093}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got geodesy.Point
+			assert.NoError(t, json.Unmarshal([]byte(tt.input), &got))
+			assert.InDelta(t, tt.want.Lat(), got.Lat(), 1e-12)
+			assert.InDelta(t, tt.want.Lon(), got.Lon(), 1e-12)
+		})
+	}
+
+	t.Run("OK/null", func(t *testing.T) {
+		got := geodesy.Point{1, 2}
+		assert.NoError(t, json.Unmarshal([]byte(`null`), &got))
+		assert.Equal(t, geodesy.Point{1, 2}, got)
+	})
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/lat_lon_range", input: `[95, 10]`, message: "geodesy: invalid point: [95 10]"},
+		{name: "FAIL/array_length", input: `[10, 20, 30]`, message: "geodesy: point array has 3 elements instead of 2"},
+		{name: "FAIL/object_range", input: `{"lat": 10, "lon": -181}`, message: "geodesy: invalid point: [10 -181]"},
+		{name: "FAIL/object_missing", input: `{"lat": 10}`, message: `geodesy: point object must have both "lat" and "lon"`},
+		{name: "FAIL/iso6709_range", input: `"+91-075/"`, message: `iso6709: value out of range: latitude "+91" at offset 0 in "+91-075/"`},
+		{name: "FAIL/number", input: `42`, message: "geodesy: cannot decode point from 42"},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			got := geodesy.Point{1, 2}
+			assert.EqualError(t, json.Unmarshal([]byte(tt.input), &got), tt.message)
+			assert.Equal(t, geodesy.Point{1, 2}, got)
+		})
+	}
+
+	t.Run("OK/geojson_layout", func(t *testing.T) {
+		var got geodesy.Point
+		assert.NoError(t, got.UnmarshalJSONLayout([]byte(` [ 179.5 , -89.5 ] `), geodesy.GeoJSONLayout))
+		assert.Equal(t, geodesy.Point{-89.5, 179.5}, got)
+		assert.NoError(t, got.UnmarshalJSONLayout([]byte(`{"lat": 10, "lon": 20}`), geodesy.GeoJSONLayout))
+		assert.Equal(t, geodesy.Point{10, 20}, got)
+	})
+
+	t.Run("FAIL/geojson_range", func(t *testing.T) {
+		var got geodesy.Point
+		assert.EqualError(t, got.UnmarshalJSONLayout([]byte(`[10, 95]`), geodesy.GeoJSONLayout), "geodesy: invalid point: [95 10]")
+	})
+
+	t.Run("FAIL/unknown_layout", func(t *testing.T) {
+		var got geodesy.Point
+		assert.EqualError(t, got.UnmarshalJSONLayout([]byte(`[10, 20]`), geodesy.Layout(-1)), "geodesy: unknown layout -1")
+	})
+}
+
+func TestPoint_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		p    geodesy.Point
+		want string
+	}{
+		{name: "OK/integers", p: geodesy.Point{-5, 7}, want: "-05+007/"},
+		{name: "OK/decimals", p: geodesy.Point{40.20361, -75.00417}, want: "+40.20361-075.00417/"},
+		{name: "OK/bounds", p: geodesy.Point{90, -180}, want: "+90-180/"},
+		{name: "OK/tiny", p: geodesy.Point{1e-20, -0.1}, want: "+00.00000000000000000001-000.1/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.p.MarshalText()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(text))
+
+			var got geodesy.Point
+			assert.NoError(t, got.UnmarshalText(text))
+			assert.Equal(t, tt.p, got)
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range []geodesy.Point{{1.0 / 3, -2.0 / 3}, {math.Nextafter(90, 0), math.Nextafter(-180, 0)}, {-12.345678901234567, 98.76543210987654}} {
+			text, err := p.MarshalText()
+			assert.NoError(t, err)
+			var got geodesy.Point
+			assert.NoError(t, got.UnmarshalText(text))
+			assert.Equal(t, p, got)
+		}
+	})
+
+	t.Run("OK/out_of_range", func(t *testing.T) {
+		text, err := geodesy.Point{0, 180.5}.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, "+00+180.5/", string(text))
+	})
+
+	t.Run("FAIL/not_encodable", func(t *testing.T) {
+		_, err := geodesy.Point{100, 0}.MarshalText()
+		assert.EqualError(t, err, "geodesy: point [100 0] cannot be encoded in ISO 6709")
+		_, err = geodesy.Point{0, math.Inf(1)}.MarshalText()
+		assert.Error(t, err)
+	})
+
+	t.Run("FAIL/syntax", func(t *testing.T) {
+		var got geodesy.Point
+		assert.Error(t, got.UnmarshalText([]byte("40.2,-75.0")))
+	})
+}
+
+func TestPoint_MarshalBinary(t *testing.T) {
+	t.Run("OK/round_trip", func(t *testing.T) {
+		p := geodesy.Point{-33.8688, 151.2093}
+		data, err := p.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Len(t, data, 17)
+		assert.Equal(t, byte(1), data[0])
+
+		var got geodesy.Point
+		assert.NoError(t, got.UnmarshalBinary(data))
+		assert.Equal(t, p, got)
+	})
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		// Points out of range are encoded, and rejected on decoding
+		data, err := geodesy.Point{-90.5, 0}.MarshalBinary()
+		assert.NoError(t, err)
+		var got geodesy.Point
+		assert.ErrorIs(t, got.UnmarshalBinary(data), geodesy.ErrInvalidPoint)
+
+		data, _ = geodesy.Point{0, 0}.MarshalBinary()
+		data[1] = 0x7f // Sets the exponent of the latitude to its maximum, making it infinite or NaN
+		data[2] = 0xf0
+		assert.ErrorIs(t, got.UnmarshalBinary(data), geodesy.ErrInvalidPoint)
+	})
+
+	t.Run("FAIL/length", func(t *testing.T) {
+		var got geodesy.Point
+		assert.EqualError(t, got.UnmarshalBinary([]byte{1, 2, 3}), "geodesy: point binary encoding has 3 bytes instead of 17")
+	})
+
+	t.Run("FAIL/version", func(t *testing.T) {
+		data, _ := geodesy.Point{0, 0}.MarshalBinary()
+		data[0] = 2
+		var got geodesy.Point
+		assert.EqualError(t, got.UnmarshalBinary(data), "geodesy: unsupported point binary encoding version 2")
+	})
+}
@@ -0,0 +1,48 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// BoundingBox returns the smallest bounding box containing the geodesic between p1 and p2.
+// Besides its endpoints, the box includes the vertices of the geodesic (the points of extreme
+// latitude, where its azimuth is ±90°) lying between them, and its longitudes span the
+// direction in which the geodesic is traversed, so that it may cross the antimeridian.
+// If p1 or p2 do not constitute valid geographic coordinates, the returned box is empty
+func (g *Geodesic) BoundingBox(p1, p2 geodesy.Point) geodesy.BoundingBox {
+	b := geodesy.NewBoundingBox(p1, p2)
+	if b.IsEmpty() {
+		return b
+	}
+
+	s := g.Inverse(p1, p2)
+	switch {
+	case math.Abs(p1.Lat()) == 90 && math.Abs(p2.Lat()) == 90:
+	case math.Abs(p1.Lat()) == 90:
+		// The longitude of a pole is arbitrary, so that only the one of the other endpoint is bounded
+		b.West, b.East = p2.Lon(), p2.Lon()
+	case math.Abs(p2.Lat()) == 90:
+		b.West, b.East = p1.Lon(), p1.Lon()
+	case s.Azimuth1 > 0 && s.Azimuth1 < 180:
+		b.West, b.East = p1.Lon(), p2.Lon()
+	case s.Azimuth1 < 0 && s.Azimuth1 > -180:
+		b.West, b.East = p2.Lon(), p1.Lon()
+	}
+
+	// The vertices lie at arc lengths σ = ±90° from the equator crossing on the auxiliary sphere,
+	// and at most two of them are reached by a shortest geodesic
+	l := g.Line(p1, s.Azimuth1)
+	σ1 := atan2d(l.ssig1, l.csig1)
+	for a := math.Mod(math.Mod(90-σ1, 180)+180, 180); a < s.Arc; a += 180 {
+		if a == 0 {
+			continue
+		}
+		lat := l.ArcPosition(a).P2.Lat()
+		b.South = math.Min(b.South, lat)
+		b.North = math.Max(b.North, lat)
+	}
+
+	return b
+}
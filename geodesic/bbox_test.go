@@ -0,0 +1,88 @@
+package geodesic_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		p1, p2   geodesy.Point
+		expected geodesy.BoundingBox
+		delta    float64
+		empty    bool
+	}{
+		{
+			name:     "OK/short",
+			p1:       geodesy.Point{10, 20},
+			p2:       geodesy.Point{11, 21},
+			expected: geodesy.BoundingBox{South: 10, West: 20, North: 11, East: 21},
+		},
+		{
+			name:     "OK/meridian",
+			p1:       geodesy.Point{10, 20},
+			p2:       geodesy.Point{-10, 20},
+			expected: geodesy.BoundingBox{South: -10, West: 20, North: 10, East: 20},
+		},
+		{
+			// The geodesic between two points at 40°N bulges northwards to its vertex
+			name:     "OK/northern_vertex",
+			p1:       geodesy.Point{40, -60},
+			p2:       geodesy.Point{40, 60},
+			expected: geodesy.BoundingBox{South: 40, West: -60, North: 59.26478, East: 60},
+			delta:    1e-5,
+		},
+		{
+			name:     "OK/southern_vertex_antimeridian",
+			p1:       geodesy.Point{-40, -120},
+			p2:       geodesy.Point{-40, 120},
+			expected: geodesy.BoundingBox{South: -59.26478, West: 120, North: -40, East: -120},
+			delta:    1e-5,
+		},
+		{
+			name:     "OK/over_the_pole",
+			p1:       geodesy.Point{80, 0},
+			p2:       geodesy.Point{80, 180},
+			expected: geodesy.BoundingBox{South: 80, West: -180, North: 90, East: 0},
+			delta:    1e-9,
+		},
+		{
+			name:     "OK/from_the_pole",
+			p1:       geodesy.Point{90, 0},
+			p2:       geodesy.Point{80, 30},
+			expected: geodesy.BoundingBox{South: 80, West: 30, North: 90, East: 30},
+		},
+		{
+			name:  "FAIL/invalid_point",
+			p1:    geodesy.Point{0, 0},
+			p2:    geodesy.Point{0, 181},
+			empty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := geodesic.WGS84.BoundingBox(tt.p1, tt.p2)
+			if tt.empty {
+				assert.True(t, b.IsEmpty())
+				return
+			}
+			assert.InDelta(t, tt.expected.South, b.South, tt.delta)
+			assert.InDelta(t, tt.expected.West, b.West, tt.delta)
+			assert.InDelta(t, tt.expected.North, b.North, tt.delta)
+			assert.InDelta(t, tt.expected.East, b.East, tt.delta)
+
+			// Every point along the geodesic lies within the box
+			s := geodesic.WGS84.Inverse(tt.p1, tt.p2)
+			l := geodesic.WGS84.Line(tt.p1, s.Azimuth1)
+			e := b.Expand(1e-6)
+			for i := 0; i <= 100; i++ {
+				p := l.Position(s.Distance * float64(i) / 100).P2
+				assert.True(t, e.Contains(p), p)
+			}
+		})
+	}
+}
@@ -0,0 +1,594 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+const (
+	maxit1 = 20
+	maxit2 = maxit1 + 53 + 10 // Iterations of the bisection, bounded by the precision of float64
+)
+
+// Geodesic solves geodesic problems on an ellipsoid of revolution, using the algorithms by
+// Karney (2013), "Algorithms for geodesics", Journal of Geodesy 87, which are accurate to round-off
+// and always converge, including nearly antipodal points. This is a port of the C implementation
+// of GeographicLib (https://geographiclib.sourceforge.io)
+type Geodesic struct {
+	a, f, f1, e2, ep2, n, b, c2, etol2 float64
+
+	a3x [nA3x]float64
+	c3x [nC3x]float64
+	c4x [nC4x]float64
+}
+
+// WGS84 solves geodesic problems on the WGS-84 ellipsoid
+var WGS84 = New(ellipsoids.WGS84)
+
+// New returns a Geodesic for the ellipsoid e
+func New(e ellipsoids.Ellipsoid) *Geodesic {
+	g := &Geodesic{
+		a:  e.SemiMajorAxis,
+		f:  e.Flattening,
+		f1: 1 - e.Flattening,
+		e2: e.EccentricitySquared(),
+		n:  e.Flattening / (2 - e.Flattening),
+		b:  e.SemiMinorAxis(),
+	}
+	g.ep2 = g.e2 / sq(g.f1)
+
+	// Authalic radius squared
+	switch {
+	case g.e2 == 0:
+		g.c2 = sq(g.a)
+	case g.e2 > 0:
+		g.c2 = (sq(g.a) + sq(g.b)*math.Atanh(math.Sqrt(g.e2))/math.Sqrt(g.e2)) / 2
+	default:
+		g.c2 = (sq(g.a) + sq(g.b)*math.Atan(math.Sqrt(-g.e2))/math.Sqrt(-g.e2)) / 2
+	}
+	// Threshold of the short line approximation, see Karney (2013), section 5
+	g.etol2 = 0.1 * tol2 / math.Sqrt(math.Max(0.001, math.Abs(g.f))*math.Min(1, 1-g.f/2)/2)
+
+	g.a3coeff()
+	g.c3coeff()
+	g.c4coeff()
+
+	return g
+}
+
+// EllipsoidArea returns the total area of the ellipsoid of g, in square meters (m²)
+func (g *Geodesic) EllipsoidArea() float64 {
+	return 4 * math.Pi * g.c2
+}
+
+// Solution holds the solution of a geodesic problem between the points P1 and P2
+type Solution struct {
+	P1, P2 geodesy.Point
+	// Distance s12 between P1 and P2, defined in meters (m)
+	Distance float64
+	// Forward azimuths of the geodesic at P1 and P2, in degrees clockwise from north
+	Azimuth1, Azimuth2 float64
+	// Arc length a12 between P1 and P2 on the auxiliary sphere, in degrees
+	Arc float64
+	// Reduced length m12 of the geodesic, defined in meters (m)
+	ReducedLength float64
+	// Geodesic scales M12 and M21; adimensional
+	Scale12, Scale21 float64
+	// Area S12 between the geodesic from P1 to P2 and the equator, defined in square meters (m²).
+	// It is positive for geodesics running from west to east in the northern hemisphere
+	Area float64
+}
+
+func invalidSolution(p1, p2 geodesy.Point) Solution {
+	nan := math.NaN()
+	return Solution{
+		P1: p1, P2: p2,
+		Distance: nan, Azimuth1: nan, Azimuth2: nan, Arc: nan,
+		ReducedLength: nan, Scale12: nan, Scale21: nan, Area: nan,
+	}
+}
+
+// Inverse solves the inverse geodesic problem, that is, finding the shortest geodesic between p1 and p2.
+// If any of the points does not constitute a valid geographic coordinate, the values of the
+// returned solution will be math.NaN()
+func (g *Geodesic) Inverse(p1, p2 geodesy.Point) Solution {
+	if !p1.Valid() || !p2.Valid() {
+		return invalidSolution(p1, p2)
+	}
+
+	s := Solution{P1: p1, P2: p2}
+	var salp1, calp1, salp2, calp2 float64
+	s.Arc, salp1, calp1, salp2, calp2 = g.inverse(p1.Lat(), p1.Lon(), p2.Lat(), p2.Lon(), &s)
+	s.Azimuth1 = atan2d(salp1, calp1)
+	s.Azimuth2 = atan2d(salp2, calp2)
+
+	return s
+}
+
+// Direct solves the direct geodesic problem, that is, finding the point at distance s12 (in meters)
+// from p1 along the geodesic with azimuth azi1 (in degrees clockwise from north).
+// If p1 does not constitute a valid geographic coordinate, the values of the returned solution
+// will be math.NaN()
+func (g *Geodesic) Direct(p1 geodesy.Point, azi1, s12 float64) Solution {
+	if !p1.Valid() {
+		return invalidSolution(p1, geodesy.Point{math.NaN(), math.NaN()})
+	}
+
+	return g.Line(p1, azi1).Position(s12)
+}
+
+// lengths computes the distance s12b, reduced length m12b, the m0 coefficient and the
+// geodesic scales M12 and M21 of a geodesic, with distances scaled to the semi minor axis
+func (g *Geodesic) lengths(ε, σ12, ssig1, csig1, dn1, ssig2, csig2, dn2, cbet1, cbet2 float64, ca []float64) (s12b, m12b, m0, M12, M21 float64) {
+	var cb [nC]float64
+
+	A1 := a1m1f(ε)
+	c1f(ε, ca)
+	A2 := a2m1f(ε)
+	c2f(ε, cb[:])
+	m0 = A1 - A2
+	A1++
+	A2++
+
+	B1 := sinCosSeries(true, ssig2, csig2, ca, nC1) - sinCosSeries(true, ssig1, csig1, ca, nC1)
+	s12b = A1 * (σ12 + B1)
+	B2 := sinCosSeries(true, ssig2, csig2, cb[:], nC2) - sinCosSeries(true, ssig1, csig1, cb[:], nC2)
+	J12 := m0*σ12 + (A1*B1 - A2*B2)
+
+	// Missing a factor of b, as well as s12b
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*J12
+
+	csig12 := csig1*csig2 + ssig1*ssig2
+	t := g.ep2 * (cbet1 - cbet2) * (cbet1 + cbet2) / (dn1 + dn2)
+	M12 = csig12 + (t*ssig2-csig2*J12)*ssig1/dn1
+	M21 = csig12 - (t*ssig1-csig1*J12)*ssig2/dn2
+
+	return s12b, m12b, m0, M12, M21
+}
+
+// astroid solves k⁴ + 2k³ − (x² + y² − 1)k² − 2y²k − y² = 0 for its positive root
+func astroid(x, y float64) float64 {
+	p, q := sq(x), sq(y)
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+
+	S := p * q / 4
+	r2 := sq(r)
+	r3 := r * r2
+	disc := S * (S + 2*r3)
+	u := r
+	if disc >= 0 {
+		T3 := S + r3
+		if T3 < 0 {
+			T3 -= math.Sqrt(disc)
+		} else {
+			T3 += math.Sqrt(disc)
+		}
+		T := math.Cbrt(T3)
+		u += T
+		if T != 0 {
+			u += r2 / T
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(S + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+
+	v := math.Sqrt(sq(u) + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+
+	return uv / (math.Sqrt(uv+sq(w)) + w)
+}
+
+// inverseStart returns a starting point for Newton's method in salp1 and calp1, solving
+// the problem directly (with σ12 ≥ 0) for short lines
+func (g *Geodesic) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, λ12, slam12, clam12 float64, ca []float64) (σ12, salp1, calp1, salp2, calp2, dnm float64) {
+	σ12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*λ12 < 0.5
+
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := sq(sbet1 + sbet2)
+		sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+		dnm = math.Sqrt(1 + g.ep2*sbetm2)
+		somg12, comg12 = math.Sincos(λ12 / (g.f1 * dnm))
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < g.etol2 {
+		// Really short lines
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*sq(somg12)/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		σ12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(g.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(g.n)*math.Pi*sq(cbet1) {
+		// Nothing to do, zeroth order spherical approximation is OK
+	} else {
+		// Scale λ12 and β2 − β1 to x and y, solving the astroid problem for nearly antipodal points
+		var x, y, lamscale, betscale float64
+		λ12x := math.Atan2(-slam12, -clam12) // λ12 − π
+		if g.f >= 0 {
+			k2 := sq(sbet1) * g.ep2
+			ε := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			lamscale = g.f * cbet1 * g.a3f(ε) * math.Pi
+			betscale = lamscale * cbet1
+			x = λ12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+			_, m12b, m0, _, _ := g.lengths(g.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2, cbet1, cbet2, ca)
+			x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -g.f * sq(cbet1) * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = λ12x / lamscale
+		}
+
+		if y > -tol1 && x > -1-xthresh {
+			if g.f >= 0 {
+				salp1 = math.Min(1, -x)
+				calp1 = -math.Sqrt(1 - sq(salp1))
+			} else {
+				if x > -tol1 {
+					calp1 = math.Max(0, x)
+				} else {
+					calp1 = math.Max(-1, x)
+				}
+				salp1 = math.Sqrt(1 - sq(calp1))
+			}
+		} else {
+			k := astroid(x, y)
+			var omg12a float64
+			if g.f >= 0 {
+				omg12a = lamscale * (-x * k / (1 + k))
+			} else {
+				omg12a = lamscale * (-y * (1 + k) / k)
+			}
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1-comg12)
+		}
+	}
+
+	if !(salp1 <= 0) {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+
+	return σ12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12State holds the intermediate results of lambda12 required to finish the inverse problem
+type lambda12State struct {
+	salp2, calp2, σ12, ssig1, csig1, ssig2, csig2, ε, domg12 float64
+}
+
+// lambda12 returns the longitude difference λ12 on the auxiliary sphere of the geodesic with
+// azimuth α1 at the first point, along with its derivative with respect to α1 if diffp is set
+func (g *Geodesic) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool, ca []float64) (λ12, dλ12 float64, st lambda12State) {
+	if sbet1 == 0 && calp1 == 0 {
+		// Break degeneracy of equatorial line
+		calp1 = -tiny
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	st.ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	st.csig1 = calp1 * cbet1
+	comg1 := st.csig1
+	st.ssig1, st.csig1 = norm2(st.ssig1, st.csig1)
+
+	if cbet2 != cbet1 {
+		st.salp2 = salp0 / cbet2
+	} else {
+		st.salp2 = salp1
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var d float64
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			d = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		st.calp2 = math.Sqrt(sq(calp1*cbet1)+d) / cbet2
+	} else {
+		st.calp2 = math.Abs(calp1)
+	}
+
+	st.ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	st.csig2 = st.calp2 * cbet2
+	comg2 := st.csig2
+	st.ssig2, st.csig2 = norm2(st.ssig2, st.csig2)
+
+	st.σ12 = math.Atan2(math.Max(0, st.csig1*st.ssig2-st.ssig1*st.csig2), st.csig1*st.csig2+st.ssig1*st.ssig2)
+
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	η := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := sq(calp0) * g.ep2
+	st.ε = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	g.c3f(st.ε, ca)
+	B312 := sinCosSeries(true, st.ssig2, st.csig2, ca, nC3-1) - sinCosSeries(true, st.ssig1, st.csig1, ca, nC3-1)
+	st.domg12 = -g.f * g.a3f(st.ε) * salp0 * (st.σ12 + B312)
+	λ12 = η + st.domg12
+
+	if diffp {
+		if st.calp2 == 0 {
+			dλ12 = -2 * g.f1 * dn1 / sbet1
+		} else {
+			_, dλ12, _, _, _ = g.lengths(st.ε, st.σ12, st.ssig1, st.csig1, dn1, st.ssig2, st.csig2, dn2, cbet1, cbet2, ca)
+			dλ12 *= g.f1 / (st.calp2 * cbet2)
+		}
+	}
+
+	return λ12, dλ12, st
+}
+
+// inverse solves the inverse problem, filling the lengths and area of s, and returns the arc
+// length along with the sine and cosine of the azimuths at both points
+func (g *Geodesic) inverse(lat1, lon1, lat2, lon2 float64, s *Solution) (a12, salp1, calp1, salp2, calp2 float64) {
+	var ca [nC]float64
+	var s12x, m12x, M12, M21 float64
+
+	// Make longitude difference positive
+	lon12, lon12s := angDiff(lon1, lon2)
+	lonsign := 1.0
+	if math.Signbit(lon12) {
+		lonsign = -1
+	}
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	λ12 := lon12 * degree
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	// If really close to the equator, treat as on equator
+	lat1 = angRound(latFix(lat1))
+	lat2 = angRound(latFix(lat2))
+	// Swap points so that the point with higher (abs) latitude is point 1
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) || math.IsNaN(lat2) {
+		swapp = -1
+		lonsign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+	// Make lat1 <= −0
+	latsign := -1.0
+	if math.Signbit(lat1) {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= g.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= g.f1
+	sbet2, cbet2 = norm2(sbet2, cbet2)
+	cbet2 = math.Max(tiny, cbet2)
+
+	// Ensure that β1 = −β2 (or |β1| = |β2|) is detected exactly
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + g.ep2*sq(sbet1))
+	dn2 := math.Sqrt(1 + g.ep2*sq(sbet2))
+
+	var σ12, omg12 float64
+	somg12, comg12 := 2.0, 0.0
+
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		// The geodesic is a meridian (or runs through a pole)
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+
+		σ12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12x, m12x, _, M12, M21 = g.lengths(g.n, σ12, ssig1, csig1, dn1, ssig2, csig2, dn2, cbet1, cbet2, ca[:])
+		// Accept the meridian unless it is longer than half of it (with a negative reduced length)
+		if σ12 < 1 || m12x >= 0 {
+			// Prevent negative lengths for short lines
+			if σ12 < 3*tiny || (σ12 < tol0 && (s12x < 0 || m12x < 0)) {
+				σ12, m12x, s12x = 0, 0, 0
+			}
+			m12x *= g.b
+			s12x *= g.b
+			a12 = σ12 / degree
+		} else {
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 && (g.f <= 0 || lon12s >= g.f*180) {
+		// The geodesic runs along the equator
+		calp1, calp2 = 0, 0
+		salp1, salp2 = 1, 1
+		s12x = g.a * λ12
+		σ12 = λ12 / g.f1
+		omg12 = σ12
+		m12x = g.b * math.Sin(σ12)
+		M12 = math.Cos(σ12)
+		M21 = M12
+		a12 = lon12 / g.f1
+	} else if !meridian {
+		var dnm float64
+		σ12, salp1, calp1, salp2, calp2, dnm = g.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, λ12, slam12, clam12, ca[:])
+
+		if σ12 >= 0 {
+			// Short lines, solved directly by inverseStart
+			s12x = σ12 * g.b * dnm
+			m12x = sq(dnm) * g.b * math.Sin(σ12/dnm)
+			M12 = math.Cos(σ12 / dnm)
+			M21 = M12
+			a12 = σ12 / degree
+			omg12 = λ12 / (g.f1 * dnm)
+		} else {
+			// Newton's method on α1, bracketed by bisection
+			var st lambda12State
+			salp1a, calp1a := tiny, 1.0
+			salp1b, calp1b := tiny, -1.0
+			tripn, tripb := false, false
+			for numit := 0; ; numit++ {
+				var v, dv float64
+				v, dv, st = g.lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, numit < maxit1, ca[:])
+				salp2, calp2 = st.salp2, st.calp2
+
+				limit := 1.0
+				if tripn {
+					limit = 8
+				}
+				if tripb || !(math.Abs(v) >= limit*tol0) || numit == maxit2 {
+					break
+				}
+				// Update the bracketing values
+				if v > 0 && (numit > maxit1 || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 && (numit > maxit1 || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+				if numit < maxit1 && dv > 0 {
+					dalp1 := -v / dv
+					if math.Abs(dalp1) < math.Pi {
+						sdalp1, cdalp1 := math.Sincos(dalp1)
+						nsalp1 := salp1*cdalp1 + calp1*sdalp1
+						if nsalp1 > 0 {
+							calp1 = calp1*cdalp1 - salp1*sdalp1
+							salp1 = nsalp1
+							salp1, calp1 = norm2(salp1, calp1)
+							tripn = math.Abs(v) <= 16*tol0
+							continue
+						}
+					}
+				}
+				// Fall back to bisection when Newton's method diverges
+				salp1 = (salp1a + salp1b) / 2
+				calp1 = (calp1a + calp1b) / 2
+				salp1, calp1 = norm2(salp1, calp1)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < tolb || math.Abs(salp1-salp1b)+(calp1-calp1b) < tolb
+			}
+
+			σ12 = st.σ12
+			s12x, m12x, _, M12, M21 = g.lengths(st.ε, σ12, st.ssig1, st.csig1, dn1, st.ssig2, st.csig2, dn2, cbet1, cbet2, ca[:])
+			m12x *= g.b
+			s12x *= g.b
+			a12 = σ12 / degree
+
+			sdomg12, cdomg12 := math.Sincos(st.domg12)
+			somg12 = slam12*cdomg12 - clam12*sdomg12
+			comg12 = clam12*cdomg12 + slam12*sdomg12
+		}
+	}
+
+	s.Distance = 0 + s12x // Convert −0 to 0
+	s.ReducedLength = 0 + m12x
+
+	// Area between the geodesic and the equator
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+	var S12 float64
+	if calp0 != 0 && salp0 != 0 {
+		ssig1, csig1 := norm2(sbet1, calp1*cbet1)
+		ssig2, csig2 := norm2(sbet2, calp2*cbet2)
+		k2 := sq(calp0) * g.ep2
+		ε := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		A4 := sq(g.a) * calp0 * salp0 * g.e2
+		g.c4f(ε, ca[:])
+		B41 := sinCosSeries(false, ssig1, csig1, ca[:], nC4)
+		B42 := sinCosSeries(false, ssig2, csig2, ca[:], nC4)
+		S12 = A4 * (B42 - B41)
+	}
+
+	if !meridian && somg12 == 2 {
+		somg12, comg12 = math.Sincos(omg12)
+	}
+	var alp12 float64
+	if !meridian && comg12 > -0.7071 && sbet2-sbet1 < 1.75 {
+		// Use tan(Ω/2) = tan(ω12/2) (tan(β1/2) + tan(β2/2)) / (1 + tan(β1/2) tan(β2/2)), which avoids
+		// cancellation for long lines
+		domg12 := 1 + comg12
+		dbet1 := 1 + cbet1
+		dbet2 := 1 + cbet2
+		alp12 = 2 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1), domg12*(sbet1*sbet2+dbet1*dbet2))
+	} else {
+		salp12 := salp2*calp1 - calp2*salp1
+		calp12 := calp2*calp1 + salp2*salp1
+		// The right thing appears to happen if α1 = ±180° and α2 = 0
+		if salp12 == 0 && calp12 < 0 {
+			salp12 = tiny * calp1
+			calp12 = -1
+		}
+		alp12 = math.Atan2(salp12, calp12)
+	}
+	S12 += g.c2 * alp12
+	S12 *= swapp * lonsign * latsign
+	s.Area = 0 + S12
+
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+		M12, M21 = M21, M12
+	}
+	s.Scale12, s.Scale21 = M12, M21
+
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	return a12, salp1, calp1, salp2, calp2
+}
@@ -0,0 +1,148 @@
+package geodesic_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInverse(t *testing.T) {
+	tests := []struct {
+		name             string
+		p1, p2           geodesy.Point
+		expectedDistance float64
+		expectedAzimuth1 float64
+		expectedAzimuth2 float64
+	}{
+		{
+			// Karney (2013), "Algorithms for geodesics", nearly antipodal example
+			name:             "OK/nearly_antipodal",
+			p1:               geodesy.Point{-41.32, 174.81},
+			p2:               geodesy.Point{40.96, -5.50},
+			expectedDistance: 19959679.267,
+			expectedAzimuth1: 161.067669986,
+			expectedAzimuth2: 18.825195123,
+		},
+		{
+			name:             "OK/meridian_quadrant",
+			p1:               geodesy.Point{0, 10},
+			p2:               geodesy.Point{90, 0},
+			expectedDistance: ellipsoids.WGS84_MERIDIAN_QUADRANT,
+			expectedAzimuth1: 0,
+			// At the pole, azimuths are measured relative to the meridian of the geodesic
+			expectedAzimuth2: -10,
+		},
+		{
+			name:             "OK/pole_to_pole",
+			p1:               geodesy.Point{90, 0},
+			p2:               geodesy.Point{-90, 0},
+			expectedDistance: 2 * ellipsoids.WGS84_MERIDIAN_QUADRANT,
+			expectedAzimuth1: 180,
+			expectedAzimuth2: 180,
+		},
+		{
+			name:             "OK/equator",
+			p1:               geodesy.Point{0, -10},
+			p2:               geodesy.Point{0, 20},
+			expectedDistance: ellipsoids.WGS84_SEMI_MAJOR_AXIS * math.Pi / 6,
+			expectedAzimuth1: 90,
+			expectedAzimuth2: 90,
+		},
+		{
+			name:             "OK/equal_points",
+			p1:               geodesy.Point{-34.579340, -57.534954},
+			p2:               geodesy.Point{-34.579340, -57.534954},
+			expectedDistance: 0,
+			expectedAzimuth1: 0,
+			expectedAzimuth2: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := geodesic.WGS84.Inverse(tt.p1, tt.p2)
+			assert.InDelta(t, tt.expectedDistance, s.Distance, 1e-3)
+			assert.InDelta(t, tt.expectedAzimuth1, s.Azimuth1, 1e-9)
+			assert.InDelta(t, tt.expectedAzimuth2, s.Azimuth2, 1e-9)
+		})
+	}
+
+	t.Run("OK/vincenty", func(t *testing.T) {
+		p1, p2 := geodesy.Point{-34.6, -58.4}, geodesy.Point{48.85, 2.35}
+		expected, azi1, _ := distance.VincentyInverse(p1, p2, -1, true)
+		s := geodesic.WGS84.Inverse(p1, p2)
+		assert.InDelta(t, expected, s.Distance, 1e-4)
+		assert.InDelta(t, azi1, s.Azimuth1, 1e-9)
+	})
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		s := geodesic.WGS84.Inverse(geodesy.Point{0, 0}, geodesy.Point{0, 181})
+		assert.True(t, math.IsNaN(s.Distance))
+		assert.True(t, math.IsNaN(s.Azimuth1))
+		assert.True(t, math.IsNaN(s.Area))
+	})
+}
+
+func TestDirect(t *testing.T) {
+	points := []geodesy.Point{{40.64, -73.78}, {-41.32, 174.81}, {0, 0}, {89.9, 45}, {-90, 0}}
+	for _, p1 := range points {
+		for azi1 := -180.0; azi1 < 180; azi1 += 22.5 {
+			for _, s12 := range []float64{1, 1e5, 1e7, 1.9e7} {
+				d := geodesic.WGS84.Direct(p1, azi1, s12)
+				assert.True(t, d.P2.Valid())
+
+				// Solving the inverse problem for the result must give back the same geodesic
+				s := geodesic.WGS84.Inverse(p1, d.P2)
+				assert.InDelta(t, s12, s.Distance, 1e-6, "%v %v %v", p1, azi1, s12)
+				if s12 > 1e7 || math.Abs(p1.Lat()) == 90 {
+					// Beyond this length (or from a pole) the direct geodesic is not necessarily the
+					// one chosen by the inverse problem among those of the same length
+					continue
+				}
+				// The area of geodesics running through a pole is defined up to half of the ellipsoid area
+				ΔS := math.Remainder(d.Area-s.Area, geodesic.WGS84.EllipsoidArea()/2)
+				assert.InDelta(t, 0, ΔS, 1e-1, "%v %v %v", p1, azi1, s12)
+				assert.InDelta(t, d.ReducedLength, s.ReducedLength, 1e-6, "%v %v %v", p1, azi1, s12)
+				if s12 > 1 {
+					Δ := math.Remainder(d.Azimuth2-s.Azimuth2, 360)
+					assert.InDelta(t, 0, Δ, 1e-8, "%v %v %v", p1, azi1, s12)
+				}
+			}
+		}
+	}
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		d := geodesic.WGS84.Direct(geodesy.Point{-91, 0}, 0, 100)
+		assert.True(t, math.IsNaN(d.Distance))
+		assert.True(t, math.IsNaN(d.P2.Lat()))
+	})
+}
+
+func TestLine(t *testing.T) {
+	p1, p2 := geodesy.Point{-34.6, -58.4}, geodesy.Point{48.85, 2.35}
+	s := geodesic.WGS84.Inverse(p1, p2)
+	line := geodesic.WGS84.Line(p1, s.Azimuth1)
+
+	end := line.Position(s.Distance)
+	assert.InDelta(t, p2.Lat(), end.P2.Lat(), 1e-9)
+	assert.InDelta(t, p2.Lon(), end.P2.Lon(), 1e-9)
+
+	arcEnd := line.ArcPosition(s.Arc)
+	assert.InDelta(t, s.Distance, arcEnd.Distance, 1e-6)
+	assert.InDelta(t, p2.Lat(), arcEnd.P2.Lat(), 1e-9)
+
+	// The midpoint is equidistant from both ends
+	mid := line.Position(s.Distance / 2)
+	d1 := geodesic.WGS84.Inverse(p1, mid.P2).Distance
+	d2 := geodesic.WGS84.Inverse(mid.P2, p2).Distance
+	assert.InDelta(t, d1, d2, 1e-6)
+}
+
+func TestEllipsoidArea(t *testing.T) {
+	assert.InDelta(t, 4*math.Pi*math.Pow(ellipsoids.WGS84_AUTHALIC_MEAN_RADIUS, 2), geodesic.WGS84.EllipsoidArea(), 1e6)
+	sphere := geodesic.New(ellipsoids.Ellipsoid{SemiMajorAxis: 6371000})
+	assert.InDelta(t, 4*math.Pi*6371000*6371000, sphere.EllipsoidArea(), 1e-3)
+}
@@ -0,0 +1,194 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// Line represents a geodesic line starting at a point with a given azimuth, allowing
+// the efficient computation of multiple positions along it
+type Line struct {
+	p1                              geodesy.Point
+	azi1                            float64
+	a, f, b, c2, f1                 float64
+	salp0, calp0, k2                float64
+	salp1, calp1, ssig1, csig1, dn1 float64
+	stau1, ctau1, somg1, comg1      float64
+	A1m1, A2m1, A3c, B11, B21, B31  float64
+	A4, B41                         float64
+	c1a, c1pa, c2a, c3a, c4a        [nC]float64
+}
+
+// Line returns the geodesic line starting at p1 with azimuth azi1, in degrees clockwise from north
+func (g *Geodesic) Line(p1 geodesy.Point, azi1 float64) *Line {
+	azi1 = angNormalize(azi1)
+	salp1, calp1 := sincosd(angRound(azi1))
+
+	l := &Line{
+		p1:    p1,
+		azi1:  azi1,
+		a:     g.a,
+		f:     g.f,
+		b:     g.b,
+		c2:    g.c2,
+		f1:    g.f1,
+		salp1: salp1,
+		calp1: calp1,
+	}
+
+	sbet1, cbet1 := sincosd(angRound(latFix(p1.Lat())))
+	sbet1 *= l.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+	l.dn1 = math.Sqrt(1 + g.ep2*sq(sbet1))
+
+	// Evaluate α0 from sin α1 cos β1 = sin α0
+	l.salp0 = l.salp1 * cbet1
+	l.calp0 = math.Hypot(l.calp1, l.salp1*sbet1)
+
+	// Distance and longitude on the auxiliary sphere are measured from the equator crossing
+	l.ssig1 = sbet1
+	l.somg1 = l.salp0 * sbet1
+	if sbet1 != 0 || l.calp1 != 0 {
+		l.csig1 = cbet1 * l.calp1
+	} else {
+		l.csig1 = 1
+	}
+	l.comg1 = l.csig1
+	l.ssig1, l.csig1 = norm2(l.ssig1, l.csig1)
+
+	l.k2 = sq(l.calp0) * g.ep2
+	ε := l.k2 / (2*(1+math.Sqrt(1+l.k2)) + l.k2)
+
+	l.A1m1 = a1m1f(ε)
+	c1f(ε, l.c1a[:])
+	l.B11 = sinCosSeries(true, l.ssig1, l.csig1, l.c1a[:], nC1)
+	s, c := math.Sincos(l.B11)
+	l.stau1 = l.ssig1*c + l.csig1*s
+	l.ctau1 = l.csig1*c - l.ssig1*s
+
+	c1pf(ε, l.c1pa[:])
+
+	l.A2m1 = a2m1f(ε)
+	c2f(ε, l.c2a[:])
+	l.B21 = sinCosSeries(true, l.ssig1, l.csig1, l.c2a[:], nC2)
+
+	g.c3f(ε, l.c3a[:])
+	l.A3c = -l.f * l.salp0 * g.a3f(ε)
+	l.B31 = sinCosSeries(true, l.ssig1, l.csig1, l.c3a[:], nC3-1)
+
+	g.c4f(ε, l.c4a[:])
+	l.A4 = sq(l.a) * l.calp0 * l.salp0 * g.e2
+	l.B41 = sinCosSeries(false, l.ssig1, l.csig1, l.c4a[:], nC4)
+
+	return l
+}
+
+// Position returns the solution of the direct problem for the point at distance s12
+// (in meters, which may be negative) along l
+func (l *Line) Position(s12 float64) Solution {
+	return l.position(false, s12)
+}
+
+// ArcPosition returns the solution of the direct problem for the point at arc length a12
+// (in degrees on the auxiliary sphere, which may be negative) along l
+func (l *Line) ArcPosition(a12 float64) Solution {
+	return l.position(true, a12)
+}
+
+func (l *Line) position(arcmode bool, s12a12 float64) Solution {
+	var σ12, ssig12, csig12, B12, AB1 float64
+	if arcmode {
+		σ12 = s12a12 * degree
+		ssig12, csig12 = sincosd(s12a12)
+	} else {
+		// Interpreting s12a12 as distance
+		τ12 := s12a12 / (l.b * (1 + l.A1m1))
+		s, c := math.Sincos(τ12)
+		B12 = -sinCosSeries(true, l.stau1*c+l.ctau1*s, l.ctau1*c-l.stau1*s, l.c1pa[:], nC1p)
+		σ12 = τ12 - (B12 - l.B11)
+		ssig12, csig12 = math.Sincos(σ12)
+		if math.Abs(l.f) > 0.01 {
+			// Reverted distance series is inaccurate for |f| > 1/100, so correct σ12 with
+			// one step of Newton's method
+			ssig2 := l.ssig1*csig12 + l.csig1*ssig12
+			csig2 := l.csig1*csig12 - l.ssig1*ssig12
+			B12 = sinCosSeries(true, ssig2, csig2, l.c1a[:], nC1)
+			serr := (1+l.A1m1)*(σ12+(B12-l.B11)) - s12a12/l.b
+			σ12 -= serr / math.Sqrt(1+l.k2*sq(ssig2))
+			ssig12, csig12 = math.Sincos(σ12)
+		}
+	}
+
+	// σ2 = σ1 + σ12
+	ssig2 := l.ssig1*csig12 + l.csig1*ssig12
+	csig2 := l.csig1*csig12 - l.ssig1*ssig12
+	dn2 := math.Sqrt(1 + l.k2*sq(ssig2))
+	if arcmode || math.Abs(l.f) > 0.01 {
+		B12 = sinCosSeries(true, ssig2, csig2, l.c1a[:], nC1)
+	}
+	AB1 = (1 + l.A1m1) * (B12 - l.B11)
+
+	// sin β2 = cos α0 sin σ2
+	sbet2 := l.calp0 * ssig2
+	// Alternatively, cos β2 = √((cos α0 cos σ2)² + sin² α0)
+	cbet2 := math.Hypot(l.salp0, l.calp0*csig2)
+	if cbet2 == 0 {
+		// I.e., salp0 = 0, csig2 = 0. Break the degeneracy in this case
+		cbet2, csig2 = tiny, tiny
+	}
+	// tan ω2 = sin α0 tan σ2
+	somg2, comg2 := l.salp0*ssig2, csig2
+	// tan α0 = cos(α2) tan(σ2)
+	salp2, calp2 := l.salp0, l.calp0*csig2
+
+	omg12 := math.Atan2(somg2*l.comg1-comg2*l.somg1, comg2*l.comg1+somg2*l.somg1)
+	λ12 := omg12 + l.A3c*(σ12+(sinCosSeries(true, ssig2, csig2, l.c3a[:], nC3-1)-l.B31))
+	lon12 := λ12 / degree
+	lon2 := angNormalize(angNormalize(l.p1.Lon()) + angNormalize(lon12))
+	lat2 := atan2d(sbet2, l.f1*cbet2)
+
+	sol := Solution{
+		P1:       l.p1,
+		P2:       geodesy.Point{lat2, lon2},
+		Azimuth1: l.azi1,
+		Azimuth2: atan2d(salp2, calp2),
+	}
+	if arcmode {
+		sol.Arc = s12a12
+		sol.Distance = l.b * ((1+l.A1m1)*σ12 + AB1)
+	} else {
+		sol.Arc = σ12 / degree
+		sol.Distance = s12a12
+	}
+
+	B22 := sinCosSeries(true, ssig2, csig2, l.c2a[:], nC2)
+	AB2 := (1 + l.A2m1) * (B22 - l.B21)
+	J12 := (l.A1m1-l.A2m1)*σ12 + (AB1 - AB2)
+	// Add parens around (csig1 * ssig2) and (ssig1 * csig2) to ensure accurate cancellation
+	// in the case of coincident points
+	sol.ReducedLength = l.b * ((dn2*(l.csig1*ssig2) - l.dn1*(l.ssig1*csig2)) - l.csig1*csig2*J12)
+	t := l.k2 * (ssig2 - l.ssig1) * (ssig2 + l.ssig1) / (l.dn1 + dn2)
+	sol.Scale12 = csig12 + (t*ssig2-csig2*J12)*l.ssig1/l.dn1
+	sol.Scale21 = csig12 - (t*l.ssig1-l.csig1*J12)*ssig2/dn2
+
+	B42 := sinCosSeries(false, ssig2, csig2, l.c4a[:], nC4)
+	var salp12, calp12 float64
+	if l.calp0 == 0 || l.salp0 == 0 {
+		// α12 = α2 − α1
+		salp12 = salp2*l.calp1 - calp2*l.salp1
+		calp12 = calp2*l.calp1 + salp2*l.salp1
+	} else {
+		// tan(α12) = tan(α2 − α1), evaluated so as to avoid cancellation
+		if csig12 <= 0 {
+			salp12 = l.calp0 * l.salp0 * (l.csig1*(1-csig12) + ssig12*l.ssig1)
+		} else {
+			salp12 = l.calp0 * l.salp0 * ssig12 * (l.csig1*ssig12/(1+csig12) + l.ssig1)
+		}
+		calp12 = sq(l.salp0) + sq(l.calp0)*l.csig1*csig2
+	}
+	sol.Area = l.c2*math.Atan2(salp12, calp12) + l.A4*(B42-l.B41)
+
+	return sol
+}
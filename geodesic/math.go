@@ -0,0 +1,151 @@
+package geodesic
+
+import "math"
+
+const (
+	degree = math.Pi / 180
+
+	// tiny is the square root of the smallest positive normal number
+	tiny = 1.4916681462400413e-154
+	tol0 = 0x1p-52 // Machine epsilon
+	tol1 = 200 * tol0
+	tol2 = 1.4901161193847656e-08 // √tol0
+	tolb = tol0
+	// xthresh is the threshold of the astroid solution in InverseStart
+	xthresh = 1000 * tol2
+)
+
+func sq(x float64) float64 {
+	return x * x
+}
+
+// sumx returns u + v along with the rounding error t of the sum, such that s + t = u + v exactly
+func sumx(u, v float64) (s, t float64) {
+	s = u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	t = -(up + vpp)
+
+	return s, t
+}
+
+// polyval evaluates the polynomial of degree n with coefficients p (from the highest degree) at x
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+	y := p[0]
+	for i := 1; i <= n; i++ {
+		y = y*x + p[i]
+	}
+
+	return y
+}
+
+// angRound rounds tiny angles, so that angles very close to zero are treated as zero
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+
+	return math.Copysign(y, x)
+}
+
+// angNormalize reduces the angle x (in degrees) to the range (−180°, 180°]
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+
+	return y
+}
+
+// latFix returns math.NaN() if the latitude x lies outside of [−90°, 90°]
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90 {
+		return math.NaN()
+	}
+
+	return x
+}
+
+// angDiff returns the exact difference y − x of two angles, reduced to (−180°, 180°],
+// along with the rounding error e of the difference
+func angDiff(x, y float64) (d, e float64) {
+	d, t := sumx(math.Remainder(-x, 360), math.Remainder(y, 360))
+	d, t = sumx(math.Remainder(d, 360), t)
+	if d == 0 || math.Abs(d) == 180 {
+		if t == 0 {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -t)
+		}
+	}
+
+	return d, t
+}
+
+// sincosd returns the sine and cosine of x (in degrees), reducing the angle exactly so that
+// the results are exact for multiples of 90°
+func sincosd(x float64) (sinx, cosx float64) {
+	r := math.Mod(x, 360)
+	q := 0
+	if !math.IsNaN(r) && !math.IsInf(r, 0) {
+		q = int(math.Round(r / 90))
+	}
+	r -= 90 * float64(q)
+	s, c := math.Sincos(r * degree)
+
+	switch uint(q) & 3 {
+	case 0:
+		sinx, cosx = s, c
+	case 1:
+		sinx, cosx = c, -s
+	case 2:
+		sinx, cosx = -s, -c
+	default:
+		sinx, cosx = -c, s
+	}
+	cosx += 0
+	if sinx == 0 {
+		sinx = math.Copysign(sinx, x)
+	}
+
+	return sinx, cosx
+}
+
+// atan2d returns atan2(y, x) in degrees, reducing the angle exactly for multiples of 45°
+func atan2d(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		x, y = y, x
+		q = 2
+	}
+	if math.Signbit(x) {
+		x = -x
+		q++
+	}
+
+	ang := math.Atan2(y, x) / degree
+	switch q {
+	case 1:
+		ang = math.Copysign(180, y) - ang
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+
+	return ang
+}
+
+// norm2 normalizes the vector (x, y) to unit length
+func norm2(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
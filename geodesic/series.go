@@ -0,0 +1,309 @@
+package geodesic
+
+// Order of the series expansions in the third flattening n and the parameter ε,
+// which gives full double precision for |f| < 0.01. See Karney (2013), "Algorithms for
+// geodesics", Journal of Geodesy 87, and https://geographiclib.sourceforge.io/C/doc
+const (
+	nA1  = 6
+	nC1  = 6
+	nC1p = 6
+	nA2  = 6
+	nC2  = 6
+	nA3  = 6
+	nA3x = nA3
+	nC3  = 6
+	nC3x = (nC3 * (nC3 - 1)) / 2
+	nC4  = 6
+	nC4x = (nC4 * (nC4 + 1)) / 2
+	nC   = nC1 + 1 // Size of the coefficient arrays
+)
+
+// a1m1f returns the scale factor A1 − 1 of the distance integral I1
+func a1m1f(ε float64) float64 {
+	coeff := [...]float64{
+		// (1-eps)*A1-1, polynomial in eps2 of order 3
+		1, 4, 64, 0, 256,
+	}
+	m := nA1 / 2
+	t := polyval(m, coeff[:], sq(ε)) / coeff[m+1]
+
+	return (t + ε) / (1 - ε)
+}
+
+// c1f computes the coefficients C1[l] of the Fourier series of the distance integral I1
+func c1f(ε float64, c []float64) {
+	coeff := [...]float64{
+		// C1[1]/eps^1, polynomial in eps2 of order 2
+		-1, 6, -16, 32,
+		// C1[2]/eps^2, polynomial in eps2 of order 2
+		-9, 64, -128, 2048,
+		// C1[3]/eps^3, polynomial in eps2 of order 1
+		9, -16, 768,
+		// C1[4]/eps^4, polynomial in eps2 of order 1
+		3, -5, 512,
+		// C1[5]/eps^5, polynomial in eps2 of order 0
+		-7, 1280,
+		// C1[6]/eps^6, polynomial in eps2 of order 0
+		-7, 2048,
+	}
+	ε2, d := sq(ε), ε
+	o := 0
+	for l := 1; l <= nC1; l++ {
+		m := (nC1 - l) / 2
+		c[l] = d * polyval(m, coeff[o:], ε2) / coeff[o+m+1]
+		o += m + 2
+		d *= ε
+	}
+}
+
+// c1pf computes the coefficients C1'[l] of the reversion of the series of the distance integral I1
+func c1pf(ε float64, c []float64) {
+	coeff := [...]float64{
+		// C1p[1]/eps^1, polynomial in eps2 of order 2
+		205, -432, 768, 1536,
+		// C1p[2]/eps^2, polynomial in eps2 of order 2
+		4005, -4736, 3840, 12288,
+		// C1p[3]/eps^3, polynomial in eps2 of order 1
+		-225, 116, 384,
+		// C1p[4]/eps^4, polynomial in eps2 of order 1
+		-7173, 2695, 7680,
+		// C1p[5]/eps^5, polynomial in eps2 of order 0
+		3467, 7680,
+		// C1p[6]/eps^6, polynomial in eps2 of order 0
+		38081, 61440,
+	}
+	ε2, d := sq(ε), ε
+	o := 0
+	for l := 1; l <= nC1p; l++ {
+		m := (nC1p - l) / 2
+		c[l] = d * polyval(m, coeff[o:], ε2) / coeff[o+m+1]
+		o += m + 2
+		d *= ε
+	}
+}
+
+// a2m1f returns the scale factor A2 − 1 of the reduced length integral I2
+func a2m1f(ε float64) float64 {
+	coeff := [...]float64{
+		// (eps+1)*A2-1, polynomial in eps2 of order 3
+		-11, -28, -192, 0, 256,
+	}
+	m := nA2 / 2
+	t := polyval(m, coeff[:], sq(ε)) / coeff[m+1]
+
+	return (t - ε) / (1 + ε)
+}
+
+// c2f computes the coefficients C2[l] of the Fourier series of the reduced length integral I2
+func c2f(ε float64, c []float64) {
+	coeff := [...]float64{
+		// C2[1]/eps^1, polynomial in eps2 of order 2
+		1, 2, 16, 32,
+		// C2[2]/eps^2, polynomial in eps2 of order 2
+		35, 64, 384, 2048,
+		// C2[3]/eps^3, polynomial in eps2 of order 1
+		15, 80, 768,
+		// C2[4]/eps^4, polynomial in eps2 of order 1
+		7, 35, 512,
+		// C2[5]/eps^5, polynomial in eps2 of order 0
+		63, 1280,
+		// C2[6]/eps^6, polynomial in eps2 of order 0
+		77, 2048,
+	}
+	ε2, d := sq(ε), ε
+	o := 0
+	for l := 1; l <= nC2; l++ {
+		m := (nC2 - l) / 2
+		c[l] = d * polyval(m, coeff[o:], ε2) / coeff[o+m+1]
+		o += m + 2
+		d *= ε
+	}
+}
+
+// a3coeff computes the coefficients of the scale factor A3 of the longitude integral I3 as polynomials in ε
+func (g *Geodesic) a3coeff() {
+	coeff := [...]float64{
+		// A3, coeff of eps^5, polynomial in n of order 0
+		-3, 128,
+		// A3, coeff of eps^4, polynomial in n of order 1
+		-2, -3, 64,
+		// A3, coeff of eps^3, polynomial in n of order 2
+		-1, -3, -1, 16,
+		// A3, coeff of eps^2, polynomial in n of order 2
+		3, -1, -2, 8,
+		// A3, coeff of eps^1, polynomial in n of order 1
+		1, -1, 2,
+		// A3, coeff of eps^0, polynomial in n of order 0
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := nA3 - 1; j >= 0; j-- {
+		m := nA3 - j - 1
+		if j < m {
+			m = j
+		}
+		g.a3x[k] = polyval(m, coeff[o:], g.n) / coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+}
+
+// c3coeff computes the coefficients of the Fourier series of the longitude integral I3 as polynomials in ε
+func (g *Geodesic) c3coeff() {
+	coeff := [...]float64{
+		// C3[1], coeff of eps^5, polynomial in n of order 0
+		3, 128,
+		// C3[1], coeff of eps^4, polynomial in n of order 1
+		2, 5, 128,
+		// C3[1], coeff of eps^3, polynomial in n of order 2
+		-1, 3, 3, 64,
+		// C3[1], coeff of eps^2, polynomial in n of order 2
+		-1, 0, 1, 8,
+		// C3[1], coeff of eps^1, polynomial in n of order 1
+		-1, 1, 4,
+		// C3[2], coeff of eps^5, polynomial in n of order 0
+		5, 256,
+		// C3[2], coeff of eps^4, polynomial in n of order 1
+		1, 3, 128,
+		// C3[2], coeff of eps^3, polynomial in n of order 2
+		-3, -2, 3, 64,
+		// C3[2], coeff of eps^2, polynomial in n of order 2
+		1, -3, 2, 32,
+		// C3[3], coeff of eps^5, polynomial in n of order 0
+		7, 512,
+		// C3[3], coeff of eps^4, polynomial in n of order 1
+		-10, 9, 384,
+		// C3[3], coeff of eps^3, polynomial in n of order 2
+		5, -9, 5, 192,
+		// C3[4], coeff of eps^5, polynomial in n of order 0
+		7, 512,
+		// C3[4], coeff of eps^4, polynomial in n of order 1
+		-14, 7, 512,
+		// C3[5], coeff of eps^5, polynomial in n of order 0
+		21, 2560,
+	}
+	o, k := 0, 0
+	for l := 1; l < nC3; l++ {
+		for j := nC3 - 1; j >= l; j-- {
+			m := nC3 - j - 1
+			if j < m {
+				m = j
+			}
+			g.c3x[k] = polyval(m, coeff[o:], g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+// c4coeff computes the coefficients of the Fourier series of the area integral I4 as polynomials in ε
+func (g *Geodesic) c4coeff() {
+	coeff := [...]float64{
+		// C4[0], coeff of eps^5, polynomial in n of order 0
+		97, 15015,
+		// C4[0], coeff of eps^4, polynomial in n of order 1
+		1088, 156, 45045,
+		// C4[0], coeff of eps^3, polynomial in n of order 2
+		-224, -4784, 1573, 45045,
+		// C4[0], coeff of eps^2, polynomial in n of order 3
+		-10656, 14144, -4576, -858, 45045,
+		// C4[0], coeff of eps^1, polynomial in n of order 4
+		64, 624, -4576, 6864, -3003, 15015,
+		// C4[0], coeff of eps^0, polynomial in n of order 5
+		100, 208, 572, 3432, -12012, 30030, 45045,
+		// C4[1], coeff of eps^5, polynomial in n of order 0
+		1, 9009,
+		// C4[1], coeff of eps^4, polynomial in n of order 1
+		-2944, 468, 135135,
+		// C4[1], coeff of eps^3, polynomial in n of order 2
+		5792, 1040, -1287, 135135,
+		// C4[1], coeff of eps^2, polynomial in n of order 3
+		5952, -11648, 9152, -2574, 135135,
+		// C4[1], coeff of eps^1, polynomial in n of order 4
+		-64, -624, 4576, -6864, 3003, 135135,
+		// C4[2], coeff of eps^5, polynomial in n of order 0
+		8, 10725,
+		// C4[2], coeff of eps^4, polynomial in n of order 1
+		1856, -936, 225225,
+		// C4[2], coeff of eps^3, polynomial in n of order 2
+		-8448, 4992, -1144, 225225,
+		// C4[2], coeff of eps^2, polynomial in n of order 3
+		-1440, 4160, -4576, 1716, 225225,
+		// C4[3], coeff of eps^5, polynomial in n of order 0
+		-136, 63063,
+		// C4[3], coeff of eps^4, polynomial in n of order 1
+		1024, -208, 105105,
+		// C4[3], coeff of eps^3, polynomial in n of order 2
+		3584, -3328, 1144, 315315,
+		// C4[4], coeff of eps^5, polynomial in n of order 0
+		-128, 135135,
+		// C4[4], coeff of eps^4, polynomial in n of order 1
+		-2560, 832, 405405,
+		// C4[5], coeff of eps^5, polynomial in n of order 0
+		128, 99099,
+	}
+	o, k := 0, 0
+	for l := 0; l < nC4; l++ {
+		for j := nC4 - 1; j >= l; j-- {
+			m := nC4 - j - 1
+			g.c4x[k] = polyval(m, coeff[o:], g.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+// a3f returns the scale factor A3 of the longitude integral I3
+func (g *Geodesic) a3f(ε float64) float64 {
+	return polyval(nA3x-1, g.a3x[:], ε)
+}
+
+// c3f computes the coefficients C3[l] of the Fourier series of the longitude integral I3
+func (g *Geodesic) c3f(ε float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= ε
+		c[l] = mult * polyval(m, g.c3x[o:], ε)
+		o += m + 1
+	}
+}
+
+// c4f computes the coefficients C4[l] of the Fourier series of the area integral I4
+func (g *Geodesic) c4f(ε float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 0; l < nC4; l++ {
+		m := nC4 - l - 1
+		c[l] = mult * polyval(m, g.c4x[o:], ε)
+		o += m + 1
+		mult *= ε
+	}
+}
+
+// sinCosSeries evaluates the Fourier series Σ c[l] sin(2lx) (l = 1..n) if sinp is set, and
+// Σ c[l] cos((2l+1)x) (l = 0..n−1) otherwise, given sin x and cos x, using Clenshaw summation
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64, n int) float64 {
+	k := n
+	if sinp {
+		k++
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx) // 2 cos 2x
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+
+	if sinp {
+		return 2 * sinx * cosx * y0 // sin 2x · y0
+	}
+	return cosx * (y0 - y1) // cos x · (y0 − y1)
+}
@@ -0,0 +1,294 @@
+// Package geohash implements the geohash geocoding system, which encodes points as strings of base 32
+// characters identifying the cells of a hierarchical grid of latitudes and longitudes
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+var (
+	// ErrSyntax is returned when a string is not a geohash
+	ErrSyntax = errors.New("geohash: invalid syntax")
+	// ErrRange is returned when a point, radius or precision is out of range
+	ErrRange = errors.New("geohash: value out of range")
+	// ErrCoverTooLarge is returned when a cover would hold more than MaxCoverCells cells
+	ErrCoverTooLarge = errors.New("geohash: cover too large")
+)
+
+const (
+	// MaxPrecision is the maximum length of geohashes, whose cells are smaller than 4 cm
+	MaxPrecision = 12
+	// MaxCoverCells is the maximum amount of cells returned by Cover
+	MaxCoverCells = 1 << 16
+
+	alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+// Direction represents the direction of a neighboring cell
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// offsets holds the latitude and longitude cell offsets of each direction
+var offsets = [...][2]int{
+	North:     {1, 0},
+	NorthEast: {1, 1},
+	East:      {0, 1},
+	SouthEast: {-1, 1},
+	South:     {-1, 0},
+	SouthWest: {-1, -1},
+	West:      {0, -1},
+	NorthWest: {1, -1},
+}
+
+// grid returns the amount of latitude and longitude bits of the geohashes of the given precision.
+// Bits alternate starting with a longitude one, so longitudes get the extra bit of odd amounts
+func grid(precision int) (latBits, lonBits uint) {
+	bits := uint(5 * precision)
+	return bits / 2, bits - bits/2
+}
+
+// cell holds the row and column of a cell in the grid of a precision, counted from the south and west
+type cell struct {
+	row, col  uint64
+	precision int
+}
+
+func (c cell) String() string {
+	latBits, lonBits := grid(c.precision)
+	var b [MaxPrecision]byte
+	for i := 0; i < c.precision; i++ {
+		var v byte
+		for bit := 5 * i; bit < 5*i+5; bit++ {
+			// Even bits are the longitude ones, odd bits the latitude ones, most significant first
+			var set uint64
+			if bit%2 == 0 {
+				set = c.col >> (lonBits - 1 - uint(bit/2)) & 1
+			} else {
+				set = c.row >> (latBits - 1 - uint(bit/2)) & 1
+			}
+			v = v<<1 | byte(set)
+		}
+		b[i] = alphabet[v]
+	}
+
+	return string(b[:c.precision])
+}
+
+// box returns the bounding box of c
+func (c cell) box() geodesy.BoundingBox {
+	latBits, lonBits := grid(c.precision)
+	h := 180 / math.Ldexp(1, int(latBits))
+	w := 360 / math.Ldexp(1, int(lonBits))
+
+	return geodesy.BoundingBox{
+		South: geodesy.LatLowerBound + float64(c.row)*h,
+		West:  geodesy.LonLowerBound + float64(c.col)*w,
+		North: geodesy.LatLowerBound + float64(c.row+1)*h,
+		East:  geodesy.LonLowerBound + float64(c.col+1)*w,
+	}
+}
+
+// cellOf returns the cell of the given precision containing p, where points on the boundary between
+// cells belong to the northern and eastern ones
+func cellOf(p geodesy.Point, precision int) cell {
+	latBits, lonBits := grid(precision)
+	rows, cols := uint64(1)<<latBits, uint64(1)<<lonBits
+	row := uint64(math.Floor((p.Lat() - geodesy.LatLowerBound) / 180 * float64(rows)))
+	col := uint64(math.Floor((p.Lon() - geodesy.LonLowerBound) / 360 * float64(cols)))
+
+	return cell{row: min(row, rows-1), col: min(col, cols-1), precision: precision}
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// parse returns the cell of the geohash s, which may be in upper case
+func parse(s string) (cell, error) {
+	if len(s) == 0 || len(s) > MaxPrecision {
+		return cell{}, fmt.Errorf("%w: %q has %d characters instead of 1 to %d", ErrSyntax, s, len(s), MaxPrecision)
+	}
+
+	c := cell{precision: len(s)}
+	bit := 0
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(alphabet, s[i]|0x20)
+		if v < 0 || s[i] >= 0x80 {
+			return cell{}, fmt.Errorf("%w: invalid character %q at offset %d in %q", ErrSyntax, s[i], i, s)
+		}
+		for shift := 4; shift >= 0; shift-- {
+			set := uint64(v>>uint(shift)) & 1
+			if bit%2 == 0 {
+				c.col = c.col<<1 | set
+			} else {
+				c.row = c.row<<1 | set
+			}
+			bit++
+		}
+	}
+
+	return c, nil
+}
+
+// Encode returns the geohash of the given amount of characters, from 1 to MaxPrecision, of the cell
+// containing p. Points on the boundary between cells belong to the northern and eastern ones. If p is
+// invalid or precision is out of range, the returned geohash is empty
+func Encode(p geodesy.Point, precision int) string {
+	if !p.Valid() || precision < 1 || precision > MaxPrecision {
+		return ""
+	}
+
+	return cellOf(p, precision).String()
+}
+
+// Decode returns the center of the cell of the geohash s, which is case-insensitive
+func Decode(s string) (geodesy.Point, error) {
+	c, err := parse(s)
+	if err != nil {
+		return geodesy.Point{math.NaN(), math.NaN()}, err
+	}
+	b := c.box()
+
+	return geodesy.Point{(b.South + b.North) / 2, (b.West + b.East) / 2}, nil
+}
+
+// Bounds returns the bounding box of the cell of the geohash s
+func Bounds(s string) (geodesy.BoundingBox, error) {
+	c, err := parse(s)
+	if err != nil {
+		nan := math.NaN()
+		return geodesy.BoundingBox{South: nan, West: nan, North: nan, East: nan}, err
+	}
+
+	return c.box(), nil
+}
+
+// Neighbor returns the geohash of the cell adjacent to the one of s in direction d, which has the same
+// precision. Neighbors wrap around the antimeridian, and the cells of the northernmost and southernmost
+// rows have no neighbors towards their pole, for which the returned geohash is empty
+func Neighbor(s string, d Direction) (string, error) {
+	c, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	if d < North || d > NorthWest {
+		return "", fmt.Errorf("%w: direction %d", ErrRange, d)
+	}
+
+	return c.neighbor(d), nil
+}
+
+func (c cell) neighbor(d Direction) string {
+	latBits, lonBits := grid(c.precision)
+	rows, cols := int64(1)<<latBits, int64(1)<<lonBits
+	row := int64(c.row) + int64(offsets[d][0])
+	if row < 0 || row >= rows {
+		return ""
+	}
+	col := (int64(c.col) + int64(offsets[d][1]) + cols) % cols
+
+	return cell{row: uint64(row), col: uint64(col), precision: c.precision}.String()
+}
+
+// Neighbors returns the geohashes of the 8 cells adjacent to the one of s, indexed by their Direction.
+// The neighbors beyond the poles are empty, as with Neighbor
+func Neighbors(s string) ([8]string, error) {
+	var neighbors [8]string
+	c, err := parse(s)
+	if err != nil {
+		return neighbors, err
+	}
+	for d := range neighbors {
+		neighbors[d] = c.neighbor(Direction(d))
+	}
+
+	return neighbors, nil
+}
+
+// coverSlack accounts for the difference between the spherical distances used to prune cells and the
+// ellipsoidal ones, as the ratio of the mean radius of the WGS84 ellipsoid to its smallest radius of
+// curvature, so that covers are never missing cells
+const coverSlack = ellipsoids.WGS84_MEAN_RADIUS / ellipsoids.WGS84_MERIDIAN_CURVATURE_EQUATORIAL_RADIUS
+
+// Cover returns the geohashes of the given precision of the cells intersecting the circle of radius
+// meters around center, sorted by row from south to north and by column from west to east.
+//
+// Candidate cells are the ones intersecting the bounding box of the circle, and they are pruned by the
+// distance.Haversine distance from center to their nearest point, allowing for the difference between
+// spherical and ellipsoidal distances, so that some cells just outside the circle may be included.
+// If the cover would hold more than MaxCoverCells cells, it returns an error wrapping ErrCoverTooLarge
+func Cover(center geodesy.Point, radius float64, precision int) ([]string, error) {
+	if !center.Valid() || !(radius >= 0) || math.IsInf(radius, 0) || precision < 1 || precision > MaxPrecision {
+		return nil, fmt.Errorf("%w: center %v, radius %v and precision %d", ErrRange, center, radius, precision)
+	}
+
+	box := geodesy.NewBoundingBox(center).Expand(radius)
+	first, last := cellOf(geodesy.Point{box.South, box.West}, precision), cellOf(geodesy.Point{box.North, box.East}, precision)
+	_, lonBits := grid(precision)
+	cols := uint64(1) << lonBits
+	if box.West == geodesy.LonLowerBound && box.East == geodesy.LonUpperBound {
+		first.col, last.col = 0, cols-1
+	}
+	width := (last.col - first.col + cols) % cols
+	if width+1 > MaxCoverCells || (last.row-first.row+1)*(width+1) > MaxCoverCells {
+		return nil, fmt.Errorf("%w: %d rows of %d cells", ErrCoverTooLarge, last.row-first.row+1, width+1)
+	}
+
+	var hashes []string
+	for row := first.row; row <= last.row; row++ {
+		for i := uint64(0); i <= width; i++ {
+			c := cell{row: row, col: (first.col + i) % cols, precision: precision}
+			if distance.Haversine(center, nearest(center, c.box())) <= radius*coverSlack {
+				hashes = append(hashes, c.String())
+			}
+		}
+	}
+
+	return hashes, nil
+}
+
+// nearest returns the point of the cell bounded by b nearest to p on a sphere, where b does not cross
+// the antimeridian
+func nearest(p geodesy.Point, b geodesy.BoundingBox) geodesy.Point {
+	clamp := func(φ float64) float64 {
+		return math.Max(b.South, math.Min(b.North, φ))
+	}
+	if b.West <= p.Lon() && p.Lon() <= b.East {
+		return geodesy.Point{clamp(p.Lat()), p.Lon()}
+	}
+
+	// The nearest point lies on a meridian edge, where the cosine of the distance to p varies as
+	// cos(φ - θ) with the latitude φ along the edge, so it is the one closest to θ
+	var best geodesy.Point
+	bestDistance := math.Inf(1)
+	for _, λ := range []float64{b.West, b.East} {
+		Δλ := (λ - p.Lon()) * math.Pi / 180
+		θ := math.Atan2(math.Sin(p.LatRadians()), math.Cos(p.LatRadians())*math.Cos(Δλ)) * 180 / math.Pi
+		q := geodesy.Point{clamp(θ), λ}
+		if d := distance.Haversine(p, q); d < bestDistance {
+			best, bestDistance = q, d
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,221 @@
+package geohash_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/geohash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name      string
+		p         geodesy.Point
+		precision int
+		want      string
+	}{
+		{name: "OK/ezs42", p: geodesy.Point{42.6, -5.6}, precision: 5, want: "ezs42"},
+		{name: "OK/jutland", p: geodesy.Point{57.64911, 10.40744}, precision: 11, want: "u4pruydqqvj"},
+		{name: "OK/single_character", p: geodesy.Point{57.64911, 10.40744}, precision: 1, want: "u"},
+		{name: "OK/origin", p: geodesy.Point{0, 0}, precision: 4, want: "s000"},
+		{name: "OK/south_west_corner", p: geodesy.Point{-90, -180}, precision: 12, want: "000000000000"},
+		{name: "OK/north_east_corner", p: geodesy.Point{90, 180}, precision: 12, want: "zzzzzzzzzzzz"},
+		{name: "FAIL/invalid_point", p: geodesy.Point{91, 0}, precision: 5, want: ""},
+		{name: "FAIL/zero_precision", p: geodesy.Point{0, 0}, precision: 0, want: ""},
+		{name: "FAIL/large_precision", p: geodesy.Point{0, 0}, precision: 13, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, geohash.Encode(tt.p, tt.precision))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		want    geodesy.Point
+		delta   float64
+		wantErr error
+	}{
+		{name: "OK/ezs42", hash: "ezs42", want: geodesy.Point{42.605, -5.603}, delta: 1e-3},
+		{name: "OK/jutland", hash: "u4pruydqqvj", want: geodesy.Point{57.64911, 10.40744}, delta: 1e-5},
+		{name: "OK/upper_case", hash: "EZS42", want: geodesy.Point{42.605, -5.603}, delta: 1e-3},
+		{name: "OK/whole_cell", hash: "s", want: geodesy.Point{22.5, 22.5}, delta: 0},
+		{name: "FAIL/empty", hash: "", wantErr: geohash.ErrSyntax},
+		{name: "FAIL/too_long", hash: "u4pruydqqvjzz", wantErr: geohash.ErrSyntax},
+		{name: "FAIL/invalid_character", hash: "ezs4a", wantErr: geohash.ErrSyntax},
+		{name: "FAIL/non_ascii", hash: "ezsÂ", wantErr: geohash.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geohash.Decode(tt.hash)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, got.Valid())
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.Lat(), got.Lat(), tt.delta)
+			assert.InDelta(t, tt.want.Lon(), got.Lon(), tt.delta)
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		points := []geodesy.Point{{0, 0}, {-33.8688, 151.2093}, {89.9999, -179.9999}, {-45.5, 0.25}}
+		for precision := 1; precision <= geohash.MaxPrecision; precision++ {
+			for _, p := range points {
+				hash := geohash.Encode(p, precision)
+				b, err := geohash.Bounds(hash)
+				assert.NoError(t, err)
+				assert.True(t, b.Contains(p), "%v in %s", p, hash)
+				center, err := geohash.Decode(hash)
+				assert.NoError(t, err)
+				assert.Equal(t, hash, geohash.Encode(center, precision))
+			}
+		}
+	})
+}
+
+func TestBounds(t *testing.T) {
+	b, err := geohash.Bounds("ezs42")
+	assert.NoError(t, err)
+	assert.InDelta(t, 42.583, b.South, 1e-3)
+	assert.InDelta(t, 42.627, b.North, 1e-3)
+	assert.InDelta(t, -5.625, b.West, 1e-3)
+	assert.InDelta(t, -5.581, b.East, 1e-3)
+
+	b, err = geohash.Bounds("0")
+	assert.NoError(t, err)
+	assert.Equal(t, geodesy.BoundingBox{South: -90, West: -180, North: -45, East: -135}, b)
+
+	b, err = geohash.Bounds("ezs!2")
+	assert.ErrorIs(t, err, geohash.ErrSyntax)
+	assert.True(t, b.IsEmpty())
+}
+
+func TestNeighbors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		want    [8]string
+		wantErr error
+	}{
+		{
+			name: "OK/dqcjq",
+			hash: "dqcjq",
+			want: [8]string{"dqcjw", "dqcjx", "dqcjr", "dqcjp", "dqcjn", "dqcjj", "dqcjm", "dqcjt"},
+		},
+		{
+			name: "OK/interior",
+			hash: "9",
+			want: [8]string{"c", "f", "d", "6", "3", "2", "8", "b"},
+		},
+		{
+			name: "OK/antimeridian",
+			hash: "r",
+			want: [8]string{"x", "8", "2", "0", "p", "n", "q", "w"},
+		},
+		{
+			name: "OK/north_pole",
+			hash: "b",
+			want: [8]string{"", "", "c", "9", "8", "x", "z", ""},
+		},
+		{
+			name: "OK/south_pole",
+			hash: "h",
+			want: [8]string{"k", "m", "j", "", "", "", "5", "7"},
+		},
+		{name: "FAIL/invalid", hash: "dqcjl", wantErr: geohash.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geohash.Neighbors(tt.hash)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			for d, want := range tt.want {
+				n, err := geohash.Neighbor(tt.hash, geohash.Direction(d))
+				assert.NoError(t, err)
+				assert.Equal(t, want, n)
+			}
+		})
+	}
+
+	t.Run("FAIL/direction", func(t *testing.T) {
+		_, err := geohash.Neighbor("dqcjq", geohash.NorthWest+1)
+		assert.ErrorIs(t, err, geohash.ErrRange)
+	})
+}
+
+func TestCover(t *testing.T) {
+	t.Run("OK/contains_circle", func(t *testing.T) {
+		centers := []geodesy.Point{{51.5074, -0.1278}, {-33.8688, 151.2093}, {0.01, 179.99}, {89.99, 45}}
+		for _, center := range centers {
+			const radius = 5000
+			hashes, err := geohash.Cover(center, radius, 5)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, hashes)
+			set := map[string]bool{}
+			for _, h := range hashes {
+				set[h] = true
+			}
+			assert.Len(t, set, len(hashes))
+
+			// Points sampled along and inside the circle must fall within the cover
+			for i := 0; i < 360; i += 5 {
+				for _, fraction := range []float64{0, 0.5, 0.99} {
+					p := geodesic.WGS84.Direct(center, float64(i), radius*fraction).P2
+					assert.True(t, set[geohash.Encode(p, 5)], "%v from %v", p, center)
+				}
+			}
+
+			// Cells of the cover must be near the circle
+			for _, h := range hashes {
+				b, err := geohash.Bounds(h)
+				assert.NoError(t, err)
+				c := geodesy.Point{(b.South + b.North) / 2, (b.West + b.East) / 2}
+				assert.Less(t, distance.Haversine(center, c), float64(radius+5000))
+			}
+		}
+	})
+
+	t.Run("OK/point", func(t *testing.T) {
+		hashes, err := geohash.Cover(geodesy.Point{42.6, -5.6}, 0, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ezs42"}, hashes)
+	})
+
+	t.Run("OK/prunes_corners", func(t *testing.T) {
+		center, err := geohash.Decode("dqcjq")
+		assert.NoError(t, err)
+		hashes, err := geohash.Cover(center, 3000, 5)
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 5)
+		assert.ElementsMatch(t, []string{"dqcjn", "dqcjm", "dqcjq", "dqcjr", "dqcjw"}, hashes)
+	})
+
+	t.Run("FAIL/range", func(t *testing.T) {
+		_, err := geohash.Cover(geodesy.Point{91, 0}, 100, 5)
+		assert.ErrorIs(t, err, geohash.ErrRange)
+		_, err = geohash.Cover(geodesy.Point{0, 0}, -1, 5)
+		assert.ErrorIs(t, err, geohash.ErrRange)
+		_, err = geohash.Cover(geodesy.Point{0, 0}, math.NaN(), 5)
+		assert.ErrorIs(t, err, geohash.ErrRange)
+		_, err = geohash.Cover(geodesy.Point{0, 0}, 100, 13)
+		assert.ErrorIs(t, err, geohash.ErrRange)
+	})
+
+	t.Run("FAIL/too_large", func(t *testing.T) {
+		_, err := geohash.Cover(geodesy.Point{0, 0}, 100000, 9)
+		assert.ErrorIs(t, err, geohash.ErrCoverTooLarge)
+	})
+}
@@ -0,0 +1,157 @@
+package geoid
+
+import (
+	"errors"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// Interpolation identifies the method used to interpolate geoid heights between grid nodes
+type Interpolation int
+
+const (
+	// Bilinear interpolates over the 4 nodes surrounding a point
+	Bilinear Interpolation = iota
+	// Cubic interpolates over the 16 nodes surrounding a point, using bicubic convolution
+	Cubic
+)
+
+// ErrFormat is returned when a geoid grid file is malformed or uses an unsupported layout
+var ErrFormat = errors.New("geoid: invalid grid file")
+
+// Model represents a geoid undulation grid, with nodes stored row-major
+// from north to south and from west to east
+type Model struct {
+	// North and West hold the coordinates of the north-west node, in decimal degrees
+	North, West float64
+	// LatStep and LonStep hold the node spacing, in decimal degrees
+	LatStep, LonStep float64
+	// Rows and Cols hold the amount of nodes along each axis
+	Rows, Cols int
+
+	// global is set when the columns cover the whole parallel, so that they wrap around
+	global bool
+	// height returns the undulation in meters of the node at index i
+	height func(i int) float64
+}
+
+func newModel(north, west, latStep, lonStep float64, rows, cols int, height func(i int) float64) *Model {
+	return &Model{
+		North:   north,
+		West:    west,
+		LatStep: latStep,
+		LonStep: lonStep,
+		Rows:    rows,
+		Cols:    cols,
+		global:  math.Abs(float64(cols)*lonStep-360) < lonStep/2,
+		height:  height,
+	}
+}
+
+// Undulation returns the geoid undulation N (the height of the geoid over the ellipsoid)
+// at p in meters, interpolated from the model nodes with the given method.
+// If p does not constitute a valid geographic coordinate or falls outside of the
+// model coverage, the returned undulation will be math.NaN()
+func (m *Model) Undulation(p geodesy.Point, method Interpolation) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	lon := p.Lon() - m.West
+	if m.global {
+		lon = math.Mod(lon, 360)
+		if lon < 0 {
+			lon += 360
+		}
+	}
+	x := lon / m.LonStep
+	y := (m.North - p.Lat()) / m.LatStep
+	if y < 0 || y > float64(m.Rows-1) || x < 0 || (!m.global && x > float64(m.Cols-1)) {
+		return math.NaN()
+	}
+
+	col, row := math.Floor(x), math.Floor(y)
+	fx, fy := x-col, y-row
+
+	if method == Cubic {
+		var rows [4]float64
+		for j := -1; j <= 2; j++ {
+			rows[j+1] = cubic(
+				m.node(int(row)+j, int(col)-1), m.node(int(row)+j, int(col)),
+				m.node(int(row)+j, int(col)+1), m.node(int(row)+j, int(col)+2), fx)
+		}
+		return cubic(rows[0], rows[1], rows[2], rows[3], fy)
+	}
+
+	v00, v10 := m.node(int(row), int(col)), m.node(int(row), int(col)+1)
+	v01, v11 := m.node(int(row)+1, int(col)), m.node(int(row)+1, int(col)+1)
+
+	return v00*(1-fx)*(1-fy) + v10*fx*(1-fy) + v01*(1-fx)*fy + v11*fx*fy
+}
+
+// OrthometricHeight converts the ellipsoidal height h at p (in meters) into
+// its orthometric height H = h − N over the geoid
+func (m *Model) OrthometricHeight(p geodesy.Point, h float64, method Interpolation) float64 {
+	return OrthometricHeight(h, m.Undulation(p, method))
+}
+
+// EllipsoidalHeight converts the orthometric height H at p (in meters) into
+// its ellipsoidal height h = H + N over the ellipsoid
+func (m *Model) EllipsoidalHeight(p geodesy.Point, H float64, method Interpolation) float64 {
+	return EllipsoidalHeight(H, m.Undulation(p, method))
+}
+
+// OrthometricHeight converts the ellipsoidal height h into its orthometric
+// height H = h − N given the geoid undulation N, all in meters
+func OrthometricHeight(h, N float64) float64 {
+	return h - N
+}
+
+// EllipsoidalHeight converts the orthometric height H into its ellipsoidal
+// height h = H + N given the geoid undulation N, all in meters
+func EllipsoidalHeight(H, N float64) float64 {
+	return H + N
+}
+
+// node returns the undulation of the node at row and col, extending the grid beyond its edges:
+// global grids wrap around the antimeridian and across the poles, while regional ones are clamped
+func (m *Model) node(row, col int) float64 {
+	if m.global && m.Cols%2 == 0 {
+		lastRow := m.Rows - 1
+		if row < 0 && m.North == geodesy.LatUpperBound {
+			row, col = -row, col+m.Cols/2
+		} else if row > lastRow && m.North-float64(lastRow)*m.LatStep == geodesy.LatLowerBound {
+			row, col = 2*lastRow-row, col+m.Cols/2
+		}
+	}
+
+	row = clamp(row, 0, m.Rows-1)
+	if m.global {
+		col %= m.Cols
+		if col < 0 {
+			col += m.Cols
+		}
+	} else {
+		col = clamp(col, 0, m.Cols-1)
+	}
+
+	return m.height(row*m.Cols + col)
+}
+
+// cubic interpolates at t ∈ [0, 1] between v1 and v2 using the Keys cubic
+// convolution kernel (a = −0.5), which reproduces quadratic polynomials exactly
+func cubic(v0, v1, v2, v3, t float64) float64 {
+	return v1 + 0.5*t*(v2-v0+t*(2*v0-5*v1+4*v2-v3+t*(3*(v1-v2)+v3-v0)))
+}
+
+func clamp(v, lower, upper int) int {
+	if v < lower {
+		return lower
+	}
+	if v > upper {
+		return upper
+	}
+
+	return v
+}
@@ -0,0 +1,158 @@
+package geoid_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geoid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Synthetic undulation, made of low degree spherical harmonics so that it is smooth across the poles
+func undulation(lat, lon float64) float64 {
+	φ, λ := lat*math.Pi/180, lon*math.Pi/180
+	return 20*math.Cos(φ)*math.Cos(λ) - 15*math.Sin(φ) + 5*math.Cos(φ)*math.Cos(φ)*math.Sin(2*λ)
+}
+
+// pgmFile builds a global PGM grid with the given step in degrees
+func pgmFile(step float64) []byte {
+	const offset, scale = -108.0, 0.003
+	cols, rows := int(360/step), int(180/step)+1
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "P5\n# Geoid file in PGM format for the GeographicLib::Geoid class\n")
+	fmt.Fprintf(buf, "# Offset %v\n# Scale %v\n# Origin 90N 0E\n%d %d\n65535\n", offset, scale, cols, rows)
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			N := undulation(90-float64(j)*step, float64(i)*step)
+			_ = binary.Write(buf, binary.BigEndian, uint16(math.Round((N-offset)/scale)))
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadPGM(t *testing.T) {
+	model, err := geoid.ReadPGM(bytes.NewReader(pgmFile(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, 181, model.Rows)
+	assert.Equal(t, 360, model.Cols)
+
+	tests := []struct {
+		name      string
+		p         geodesy.Point
+		tolerance float64
+	}{
+		{name: "OK/node", p: geodesy.Point{45, 10}, tolerance: 0.002},
+		{name: "OK/between_nodes", p: geodesy.Point{-33.25, 151.75}, tolerance: 0.01},
+		{name: "OK/antimeridian", p: geodesy.Point{12.5, -179.8}, tolerance: 0.01},
+		{name: "OK/last_column", p: geodesy.Point{-5.5, 359.5 - 360}, tolerance: 0.01},
+		{name: "OK/north_pole", p: geodesy.Point{90, 37}, tolerance: 0.002},
+		{name: "OK/near_south_pole", p: geodesy.Point{-89.6, -120.3}, tolerance: 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := undulation(tt.p.Lat(), tt.p.Lon())
+			bilinear := model.Undulation(tt.p, geoid.Bilinear)
+			cubic := model.Undulation(tt.p, geoid.Cubic)
+			assert.InDelta(t, expected, bilinear, tt.tolerance)
+			assert.InDelta(t, expected, cubic, tt.tolerance)
+			// Cubic interpolation must be at least as accurate as bilinear (up to quantization)
+			assert.LessOrEqual(t, math.Abs(cubic-expected), math.Abs(bilinear-expected)+0.002)
+		})
+	}
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.True(t, math.IsNaN(model.Undulation(geodesy.Point{91, 0}, geoid.Bilinear)))
+	})
+	t.Run("FAIL/not_pgm", func(t *testing.T) {
+		_, err := geoid.ReadPGM(bytes.NewReader([]byte("P2\n2 2\n255\n0 0 0 0\n")))
+		assert.ErrorIs(t, err, geoid.ErrFormat)
+	})
+	t.Run("FAIL/truncated", func(t *testing.T) {
+		data := pgmFile(1)
+		_, err := geoid.ReadPGM(bytes.NewReader(data[:len(data)-10]))
+		assert.ErrorIs(t, err, geoid.ErrFormat)
+	})
+}
+
+func TestReadRaw(t *testing.T) {
+	regional := geoid.RawLayout{
+		North: 50, West: -10, LatStep: 0.5, LonStep: 0.5, Rows: 21, Cols: 41,
+		Type: geoid.Float32, Order: binary.BigEndian, Scale: 1, Records: true,
+	}
+	buf := &bytes.Buffer{}
+	for j := 0; j < regional.Rows; j++ {
+		_ = binary.Write(buf, binary.BigEndian, uint32(4*regional.Cols))
+		for i := 0; i < regional.Cols; i++ {
+			_ = binary.Write(buf, binary.BigEndian, float32(undulation(50-float64(j)*0.5, -10+float64(i)*0.5)))
+		}
+		_ = binary.Write(buf, binary.BigEndian, uint32(4*regional.Cols))
+	}
+
+	model, err := geoid.ReadRaw(bytes.NewReader(buf.Bytes()), regional)
+	assert.NoError(t, err)
+
+	p := geodesy.Point{45.1, 2.3}
+	assert.InDelta(t, undulation(p.Lat(), p.Lon()), model.Undulation(p, geoid.Cubic), 1e-3)
+	assert.True(t, math.IsNaN(model.Undulation(geodesy.Point{0, 0}, geoid.Cubic)), "outside of coverage")
+
+	t.Run("OK/EGM96_layout", func(t *testing.T) {
+		dac := &bytes.Buffer{}
+		for j := 0; j < geoid.EGM96.Rows; j++ {
+			for i := 0; i < geoid.EGM96.Cols; i++ {
+				N := undulation(90-float64(j)*0.25, float64(i)*0.25)
+				_ = binary.Write(dac, binary.BigEndian, int16(math.Round(N*100)))
+			}
+		}
+
+		model, err := geoid.ReadRaw(bytes.NewReader(dac.Bytes()), geoid.EGM96)
+		assert.NoError(t, err)
+		assert.InDelta(t, undulation(-34.6, -58.4), model.Undulation(geodesy.Point{-34.6, -58.4}, geoid.Bilinear), 0.01)
+	})
+	t.Run("OK/EGM2008_layout", func(t *testing.T) {
+		// A few rows of the little-endian records of the _SE file, as they are laid out in it
+		layout := geoid.EGM2008
+		layout.Rows, layout.Cols = 3, 5
+		se := &bytes.Buffer{}
+		for j := 0; j < layout.Rows; j++ {
+			_ = binary.Write(se, binary.LittleEndian, uint32(4*layout.Cols))
+			for i := 0; i < layout.Cols; i++ {
+				_ = binary.Write(se, binary.LittleEndian, float32(undulation(90-float64(j)*layout.LatStep, float64(i)*layout.LonStep)))
+			}
+			_ = binary.Write(se, binary.LittleEndian, uint32(4*layout.Cols))
+		}
+		assert.Equal(t, []byte{20, 0, 0, 0}, se.Bytes()[:4])
+
+		model, err := geoid.ReadRaw(bytes.NewReader(se.Bytes()), layout)
+		assert.NoError(t, err)
+		p := geodesy.Point{90 - layout.LatStep, 2 * layout.LonStep}
+		assert.InDelta(t, undulation(p.Lat(), p.Lon()), model.Undulation(p, geoid.Bilinear), 1e-4)
+
+		layout.Order = binary.BigEndian
+		_, err = geoid.ReadRaw(bytes.NewReader(se.Bytes()), layout)
+		assert.ErrorIs(t, err, geoid.ErrFormat)
+	})
+	t.Run("FAIL/record_length", func(t *testing.T) {
+		layout := regional
+		layout.Cols--
+		_, err := geoid.ReadRaw(bytes.NewReader(buf.Bytes()), layout)
+		assert.ErrorIs(t, err, geoid.ErrFormat)
+	})
+}
+
+func TestHeights(t *testing.T) {
+	assert.EqualValues(t, 75.5, geoid.OrthometricHeight(100, 24.5))
+	assert.EqualValues(t, 100, geoid.EllipsoidalHeight(75.5, 24.5))
+
+	model, err := geoid.ReadPGM(bytes.NewReader(pgmFile(2)))
+	assert.NoError(t, err)
+
+	p := geodesy.Point{-22.9, -43.2}
+	H := model.OrthometricHeight(p, 10, geoid.Cubic)
+	assert.InDelta(t, 10, model.EllipsoidalHeight(p, H, geoid.Cubic), 1e-9)
+}
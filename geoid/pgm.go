@@ -0,0 +1,92 @@
+package geoid
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadPGM reads the geoid grid in PGM format at path
+func LoadPGM(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadPGM(f)
+}
+
+// ReadPGM reads a geoid grid in the PGM (portable graymap) format used by GeographicLib
+// (e.g. egm96-5.pgm or egm2008-1.pgm).
+//
+// The file holds a binary (P5) 16-bit image with big-endian samples, covering the whole globe
+// from latitude 90° to −90° and from longitude 0° eastwards. The undulation of each node is
+// Offset + Scale·sample, with Offset and Scale given as header comments
+func ReadPGM(r io.Reader) (*Model, error) {
+	br := bufio.NewReader(r)
+
+	offset, scale := 0.0, 1.0
+	var fields []int
+	magic := ""
+	for len(fields) < 3 {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading PGM header: %v", ErrFormat, err)
+		}
+
+		if strings.HasPrefix(line, "#") {
+			comment := strings.Fields(strings.TrimPrefix(line, "#"))
+			if len(comment) < 2 {
+				continue
+			}
+			switch comment[0] {
+			case "Offset":
+				offset, err = strconv.ParseFloat(comment[1], 64)
+			case "Scale":
+				scale, err = strconv.ParseFloat(comment[1], 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid PGM %s: %v", ErrFormat, comment[0], err)
+			}
+			continue
+		}
+
+		for _, token := range strings.Fields(line) {
+			if magic == "" {
+				magic = token
+				continue
+			}
+			v, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid PGM header: %v", ErrFormat, err)
+			}
+			fields = append(fields, v)
+		}
+	}
+	if magic != "P5" {
+		return nil, fmt.Errorf("%w: unsupported PGM format %q", ErrFormat, magic)
+	}
+
+	cols, rows, maxValue := fields[0], fields[1], fields[2]
+	if cols < 2 || rows < 2 || maxValue != 0xFFFF {
+		return nil, fmt.Errorf("%w: unsupported PGM layout %dx%d (max value %d)", ErrFormat, cols, rows, maxValue)
+	}
+
+	data := make([]byte, 2*rows*cols)
+	if _, err := io.ReadFull(br, data); err != nil {
+		return nil, fmt.Errorf("%w: reading PGM samples: %v", ErrFormat, err)
+	}
+	samples := make([]uint16, rows*cols)
+	for i := range samples {
+		samples[i] = binary.BigEndian.Uint16(data[2*i:])
+	}
+
+	return newModel(90, 0, 180/float64(rows-1), 360/float64(cols), rows, cols, func(i int) float64 {
+		return offset + scale*float64(samples[i])
+	}), nil
+}
@@ -0,0 +1,118 @@
+package geoid
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// SampleType identifies the encoding of the samples of a raw geoid grid
+type SampleType int
+
+const (
+	// Int16 samples are signed 16-bit integers
+	Int16 SampleType = iota
+	// Float32 samples are IEEE 754 single precision floating point numbers
+	Float32
+)
+
+// RawLayout describes a headerless geoid grid, with rows stored from north to south
+// and samples from west to east within each row
+type RawLayout struct {
+	// North and West hold the coordinates of the north-west node, in decimal degrees
+	North, West float64
+	// LatStep and LonStep hold the node spacing, in decimal degrees
+	LatStep, LonStep float64
+	// Rows and Cols hold the amount of nodes along each axis
+	Rows, Cols int
+
+	// Type and Order define the encoding of each sample
+	Type  SampleType
+	Order binary.ByteOrder
+	// Scale converts samples into meters
+	Scale float64
+	// Records is set when each row is a Fortran unformatted sequential record,
+	// enclosed by 4-byte markers holding its length
+	Records bool
+}
+
+var (
+	// EGM96 describes the WW15MGH.DAC file distributed by NGA with the EGM96 15-minute
+	// undulation grid: 721 rows of 1440 big-endian integers, in centimeters
+	EGM96 = RawLayout{
+		North: 90, West: 0, LatStep: 0.25, LonStep: 0.25, Rows: 721, Cols: 1440,
+		Type: Int16, Order: binary.BigEndian, Scale: 0.01,
+	}
+	// EGM2008 describes the Und_min2.5x2.5_egm2008_isw=82_WGS84_TideFree_SE file distributed
+	// by NGA with the EGM2008 2.5-minute undulation grid: 4321 little-endian ("small-endian")
+	// Fortran records of 8640 reals, in meters. The file without the _SE suffix holds the same
+	// records in big-endian order, which can be read by setting Order to binary.BigEndian
+	EGM2008 = RawLayout{
+		North: 90, West: 0, LatStep: 2.5 / 60, LonStep: 2.5 / 60, Rows: 4321, Cols: 8640,
+		Type: Float32, Order: binary.LittleEndian, Scale: 1, Records: true,
+	}
+)
+
+// LoadRaw reads the raw geoid grid at path, as described by layout
+func LoadRaw(path string, layout RawLayout) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadRaw(bufio.NewReader(f), layout)
+}
+
+// ReadRaw reads a headerless geoid grid (such as the binary EGM distributions by NGA),
+// as described by layout
+func ReadRaw(r io.Reader, layout RawLayout) (*Model, error) {
+	if layout.Rows < 2 || layout.Cols < 2 || layout.LatStep <= 0 || layout.LonStep <= 0 || layout.Order == nil {
+		return nil, fmt.Errorf("%w: invalid raw layout", ErrFormat)
+	}
+
+	size := 2
+	if layout.Type == Float32 {
+		size = 4
+	}
+	rowSize := size * layout.Cols
+
+	samples := make([]float32, layout.Rows*layout.Cols)
+	row := make([]byte, rowSize)
+	var marker [4]byte
+	for j := 0; j < layout.Rows; j++ {
+		if layout.Records {
+			if _, err := io.ReadFull(r, marker[:]); err != nil {
+				return nil, fmt.Errorf("%w: reading row %d: %v", ErrFormat, j, err)
+			}
+			if int(layout.Order.Uint32(marker[:])) != rowSize {
+				return nil, fmt.Errorf("%w: row %d has an unexpected record length", ErrFormat, j)
+			}
+		}
+		if _, err := io.ReadFull(r, row); err != nil {
+			return nil, fmt.Errorf("%w: reading row %d: %v", ErrFormat, j, err)
+		}
+		if layout.Records {
+			if _, err := io.ReadFull(r, marker[:]); err != nil {
+				return nil, fmt.Errorf("%w: reading row %d: %v", ErrFormat, j, err)
+			}
+		}
+
+		for i := 0; i < layout.Cols; i++ {
+			var v float64
+			if layout.Type == Float32 {
+				v = float64(math.Float32frombits(layout.Order.Uint32(row[4*i:])))
+			} else {
+				v = float64(int16(layout.Order.Uint16(row[2*i:])))
+			}
+			samples[j*layout.Cols+i] = float32(v * layout.Scale)
+		}
+	}
+
+	return newModel(layout.North, layout.West, layout.LatStep, layout.LonStep, layout.Rows, layout.Cols, func(i int) float64 {
+		return float64(samples[i])
+	}), nil
+}
@@ -0,0 +1,175 @@
+// Package geometry defines the simple feature geometry types shared by the geometry encodings of the
+// module, such as GeoJSON, WKT and WKB, built on geodesy.Point
+package geometry
+
+import (
+	"github.com/lggomez/go-geodesy"
+)
+
+// Type identifies the kind of a geometry
+type Type int
+
+const (
+	PointType Type = iota + 1
+	MultiPointType
+	LineStringType
+	MultiLineStringType
+	PolygonType
+	MultiPolygonType
+	CollectionType
+)
+
+func (t Type) String() string {
+	switch t {
+	case PointType:
+		return "Point"
+	case MultiPointType:
+		return "MultiPoint"
+	case LineStringType:
+		return "LineString"
+	case MultiLineStringType:
+		return "MultiLineString"
+	case PolygonType:
+		return "Polygon"
+	case MultiPolygonType:
+		return "MultiPolygon"
+	case CollectionType:
+		return "GeometryCollection"
+	}
+
+	return "Unknown"
+}
+
+// Geometry is implemented by all the geometry types of the package
+type Geometry interface {
+	// Type returns the kind of the geometry
+	Type() Type
+}
+
+// Point represents a single position
+type Point struct {
+	geodesy.Point
+}
+
+// MultiPoint represents a set of positions
+type MultiPoint []geodesy.Point
+
+// LineString represents a polyline, whose segments are usually geodesics
+type LineString []geodesy.Point
+
+// MultiLineString represents a set of polylines
+type MultiLineString []LineString
+
+// Ring represents a closed polyline, which is implicitly closed as in the polygon package, that is,
+// its last point is joined to the first one without being repeated
+type Ring []geodesy.Point
+
+// Polygon represents a surface bounded by its first ring, the exterior one, with the following ones
+// being holes within it
+type Polygon []Ring
+
+// MultiPolygon represents a set of polygons
+type MultiPolygon []Polygon
+
+// Collection represents a heterogeneous set of geometries
+type Collection []Geometry
+
+func (Point) Type() Type           { return PointType }
+func (MultiPoint) Type() Type      { return MultiPointType }
+func (LineString) Type() Type      { return LineStringType }
+func (MultiLineString) Type() Type { return MultiLineStringType }
+func (Polygon) Type() Type         { return PolygonType }
+func (MultiPolygon) Type() Type    { return MultiPolygonType }
+func (Collection) Type() Type      { return CollectionType }
+
+// Length returns the length of l as the sum of the distances between its consecutive points, given by
+// the distance function, such as distance.Haversine
+func (l LineString) Length(distance func(p1, p2 geodesy.Point) float64) float64 {
+	length := 0.0
+	for i := 1; i < len(l); i++ {
+		length += distance(l[i-1], l[i])
+	}
+
+	return length
+}
+
+// Length returns the sum of the lengths of the polylines of m, given by the distance function
+func (m MultiLineString) Length(distance func(p1, p2 geodesy.Point) float64) float64 {
+	length := 0.0
+	for _, l := range m {
+		length += l.Length(distance)
+	}
+
+	return length
+}
+
+// Points calls fn for every point of g, in order
+func Points(g Geometry, fn func(p geodesy.Point)) {
+	switch g := g.(type) {
+	case Point:
+		fn(g.Point)
+	case MultiPoint:
+		for _, p := range g {
+			fn(p)
+		}
+	case LineString:
+		for _, p := range g {
+			fn(p)
+		}
+	case MultiLineString:
+		for _, l := range g {
+			Points(l, fn)
+		}
+	case Polygon:
+		for _, r := range g {
+			for _, p := range r {
+				fn(p)
+			}
+		}
+	case MultiPolygon:
+		for _, polygon := range g {
+			Points(polygon, fn)
+		}
+	case Collection:
+		for _, member := range g {
+			Points(member, fn)
+		}
+	}
+}
+
+// Valid returns whether every point of g constitutes a valid geographic coordinate. A nil geometry
+// is not valid, nor is a collection with nil members at any depth
+func Valid(g Geometry) bool {
+	if g == nil {
+		return false
+	}
+	if c, ok := g.(Collection); ok {
+		for _, member := range c {
+			if !Valid(member) {
+				return false
+			}
+		}
+		return true
+	}
+
+	valid := true
+	Points(g, func(p geodesy.Point) {
+		valid = valid && p.Valid()
+	})
+
+	return valid
+}
+
+// Bounds returns the smallest bounding box containing every point of g, which may cross the antimeridian.
+// Note that it does not account for the latitude extrema reached along geodesic segments, which are given
+// by geodesic.Geodesic.BoundingBox. If g has no points or any of them is invalid, the returned box is empty
+func Bounds(g Geometry) geodesy.BoundingBox {
+	var points []geodesy.Point
+	if g != nil {
+		Points(g, func(p geodesy.Point) {
+			points = append(points, p)
+		})
+	}
+
+	return geodesy.NewBoundingBox(points...)
+}
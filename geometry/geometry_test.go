@@ -0,0 +1,57 @@
+package geometry_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineString_Length(t *testing.T) {
+	t.Run("OK/haversine", func(t *testing.T) {
+		l := geometry.LineString{{0, 0}, {0, 1}, {1, 1}}
+		want := distance.Haversine(l[0], l[1]) + distance.Haversine(l[1], l[2])
+		assert.Equal(t, want, l.Length(distance.Haversine))
+	})
+
+	t.Run("OK/vincenty", func(t *testing.T) {
+		vincenty := func(p1, p2 geodesy.Point) float64 {
+			d, _, _ := distance.VincentyInverse(p1, p2, 1e-12, false)
+			return d
+		}
+		m := geometry.MultiLineString{{{0, 0}, {0, 1}}, {{10, 10}, {10, 11}}}
+		assert.InDelta(t, 111319.491+109639.322, m.Length(vincenty), 1e-2)
+	})
+
+	t.Run("OK/single_point", func(t *testing.T) {
+		assert.Zero(t, geometry.LineString{{1, 2}}.Length(distance.Haversine))
+	})
+}
+
+func TestBounds(t *testing.T) {
+	t.Run("OK/collection", func(t *testing.T) {
+		g := geometry.Collection{
+			geometry.Point{Point: geodesy.Point{10, 170}},
+			geometry.Polygon{{{-10, -170}, {-5, -175}, {0, -170}}},
+		}
+		assert.Equal(t, geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170}, geometry.Bounds(g))
+	})
+
+	t.Run("FAIL/invalid", func(t *testing.T) {
+		assert.True(t, geometry.Bounds(geometry.MultiPoint{{0, 0}, {95, 0}}).IsEmpty())
+		assert.True(t, geometry.Bounds(nil).IsEmpty())
+	})
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, geometry.Valid(geometry.MultiLineString{{{0, 0}, {90, 180}}}))
+	assert.False(t, geometry.Valid(geometry.MultiPolygon{{{{0, 0}, {0, 181}, {1, 1}}}}))
+	assert.False(t, geometry.Valid(nil))
+	assert.False(t, geometry.Valid(geometry.Collection{nil}))
+	assert.False(t, geometry.Valid(geometry.Collection{geometry.Point{}, geometry.Collection{geometry.Collection{nil}}}))
+	assert.False(t, geometry.Valid(geometry.Collection{geometry.LineString{{0, 0}, {0, 181}}}))
+	assert.True(t, geometry.Valid(geometry.Collection{geometry.Point{}, geometry.Collection{}}))
+	assert.Equal(t, "GeometryCollection", geometry.Collection{}.Type().String())
+}
@@ -0,0 +1,262 @@
+// Package geouri parses and formats geo URIs as defined in RFC 5870, such as
+// geo:40.20361,-75.00417,350.517;crs=wgs84;u=35
+package geouri
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+const (
+	// Scheme is the URI scheme of geo URIs
+	Scheme = "geo"
+	// WGS84 is the label of the default coordinate reference system, the World Geodetic System 1984
+	WGS84 = "wgs84"
+)
+
+var (
+	// ErrSyntax is returned when a string is not a geo URI
+	ErrSyntax = errors.New("geouri: invalid syntax")
+	// ErrRange is returned when a coordinate or the uncertainty of a geo URI is out of range
+	ErrRange = errors.New("geouri: value out of range")
+)
+
+// URI represents a geo URI. Its point holds a math.NaN() height when the altitude is absent, and
+// its uncertainty, in meters, is math.NaN() when absent. An empty CRS stands for WGS84
+type URI struct {
+	Point       geodesy.Point3D
+	CRS         string
+	Uncertainty float64
+	// Params holds the parameters following crs and u, in order
+	Params []Param
+}
+
+// Param represents a geo URI parameter. Values are held unescaped, and parameters without a value
+// have an empty one
+type Param struct {
+	Name, Value string
+}
+
+// New returns a geo URI for the point p in WGS84, with no altitude nor uncertainty
+func New(p geodesy.Point) URI {
+	return URI{Point: geodesy.Point3D{p.Lat(), p.Lon(), math.NaN()}, Uncertainty: math.NaN()}
+}
+
+// IsWGS84 returns whether the coordinate reference system of u is WGS84
+func (u URI) IsWGS84() bool {
+	return u.CRS == "" || strings.EqualFold(u.CRS, WGS84)
+}
+
+// Param returns the value of the parameter named name, matched case-insensitively, and whether
+// it is present
+func (u URI) Param(name string) (string, bool) {
+	for _, p := range u.Params {
+		if strings.EqualFold(p.Name, name) {
+			return p.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Parse parses s as a geo URI. The scheme and parameter names are case-insensitive, and the crs and
+// u parameters must precede any other one, in that order. Coordinates are checked to be within range
+// when their reference system is WGS84.
+// The returned errors wrap either ErrSyntax or ErrRange
+func Parse(s string) (URI, error) {
+	u := URI{Point: geodesy.Point3D{0, 0, math.NaN()}, Uncertainty: math.NaN()}
+	if len(s) < len(Scheme)+1 || !strings.EqualFold(s[:len(Scheme)+1], Scheme+":") {
+		return URI{}, fmt.Errorf("%w: missing %q scheme in %q", ErrSyntax, Scheme+":", s)
+	}
+
+	fields := strings.Split(s[len(Scheme)+1:], ";")
+	coordinates := strings.Split(fields[0], ",")
+	if len(coordinates) < 2 || len(coordinates) > 3 {
+		return URI{}, fmt.Errorf("%w: found %d coordinates instead of 2 or 3 in %q", ErrSyntax, len(coordinates), s)
+	}
+	for i, c := range coordinates {
+		v, err := parseNumber(c, true)
+		if err != nil {
+			return URI{}, fmt.Errorf("%w: invalid coordinate %q in %q", ErrSyntax, c, s)
+		}
+		u.Point[i] = v
+	}
+
+	for i, field := range fields[1:] {
+		name, value := field, ""
+		hasValue := false
+		if eq := strings.IndexByte(field, '='); eq >= 0 {
+			name, value, hasValue = field[:eq], field[eq+1:], true
+		}
+		if !isLabel(name) {
+			return URI{}, fmt.Errorf("%w: invalid parameter name %q in %q", ErrSyntax, name, s)
+		}
+		if hasValue && value == "" {
+			return URI{}, fmt.Errorf("%w: empty value of parameter %q in %q", ErrSyntax, name, s)
+		}
+
+		switch {
+		case strings.EqualFold(name, "crs"):
+			if i > 0 {
+				return URI{}, fmt.Errorf("%w: crs must be the first parameter in %q", ErrSyntax, s)
+			}
+			if !isLabel(value) {
+				return URI{}, fmt.Errorf("%w: invalid crs %q in %q", ErrSyntax, value, s)
+			}
+			u.CRS = value
+		case strings.EqualFold(name, "u"):
+			if i > 1 || i == 1 && u.CRS == "" || !math.IsNaN(u.Uncertainty) {
+				return URI{}, fmt.Errorf("%w: u must follow the coordinates or the crs in %q", ErrSyntax, s)
+			}
+			v, err := parseNumber(value, false)
+			if err != nil {
+				return URI{}, fmt.Errorf("%w: invalid uncertainty %q in %q", ErrSyntax, value, s)
+			}
+			u.Uncertainty = v
+		default:
+			unescaped, err := unescape(value)
+			if err != nil {
+				return URI{}, fmt.Errorf("%w: invalid value %q of parameter %q in %q", ErrSyntax, value, name, s)
+			}
+			u.Params = append(u.Params, Param{Name: name, Value: unescaped})
+		}
+	}
+
+	if u.IsWGS84() && !u.Point.Point().Valid() {
+		return URI{}, fmt.Errorf("%w: coordinates %s in %q", ErrRange, fields[0], s)
+	}
+
+	return u, nil
+}
+
+// String returns u as a geo URI. Numbers are written in their shortest exact representation, and the
+// crs parameter is omitted for WGS84
+func (u URI) String() string {
+	var b strings.Builder
+	b.WriteString(Scheme)
+	b.WriteByte(':')
+	b.WriteString(formatNumber(u.Point.Lat()))
+	b.WriteByte(',')
+	b.WriteString(formatNumber(u.Point.Lon()))
+	if u.Point.HasHeight() {
+		b.WriteByte(',')
+		b.WriteString(formatNumber(u.Point.Height()))
+	}
+	if !u.IsWGS84() {
+		b.WriteString(";crs=")
+		b.WriteString(u.CRS)
+	}
+	if !math.IsNaN(u.Uncertainty) {
+		b.WriteString(";u=")
+		b.WriteString(formatNumber(u.Uncertainty))
+	}
+	for _, p := range u.Params {
+		b.WriteByte(';')
+		b.WriteString(p.Name)
+		if p.Value != "" {
+			b.WriteByte('=')
+			b.WriteString(escape(p.Value))
+		}
+	}
+
+	return b.String()
+}
+
+// parseNumber parses the decimal number s, which may be negative if signed is set. Exponents,
+// leading plus signs and special values are rejected
+func parseNumber(s string, signed bool) (float64, error) {
+	digits := s
+	if signed && strings.HasPrefix(digits, "-") {
+		digits = digits[1:]
+	}
+	integer, fraction := digits, "0"
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		integer, fraction = digits[:dot], digits[dot+1:]
+	}
+	if !isDigits(integer) || !isDigits(fraction) {
+		return 0, ErrSyntax
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+func formatNumber(v float64) string {
+	if v == 0 {
+		// Negative zero has no representation
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isLabel reports whether s is made of letters, digits and hyphens
+func isLabel(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !isAlphanum(c) && c != '-' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isAlphanum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// isParamChar reports whether c may appear unescaped in a parameter value
+func isParamChar(c byte) bool {
+	return isAlphanum(c) || strings.IndexByte("-._~[]:&+$", c) >= 0
+}
+
+// unescape decodes the percent-encoded octets of the parameter value s
+func unescape(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '%':
+			if i+2 >= len(s) {
+				return "", ErrSyntax
+			}
+			v, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", ErrSyntax
+			}
+			b.WriteByte(byte(v))
+			i += 2
+		case isParamChar(c):
+			b.WriteByte(c)
+		default:
+			return "", ErrSyntax
+		}
+	}
+
+	return b.String(), nil
+}
+
+// escape percent-encodes the octets of s which may not appear unescaped in a parameter value
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; isParamChar(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
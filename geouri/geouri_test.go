@@ -0,0 +1,117 @@
+package geouri_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geouri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name        string
+		input       string
+		want        geodesy.Point3D
+		crs         string
+		uncertainty float64
+		params      []geouri.Param
+	}{
+		{name: "OK/point", input: "geo:13.4125,103.8667", want: geodesy.Point3D{13.4125, 103.8667, nan}, uncertainty: nan},
+		{name: "OK/altitude", input: "geo:48.2010,16.3695,183", want: geodesy.Point3D{48.2010, 16.3695, 183}, uncertainty: nan},
+		{name: "OK/crs_uncertainty", input: "geo:48.198634,-16.371648,-3.5;crs=wgs84;u=40", want: geodesy.Point3D{48.198634, -16.371648, -3.5}, crs: "wgs84", uncertainty: 40},
+		{name: "OK/uncertainty", input: "GEO:-90,0;U=0.5", want: geodesy.Point3D{-90, 0, nan}, uncertainty: 0.5},
+		{name: "OK/other_crs", input: "geo:400000,200000;crs=osgb36", want: geodesy.Point3D{400000, 200000, nan}, crs: "osgb36", uncertainty: nan},
+		{
+			name:        "OK/params",
+			input:       "geo:66,30;u=6.500;FOo=this%2dthat;bar;baz=a%3Bb",
+			want:        geodesy.Point3D{66, 30, nan},
+			uncertainty: 6.5,
+			params:      []geouri.Param{{Name: "FOo", Value: "this-that"}, {Name: "bar"}, {Name: "baz", Value: "a;b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geouri.Parse(tt.input)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equals(got.Point), got.Point)
+			assert.Equal(t, tt.crs, got.CRS)
+			if math.IsNaN(tt.uncertainty) {
+				assert.True(t, math.IsNaN(got.Uncertainty))
+			} else {
+				assert.Equal(t, tt.uncertainty, got.Uncertainty)
+			}
+			assert.Equal(t, tt.params, got.Params)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		err     error
+		message string
+	}{
+		{name: "FAIL/scheme", input: "geo 1,2", err: geouri.ErrSyntax, message: `geouri: invalid syntax: missing "geo:" scheme in "geo 1,2"`},
+		{name: "FAIL/single_coordinate", input: "geo:1", err: geouri.ErrSyntax},
+		{name: "FAIL/four_coordinates", input: "geo:1,2,3,4", err: geouri.ErrSyntax},
+		{name: "FAIL/plus_sign", input: "geo:+1,2", err: geouri.ErrSyntax, message: `geouri: invalid syntax: invalid coordinate "+1" in "geo:+1,2"`},
+		{name: "FAIL/exponent", input: "geo:1e1,2", err: geouri.ErrSyntax},
+		{name: "FAIL/whitespace", input: "geo:1, 2", err: geouri.ErrSyntax},
+		{name: "FAIL/negative_uncertainty", input: "geo:1,2;u=-1", err: geouri.ErrSyntax},
+		{name: "FAIL/crs_order", input: "geo:1,2;u=1;crs=wgs84", err: geouri.ErrSyntax},
+		{name: "FAIL/uncertainty_order", input: "geo:1,2;foo=bar;u=1", err: geouri.ErrSyntax},
+		{name: "FAIL/duplicate_uncertainty", input: "geo:1,2;u=1;u=2", err: geouri.ErrSyntax},
+		{name: "FAIL/parameter_name", input: "geo:1,2;a_b=c", err: geouri.ErrSyntax},
+		{name: "FAIL/parameter_value", input: "geo:1,2;a=b c", err: geouri.ErrSyntax},
+		{name: "FAIL/empty_value", input: "geo:1,2;a=", err: geouri.ErrSyntax},
+		{name: "FAIL/escape", input: "geo:1,2;a=%4", err: geouri.ErrSyntax},
+		{name: "FAIL/latitude_range", input: "geo:90.5,0", err: geouri.ErrRange, message: `geouri: value out of range: coordinates 90.5,0 in "geo:90.5,0"`},
+		{name: "FAIL/longitude_range", input: "geo:0,-180.5;crs=WGS84", err: geouri.ErrRange},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := geouri.Parse(tt.input)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.message != "" {
+				assert.EqualError(t, err, tt.message)
+			}
+		})
+	}
+}
+
+func TestURI_String(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name string
+		u    geouri.URI
+		want string
+	}{
+		{name: "OK/new", u: geouri.New(geodesy.Point{-33.8688, 151.2093}), want: "geo:-33.8688,151.2093"},
+		{name: "OK/altitude_uncertainty", u: geouri.URI{Point: geodesy.Point3D{48.2, 16.37, 183}, Uncertainty: 35}, want: "geo:48.2,16.37,183;u=35"},
+		{name: "OK/wgs84_omitted", u: geouri.URI{Point: geodesy.Point3D{1, 2, nan}, CRS: "WGS84", Uncertainty: nan}, want: "geo:1,2"},
+		{name: "OK/other_crs", u: geouri.URI{Point: geodesy.Point3D{1, 2, nan}, CRS: "osgb36", Uncertainty: 0}, want: "geo:1,2;crs=osgb36;u=0"},
+		{name: "OK/negative_zero", u: geouri.URI{Point: geodesy.Point3D{math.Copysign(0, -1), 0, nan}, Uncertainty: nan}, want: "geo:0,0"},
+		{
+			name: "OK/params",
+			u:    geouri.URI{Point: geodesy.Point3D{1, 2, nan}, Uncertainty: nan, Params: []geouri.Param{{Name: "name", Value: "a b;c"}, {Name: "flag"}}},
+			want: "geo:1,2;name=a%20b%3Bc;flag",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.u.String())
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		s := "geo:48.198634,-16.371648,-3.5;crs=wgs84;u=40;name=caf%C3%A9"
+		u, err := geouri.Parse(s)
+		assert.NoError(t, err)
+		value, ok := u.Param("NAME")
+		assert.True(t, ok)
+		assert.Equal(t, "café", value)
+		assert.Equal(t, "geo:48.198634,-16.371648,-3.5;u=40;name=caf%C3%A9", u.String())
+	})
+}
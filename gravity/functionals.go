@@ -0,0 +1,111 @@
+package gravity
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/transform"
+)
+
+const (
+	// mGal holds the amount of milligals in 1 (m)/(s^2)
+	mGal = 1e5
+	// arcSeconds holds the amount of arc-seconds in 1 radian
+	arcSeconds = 180 * 3600 / math.Pi
+)
+
+// evaluation holds the disturbing potential at a position, along with the magnitude of the
+// normal gravity vector and its spherical coordinates
+type evaluation struct {
+	t synthesis
+	γ float64
+	r float64
+}
+
+// evaluate computes the disturbing potential T = V − U at p with ellipsoidal height h, where
+// V is the gravitational potential of m and U the one of its normal field (the centrifugal
+// potential is shared by both and cancels out)
+func (m *Model) evaluate(p geodesy.Point, h float64) evaluation {
+	c := transform.ToCartesian(p, h, m.Reference.Ellipsoid)
+	r := math.Sqrt(c.X*c.X + c.Y*c.Y + c.Z*c.Z)
+	φ := math.Atan2(c.Z, math.Hypot(c.X, c.Y))
+	λ := p.LonRadians()
+
+	v := m.coefficients.synthesize(m.GM, m.Radius, r, φ, λ, m.degree, m.order)
+	u := m.Reference.coefficients().synthesize(m.Reference.GM, m.Reference.Ellipsoid.SemiMajorAxis, r, φ, λ, normalDegree, 0)
+
+	return evaluation{
+		t: synthesis{
+			v:  v.v - u.v,
+			dr: v.dr - u.dr,
+			dφ: v.dφ - u.dφ,
+			dλ: v.dλ - u.dλ,
+		},
+		γ: m.Reference.gravity(u, r, φ),
+		r: r,
+	}
+}
+
+// DisturbingPotential returns the disturbing potential T (the difference between the actual
+// and the normal gravity potential) at p with ellipsoidal height h, in (m^2)/(s^2).
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (m *Model) DisturbingPotential(p geodesy.Point, h float64) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	return m.evaluate(p, h).t.v
+}
+
+// GeoidHeight returns the geoid undulation N = T/γ at p in meters, given by Bruns' formula
+// evaluated on the surface of the reference ellipsoid.
+// The correction from height anomaly to geoid undulation caused by the topographic masses
+// (which reaches a few meters on high mountains) is not applied.
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (m *Model) GeoidHeight(p geodesy.Point) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	e := m.evaluate(p, 0)
+	return e.t.v / e.γ
+}
+
+// GravityDisturbance returns the gravity disturbance δg = −∂T/∂r at p with ellipsoidal height h,
+// in milligals (mGal), in spherical approximation.
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (m *Model) GravityDisturbance(p geodesy.Point, h float64) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	return -m.evaluate(p, h).t.dr * mGal
+}
+
+// GravityAnomaly returns the gravity anomaly Δg = −∂T/∂r − 2T/r at p with ellipsoidal height h,
+// in milligals (mGal), in spherical approximation.
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (m *Model) GravityAnomaly(p geodesy.Point, h float64) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	e := m.evaluate(p, h)
+	return (-e.t.dr - 2*e.t.v/e.r) * mGal
+}
+
+// Deflection returns the north-south (ξ) and east-west (η) components of the deflection of
+// the vertical at p with ellipsoidal height h, in arc-seconds:
+//
+//	ξ = −1/(γr) ∂T/∂φ̄, η = −1/(γr cos φ̄) ∂T/∂λ
+//
+// If p does not constitute a valid geographic coordinate, the returned values will be math.NaN()
+func (m *Model) Deflection(p geodesy.Point, h float64) (ξ, η float64) {
+	if !p.Valid() {
+		return math.NaN(), math.NaN()
+	}
+
+	e := m.evaluate(p, h)
+	k := -arcSeconds / (e.γ * e.r)
+	return k * e.t.dφ, k * e.t.dλ
+}
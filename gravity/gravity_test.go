@@ -0,0 +1,161 @@
+package gravity_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/gravity"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	c31 = 2.0e-6
+	s31 = -1.5e-6
+)
+
+// normalModel builds a model holding the normal field of WGS84 plus a single (3,1) term,
+// in the EGM2008 file format
+func normalModel(t *testing.T, extra string) *gravity.Model {
+	// Even zonal coefficients of the WGS84 normal potential
+	file := `    2    0 -0.484166774985D-03  0.000000000000D+00  0.0D+00 0.0D+00
+    3    1  2.0D-06 -1.5D-06  0.0D+00 0.0D+00
+    4    0  0.790303733511D-06  0.000000000000D+00  0.0D+00 0.0D+00
+    6    0 -0.168724961151D-08  0.000000000000D+00  0.0D+00 0.0D+00
+    8    0  0.346052468394D-11  0.000000000000D+00  0.0D+00 0.0D+00
+   10    0 -0.265002225747D-14  0.000000000000D+00  0.0D+00 0.0D+00
+` + extra
+	model, err := gravity.ReadModel(strings.NewReader(file), 0)
+	assert.NoError(t, err)
+	model.GM = gravity.WGS84.GM
+	model.Radius = gravity.WGS84.Ellipsoid.SemiMajorAxis
+
+	return model
+}
+
+// disturbingPotential returns the analytic disturbing potential of normalModel at the equator
+func disturbingPotential(lon, h float64) float64 {
+	r := gravity.WGS84.Ellipsoid.SemiMajorAxis + h
+	λ := lon * math.Pi / 180
+	q := gravity.WGS84.Ellipsoid.SemiMajorAxis / r
+	// P̄31(sin φ̄) = √(7/6)·(3/2)·cos φ̄·(5sin²φ̄ − 1)
+	p31 := -math.Sqrt(7.0/6) * 1.5
+
+	return gravity.WGS84.GM / r * q * q * q * p31 * (c31*math.Cos(λ) + s31*math.Sin(λ))
+}
+
+func TestReadModel(t *testing.T) {
+	model := normalModel(t, "")
+	assert.Equal(t, 10, model.Degree())
+	assert.Equal(t, 10, model.Order())
+
+	truncated := model.Truncate(4, 2)
+	assert.Equal(t, 4, truncated.Degree())
+	assert.Equal(t, 2, truncated.Order())
+	assert.Equal(t, 10, model.Degree())
+	assert.Equal(t, 4, model.Truncate(4, 8).Order())
+
+	limited, err := gravity.ReadModel(strings.NewReader("2 0 -0.48E-03 0\n3 1 1E-6 0\n5 5 1E-7 1E-7\n"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, limited.Degree())
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "FAIL/empty", file: ""},
+		{name: "FAIL/missing_fields", file: "2 0 -0.48E-03\n"},
+		{name: "FAIL/invalid_order", file: "2 3 -0.48E-03 0\n"},
+		{name: "FAIL/invalid_number", file: "2 0 -0.48X-03 0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gravity.ReadModel(strings.NewReader(tt.file), 0)
+			assert.ErrorIs(t, err, gravity.ErrFormat)
+		})
+	}
+}
+
+func TestFunctionals(t *testing.T) {
+	model := normalModel(t, "")
+
+	t.Run("OK/disturbing_potential", func(t *testing.T) {
+		for _, lon := range []float64{0, 37.5, -120, 180} {
+			assert.InDelta(t, disturbingPotential(lon, 0), model.DisturbingPotential(geodesy.Point{0, lon}, 0), 1e-6)
+			assert.InDelta(t, disturbingPotential(lon, 1000), model.DisturbingPotential(geodesy.Point{0, lon}, 1000), 1e-6)
+		}
+	})
+	t.Run("OK/normal_field", func(t *testing.T) {
+		// Without the (3,1) term, the model matches the normal field
+		normal := model.Truncate(10, 0)
+		for _, p := range []geodesy.Point{{0, 0}, {-34.6, -58.4}, {89.9, 10}, {90, 0}} {
+			assert.InDelta(t, 0, normal.GeoidHeight(p), 1e-6)
+			assert.InDelta(t, 0, normal.GravityAnomaly(p, 100), 1e-6)
+		}
+	})
+
+	p := geodesy.Point{0, 25}
+	T := model.DisturbingPotential(p, 0)
+	γ := T / model.GeoidHeight(p)
+	assert.InDelta(t, 9.7803253359, γ, 1e-9, "normal gravity at the equator")
+
+	t.Run("OK/gravity_disturbance", func(t *testing.T) {
+		const dh = 100.0
+		dT := (model.DisturbingPotential(p, dh) - model.DisturbingPotential(p, -dh)) / (2 * dh)
+		assert.InDelta(t, -dT*1e5, model.GravityDisturbance(p, 0), 1e-4)
+
+		r := gravity.WGS84.Ellipsoid.SemiMajorAxis
+		assert.InDelta(t, model.GravityDisturbance(p, 0)-2*T/r*1e5, model.GravityAnomaly(p, 0), 1e-9)
+	})
+	t.Run("OK/deflection", func(t *testing.T) {
+		const dλ = 0.01
+		r := gravity.WGS84.Ellipsoid.SemiMajorAxis
+		e2 := gravity.WGS84.Ellipsoid.EccentricitySquared()
+
+		ξ, η := model.Deflection(p, 0)
+		// At the equator, geocentric latitude changes (1 − e²) times as fast as the geodetic one
+		dTφ := (model.DisturbingPotential(geodesy.Point{dλ, 25}, 0) - model.DisturbingPotential(geodesy.Point{-dλ, 25}, 0)) /
+			(2 * dλ * math.Pi / 180) / (1 - e2)
+		dTλ := (model.DisturbingPotential(geodesy.Point{0, 25 + dλ}, 0) - model.DisturbingPotential(geodesy.Point{0, 25 - dλ}, 0)) /
+			(2 * dλ * math.Pi / 180)
+		arcSeconds := 180 * 3600 / math.Pi
+		assert.InDelta(t, -dTφ/(γ*r)*arcSeconds, ξ, 1e-5)
+		assert.InDelta(t, -dTλ/(γ*r)*arcSeconds, η, 1e-5)
+	})
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.True(t, math.IsNaN(model.GeoidHeight(geodesy.Point{0, 181})))
+		ξ, η := model.Deflection(geodesy.Point{-91, 0}, 0)
+		assert.True(t, math.IsNaN(ξ))
+		assert.True(t, math.IsNaN(η))
+	})
+}
+
+func TestHighDegree(t *testing.T) {
+	// Coefficients on every order of a few very high degrees, with the magnitude given by Kaula's rule
+	buf := &bytes.Buffer{}
+	for _, n := range []int{2000, 2189, 2190} {
+		for m := 0; m <= n; m++ {
+			k := 1e-5 / float64(n*n)
+			fmt.Fprintf(buf, "%5d%5d %.12E %.12E\n", n, m, k*math.Sin(float64(n+m)), k*math.Cos(float64(n*m)))
+		}
+	}
+	model := normalModel(t, buf.String())
+	assert.Equal(t, 2190, model.Degree())
+
+	for _, lat := range []float64{0, 45, -72.5, 89.999, 90, -90} {
+		N := model.GeoidHeight(geodesy.Point{lat, 123.4})
+		ξ, η := model.Deflection(geodesy.Point{lat, 123.4}, 0)
+		assert.False(t, math.IsNaN(N) || math.IsInf(N, 0), "latitude %v", lat)
+		assert.False(t, math.IsNaN(ξ) || math.IsInf(ξ, 0), "latitude %v", lat)
+		assert.False(t, math.IsNaN(η) || math.IsInf(η, 0), "latitude %v", lat)
+		assert.Less(t, math.Abs(N), 100.0, "latitude %v", lat)
+	}
+
+	// The truncated model is not affected by the high degree terms
+	low := model.Truncate(10, 10)
+	p := geodesy.Point{0, 25}
+	assert.InDelta(t, disturbingPotential(25, 0), low.DisturbingPotential(p, 0), 1e-6)
+}
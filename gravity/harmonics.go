@@ -0,0 +1,154 @@
+package gravity
+
+import "math"
+
+// scaleExponent defines the binary scale factor 2^−930 (~10^−280) applied to the fully normalized
+// associated Legendre functions during synthesis, so that high degree and order terms do not
+// overflow near the poles. See Holmes & Featherstone (2002), "A unified approach to the Clenshaw
+// summation and the recursive computation of very high degree and order normalised associated
+// Legendre functions", Journal of Geodesy 76
+const scaleExponent = 930
+
+// coefficients holds the fully normalized spherical harmonic coefficients C̄nm and S̄nm of a
+// potential, up to a given degree. Coefficients are stored in triangular, row-major order
+type coefficients struct {
+	degree int
+	c, s   []float64
+}
+
+func newCoefficients(degree int) *coefficients {
+	size := index(degree+1, 0)
+	return &coefficients{
+		degree: degree,
+		c:      make([]float64, size),
+		s:      make([]float64, size),
+	}
+}
+
+func index(n, m int) int {
+	return n*(n+1)/2 + m
+}
+
+// set stores the coefficients of degree n and order m, growing the storage when needed
+func (cs *coefficients) set(n, m int, c, s float64) {
+	if n > cs.degree {
+		size := index(n+1, 0)
+		cs.c = append(cs.c, make([]float64, size-len(cs.c))...)
+		cs.s = append(cs.s, make([]float64, size-len(cs.s))...)
+		cs.degree = n
+	}
+
+	i := index(n, m)
+	cs.c[i], cs.s[i] = c, s
+}
+
+// synthesis holds a potential V along with its partial derivatives in spherical coordinates
+type synthesis struct {
+	// v holds the potential V, in (m^2)/(s^2)
+	v float64
+	// dr holds ∂V/∂r, in (m)/(s^2)
+	dr float64
+	// dφ holds ∂V/∂φ̄, in (m^2)/(s^2)
+	dφ float64
+	// dλ holds (1/cosφ̄) ∂V/∂λ, in (m^2)/(s^2), which remains finite at the poles
+	dλ float64
+}
+
+// synthesize evaluates the potential
+//
+//	V = GM/r Σn (R/r)^n Σm P̄nm(sin φ̄) (C̄nm cos mλ + S̄nm sin mλ)
+//
+// and its partial derivatives at radius r, geocentric latitude φ̄ and longitude λ (in radians),
+// truncated to the given degree and order.
+//
+// The fully normalized associated Legendre functions are computed divided by cos^m φ̄ with the
+// standard forward column recursion, and the sum over the orders is evaluated with Horner's
+// scheme in cos φ̄, which is numerically stable up to very high degrees at any latitude
+func (cs *coefficients) synthesize(gm, radius, r, φ, λ float64, degree, order int) synthesis {
+	t, u := math.Sincos(φ)
+
+	root := make([]float64, 2*degree+6)
+	for i := range root {
+		root[i] = math.Sqrt(float64(i))
+	}
+	qn := make([]float64, degree+1)
+	qn[0] = 1
+	for n := 1; n <= degree; n++ {
+		qn[n] = qn[n-1] * radius / r
+	}
+
+	// Scaled sectorial functions P̄mm/cos^m φ̄
+	sectorial := make([]float64, degree+2)
+	sectorial[0] = math.Ldexp(1, -scaleExponent)
+	sectorial[1] = math.Ldexp(root[3], -scaleExponent)
+	for m := 2; m < len(sectorial); m++ {
+		sectorial[m] = sectorial[m-1] * root[2*m+1] / root[2*m]
+	}
+
+	// cur holds the column of order m being computed, and next the one of order m+1, which
+	// is required by the derivative dP̄nm/dφ̄ = e(n,m) P̄n,m+1 − m tan φ̄ P̄nm
+	cur, next := make([]float64, degree+1), make([]float64, degree+1)
+
+	var v, vr, vd, vm, vl float64
+	start := order
+	if order < degree {
+		start++
+	}
+	for m := start; m >= 0; m-- {
+		var c0, s0, c1, s1, cd, sd float64
+		for n := m; n <= degree; n++ {
+			var p float64
+			switch n {
+			case m:
+				p = sectorial[m]
+			case m + 1:
+				p = root[2*m+3] * t * cur[m]
+			default:
+				a := root[2*n-1] * root[2*n+1] / (root[n-m] * root[n+m])
+				b := root[2*n+1] * root[n+m-1] * root[n-m-1] / (root[n-m] * root[n+m] * root[2*n-3])
+				p = a*t*cur[n-1] - b*cur[n-2]
+			}
+			cur[n] = p
+
+			if m > order {
+				continue
+			}
+			i := index(n, m)
+			c, s := cs.c[i]*qn[n], cs.s[i]*qn[n]
+			c0 += p * c
+			s0 += p * s
+			c1 += float64(n+1) * p * c
+			s1 += float64(n+1) * p * s
+			if n > m {
+				e := root[n-m] * root[n+m+1]
+				if m == 0 {
+					e /= math.Sqrt2
+				}
+				cd += e * next[n] * c
+				sd += e * next[n] * s
+			}
+		}
+		cur, next = next, cur
+		if m > order {
+			continue
+		}
+
+		sinmλ, cosmλ := math.Sincos(float64(m) * λ)
+		y := c0*cosmλ + s0*sinmλ
+		v = v*u + y
+		vr = vr*u + c1*cosmλ + s1*sinmλ
+		vd = vd*u + cd*cosmλ + sd*sinmλ
+		if m > 0 {
+			vm = vm*u + float64(m)*y
+			vl = vl*u + float64(m)*(s0*cosmλ-c0*sinmλ)
+		}
+	}
+
+	k := gm / r
+	return synthesis{
+		v:  math.Ldexp(k*v, scaleExponent),
+		dr: -math.Ldexp(k*vr/r, scaleExponent),
+		dφ: math.Ldexp(k*(u*vd-t*vm), scaleExponent),
+		dλ: math.Ldexp(k*vl, scaleExponent),
+	}
+}
@@ -0,0 +1,146 @@
+package gravity
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrFormat is returned when a coefficient file is malformed
+var ErrFormat = errors.New("gravity: invalid coefficient file")
+
+// Constants shared by the EGM96 and EGM2008 geopotential models
+const (
+	// egmGM holds the geocentric gravitational constant GM, defined in (m^3)/(s^2)
+	egmGM = 3_986_004.415e8
+	// egmRadius holds the reference radius, defined in meters (m)
+	egmRadius = 6_378_136.3
+)
+
+// Model represents a geopotential model, defined by the fully normalized spherical harmonic
+// coefficients of the gravitational potential of the earth
+type Model struct {
+	// Geocentric gravitational constant GM of the model, defined in (m^3)/(s^2)
+	GM float64
+	// Reference radius R of the model, defined in meters (m)
+	Radius float64
+	// Reference holds the normal gravity field the disturbing potential is computed against,
+	// and whose ellipsoid the evaluated positions refer to
+	Reference Normal
+
+	degree, order int
+	coefficients  *coefficients
+}
+
+// LoadModel reads the coefficient file at path, up to maxDegree (all degrees if maxDegree ≤ 0)
+func LoadModel(path string, maxDegree int) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadModel(f, maxDegree)
+}
+
+// ReadModel reads a coefficient file up to maxDegree (all degrees if maxDegree ≤ 0), in the
+// format used by the EGM96 (EGM96) and EGM2008 (EGM2008_to2190_TideFree) distributions by NGA.
+// Each line holds the degree n, order m and the coefficients C̄nm and S̄nm, optionally followed by
+// their standard deviations. Fortran double precision exponents (e.g. 0.4841D-03) are accepted.
+//
+// The returned model uses the GM and radius shared by EGM96 and EGM2008, and the WGS84
+// normal gravity field as reference. Like the coefficients, the model is in the tide free system
+func ReadModel(r io.Reader, maxDegree int) (*Model, error) {
+	cs := newCoefficients(1)
+	cs.c[0] = 1
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(strings.NewReplacer("D", "E", "d", "e").Replace(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("%w: line %d has %d fields", ErrFormat, line, len(fields))
+		}
+
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		m, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		if n < 0 || m < 0 || m > n {
+			return nil, fmt.Errorf("%w: line %d has an invalid degree and order (%d, %d)", ErrFormat, line, n, m)
+		}
+		if maxDegree > 0 && n > maxDegree {
+			continue
+		}
+
+		c, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		s, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrFormat, line, err)
+		}
+		cs.set(n, m, c, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+	if cs.degree < 2 {
+		return nil, fmt.Errorf("%w: no coefficients found", ErrFormat)
+	}
+
+	return &Model{
+		GM:           egmGM,
+		Radius:       egmRadius,
+		Reference:    WGS84,
+		degree:       cs.degree,
+		order:        cs.degree,
+		coefficients: cs,
+	}, nil
+}
+
+// Degree returns the maximum degree evaluated by m
+func (m *Model) Degree() int {
+	return m.degree
+}
+
+// Order returns the maximum order evaluated by m
+func (m *Model) Order() int {
+	return m.order
+}
+
+// Truncate returns a copy of m which is evaluated up to the given degree and order.
+// The degree and order are capped to the ones of m, and the copy shares its coefficients with m
+func (m *Model) Truncate(degree, order int) *Model {
+	t := *m
+	if degree < t.degree {
+		t.degree = degree
+	}
+	if t.degree < 0 {
+		t.degree = 0
+	}
+	if order < t.order {
+		t.order = order
+	}
+	if t.order > t.degree {
+		t.order = t.degree
+	}
+	if t.order < 0 {
+		t.order = 0
+	}
+
+	return &t
+}
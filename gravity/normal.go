@@ -0,0 +1,75 @@
+package gravity
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+// Normal represents the normal gravity field of a level ellipsoid, that is, a rotating
+// ellipsoid whose surface is an equipotential surface of its own gravity field
+type Normal struct {
+	Ellipsoid ellipsoids.Ellipsoid
+	// Geocentric gravitational constant GM, defined in (m^3)/(s^2)
+	GM float64
+	// Dynamical form factor J2; adimensional
+	J2 float64
+	// Angular velocity ω; defined in s^-1
+	AngularVelocity float64
+}
+
+var (
+	// WGS84 is the normal gravity field of the World Geodetic System 1984
+	WGS84 = Normal{
+		Ellipsoid:       ellipsoids.WGS84,
+		GM:              ellipsoids.WGS84_GEOCENTRIC_GRAVITATIONAL_CONSTANT,
+		J2:              ellipsoids.WGS84_DYNAMICAL_FORM_FACTOR,
+		AngularVelocity: ellipsoids.WGS84_ANGULAR_VELOCITY,
+	}
+	// GRS80 is the normal gravity field of the Geodetic Reference System 1980
+	GRS80 = Normal{
+		Ellipsoid:       ellipsoids.GRS80,
+		GM:              ellipsoids.GRS80_GEOCENTRIC_GRAVITATIONAL_CONSTANT,
+		J2:              ellipsoids.GRS80_DYNAMICAL_FORM_FACTOR,
+		AngularVelocity: ellipsoids.GRS80_ANGULAR_VELOCITY,
+	}
+)
+
+// normalDegree is the maximum degree of the zonal expansion of the normal potential.
+// The coefficients decrease as e^(2n), so higher degrees are below double precision
+const normalDegree = 20
+
+// coefficients returns the spherical harmonic expansion of the gravitational potential
+// of n, which only holds even zonal terms: C̄(2k,0) = −J(2k)/√(4k+1), with
+//
+//	J(2k) = (−1)^(k+1) 3e^(2k) / ((2k+1)(2k+3)) · (1 − k + 5k·J2/e²)
+//
+// See Heiskanen & Moritz (1967), "Physical Geodesy", eq. 2-92
+func (n Normal) coefficients() *coefficients {
+	e2 := n.Ellipsoid.EccentricitySquared()
+
+	cs := newCoefficients(normalDegree)
+	cs.c[0] = 1
+	for k := 1; 2*k <= normalDegree; k++ {
+		J := 3 * math.Pow(e2, float64(k)) / float64((2*k+1)*(2*k+3)) * (1 - float64(k) + 5*float64(k)*n.J2/e2)
+		if k%2 == 0 {
+			J = -J
+		}
+		cs.set(2*k, 0, -J/math.Sqrt(float64(4*k+1)), 0)
+	}
+
+	return cs
+}
+
+// gravity returns the magnitude of the normal gravity vector in (m)/(s^2), that is, the
+// gradient of the gravitational potential with its partial derivatives s (as returned
+// by synthesis) plus the centrifugal potential ½ω²r²cos²φ̄, at radius r and geocentric latitude φ̄
+func (n Normal) gravity(s synthesis, r, φ float64) float64 {
+	sinφ, cosφ := math.Sincos(φ)
+	ω2 := n.AngularVelocity * n.AngularVelocity
+
+	gr := s.dr + ω2*r*cosφ*cosφ
+	gφ := s.dφ/r - ω2*r*cosφ*sinφ
+
+	return math.Hypot(gr, gφ)
+}
@@ -0,0 +1,71 @@
+package gravity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/gravity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalConstants(t *testing.T) {
+	tests := []struct {
+		name                 string
+		normal               gravity.Normal
+		potential, m, γe, γp float64
+	}{
+		{
+			name:      "OK/WGS84",
+			normal:    gravity.WGS84,
+			potential: ellipsoids.WGS84_NORMAL_POTENTIAL,
+			m:         ellipsoids.WGS84_M,
+			γe:        ellipsoids.WGS84_EQUATORIAL_GRAVITY,
+			γp:        ellipsoids.WGS84_POLAR_GRAVITY,
+		},
+		{
+			name:      "OK/GRS80",
+			normal:    gravity.GRS80,
+			potential: ellipsoids.GRS80_NORMAL_POTENTIAL,
+			m:         ellipsoids.GRS80_M,
+			γe:        ellipsoids.GRS80_EQUATORIAL_GRAVITY,
+			γp:        ellipsoids.GRS80_POLAR_GRAVITY,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.potential, tt.normal.SurfacePotential(), 1e-3)
+			assert.InDelta(t, tt.m, tt.normal.M(), 1e-14)
+			assert.InDelta(t, tt.γe, tt.normal.EquatorialGravity(), 1e-10)
+			assert.InDelta(t, tt.γp, tt.normal.PolarGravity(), 1e-10)
+			assert.InDelta(t, tt.γe, tt.normal.Gravity(geodesy.Point{0, 0}), 1e-10)
+			assert.InDelta(t, tt.γp, tt.normal.Gravity(geodesy.Point{-90, 0}), 1e-10)
+		})
+	}
+}
+
+func TestNormalGravity(t *testing.T) {
+	// GRS80 normal gravity at 45° of latitude, as published by Moritz (2000), "Geodetic Reference System 1980"
+	assert.InDelta(t, 9.806199203, gravity.GRS80.Gravity(geodesy.Point{45, 0}), 1e-9)
+
+	for lat := -90.0; lat <= 90; lat += 7.5 {
+		p := geodesy.Point{lat, 10}
+		γ := gravity.WGS84.Gravity(p)
+		assert.InDelta(t, γ, gravity.WGS84.GravityAt(p, 0), 1e-12, "latitude %v", lat)
+
+		// Normal vertical gradient of ~0.3086 mGal/m
+		gradient := (gravity.WGS84.GravityAt(p, -100) - gravity.WGS84.GravityAt(p, 100)) / 200
+		assert.InDelta(t, 3.086e-6, gradient, 0.003e-6, "latitude %v", lat)
+
+		for _, h := range []float64{-400, 1000, 8848, 20000} {
+			assert.InDelta(t, gravity.WGS84.GravityAt(p, h), gravity.WGS84.FreeAirGravity(p, h), 1.5e-6, "latitude %v, height %v", lat, h)
+		}
+	}
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.True(t, math.IsNaN(gravity.WGS84.Gravity(geodesy.Point{95, 0})))
+		assert.True(t, math.IsNaN(gravity.WGS84.GravityAt(geodesy.Point{0, -190}, 0)))
+		assert.True(t, math.IsNaN(gravity.WGS84.FreeAirGravity(geodesy.Point{-95, 0}, 0)))
+	})
+}
@@ -0,0 +1,135 @@
+package gravity
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/transform"
+)
+
+// linearEccentricity returns the linear eccentricity E = √(a² − b²) of the ellipsoid of n, in meters (m)
+func (n Normal) linearEccentricity() float64 {
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	return math.Sqrt(a*a - b*b)
+}
+
+// q returns the auxiliary function q = ½((1 + 3u²/E²) arctan(E/u) − 3u/E) of the ellipsoidal
+// coordinate u, along with its derivative q' = 3(1 + u²/E²)(1 − (u/E) arctan(E/u)) − 1
+func q(u, E float64) (q, qʹ float64) {
+	k := u / E
+	atan := math.Atan(1 / k)
+	q = ((1+3*k*k)*atan - 3*k) / 2
+	qʹ = 3*(1+k*k)*(1-k*atan) - 1
+
+	return q, qʹ
+}
+
+// M returns the derived geodetic constant m = ω²a²b/GM of n, that is, the ratio between the
+// centrifugal and the gravitational acceleration at the equator; adimensional
+func (n Normal) M() float64 {
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	return n.AngularVelocity * n.AngularVelocity * a * a * b / n.GM
+}
+
+// SurfacePotential returns the normal potential U0 = GM/E arctan(e') + ω²a²/3 on the surface
+// of the ellipsoid of n, in (m^2)/(s^2)
+func (n Normal) SurfacePotential() float64 {
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	E := n.linearEccentricity()
+
+	return n.GM/E*math.Atan(E/b) + n.AngularVelocity*n.AngularVelocity*a*a/3
+}
+
+// EquatorialGravity returns the normal gravity γe = GM/(ab) (1 − m − m e' q0'/(6 q0)) at the
+// equator of n, in (m)/(s^2)
+func (n Normal) EquatorialGravity() float64 {
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	E := n.linearEccentricity()
+	q0, q0ʹ := q(b, E)
+	m := n.M()
+
+	return n.GM / (a * b) * (1 - m - m*E/b*q0ʹ/(6*q0))
+}
+
+// PolarGravity returns the normal gravity γp = GM/a² (1 + m e' q0'/(3 q0)) at the poles of n,
+// in (m)/(s^2)
+func (n Normal) PolarGravity() float64 {
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	E := n.linearEccentricity()
+	q0, q0ʹ := q(b, E)
+	m := n.M()
+
+	return n.GM / (a * a) * (1 + m*E/b*q0ʹ/(3*q0))
+}
+
+// Gravity returns the normal gravity at p on the surface of the ellipsoid of n in (m)/(s^2),
+// given by the closed form formula of Somigliana:
+//
+//	γ = (a γe cos²φ + b γp sin²φ) / √(a² cos²φ + b² sin²φ)
+//
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (n Normal) Gravity(p geodesy.Point) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	sinφ, cosφ := math.Sincos(p.LatRadians())
+	sin2, cos2 := sinφ*sinφ, cosφ*cosφ
+
+	return (a*n.EquatorialGravity()*cos2 + b*n.PolarGravity()*sin2) / math.Sqrt(a*a*cos2+b*b*sin2)
+}
+
+// GravityAt returns the magnitude of the normal gravity vector at p with ellipsoidal height h
+// (in meters) in (m)/(s^2), computed exactly from the normal potential in ellipsoidal-harmonic
+// coordinates (u, β). See NIMA TR8350.2 (2000), "Department of Defense World Geodetic System 1984",
+// section 4.2.2.
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (n Normal) GravityAt(p geodesy.Point, h float64) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	a, b := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.SemiMinorAxis()
+	E := n.linearEccentricity()
+	E2 := E * E
+	ω2 := n.AngularVelocity * n.AngularVelocity
+
+	c := transform.ToCartesian(p, h, n.Ellipsoid)
+	w := math.Hypot(c.X, c.Y)
+	r2 := w*w + c.Z*c.Z
+
+	// Ellipsoidal-harmonic coordinates of the position
+	k := r2 - E2
+	u2 := k / 2 * (1 + math.Sqrt(1+4*E2*c.Z*c.Z/(k*k)))
+	u := math.Sqrt(u2)
+	β := math.Atan2(c.Z*math.Sqrt(u2+E2), u*w)
+	sinβ, cosβ := math.Sincos(β)
+	W := math.Sqrt((u2 + E2*sinβ*sinβ) / (u2 + E2))
+
+	q0, _ := q(b, E)
+	qu, quʹ := q(u, E)
+
+	γu := -(n.GM/(u2+E2) + ω2*a*a*E/(u2+E2)*quʹ/q0*(sinβ*sinβ/2-1.0/6) - ω2*u*cosβ*cosβ) / W
+	γβ := (ω2*a*a*qu/(math.Sqrt(u2+E2)*q0) - ω2*math.Sqrt(u2+E2)) * sinβ * cosβ / W
+
+	return math.Hypot(γu, γβ)
+}
+
+// FreeAirGravity returns the normal gravity at p with ellipsoidal height h (in meters) in
+// (m)/(s^2), given by the second order free-air formula
+//
+//	γh = γ (1 − 2/a (1 + f + m − 2f sin²φ) h + 3h²/a²)
+//
+// which departs from GravityAt by less than 0.15 mGal for heights up to 20 km.
+// If p does not constitute a valid geographic coordinate, the returned value will be math.NaN()
+func (n Normal) FreeAirGravity(p geodesy.Point, h float64) float64 {
+	if !p.Valid() {
+		return math.NaN()
+	}
+
+	a, f := n.Ellipsoid.SemiMajorAxis, n.Ellipsoid.Flattening
+	sinφ := math.Sin(p.LatRadians())
+
+	return n.Gravity(p) * (1 - 2/a*(1+f+n.M()-2*f*sinφ*sinφ)*h + 3*h*h/(a*a))
+}
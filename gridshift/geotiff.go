@@ -0,0 +1,225 @@
+package gridshift
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lggomez/go-geodesy/internal/geomath"
+)
+
+const (
+	geoKeyRasterType = 1025
+	rasterPixelIsPt  = 2
+
+	latitudeOffset  = "latitude_offset"
+	longitudeOffset = "longitude_offset"
+)
+
+// gdalMetadata represents the contents of the GDAL_METADATA TIFF tag
+type gdalMetadata struct {
+	Items []struct {
+		Name   string `xml:"name,attr"`
+		Sample string `xml:"sample,attr"`
+		Role   string `xml:"role,attr"`
+		Value  string `xml:",chardata"`
+	} `xml:"Item"`
+}
+
+// LoadGeoTIFF reads the GeoTIFF grid shift file at path
+func LoadGeoTIFF(path string) (GridSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadGeoTIFF(f)
+}
+
+// ReadGeoTIFF reads a grid set from a GeoTIFF horizontal shift grid, as described by the
+// PROJ Geodetic TIFF Grids (GTG) specification.
+//
+// Each full resolution image of the file is read as a grid, and grids contained within the
+// extent of another become its subgrids. Bands are identified through their GDAL_METADATA
+// description (latitude_offset and longitude_offset, defaulting to the first and second band),
+// along with their unit (arc-seconds by default, or degrees and radians) and the positive direction
+// of longitude offsets (east by default). Only uncompressed or deflate compressed floating point
+// samples are supported
+func ReadGeoTIFF(r io.ReaderAt) (GridSet, error) {
+	ifds, err := readTIFF(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var grids []*Grid
+	for _, ifd := range ifds {
+		if ifd.uint(tagNewSubfileType, 0)&(subfileReducedResolution|subfileMask) != 0 {
+			continue
+		}
+
+		g, err := readGeoTIFFGrid(r, ifd)
+		if err != nil {
+			return nil, err
+		}
+		grids = append(grids, g)
+	}
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("%w: no grids found in GeoTIFF file", ErrFormat)
+	}
+
+	return nest(grids), nil
+}
+
+func readGeoTIFFGrid(r io.ReaderAt, ifd tiffIFD) (*Grid, error) {
+	scale := ifd.floats(tagModelPixelScale)
+	tiepoint := ifd.floats(tagModelTiepoint)
+	if len(scale) < 2 || len(tiepoint) < 6 || scale[0] <= 0 || scale[1] <= 0 {
+		return nil, fmt.Errorf("%w: missing GeoTIFF georeferencing", ErrFormat)
+	}
+
+	bands, err := ifd.bands(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(bands) < 2 {
+		return nil, fmt.Errorf("%w: GeoTIFF grid needs latitude and longitude offset bands", ErrFormat)
+	}
+
+	width := int(ifd.uint(tagImageWidth, 0))
+	height := int(ifd.uint(tagImageLength, 0))
+
+	// Pixel values represent the center of their area, unless flagged as points
+	offset := 0.5
+	if geoKey(ifd, geoKeyRasterType) == rasterPixelIsPt {
+		offset = 0
+	}
+	west := tiepoint[3] + (offset-tiepoint[0])*scale[0]
+	north := tiepoint[4] - (offset-tiepoint[1])*scale[1]
+
+	g := &Grid{
+		South:   north - float64(height-1)*scale[1],
+		West:    west,
+		LatStep: scale[1],
+		LonStep: scale[0],
+		Rows:    height,
+		Cols:    width,
+	}
+
+	meta := gdalMetadata{}
+	if text := ifd.ascii(tagGDALMetadata); text != "" {
+		if err := xml.Unmarshal([]byte(text), &meta); err != nil {
+			return nil, fmt.Errorf("%w: invalid GDAL metadata: %v", ErrFormat, err)
+		}
+	}
+
+	latBand, lonBand := 0, 1
+	latFactor, lonFactor := 1.0, 1.0
+	for _, item := range meta.Items {
+		value := strings.ToLower(strings.TrimSpace(item.Value))
+		if item.Sample == "" && strings.EqualFold(item.Name, "grid_name") {
+			g.Name = strings.TrimSpace(item.Value)
+		}
+
+		band, err := strconv.Atoi(item.Sample)
+		if err != nil || band < 0 || band >= len(bands) || !strings.EqualFold(item.Role, "description") {
+			continue
+		}
+		switch value {
+		case latitudeOffset:
+			latBand = band
+		case longitudeOffset:
+			lonBand = band
+		}
+	}
+	for _, item := range meta.Items {
+		band, err := strconv.Atoi(item.Sample)
+		if err != nil || (band != latBand && band != lonBand) {
+			continue
+		}
+
+		factor := 1.0
+		value := strings.ToLower(strings.TrimSpace(item.Value))
+		switch {
+		case strings.EqualFold(item.Role, "unittype"):
+			switch value {
+			case "degree", "degrees":
+				factor = secondsPerDegree
+			case "radian", "radians":
+				factor = secondsPerDegree * geomath.RadToDeg
+			}
+		case strings.EqualFold(item.Name, "positive_value") && value == "west" && band == lonBand:
+			factor = -1
+		}
+
+		if band == latBand {
+			latFactor *= factor
+		} else {
+			lonFactor *= factor
+		}
+	}
+
+	// Flip rows, as images are stored from north to south
+	g.latShifts = make([]float32, width*height)
+	g.lonShifts = make([]float32, width*height)
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			src := row*width + col
+			dst := (height-1-row)*width + col
+			g.latShifts[dst] = float32(bands[latBand][src] * latFactor)
+			g.lonShifts[dst] = float32(bands[lonBand][src] * lonFactor)
+		}
+	}
+
+	return g, nil
+}
+
+// geoKey returns the value of a short GeoKey stored within the GeoKeyDirectory tag, or 0 if not present
+func geoKey(ifd tiffIFD, key uint64) uint64 {
+	dir := ifd.uints(tagGeoKeyDirectory)
+	if len(dir) < 4 {
+		return 0
+	}
+	for i := 4; i+3 < len(dir); i += 4 {
+		// Short values are stored inline, with a zero tag location
+		if dir[i] == key && dir[i+1] == 0 {
+			return dir[i+3]
+		}
+	}
+
+	return 0
+}
+
+// nest arranges grids into a hierarchy, making each grid a child of the
+// smallest other grid containing its extent
+func nest(grids []*Grid) GridSet {
+	var set GridSet
+	for _, g := range grids {
+		var parent *Grid
+		for _, candidate := range grids {
+			if candidate == g || !containsExtent(candidate, g) {
+				continue
+			}
+			if parent == nil || containsExtent(parent, candidate) {
+				parent = candidate
+			}
+		}
+
+		if parent != nil {
+			parent.Children = append(parent.Children, g)
+		} else {
+			set = append(set, g)
+		}
+	}
+
+	return set
+}
+
+func containsExtent(outer, inner *Grid) bool {
+	return outer.South <= inner.South && outer.North() >= inner.North() &&
+		outer.West <= inner.West && outer.East() >= inner.East() &&
+		(outer.LatStep > inner.LatStep || outer.LonStep > inner.LonStep)
+}
@@ -0,0 +1,207 @@
+package gridshift
+
+import (
+	"errors"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+const (
+	secondsPerDegree = 3_600
+
+	inverseAccuracy      = 1e-12 // in degrees, approximates to 0.1 µm
+	inverseMaxIterations = 20
+)
+
+var (
+	// ErrOutsideGrid is returned when a point is not covered by any grid of a set
+	ErrOutsideGrid = errors.New("gridshift: point outside of grid coverage")
+	// ErrNotConverged is returned when the inverse application of a grid does not converge
+	ErrNotConverged = errors.New("gridshift: inverse shift did not converge")
+	// ErrFormat is returned when a grid file is malformed or uses an unsupported layout
+	ErrFormat = errors.New("gridshift: invalid grid file")
+)
+
+// Grid represents a regular grid of latitude and longitude shifts. Nodes are stored
+// row-major from south to north and from west to east, and shifts are defined in
+// arc-seconds with longitudes positive east
+type Grid struct {
+	// Name identifies the grid within its file
+	Name string
+
+	// South and West hold the coordinates of the south-west node, in decimal degrees
+	South, West float64
+	// LatStep and LonStep hold the node spacing, in decimal degrees
+	LatStep, LonStep float64
+	// Rows and Cols hold the amount of nodes along each axis
+	Rows, Cols int
+
+	// Children holds the denser subgrids contained within this grid
+	Children []*Grid
+
+	latShifts []float32
+	lonShifts []float32
+}
+
+// North returns the latitude of the northernmost row of nodes of g, in decimal degrees
+func (g *Grid) North() float64 {
+	return g.South + float64(g.Rows-1)*g.LatStep
+}
+
+// East returns the longitude of the easternmost column of nodes of g, in decimal degrees
+func (g *Grid) East() float64 {
+	return g.West + float64(g.Cols-1)*g.LonStep
+}
+
+// Contains returns whether p falls within the extent of g
+func (g *Grid) Contains(p geodesy.Point) bool {
+	_, _, ok := g.locate(p)
+	return ok
+}
+
+// Shift returns the latitude and longitude shifts at p in arc-seconds, bilinearly
+// interpolated from the nodes of g. If p falls outside of g, ok will be false
+func (g *Grid) Shift(p geodesy.Point) (Δφ, Δλ float64, ok bool) {
+	x, y, ok := g.locate(p)
+	if !ok {
+		return 0, 0, false
+	}
+
+	// Cell containing p, clamped so that the last row and column use the previous cell
+	col := math.Min(math.Floor(x), float64(g.Cols-2))
+	row := math.Min(math.Floor(y), float64(g.Rows-2))
+	if g.Cols == 1 {
+		col = 0
+	}
+	if g.Rows == 1 {
+		row = 0
+	}
+	fx, fy := x-col, y-row
+
+	i := int(row)*g.Cols + int(col)
+	Δφ = bilinear(g.latShifts, i, g.Cols, g.Rows, fx, fy)
+	Δλ = bilinear(g.lonShifts, i, g.Cols, g.Rows, fx, fy)
+
+	return Δφ, Δλ, true
+}
+
+// locate returns the fractional column and row of p within g
+func (g *Grid) locate(p geodesy.Point) (float64, float64, bool) {
+	lat, lon := p.Lat(), p.Lon()
+	// Grids may be defined over the [0, 360) longitude range, or cross the antimeridian
+	if lon < g.West {
+		lon += 360
+	} else if lon > g.East() {
+		lon -= 360
+	}
+
+	if lat < g.South || lat > g.North() || lon < g.West || lon > g.East() {
+		return 0, 0, false
+	}
+
+	return (lon - g.West) / g.LonStep, (lat - g.South) / g.LatStep, true
+}
+
+// finest returns the densest grid (g or any of its descendants) containing p
+func (g *Grid) finest(p geodesy.Point) *Grid {
+	for _, child := range g.Children {
+		if child.Contains(p) {
+			return child.finest(p)
+		}
+	}
+
+	return g
+}
+
+func bilinear(values []float32, i, cols, rows int, fx, fy float64) float64 {
+	v00 := float64(values[i])
+	v10, v01, v11 := v00, v00, v00
+	if cols > 1 {
+		v10 = float64(values[i+1])
+	}
+	if rows > 1 {
+		v01 = float64(values[i+cols])
+		v11 = v01
+		if cols > 1 {
+			v11 = float64(values[i+cols+1])
+		}
+	}
+
+	return v00*(1-fx)*(1-fy) + v10*fx*(1-fy) + v01*(1-fx)*fy + v11*fx*fy
+}
+
+// GridSet represents the top-level grids of one or more grid shift files,
+// in order of preference
+type GridSet []*Grid
+
+// Grid returns the densest grid of gs containing p, or nil if p is not covered by gs
+func (gs GridSet) Grid(p geodesy.Point) *Grid {
+	for _, g := range gs {
+		if g.Contains(p) {
+			return g.finest(p)
+		}
+	}
+
+	return nil
+}
+
+// Shift returns the latitude and longitude shifts at p in arc-seconds,
+// interpolated from the densest grid of gs containing it
+func (gs GridSet) Shift(p geodesy.Point) (Δφ, Δλ float64, err error) {
+	g := gs.Grid(p)
+	if g == nil {
+		return 0, 0, ErrOutsideGrid
+	}
+	Δφ, Δλ, _ = g.Shift(p)
+
+	return Δφ, Δλ, nil
+}
+
+// Forward applies the shifts of gs to p, converting it from the source
+// to the target datum of the grids
+func (gs GridSet) Forward(p geodesy.Point) (geodesy.Point, error) {
+	Δφ, Δλ, err := gs.Shift(p)
+	if err != nil {
+		return geodesy.Point{}, err
+	}
+
+	return shifted(p, Δφ, Δλ), nil
+}
+
+// Inverse reverts the shifts of gs from p, converting it from the target
+// to the source datum of the grids. As the shifts are defined on the source datum,
+// the inverse is evaluated iteratively
+func (gs GridSet) Inverse(p geodesy.Point) (geodesy.Point, error) {
+	Δφ, Δλ, err := gs.Shift(p)
+	if err != nil {
+		return geodesy.Point{}, err
+	}
+	q := shifted(p, -Δφ, -Δλ)
+
+	for i := 0; i < inverseMaxIterations; i++ {
+		Δφ, Δλ, err = gs.Shift(q)
+		if err != nil {
+			return geodesy.Point{}, err
+		}
+
+		next := shifted(p, -Δφ, -Δλ)
+		if math.Abs(next.Lat()-q.Lat()) < inverseAccuracy && math.Abs(next.Lon()-q.Lon()) < inverseAccuracy {
+			return next, nil
+		}
+		q = next
+	}
+
+	return geodesy.Point{}, ErrNotConverged
+}
+
+func shifted(p geodesy.Point, Δφ, Δλ float64) geodesy.Point {
+	lon := p.Lon() + Δλ/secondsPerDegree
+	if lon > geodesy.LonUpperBound {
+		lon -= 360
+	} else if lon < geodesy.LonLowerBound {
+		lon += 360
+	}
+
+	return geodesy.Point{p.Lat() + Δφ/secondsPerDegree, lon}
+}
@@ -0,0 +1,432 @@
+package gridshift_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/gridshift"
+	"github.com/stretchr/testify/assert"
+)
+
+// Synthetic shifts in arc-seconds, linear so that bilinear interpolation is exact
+func latShift(lat, lon float64) float64 { return 1 + 0.5*(lat-40) + 0.1*(lon+76) }
+func lonShift(lat, lon float64) float64 { return -2 + 0.25*(lon+76) - 0.2*(lat-40) }
+
+const (
+	childLatShift = 5
+	childLonShift = 6
+)
+
+type record struct {
+	key   string
+	value interface{}
+}
+
+func writeNTv2Records(buf *bytes.Buffer, order binary.ByteOrder, records []record) {
+	for _, r := range records {
+		key := []byte("        ")
+		copy(key, r.key)
+		buf.Write(key)
+		switch v := r.value.(type) {
+		case int32:
+			_ = binary.Write(buf, order, v)
+			buf.Write(make([]byte, 4))
+		case float64:
+			_ = binary.Write(buf, order, v)
+		case string:
+			value := []byte("        ")
+			copy(value, v)
+			buf.Write(value)
+		}
+	}
+}
+
+// ntv2File builds a grid over [40, 42]x[-76, -74] with a 0.5° spacing, holding
+// a subgrid over [41, 41.5]x[-75.5, -75] with a 0.25° spacing and constant shifts
+func ntv2File(order binary.ByteOrder) []byte {
+	buf := &bytes.Buffer{}
+	writeNTv2Records(buf, order, []record{
+		{"NUM_OREC", int32(11)}, {"NUM_SREC", int32(11)}, {"NUM_FILE", int32(2)},
+		{"GS_TYPE", "SECONDS"}, {"VERSION", "NTv2.0"}, {"SYSTEM_F", "NAD27"}, {"SYSTEM_T", "NAD83"},
+		{"MAJOR_F", 6378206.4}, {"MINOR_F", 6356583.8}, {"MAJOR_T", 6378137.0}, {"MINOR_T", 6356752.314},
+	})
+
+	subgrid := func(name, parent string, s, n, e, w, step float64, shift func(lat, lon float64) (float64, float64)) {
+		rows, cols := int(math.Round((n-s)/step))+1, int(math.Round((w-e)/step))+1
+		writeNTv2Records(buf, order, []record{
+			{"SUB_NAME", name}, {"PARENT", parent}, {"CREATED", "20211001"}, {"UPDATED", "20211001"},
+			{"S_LAT", s * 3600}, {"N_LAT", n * 3600}, {"E_LONG", e * 3600}, {"W_LONG", w * 3600},
+			{"LAT_INC", step * 3600}, {"LONG_INC", step * 3600}, {"GS_COUNT", int32(rows * cols)},
+		})
+		for row := 0; row < rows; row++ {
+			for col := 0; col < cols; col++ {
+				// Nodes go from east to west, with positive west longitudes
+				lat, lon := s+float64(row)*step, -(e + float64(col)*step)
+				Δφ, Δλ := shift(lat, lon)
+				_ = binary.Write(buf, order, []float32{float32(Δφ), float32(-Δλ), 0.01, 0.01})
+			}
+		}
+	}
+	subgrid("PARENT", "NONE", 40, 42, 74, 76, 0.5, func(lat, lon float64) (float64, float64) {
+		return latShift(lat, lon), lonShift(lat, lon)
+	})
+	subgrid("CHILD", "PARENT", 41, 41.5, 75, 75.5, 0.25, func(lat, lon float64) (float64, float64) {
+		return childLatShift, childLonShift
+	})
+	writeNTv2Records(buf, order, []record{{"END", float64(0)}})
+
+	return buf.Bytes()
+}
+
+func assertShifts(t *testing.T, gs gridshift.GridSet) {
+	tests := []struct {
+		name    string
+		p       geodesy.Point
+		Δφ, Δλ  float64
+		outside bool
+	}{
+		{name: "OK/node", p: geodesy.Point{40.5, -75.5}, Δφ: latShift(40.5, -75.5), Δλ: lonShift(40.5, -75.5)},
+		{name: "OK/interpolated", p: geodesy.Point{40.3, -75.9}, Δφ: latShift(40.3, -75.9), Δλ: lonShift(40.3, -75.9)},
+		{name: "OK/north_east_corner", p: geodesy.Point{42, -74}, Δφ: latShift(42, -74), Δλ: lonShift(42, -74)},
+		{name: "OK/subgrid", p: geodesy.Point{41.2, -75.2}, Δφ: childLatShift, Δλ: childLonShift},
+		{name: "FAIL/outside", p: geodesy.Point{10, 10}, outside: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Δφ, Δλ, err := gs.Shift(tt.p)
+			if tt.outside {
+				assert.ErrorIs(t, err, gridshift.ErrOutsideGrid)
+				_, err = gs.Forward(tt.p)
+				assert.ErrorIs(t, err, gridshift.ErrOutsideGrid)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.Δφ, Δφ, 1e-5)
+			assert.InDelta(t, tt.Δλ, Δλ, 1e-5)
+
+			shifted, err := gs.Forward(tt.p)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.p.Lat()+tt.Δφ/3600, shifted.Lat(), 1e-9)
+			assert.InDelta(t, tt.p.Lon()+tt.Δλ/3600, shifted.Lon(), 1e-9)
+
+			if tt.p.Lat() < 42 {
+				// The inverse of the north east corner falls outside of the grid
+				back, err := gs.Inverse(shifted)
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.p.Lat(), back.Lat(), 1e-9)
+				assert.InDelta(t, tt.p.Lon(), back.Lon(), 1e-9)
+			}
+		})
+	}
+}
+
+func TestReadNTv2(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			gs, err := gridshift.ReadNTv2(bytes.NewReader(ntv2File(order)))
+			assert.NoError(t, err)
+			assert.Len(t, gs, 1)
+			assert.Equal(t, "PARENT", gs[0].Name)
+			assert.Len(t, gs[0].Children, 1)
+			assert.Equal(t, 5, gs[0].Rows)
+			assert.Equal(t, 5, gs[0].Cols)
+			assert.EqualValues(t, -76, gs[0].West)
+			assertShifts(t, gs)
+		})
+	}
+
+	t.Run("FAIL/truncated", func(t *testing.T) {
+		data := ntv2File(binary.LittleEndian)
+		_, err := gridshift.ReadNTv2(bytes.NewReader(data[:len(data)/2]))
+		assert.ErrorIs(t, err, gridshift.ErrFormat)
+	})
+	t.Run("FAIL/not_ntv2", func(t *testing.T) {
+		_, err := gridshift.ReadNTv2(bytes.NewReader(make([]byte, 512)))
+		assert.ErrorIs(t, err, gridshift.ErrFormat)
+	})
+}
+
+// nadconFile builds a NADCON file over [40, 42]x[-76, -74] with a 0.5° spacing
+func nadconFile(shift func(lat, lon float64) float64) []byte {
+	const cols, rows = 25, 5 // Records must be wide enough to hold the header
+	le := binary.LittleEndian
+
+	record := make([]byte, 4*(cols+1))
+	buf := &bytes.Buffer{}
+	copy(record, "NADCON EXTRACTED REGION")
+	copy(record[56:], "NADGRD")
+	le.PutUint32(record[64:], cols)
+	le.PutUint32(record[68:], rows)
+	le.PutUint32(record[72:], 1)
+	for i, v := range []float32{-76, 0.5, 40, 0.5, 0} {
+		le.PutUint32(record[76+4*i:], math.Float32bits(v))
+	}
+	buf.Write(record)
+
+	for row := 0; row < rows; row++ {
+		record = make([]byte, 4*(cols+1))
+		for col := 0; col < cols; col++ {
+			v := shift(40+float64(row)*0.5, -76+float64(col)*0.5)
+			le.PutUint32(record[4*(col+1):], math.Float32bits(float32(v)))
+		}
+		buf.Write(record)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadNADCON(t *testing.T) {
+	las := nadconFile(latShift)
+	los := nadconFile(func(lat, lon float64) float64 { return -lonShift(lat, lon) })
+
+	gs, err := gridshift.ReadNADCON(bytes.NewReader(las), bytes.NewReader(los))
+	assert.NoError(t, err)
+	assert.Len(t, gs, 1)
+	assert.Equal(t, "NADCON EXTRACTED REGION", gs[0].Name)
+
+	Δφ, Δλ, err := gs.Shift(geodesy.Point{41.3, -75.1})
+	assert.NoError(t, err)
+	assert.InDelta(t, latShift(41.3, -75.1), Δφ, 1e-5)
+	assert.InDelta(t, lonShift(41.3, -75.1), Δλ, 1e-5)
+
+	_, err = gridshift.ReadNADCON(bytes.NewReader(las), bytes.NewReader(los[:100]))
+	assert.ErrorIs(t, err, gridshift.ErrFormat)
+}
+
+// nadcon5File builds a NADCON5 file over [40, 42]x[284, 286] with a 0.5° spacing
+func nadcon5File(shift func(lat, lon float64) float64) []byte {
+	buf := &bytes.Buffer{}
+	writeRecord := func(values ...interface{}) {
+		record := &bytes.Buffer{}
+		for _, v := range values {
+			_ = binary.Write(record, binary.BigEndian, v)
+		}
+		_ = binary.Write(buf, binary.BigEndian, uint32(record.Len()))
+		buf.Write(record.Bytes())
+		_ = binary.Write(buf, binary.BigEndian, uint32(record.Len()))
+	}
+
+	writeRecord(float32(40), float32(284), float32(0.5), float32(0.5), int32(5), int32(5), int32(1))
+	for row := 0; row < 5; row++ {
+		values := make([]float32, 5)
+		for col := range values {
+			values[col] = float32(shift(40+float64(row)*0.5, -76+float64(col)*0.5))
+		}
+		writeRecord(values)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadNADCON5(t *testing.T) {
+	gs, err := gridshift.ReadNADCON5(bytes.NewReader(nadcon5File(latShift)), bytes.NewReader(nadcon5File(lonShift)))
+	assert.NoError(t, err)
+	assert.Len(t, gs, 1)
+	assert.EqualValues(t, -76, gs[0].West)
+
+	Δφ, Δλ, err := gs.Shift(geodesy.Point{40.8, -74.6})
+	assert.NoError(t, err)
+	assert.InDelta(t, latShift(40.8, -74.6), Δφ, 1e-5)
+	assert.InDelta(t, lonShift(40.8, -74.6), Δλ, 1e-5)
+
+	_, err = gridshift.ReadNADCON5(bytes.NewReader(nadcon5File(latShift)), bytes.NewReader(nil))
+	assert.ErrorIs(t, err, gridshift.ErrFormat)
+}
+
+type tiffTag struct {
+	tag    uint16
+	typ    uint16
+	values interface{}
+}
+
+// geoTIFFFile builds a little-endian GeoTIFF grid over [40, 42]x[-76, -74] with a 0.5° spacing,
+// holding longitude offsets positive west in the first band and latitude offsets in the second.
+// Compressed files use deflate, the floating point predictor and separate 4x4 tiles for each band
+func geoTIFFFile(compressed bool) []byte {
+	const width, height = 5, 5
+	le := binary.LittleEndian
+
+	// Bands go from north to south
+	bands := [2][]float32{make([]float32, width*height), make([]float32, width*height)}
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			lat, lon := 42-float64(row)*0.5, -76+float64(col)*0.5
+			bands[0][row*width+col] = float32(-lonShift(lat, lon))
+			bands[1][row*width+col] = float32(latShift(lat, lon))
+		}
+	}
+
+	var chunks [][]byte
+	var tags []tiffTag
+	if !compressed {
+		// A single chunky strip
+		chunk := &bytes.Buffer{}
+		for i := 0; i < width*height; i++ {
+			_ = binary.Write(chunk, le, []float32{bands[0][i], bands[1][i]})
+		}
+		chunks = append(chunks, chunk.Bytes())
+		tags = append(tags,
+			tiffTag{259, 3, []uint16{1}},
+			tiffTag{278, 3, []uint16{height}},
+			tiffTag{284, 3, []uint16{1}},
+		)
+	} else {
+		const tile = 4
+		for band := 0; band < 2; band++ {
+			for ty := 0; ty < 2; ty++ {
+				for tx := 0; tx < 2; tx++ {
+					// Floating point predictor: big-endian bytes grouped by significance, then differenced
+					raw := make([]byte, tile*tile*4)
+					for row := 0; row < tile; row++ {
+						line := make([]byte, tile*4)
+						for col := 0; col < tile; col++ {
+							x, y := tx*tile+col, ty*tile+row
+							v := float32(0)
+							if x < width && y < height {
+								v = bands[band][y*width+x]
+							}
+							var be [4]byte
+							binary.BigEndian.PutUint32(be[:], math.Float32bits(v))
+							for b := 0; b < 4; b++ {
+								line[b*tile+col] = be[b]
+							}
+						}
+						for i := len(line) - 1; i > 0; i-- {
+							line[i] -= line[i-1]
+						}
+						copy(raw[row*tile*4:], line)
+					}
+					compressed := &bytes.Buffer{}
+					zw := zlib.NewWriter(compressed)
+					_, _ = zw.Write(raw)
+					_ = zw.Close()
+					chunks = append(chunks, compressed.Bytes())
+				}
+			}
+		}
+		tags = append(tags,
+			tiffTag{259, 3, []uint16{8}},
+			tiffTag{284, 3, []uint16{2}},
+			tiffTag{317, 3, []uint16{3}},
+			tiffTag{322, 3, []uint16{tile}},
+			tiffTag{323, 3, []uint16{tile}},
+		)
+	}
+
+	metadata := `<GDALMetadata>
+  <Item name="grid_name">SYNTHETIC</Item>
+  <Item name="DESCRIPTION" sample="0" role="description">longitude_offset</Item>
+  <Item name="positive_value" sample="0">west</Item>
+  <Item name="UNITTYPE" sample="0" role="unittype">arc-second</Item>
+  <Item name="DESCRIPTION" sample="1" role="description">latitude_offset</Item>
+  <Item name="UNITTYPE" sample="1" role="unittype">arc-second</Item>
+</GDALMetadata>` + "\x00"
+
+	// Data layout: header, chunks, out-of-line values and the directory
+	buf := &bytes.Buffer{}
+	buf.Write([]byte{'I', 'I', 42, 0, 0, 0, 0, 0})
+	offsets := make([]uint32, len(chunks))
+	counts := make([]uint32, len(chunks))
+	for i, c := range chunks {
+		offsets[i], counts[i] = uint32(buf.Len()), uint32(len(c))
+		buf.Write(c)
+	}
+	offsetTag, countTag := uint16(273), uint16(279)
+	if compressed {
+		offsetTag, countTag = 324, 325
+	}
+
+	tags = append(tags,
+		tiffTag{256, 3, []uint16{width}},
+		tiffTag{257, 3, []uint16{height}},
+		tiffTag{258, 3, []uint16{32, 32}},
+		tiffTag{277, 3, []uint16{2}},
+		tiffTag{339, 3, []uint16{3, 3}},
+		tiffTag{offsetTag, 4, offsets},
+		tiffTag{countTag, 4, counts},
+		// PixelIsPoint, with the first node at the tiepoint
+		tiffTag{33550, 12, []float64{0.5, 0.5, 0}},
+		tiffTag{33922, 12, []float64{0, 0, 0, -76, 42, 0}},
+		tiffTag{34735, 3, []uint16{1, 1, 0, 1, 1025, 0, 1, 2}},
+		tiffTag{42112, 2, []byte(metadata)},
+	)
+	// Tags must be sorted
+	for i := range tags {
+		for j := i + 1; j < len(tags); j++ {
+			if tags[j].tag < tags[i].tag {
+				tags[i], tags[j] = tags[j], tags[i]
+			}
+		}
+	}
+
+	entries := &bytes.Buffer{}
+	var extra []struct {
+		entry int
+		data  []byte
+	}
+	for i, tag := range tags {
+		value := &bytes.Buffer{}
+		_ = binary.Write(value, le, tag.values)
+		size := map[uint16]int{2: 1, 3: 2, 4: 4, 12: 8}[tag.typ]
+		_ = binary.Write(entries, le, []uint16{tag.tag, tag.typ})
+		_ = binary.Write(entries, le, uint32(value.Len()/size))
+		if value.Len() <= 4 {
+			padded := make([]byte, 4)
+			copy(padded, value.Bytes())
+			entries.Write(padded)
+		} else {
+			entries.Write(make([]byte, 4))
+			extra = append(extra, struct {
+				entry int
+				data  []byte
+			}{i, value.Bytes()})
+		}
+	}
+	entryBytes := entries.Bytes()
+	for _, e := range extra {
+		le.PutUint32(entryBytes[e.entry*12+8:], uint32(buf.Len()))
+		buf.Write(e.data)
+		if buf.Len()%2 == 1 {
+			buf.WriteByte(0)
+		}
+	}
+
+	ifdOffset := buf.Len()
+	_ = binary.Write(buf, le, uint16(len(tags)))
+	buf.Write(entryBytes)
+	buf.Write(make([]byte, 4))
+
+	data := buf.Bytes()
+	le.PutUint32(data[4:], uint32(ifdOffset))
+
+	return data
+}
+
+func TestReadGeoTIFF(t *testing.T) {
+	for name, compressed := range map[string]bool{"strips": false, "deflate_tiles": true} {
+		t.Run(name, func(t *testing.T) {
+			gs, err := gridshift.ReadGeoTIFF(bytes.NewReader(geoTIFFFile(compressed)))
+			assert.NoError(t, err)
+			assert.Len(t, gs, 1)
+			assert.Equal(t, "SYNTHETIC", gs[0].Name)
+			assert.EqualValues(t, 40, gs[0].South)
+			assert.EqualValues(t, -76, gs[0].West)
+
+			for _, p := range []geodesy.Point{{40, -76}, {41.1, -75.3}, {42, -74}} {
+				Δφ, Δλ, err := gs.Shift(p)
+				assert.NoError(t, err)
+				assert.InDelta(t, latShift(p.Lat(), p.Lon()), Δφ, 1e-5)
+				assert.InDelta(t, lonShift(p.Lat(), p.Lon()), Δλ, 1e-5)
+			}
+		})
+	}
+
+	t.Run("FAIL/not_tiff", func(t *testing.T) {
+		_, err := gridshift.ReadGeoTIFF(bytes.NewReader(ntv2File(binary.LittleEndian)))
+		assert.ErrorIs(t, err, gridshift.ErrFormat)
+	})
+}
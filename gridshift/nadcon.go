@@ -0,0 +1,222 @@
+package gridshift
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+)
+
+const (
+	nadconHeaderSize = 96 // 56-byte identifier, 8-byte program name, 3 integers and 5 reals
+	nadcon5KindReal  = 1
+)
+
+// LoadNADCON reads the pair of NADCON binary grid files (.las and .los) at the given paths
+func LoadNADCON(lasPath, losPath string) (GridSet, error) {
+	return loadPair(lasPath, losPath, ReadNADCON)
+}
+
+// ReadNADCON reads a grid in the binary NADCON format, as used by the US National Geodetic Survey
+// for the NAD27 to NAD83 conversion, from the latitude (.las) and longitude (.los) shift files.
+//
+// Each file is made of little-endian records of (cols + 1) 4-byte words: the first one holds the
+// header (identifier, program name, NC, NR, NZ, XMIN, DX, YMIN, DY and ANGLE) and each of the
+// following holds a row of shifts in arc-seconds, from south to north and from west to east,
+// preceded by an unused word. Longitude shifts are positive west
+func ReadNADCON(las, los io.Reader) (GridSet, error) {
+	latGrid, err := readNADCONFile(las)
+	if err != nil {
+		return nil, err
+	}
+	lonGrid, err := readNADCONFile(los)
+	if err != nil {
+		return nil, err
+	}
+	if !sameExtent(latGrid, lonGrid) {
+		return nil, fmt.Errorf("%w: NADCON latitude and longitude grids do not match", ErrFormat)
+	}
+
+	latGrid.lonShifts = lonGrid.latShifts
+	for i, v := range latGrid.lonShifts {
+		latGrid.lonShifts[i] = -v
+	}
+
+	return GridSet{latGrid}, nil
+}
+
+// readNADCONFile reads a single NADCON file, storing its values as latitude shifts
+func readNADCONFile(r io.Reader) (*Grid, error) {
+	header := make([]byte, nadconHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: reading NADCON header: %v", ErrFormat, err)
+	}
+
+	le := binary.LittleEndian
+	cols := int(int32(le.Uint32(header[64:])))
+	rows := int(int32(le.Uint32(header[68:])))
+	if cols <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("%w: invalid NADCON grid size %dx%d", ErrFormat, cols, rows)
+	}
+
+	g := &Grid{
+		Name:    strings.TrimRight(string(header[:56]), " \x00"),
+		West:    float64(math.Float32frombits(le.Uint32(header[76:]))),
+		LonStep: float64(math.Float32frombits(le.Uint32(header[80:]))),
+		South:   float64(math.Float32frombits(le.Uint32(header[84:]))),
+		LatStep: float64(math.Float32frombits(le.Uint32(header[88:]))),
+		Rows:    rows,
+		Cols:    cols,
+	}
+
+	recordSize := 4 * (cols + 1)
+	if recordSize < nadconHeaderSize {
+		return nil, fmt.Errorf("%w: NADCON records too short for the header", ErrFormat)
+	}
+	// Skip the remainder of the header record
+	if _, err := io.CopyN(ioutil.Discard, r, int64(recordSize-nadconHeaderSize)); err != nil {
+		return nil, fmt.Errorf("%w: reading NADCON header: %v", ErrFormat, err)
+	}
+
+	record := make([]byte, recordSize)
+	g.latShifts = make([]float32, rows*cols)
+	for row := 0; row < rows; row++ {
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, fmt.Errorf("%w: reading NADCON row %d: %v", ErrFormat, row, err)
+		}
+		for col := 0; col < cols; col++ {
+			g.latShifts[row*cols+col] = math.Float32frombits(le.Uint32(record[4*(col+1):]))
+		}
+	}
+
+	return g, nil
+}
+
+// LoadNADCON5 reads the pair of NADCON5 binary grid files (.b) for latitude
+// and longitude shifts at the given paths
+func LoadNADCON5(latPath, lonPath string) (GridSet, error) {
+	return loadPair(latPath, lonPath, ReadNADCON5)
+}
+
+// ReadNADCON5 reads a grid in the binary NADCON5 format, as published by the US National Geodetic
+// Survey, from the latitude and longitude shift files.
+//
+// Each file is a big-endian Fortran unformatted sequential file: a header record holding the
+// south-west node latitude and longitude (with longitudes in the [0, 360) range), the latitude
+// and longitude spacing (all in degrees), the amount of rows and columns and the kind of values,
+// followed by a record for each row of values from south to north and from west to east.
+// Only real (4-byte floating point) values in arc-seconds, positive north and east, are supported
+func ReadNADCON5(lat, lon io.Reader) (GridSet, error) {
+	latGrid, err := readNADCON5File(lat)
+	if err != nil {
+		return nil, err
+	}
+	lonGrid, err := readNADCON5File(lon)
+	if err != nil {
+		return nil, err
+	}
+	if !sameExtent(latGrid, lonGrid) {
+		return nil, fmt.Errorf("%w: NADCON5 latitude and longitude grids do not match", ErrFormat)
+	}
+
+	latGrid.lonShifts = lonGrid.latShifts
+
+	return GridSet{latGrid}, nil
+}
+
+// readNADCON5File reads a single NADCON5 file, storing its values as latitude shifts
+func readNADCON5File(r io.Reader) (*Grid, error) {
+	be := binary.BigEndian
+
+	header, err := readFortranRecord(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading NADCON5 header: %v", ErrFormat, err)
+	}
+	if len(header) < 28 {
+		return nil, fmt.Errorf("%w: NADCON5 header too short", ErrFormat)
+	}
+
+	g := &Grid{
+		South:   float64(math.Float32frombits(be.Uint32(header[0:]))),
+		West:    float64(math.Float32frombits(be.Uint32(header[4:]))),
+		LatStep: float64(math.Float32frombits(be.Uint32(header[8:]))),
+		LonStep: float64(math.Float32frombits(be.Uint32(header[12:]))),
+		Rows:    int(int32(be.Uint32(header[16:]))),
+		Cols:    int(int32(be.Uint32(header[20:]))),
+	}
+	if kind := int32(be.Uint32(header[24:])); kind != nadcon5KindReal {
+		return nil, fmt.Errorf("%w: unsupported NADCON5 value kind %d", ErrFormat, kind)
+	}
+	if g.Rows <= 0 || g.Cols <= 0 {
+		return nil, fmt.Errorf("%w: invalid NADCON5 grid size %dx%d", ErrFormat, g.Cols, g.Rows)
+	}
+	if g.West > 180 {
+		g.West -= 360
+	}
+
+	g.latShifts = make([]float32, g.Rows*g.Cols)
+	for row := 0; row < g.Rows; row++ {
+		record, err := readFortranRecord(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading NADCON5 row %d: %v", ErrFormat, row, err)
+		}
+		if len(record) != 4*g.Cols {
+			return nil, fmt.Errorf("%w: NADCON5 row %d has %d bytes, expected %d", ErrFormat, row, len(record), 4*g.Cols)
+		}
+		for col := 0; col < g.Cols; col++ {
+			g.latShifts[row*g.Cols+col] = math.Float32frombits(be.Uint32(record[4*col:]))
+		}
+	}
+
+	return g, nil
+}
+
+// readFortranRecord reads a big-endian Fortran unformatted sequential record,
+// which is enclosed by its length in bytes
+func readFortranRecord(r io.Reader) ([]byte, error) {
+	var marker [4]byte
+	if _, err := io.ReadFull(r, marker[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(marker[:])
+
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, marker[:]); err != nil {
+		return nil, err
+	}
+	if binary.BigEndian.Uint32(marker[:]) != size {
+		return nil, errors.New("mismatched record markers")
+	}
+
+	return record, nil
+}
+
+func loadPair(latPath, lonPath string, read func(lat, lon io.Reader) (GridSet, error)) (GridSet, error) {
+	lat, err := os.Open(latPath)
+	if err != nil {
+		return nil, err
+	}
+	defer lat.Close()
+
+	lon, err := os.Open(lonPath)
+	if err != nil {
+		return nil, err
+	}
+	defer lon.Close()
+
+	return read(bufio.NewReader(lat), bufio.NewReader(lon))
+}
+
+func sameExtent(g1, g2 *Grid) bool {
+	return g1.Rows == g2.Rows && g1.Cols == g2.Cols &&
+		g1.South == g2.South && g1.West == g2.West &&
+		g1.LatStep == g2.LatStep && g1.LonStep == g2.LonStep
+}
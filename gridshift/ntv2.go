@@ -0,0 +1,171 @@
+package gridshift
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+const (
+	ntv2RecordSize   = 16
+	ntv2HeaderFields = 11
+	ntv2NoParent     = "NONE"
+)
+
+// LoadNTv2 reads the NTv2 grid shift file (.gsb) at path
+func LoadNTv2(path string) (GridSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadNTv2(bufio.NewReader(f))
+}
+
+// ReadNTv2 reads a grid set in the NTv2 (National Transformation version 2) binary format,
+// as published by Natural Resources Canada and adopted by several national agencies.
+//
+// The file consists of 16-byte records: an overview header, followed by a header and the node
+// records of each subgrid. Subgrids are nested through their parent name, and nodes are stored from
+// south to north and from east to west, with longitudes (and their shifts) positive west.
+// Both byte orders are supported, and are detected through the value of the NUM_OREC field.
+func ReadNTv2(r io.Reader) (GridSet, error) {
+	header := make([]byte, ntv2HeaderFields*ntv2RecordSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: reading NTv2 overview header: %v", ErrFormat, err)
+	}
+	if !strings.HasPrefix(string(header), "NUM_OREC") {
+		return nil, fmt.Errorf("%w: missing NTv2 overview header", ErrFormat)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if binary.LittleEndian.Uint32(header[8:]) != ntv2HeaderFields {
+		order = binary.BigEndian
+	}
+	if order.Uint32(header[8:]) != ntv2HeaderFields {
+		return nil, fmt.Errorf("%w: unexpected NTv2 overview header size", ErrFormat)
+	}
+
+	overview := ntv2Records{data: header, order: order}
+	count := int(overview.int32(2))
+	unit, err := ntv2Unit(overview.string(3))
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Grid{}
+	var set GridSet
+	for n := 0; n < count; n++ {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, fmt.Errorf("%w: reading NTv2 subgrid header: %v", ErrFormat, err)
+		}
+		if !strings.HasPrefix(string(header), "SUB_NAME") {
+			return nil, fmt.Errorf("%w: missing NTv2 subgrid header", ErrFormat)
+		}
+
+		sub := ntv2Records{data: header, order: order}
+		g, err := readNTv2Subgrid(r, sub, unit)
+		if err != nil {
+			return nil, err
+		}
+
+		parent := sub.string(1)
+		if p, ok := byName[parent]; ok && !strings.EqualFold(parent, ntv2NoParent) {
+			p.Children = append(p.Children, g)
+		} else {
+			set = append(set, g)
+		}
+		byName[g.Name] = g
+	}
+
+	return set, nil
+}
+
+func readNTv2Subgrid(r io.Reader, rec ntv2Records, unit float64) (*Grid, error) {
+	southLat := rec.float64(4) * unit
+	northLat := rec.float64(5) * unit
+	eastLon := rec.float64(6) * unit // positive west
+	westLon := rec.float64(7) * unit // positive west
+	latInc := rec.float64(8) * unit
+	lonInc := rec.float64(9) * unit
+	nodes := int(rec.int32(10))
+
+	if latInc <= 0 || lonInc <= 0 {
+		return nil, fmt.Errorf("%w: invalid NTv2 node spacing", ErrFormat)
+	}
+
+	g := &Grid{
+		Name:    rec.string(0),
+		South:   southLat,
+		West:    -westLon,
+		LatStep: latInc,
+		LonStep: lonInc,
+		Rows:    int(math.Round((northLat-southLat)/latInc)) + 1,
+		Cols:    int(math.Round((westLon-eastLon)/lonInc)) + 1,
+	}
+	if g.Rows*g.Cols != nodes {
+		return nil, fmt.Errorf("%w: NTv2 subgrid %q has %d nodes, expected %d", ErrFormat, g.Name, nodes, g.Rows*g.Cols)
+	}
+
+	data := make([]byte, nodes*ntv2RecordSize)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("%w: reading NTv2 subgrid %q: %v", ErrFormat, g.Name, err)
+	}
+
+	// Shifts are kept in arc-seconds, whatever the unit of the file headers
+	shiftUnit := unit * secondsPerDegree
+	g.latShifts = make([]float32, nodes)
+	g.lonShifts = make([]float32, nodes)
+	for row := 0; row < g.Rows; row++ {
+		for col := 0; col < g.Cols; col++ {
+			node := data[(row*g.Cols+col)*ntv2RecordSize:]
+			// Flip columns, as nodes are stored from east to west
+			i := row*g.Cols + (g.Cols - 1 - col)
+			g.latShifts[i] = float32(float64(math.Float32frombits(rec.order.Uint32(node[0:]))) * shiftUnit)
+			g.lonShifts[i] = float32(-float64(math.Float32frombits(rec.order.Uint32(node[4:]))) * shiftUnit)
+		}
+	}
+
+	return g, nil
+}
+
+// ntv2Unit returns the factor converting the angular unit of a GS_TYPE field into degrees
+func ntv2Unit(gsType string) (float64, error) {
+	switch strings.ToUpper(gsType) {
+	case "SECONDS":
+		return 1.0 / secondsPerDegree, nil
+	case "MINUTES":
+		return 1.0 / 60, nil
+	case "DEGREES":
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("%w: unsupported NTv2 GS_TYPE %q", ErrFormat, gsType)
+}
+
+// ntv2Records decodes the values of a header made of 16-byte records
+type ntv2Records struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (r ntv2Records) value(i int) []byte {
+	return r.data[i*ntv2RecordSize+8 : (i+1)*ntv2RecordSize]
+}
+
+func (r ntv2Records) string(i int) string {
+	return strings.TrimRight(string(r.value(i)), " \x00")
+}
+
+func (r ntv2Records) int32(i int) int32 {
+	return int32(r.order.Uint32(r.value(i)))
+}
+
+func (r ntv2Records) float64(i int) float64 {
+	return math.Float64frombits(r.order.Uint64(r.value(i)))
+}
@@ -0,0 +1,339 @@
+package gridshift
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+)
+
+// TIFF tags used by grid files
+const (
+	tagNewSubfileType      = 254
+	tagImageWidth          = 256
+	tagImageLength         = 257
+	tagBitsPerSample       = 258
+	tagCompression         = 259
+	tagStripOffsets        = 273
+	tagSamplesPerPixel     = 277
+	tagRowsPerStrip        = 278
+	tagStripByteCounts     = 279
+	tagPlanarConfiguration = 284
+	tagPredictor           = 317
+	tagTileWidth           = 322
+	tagTileLength          = 323
+	tagTileOffsets         = 324
+	tagTileByteCounts      = 325
+	tagSampleFormat        = 339
+	tagModelPixelScale     = 33550
+	tagModelTiepoint       = 33922
+	tagGeoKeyDirectory     = 34735
+	tagGDALMetadata        = 42112
+)
+
+const (
+	compressionNone         = 1
+	compressionDeflate      = 8
+	compressionAdobeDeflate = 32946
+
+	predictorNone          = 1
+	predictorFloatingPoint = 3
+
+	planarChunky   = 1
+	planarSeparate = 2
+
+	sampleFormatFloat = 3
+
+	subfileReducedResolution = 1
+	subfileMask              = 4
+)
+
+// tiffTypeSizes holds the size in bytes of each TIFF field type
+var tiffTypeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// tiffIFD represents a TIFF image file directory, holding the raw value of each field
+type tiffIFD struct {
+	order  binary.ByteOrder
+	fields map[uint16]tiffField
+}
+
+type tiffField struct {
+	typ   uint16
+	count int
+	data  []byte
+}
+
+// readTIFF reads every image file directory of a classic (non BigTIFF) TIFF file
+func readTIFF(r io.ReaderAt) ([]tiffIFD, error) {
+	var header [8]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, fmt.Errorf("%w: reading TIFF header: %v", ErrFormat, err)
+	}
+
+	var order binary.ByteOrder
+	switch string(header[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: not a TIFF file", ErrFormat)
+	}
+	if order.Uint16(header[2:]) != 42 {
+		return nil, fmt.Errorf("%w: unsupported TIFF version", ErrFormat)
+	}
+
+	var ifds []tiffIFD
+	visited := map[uint32]bool{}
+	for offset := order.Uint32(header[4:]); offset != 0; {
+		if visited[offset] {
+			return nil, fmt.Errorf("%w: cyclic TIFF directories", ErrFormat)
+		}
+		visited[offset] = true
+
+		ifd, next, err := readIFD(r, order, offset)
+		if err != nil {
+			return nil, err
+		}
+		ifds = append(ifds, ifd)
+		offset = next
+	}
+
+	return ifds, nil
+}
+
+func readIFD(r io.ReaderAt, order binary.ByteOrder, offset uint32) (tiffIFD, uint32, error) {
+	var countBuf [2]byte
+	if _, err := r.ReadAt(countBuf[:], int64(offset)); err != nil {
+		return tiffIFD{}, 0, fmt.Errorf("%w: reading TIFF directory: %v", ErrFormat, err)
+	}
+	count := int(order.Uint16(countBuf[:]))
+
+	entries := make([]byte, count*12+4)
+	if _, err := r.ReadAt(entries, int64(offset)+2); err != nil {
+		return tiffIFD{}, 0, fmt.Errorf("%w: reading TIFF directory: %v", ErrFormat, err)
+	}
+
+	ifd := tiffIFD{order: order, fields: map[uint16]tiffField{}}
+	for i := 0; i < count; i++ {
+		entry := entries[i*12:]
+		tag := order.Uint16(entry)
+		f := tiffField{typ: order.Uint16(entry[2:]), count: int(order.Uint32(entry[4:]))}
+
+		size, ok := tiffTypeSizes[f.typ]
+		if !ok {
+			continue // Unknown field types are skipped, as mandated by the specification
+		}
+		if size*f.count <= 4 {
+			f.data = entry[8 : 8+size*f.count]
+		} else {
+			f.data = make([]byte, size*f.count)
+			if _, err := r.ReadAt(f.data, int64(order.Uint32(entry[8:]))); err != nil {
+				return tiffIFD{}, 0, fmt.Errorf("%w: reading TIFF tag %d: %v", ErrFormat, tag, err)
+			}
+		}
+		ifd.fields[tag] = f
+	}
+
+	return ifd, order.Uint32(entries[count*12:]), nil
+}
+
+// uints returns the values of an unsigned integer field
+func (d tiffIFD) uints(tag uint16) []uint64 {
+	f, ok := d.fields[tag]
+	if !ok {
+		return nil
+	}
+
+	values := make([]uint64, f.count)
+	for i := range values {
+		switch f.typ {
+		case 1, 7:
+			values[i] = uint64(f.data[i])
+		case 3:
+			values[i] = uint64(d.order.Uint16(f.data[2*i:]))
+		case 4:
+			values[i] = uint64(d.order.Uint32(f.data[4*i:]))
+		default:
+			return nil
+		}
+	}
+
+	return values
+}
+
+// uint returns the first value of an unsigned integer field, or def if it is not present
+func (d tiffIFD) uint(tag uint16, def uint64) uint64 {
+	if values := d.uints(tag); len(values) > 0 {
+		return values[0]
+	}
+
+	return def
+}
+
+// floats returns the values of a floating point field
+func (d tiffIFD) floats(tag uint16) []float64 {
+	f, ok := d.fields[tag]
+	if !ok {
+		return nil
+	}
+
+	values := make([]float64, f.count)
+	for i := range values {
+		switch f.typ {
+		case 11:
+			values[i] = float64(math.Float32frombits(d.order.Uint32(f.data[4*i:])))
+		case 12:
+			values[i] = math.Float64frombits(d.order.Uint64(f.data[8*i:]))
+		default:
+			return nil
+		}
+	}
+
+	return values
+}
+
+// ascii returns the value of a text field
+func (d tiffIFD) ascii(tag uint16) string {
+	f, ok := d.fields[tag]
+	if !ok || f.typ != 2 {
+		return ""
+	}
+
+	return string(bytes.TrimRight(f.data, "\x00"))
+}
+
+// bands decodes the floating point samples of the image described by d, returning
+// each band in row-major order from the top left pixel
+func (d tiffIFD) bands(r io.ReaderAt) ([][]float64, error) {
+	width := int(d.uint(tagImageWidth, 0))
+	height := int(d.uint(tagImageLength, 0))
+	spp := int(d.uint(tagSamplesPerPixel, 1))
+	bps := int(d.uint(tagBitsPerSample, 32)) / 8
+	if width == 0 || height == 0 || spp == 0 {
+		return nil, fmt.Errorf("%w: invalid TIFF image size", ErrFormat)
+	}
+	if d.uint(tagSampleFormat, 1) != sampleFormatFloat || (bps != 4 && bps != 8) {
+		return nil, fmt.Errorf("%w: only floating point TIFF samples are supported", ErrFormat)
+	}
+
+	compression := d.uint(tagCompression, compressionNone)
+	if compression != compressionNone && compression != compressionDeflate && compression != compressionAdobeDeflate {
+		return nil, fmt.Errorf("%w: unsupported TIFF compression %d", ErrFormat, compression)
+	}
+	predictor := d.uint(tagPredictor, predictorNone)
+	if predictor != predictorNone && predictor != predictorFloatingPoint {
+		return nil, fmt.Errorf("%w: unsupported TIFF predictor %d", ErrFormat, predictor)
+	}
+
+	// Chunks are either tiles or strips spanning the whole width
+	chunkWidth, chunkHeight := width, int(d.uint(tagRowsPerStrip, uint64(height)))
+	offsets, counts := d.uints(tagStripOffsets), d.uints(tagStripByteCounts)
+	if _, tiled := d.fields[tagTileWidth]; tiled {
+		chunkWidth, chunkHeight = int(d.uint(tagTileWidth, 0)), int(d.uint(tagTileLength, 0))
+		offsets, counts = d.uints(tagTileOffsets), d.uints(tagTileByteCounts)
+	}
+	if chunkWidth == 0 || chunkHeight == 0 {
+		return nil, fmt.Errorf("%w: invalid TIFF chunk size", ErrFormat)
+	}
+	across := (width + chunkWidth - 1) / chunkWidth
+	down := (height + chunkHeight - 1) / chunkHeight
+
+	planes, samples := 1, spp
+	if d.uint(tagPlanarConfiguration, planarChunky) == planarSeparate {
+		planes, samples = spp, 1
+	}
+	if len(offsets) < planes*across*down || len(counts) < len(offsets) {
+		return nil, fmt.Errorf("%w: missing TIFF chunks", ErrFormat)
+	}
+
+	bands := make([][]float64, spp)
+	for b := range bands {
+		bands[b] = make([]float64, width*height)
+	}
+
+	for plane := 0; plane < planes; plane++ {
+		for cy := 0; cy < down; cy++ {
+			for cx := 0; cx < across; cx++ {
+				i := (plane*down+cy)*across + cx
+				chunk, err := d.chunk(r, offsets[i], counts[i], compression)
+				if err != nil {
+					return nil, err
+				}
+
+				rowSize := chunkWidth * samples * bps
+				rows := len(chunk) / rowSize
+				order := d.order
+				if predictor == predictorFloatingPoint {
+					order = binary.BigEndian
+					for row := 0; row < rows; row++ {
+						undoFloatingPointPredictor(chunk[row*rowSize:(row+1)*rowSize], samples, bps)
+					}
+				}
+
+				for row := 0; row < rows && cy*chunkHeight+row < height; row++ {
+					for col := 0; col < chunkWidth && cx*chunkWidth+col < width; col++ {
+						pixel := (cy*chunkHeight+row)*width + cx*chunkWidth + col
+						for s := 0; s < samples; s++ {
+							value := chunk[row*rowSize+(col*samples+s)*bps:]
+							if bps == 4 {
+								bands[plane+s][pixel] = float64(math.Float32frombits(order.Uint32(value)))
+							} else {
+								bands[plane+s][pixel] = math.Float64frombits(order.Uint64(value))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return bands, nil
+}
+
+// chunk reads and decompresses a strip or tile
+func (d tiffIFD) chunk(r io.ReaderAt, offset, count uint64, compression uint64) ([]byte, error) {
+	raw := make([]byte, count)
+	if _, err := r.ReadAt(raw, int64(offset)); err != nil {
+		return nil, fmt.Errorf("%w: reading TIFF chunk: %v", ErrFormat, err)
+	}
+	if compression == compressionNone {
+		return raw, nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decompressing TIFF chunk: %v", ErrFormat, err)
+	}
+	defer zr.Close()
+
+	data, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decompressing TIFF chunk: %v", ErrFormat, err)
+	}
+
+	return data, nil
+}
+
+// undoFloatingPointPredictor reverts the floating point predictor (TIFF Technical Note 3) of a row,
+// which stores the bytes of its values as byte-wise differences, grouped by significance.
+// The decoded values are left in big-endian order
+func undoFloatingPointPredictor(row []byte, samples, bps int) {
+	for i := samples; i < len(row); i++ {
+		row[i] += row[i-samples]
+	}
+
+	values := len(row) / bps
+	planes := make([]byte, len(row))
+	copy(planes, row)
+	for v := 0; v < values; v++ {
+		for b := 0; b < bps; b++ {
+			row[v*bps+b] = planes[b*values+v]
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package hexgrid
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+// numBaseCells is the amount of cells of resolution 0: 12 pentagons centered on the vertices of the
+// icosahedron and 110 hexagons, centered on its faces (20), around the centers of its faces (60) and
+// on the midpoints of its edges (30)
+const numBaseCells = 122
+
+// baseCell describes a cell of resolution 0
+type baseCell struct {
+	// home holds the face whose grid orients the digits of the descendants of the cell, and the
+	// coordinates of the cell in it
+	home     faceIJK
+	pentagon bool
+	// sectors holds the faces around pentagons in clockwise order starting from their home face,
+	// along with the coordinates of the pentagon in each of them
+	sectors [5]faceIJK
+}
+
+// faceBaseCell describes the cell of resolution 0 at some coordinates of a face, along with the
+// counter-clockwise 60° rotations from the grid of the face to the one of the home face of the cell,
+// which skip the deleted k axes subsequence for pentagons. For pentagons, cwOffset reports whether the
+// descendants falling on the k axes subsequence in the grid of the face are rotated clockwise out of
+// it, rather than counter-clockwise
+type faceBaseCell struct {
+	baseCell int
+	ccwRot60 int
+	cwOffset bool
+}
+
+var (
+	baseCells = newBaseCells()
+	// faceBaseCells holds the cells of resolution 0 at the normalized coordinates of each face whose
+	// components range from 0 to 2, which extend beyond the face into its neighbors
+	faceBaseCells = newFaceBaseCells()
+)
+
+// res0Coords holds the coordinates of the resolution 0 cells of a face: its center, the ones around
+// it, the midpoints of its edges and its vertices
+var res0Coords = []coordIJK{
+	{0, 0, 0},
+	{1, 0, 0}, {0, 1, 0}, {0, 0, 1},
+	{1, 1, 0}, {0, 1, 1}, {1, 0, 1},
+	{2, 0, 0}, {0, 2, 0}, {0, 0, 2},
+}
+
+// homeFaces holds the home face of each base cell, which is a convention of H3 rather than a property
+// of the geometry of the icosahedron
+var homeFaces = [numBaseCells]int{
+	1, 2, 1, 2, 0, 1, 1, 2, 0, 2, 1, 1, 3, 3, 11, 4, 0, 6, 0, 2,
+	7, 2, 0, 6, 10, 6, 3, 11, 4, 3, 0, 4, 5, 0, 7, 11, 7, 10, 12, 6,
+	7, 4, 3, 3, 4, 6, 11, 8, 5, 14, 5, 12, 10, 4, 12, 7, 11, 10, 13, 10,
+	11, 9, 8, 6, 8, 9, 14, 5, 16, 8, 5, 12, 7, 12, 10, 9, 13, 16, 15, 15,
+	16, 14, 13, 5, 8, 14, 9, 14, 17, 12, 16, 17, 15, 16, 9, 15, 13, 8, 13, 17,
+	19, 14, 19, 17, 13, 17, 16, 9, 15, 15, 18, 18, 19, 17, 19, 18, 18, 19, 19, 18,
+	19, 18,
+}
+
+// newBaseCells computes the base cells from the geometry of the icosahedron. They are numbered by
+// the latitude of their centers from north to south, and then by their longitude from west to east,
+// which is the numbering of H3
+func newBaseCells() [numBaseCells]baseCell {
+	type occurrence struct {
+		center sphere.Vector
+		at     []faceIJK
+	}
+	var cells []*occurrence
+	for f := 0; f < numFaces; f++ {
+		for _, c := range res0Coords {
+			h := faceIJK{face: f, coord: c}
+			v := h.vector(0)
+			found := false
+			for _, o := range cells {
+				if o.center.Dot(v) > 1-1e-12 {
+					o.at = append(o.at, h)
+					found = true
+					break
+				}
+			}
+			if !found {
+				cells = append(cells, &occurrence{center: v, at: []faceIJK{h}})
+			}
+		}
+	}
+	if len(cells) != numBaseCells {
+		panic("hexgrid: invalid base cell geometry")
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		pi, pj := cells[i].center.Point(), cells[j].center.Point()
+		if pi.Lat() != pj.Lat() {
+			return pi.Lat() > pj.Lat()
+		}
+		return pi.Lon() < pj.Lon()
+	})
+
+	var bcs [numBaseCells]baseCell
+	for n, o := range cells {
+		var home faceIJK
+		for _, h := range o.at {
+			if h.face == homeFaces[n] {
+				home = h
+			}
+		}
+		bcs[n] = baseCell{home: home, pentagon: len(o.at) == 5}
+		if !bcs[n].pentagon {
+			continue
+		}
+
+		// Sort the faces around the pentagon by the azimuth of their centers from it
+		north, east := tangent(o.center)
+		azimuth := func(h faceIJK) float64 {
+			c := frames[h.face].center
+			return posAngle(math.Atan2(c.Dot(east), c.Dot(north)))
+		}
+		sectors := append([]faceIJK(nil), o.at...)
+		sort.Slice(sectors, func(i, j int) bool {
+			return posAngle(azimuth(sectors[i])-azimuth(home)) < posAngle(azimuth(sectors[j])-azimuth(home))
+		})
+		copy(bcs[n].sectors[:], sectors)
+	}
+
+	return bcs
+}
+
+// tangent returns the unit vectors pointing north and east from v
+func tangent(v sphere.Vector) (north, east sphere.Vector) {
+	φ := math.Atan2(v[2], math.Hypot(v[0], v[1]))
+	λ := math.Atan2(v[1], v[0])
+	sinφ, cosφ := math.Sincos(φ)
+	sinλ, cosλ := math.Sincos(λ)
+
+	return sphere.Vector{-sinφ * cosλ, -sinφ * sinλ, cosφ}, sphere.Vector{-sinλ, cosλ, 0}
+}
+
+// baseCellAt returns the base cell centered at v
+func baseCellAt(v sphere.Vector) int {
+	for n := range baseCells {
+		if baseCells[n].home.vector(0).Dot(v) > 1-1e-12 {
+			return n
+		}
+	}
+	panic("hexgrid: no base cell at coordinates")
+}
+
+// pentagonRotations returns the counter-clockwise 60° rotations from the grid of the face of sector
+// s of a pentagon to the grid of its home face. They move the digit pointing into the face from the
+// pentagon to the digit of the sector, which are the digits of the home face clockwise from the jk
+// axes, and skip the deleted k axes subsequence as the rotations of the digits of its descendants do
+func pentagonRotations(sector faceIJK, s int) int {
+	unit := coordIJK{sector.coord.i / 2, sector.coord.j / 2, sector.coord.k / 2}
+	d := coordIJK{}.sub(unit).normalize().digit()
+	target := jkAxesDigit
+	for n := 0; n < s; n++ {
+		target = rotateDigit60cw(target)
+	}
+
+	rot := 0
+	for ; d != target; rot++ {
+		if d = rotateDigit60ccw(d); d == kAxesDigit {
+			d = rotateDigit60ccw(d)
+		}
+	}
+	return rot
+}
+
+// rotations returns the counter-clockwise 60° rotations from the grid of the face of h, which holds
+// the base cell bc, to the grid of its home face. For pentagons, it also returns whether the
+// descendants on the deleted k axes subsequence of the face are rotated clockwise rather than
+// counter-clockwise before that, which is the case of the faces with the pentagon at their j vertex
+func rotations(h faceIJK, bc int) (int, bool) {
+	home := baseCells[bc].home
+	if baseCells[bc].pentagon {
+		for s, sector := range baseCells[bc].sectors {
+			if sector.face == h.face {
+				return pentagonRotations(sector, s), sector.coord.j > 0
+			}
+		}
+	}
+	if h.face == home.face {
+		return 0, false
+	}
+	for q := ij; q <= jk; q++ {
+		if orient := faceNeighbors[h.face][q]; orient.face == home.face {
+			return orient.ccwRot60, false
+		}
+	}
+	panic("hexgrid: base cell not adjacent to its home face")
+}
+
+func newFaceBaseCells() map[faceIJK]faceBaseCell {
+	cells := make(map[faceIJK]faceBaseCell)
+	for f := 0; f < numFaces; f++ {
+		for i := 0; i <= 2; i++ {
+			for j := 0; j <= 2; j++ {
+				for k := 0; k <= 2; k++ {
+					c := coordIJK{i, j, k}.normalize()
+					h := faceIJK{face: f, coord: c}
+					if _, ok := cells[h]; ok {
+						continue
+					}
+
+					// Move beyond the face as needed, tracking the rotations of the grids
+					adjusted, rot := h, 0
+					for n := 0; n < 3; n++ {
+						face := adjusted.face
+						if adjusted.adjustOverageClassII(0, false, false) == noOverage {
+							break
+						}
+						for q := ij; q <= jk; q++ {
+							if faceNeighbors[face][q].face == adjusted.face {
+								rot += faceNeighbors[face][q].ccwRot60
+							}
+						}
+					}
+					bc := baseCellAt(adjusted.vector(0))
+					ccwRot60, cwOffset := rotations(adjusted, bc)
+					cells[h] = faceBaseCell{baseCell: bc, ccwRot60: (rot + ccwRot60) % 6, cwOffset: cwOffset}
+				}
+			}
+		}
+	}
+
+	return cells
+}
+
+// newFaceNeighbors computes the neighbors of each face, and the orientation of their grids, from the
+// vertices they share
+func newFaceNeighbors() [numFaces][4]faceOrient {
+	// Identify the vertices of the faces, at the end of their axes
+	var ids [numFaces][3]int
+	var vertices []sphere.Vector
+	axes := [3]coordIJK{{2, 0, 0}, {0, 2, 0}, {0, 0, 2}}
+	for f := 0; f < numFaces; f++ {
+		for a, axis := range axes {
+			x, y := axis.hex2d()
+			v := fromHex2d(x, y, f, 0, false)
+			ids[f][a] = -1
+			for id, w := range vertices {
+				if w.Dot(v) > 1-1e-12 {
+					ids[f][a] = id
+				}
+			}
+			if ids[f][a] < 0 {
+				ids[f][a] = len(vertices)
+				vertices = append(vertices, v)
+			}
+		}
+	}
+
+	var neighbors [numFaces][4]faceOrient
+	edges := [4][2]int{ij: {0, 1}, ki: {2, 0}, jk: {1, 2}}
+	for f := 0; f < numFaces; f++ {
+		neighbors[f][0] = faceOrient{face: f}
+		for q := ij; q <= jk; q++ {
+			a1, a2 := edges[q][0], edges[q][1]
+			for g := 0; g < numFaces; g++ {
+				b1, b2 := axisOf(ids[g], ids[f][a1]), axisOf(ids[g], ids[f][a2])
+				if g == f || b1 < 0 || b2 < 0 {
+					continue
+				}
+				// Find the rotation mapping the shared edge of f to the one of g
+				for rot := 0; rot < 6; rot++ {
+					r1, r2 := axes[a1], axes[a2]
+					for n := 0; n < rot; n++ {
+						r1, r2 = r1.rotate60ccw(), r2.rotate60ccw()
+					}
+					if r2.sub(r1).normalize() == axes[b2].sub(axes[b1]).normalize() {
+						neighbors[f][q] = faceOrient{face: g, translate: axes[b1].sub(r1).normalize(), ccwRot60: rot}
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return neighbors
+}
+
+func axisOf(ids [3]int, id int) int {
+	for a := range ids {
+		if ids[a] == id {
+			return a
+		}
+	}
+	return -1
+}
@@ -0,0 +1,270 @@
+package hexgrid
+
+import "math"
+
+// coordIJK represents a hexagon of a planar grid by its coordinates along three axes 120° apart,
+// where i, j and k grow counter-clockwise. Normalized coordinates are non-negative, with at least
+// one of them being 0
+type coordIJK struct {
+	i, j, k int
+}
+
+// Digits identify the 7 children of a cell, as the unit vectors from the center child to each of
+// them. The k axis digit is deleted from the descendants of pentagons
+const (
+	centerDigit  = 0
+	kAxesDigit   = 1
+	jAxesDigit   = 2
+	jkAxesDigit  = 3
+	iAxesDigit   = 4
+	ikAxesDigit  = 5
+	ijAxesDigit  = 6
+	invalidDigit = 7
+)
+
+// unitVecs holds the unit vector of each digit
+var unitVecs = [7]coordIJK{
+	{0, 0, 0},
+	{0, 0, 1},
+	{0, 1, 0},
+	{0, 1, 1},
+	{1, 0, 0},
+	{1, 0, 1},
+	{1, 1, 0},
+}
+
+func (c coordIJK) add(o coordIJK) coordIJK {
+	return coordIJK{c.i + o.i, c.j + o.j, c.k + o.k}
+}
+
+func (c coordIJK) sub(o coordIJK) coordIJK {
+	return coordIJK{c.i - o.i, c.j - o.j, c.k - o.k}
+}
+
+func (c coordIJK) scale(factor int) coordIJK {
+	return coordIJK{c.i * factor, c.j * factor, c.k * factor}
+}
+
+func (c coordIJK) normalize() coordIJK {
+	if c.i < 0 {
+		c.j -= c.i
+		c.k -= c.i
+		c.i = 0
+	}
+	if c.j < 0 {
+		c.i -= c.j
+		c.k -= c.j
+		c.j = 0
+	}
+	if c.k < 0 {
+		c.i -= c.k
+		c.j -= c.k
+		c.k = 0
+	}
+	min := c.i
+	if c.j < min {
+		min = c.j
+	}
+	if c.k < min {
+		min = c.k
+	}
+
+	return coordIJK{c.i - min, c.j - min, c.k - min}
+}
+
+// digit returns the digit of the unit vector c, or invalidDigit if c is not one
+func (c coordIJK) digit() int {
+	c = c.normalize()
+	for d, v := range unitVecs {
+		if c == v {
+			return d
+		}
+	}
+
+	return invalidDigit
+}
+
+// neighbor returns the hexagon adjacent to c in the direction of digit
+func (c coordIJK) neighbor(digit int) coordIJK {
+	if digit <= centerDigit || digit >= invalidDigit {
+		return c
+	}
+	return c.add(unitVecs[digit]).normalize()
+}
+
+// combine returns the normalized sum of the vectors vi, vj and vk scaled by the coordinates of c
+func (c coordIJK) combine(vi, vj, vk coordIJK) coordIJK {
+	return vi.scale(c.i).add(vj.scale(c.j)).add(vk.scale(c.k)).normalize()
+}
+
+func (c coordIJK) rotate60ccw() coordIJK {
+	return c.combine(coordIJK{1, 1, 0}, coordIJK{0, 1, 1}, coordIJK{1, 0, 1})
+}
+
+func (c coordIJK) rotate60cw() coordIJK {
+	return c.combine(coordIJK{1, 0, 1}, coordIJK{1, 1, 0}, coordIJK{0, 1, 1})
+}
+
+// Aperture 7 grids alternate between Class II grids, aligned with the axes of their face, and Class III
+// grids, rotated by asin(√(3/28)) ≈ 19.1°. upAp7 and downAp7 move from a Class III grid to its parent
+// and child Class II grids (counter-clockwise), and upAp7r and downAp7r from a Class II grid to its
+// parent and child Class III grids (clockwise)
+
+func (c coordIJK) upAp7() coordIJK {
+	i, j := c.i-c.k, c.j-c.k
+	return coordIJK{
+		int(math.Round(float64(3*i-j) / 7)),
+		int(math.Round(float64(i+2*j) / 7)),
+		0,
+	}.normalize()
+}
+
+func (c coordIJK) upAp7r() coordIJK {
+	i, j := c.i-c.k, c.j-c.k
+	return coordIJK{
+		int(math.Round(float64(2*i+j) / 7)),
+		int(math.Round(float64(3*j-i) / 7)),
+		0,
+	}.normalize()
+}
+
+func (c coordIJK) downAp7() coordIJK {
+	return c.combine(coordIJK{3, 0, 1}, coordIJK{1, 3, 0}, coordIJK{0, 1, 3})
+}
+
+func (c coordIJK) downAp7r() coordIJK {
+	return c.combine(coordIJK{3, 1, 0}, coordIJK{0, 3, 1}, coordIJK{1, 0, 3})
+}
+
+// downAp3 and downAp3r move to the aperture 3 grid whose hexagon centers include the vertices of the
+// hexagons of c's grid, counter-clockwise and clockwise
+
+func (c coordIJK) downAp3() coordIJK {
+	return c.combine(coordIJK{2, 0, 1}, coordIJK{1, 2, 0}, coordIJK{0, 1, 2})
+}
+
+func (c coordIJK) downAp3r() coordIJK {
+	return c.combine(coordIJK{2, 1, 0}, coordIJK{0, 2, 1}, coordIJK{1, 0, 2})
+}
+
+// distance returns the amount of hexagon steps between c and o
+func (c coordIJK) distance(o coordIJK) int {
+	d := c.sub(o).normalize()
+	max := d.i
+	if d.j > max {
+		max = d.j
+	}
+	if d.k > max {
+		max = d.k
+	}
+
+	return max
+}
+
+// hex2d returns the cartesian coordinates of the center of c, in units of the distance between
+// adjacent hexagon centers, with the x axis along the i axis
+func (c coordIJK) hex2d() (x, y float64) {
+	i := float64(c.i - c.k)
+	j := float64(c.j - c.k)
+
+	return i - 0.5*j, j * math.Sqrt(3) / 2
+}
+
+// hex2dToIJK returns the hexagon containing the point at cartesian coordinates x and y
+func hex2dToIJK(x, y float64) coordIJK {
+	a1, a2 := math.Abs(x), math.Abs(y)
+
+	// Reverse the conversion of hex2d
+	x2 := a2 / (math.Sqrt(3) / 2)
+	x1 := a1 + x2/2
+
+	// Check whether the point lies in the hexagon of the lower left vertex of the rhombus it falls in,
+	// or in one of the neighboring ones
+	m1, m2 := int(x1), int(x2)
+	r1, r2 := x1-float64(m1), x2-float64(m2)
+
+	var i, j int
+	switch {
+	case r1 < 0.5 && r1 < 1.0/3:
+		i, j = m1, m2
+		if r2 >= (1+r1)/2 {
+			j++
+		}
+	case r1 < 0.5:
+		j = m2
+		if r2 >= 1-r1 {
+			j++
+		}
+		i = m1
+		if 1-r1 <= r2 && r2 < 2*r1 {
+			i++
+		}
+	case r1 < 2.0/3:
+		j = m2
+		if r2 >= 1-r1 {
+			j++
+		}
+		i = m1 + 1
+		if 2*r1-1 < r2 && r2 < 1-r1 {
+			i = m1
+		}
+	default:
+		i, j = m1+1, m2
+		if r2 >= r1/2 {
+			j++
+		}
+	}
+
+	// Fold across the axes if necessary
+	if x < 0 {
+		if j%2 == 0 {
+			i -= 2 * (i - j/2)
+		} else {
+			i -= 2*(i-(j+1)/2) + 1
+		}
+	}
+	if y < 0 {
+		i -= (2*j + 1) / 2
+		j = -j
+	}
+
+	return coordIJK{i, j, 0}.normalize()
+}
+
+// rotateDigit60ccw returns the digit of the unit vector of d rotated 60° counter-clockwise
+func rotateDigit60ccw(d int) int {
+	switch d {
+	case kAxesDigit:
+		return ikAxesDigit
+	case ikAxesDigit:
+		return iAxesDigit
+	case iAxesDigit:
+		return ijAxesDigit
+	case ijAxesDigit:
+		return jAxesDigit
+	case jAxesDigit:
+		return jkAxesDigit
+	case jkAxesDigit:
+		return kAxesDigit
+	}
+	return d
+}
+
+// rotateDigit60cw returns the digit of the unit vector of d rotated 60° clockwise
+func rotateDigit60cw(d int) int {
+	switch d {
+	case kAxesDigit:
+		return jkAxesDigit
+	case jkAxesDigit:
+		return jAxesDigit
+	case jAxesDigit:
+		return ijAxesDigit
+	case ijAxesDigit:
+		return iAxesDigit
+	case iAxesDigit:
+		return ikAxesDigit
+	case ikAxesDigit:
+		return kAxesDigit
+	}
+	return d
+}
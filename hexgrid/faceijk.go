@@ -0,0 +1,272 @@
+package hexgrid
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+const (
+	numFaces = 20
+	// res0UGnomonic is the distance between the centers of adjacent resolution 0 cells in the gnomonic
+	// projection of a face, relative to the radius of the sphere
+	res0UGnomonic = 0.38196601125010500003
+	// ap7Rot is the rotation of Class III grids relative to Class II grids, asin(√(3/28))
+	ap7Rot = 0.333473172251832115336090755351601070065900389
+
+	epsilon = 1e-16
+)
+
+// faceCenters holds the latitudes and longitudes of the centers of the faces of the icosahedron, in
+// radians, in the orientation chosen by H3 to place all of its vertices in the ocean
+var faceCenters = [numFaces][2]float64{
+	{0.803582649718989942, 1.248397419617396099},
+	{1.307747883455638156, 2.536945009877921159},
+	{1.054751253523952054, -1.347517358900396623},
+	{0.600191595538186799, -0.450603909469755746},
+	{0.491715428198773866, 0.401988202911306943},
+	{0.172745327415618701, 1.678146885280433686},
+	{0.605929321571350690, 2.953923329812411617},
+	{0.427370518328979641, -1.888876200336285401},
+	{-0.079066118549212831, -0.733429513380867741},
+	{-0.230961644455383637, 0.506495587332349035},
+	{0.079066118549212831, 2.408163140208925497},
+	{0.230961644455383637, -2.635097066257443558},
+	{-0.172745327415618701, -1.463445768309359553},
+	{-0.605929321571350690, -0.187669323777381622},
+	{-0.427370518328979641, 1.252716453253507838},
+	{-0.600191595538186799, 2.690988744120037492},
+	{-0.491715428198773866, -2.739604450678486295},
+	{-0.803582649718989942, -1.893195233972397139},
+	{-1.307747883455638156, -0.604647643711872080},
+	{-1.054751253523952054, 1.794075294689396615},
+}
+
+// faceAxes holds the azimuths of the i, j and k axes of the Class II grid of each face, in radians
+// clockwise from north at the center of the face. Each axis points to a vertex of the face
+var faceAxes = [numFaces][3]float64{
+	{5.619958268523939882, 3.525563166130744542, 1.431168063737548730},
+	{5.760339081714187279, 3.665943979320991689, 1.571548876927796127},
+	{0.780213654393430055, 4.969003859179821079, 2.874608756786625655},
+	{0.430469363979999913, 4.619259568766391033, 2.524864466373195467},
+	{6.130269123335111400, 4.035874020941915804, 1.941478918548720291},
+	{2.692877706530642877, 0.598482604137447119, 4.787272808923838195},
+	{2.982963003477243874, 0.888567901084048369, 5.077358105870439581},
+	{3.532912002790141181, 1.438516900396945656, 5.627307105183336758},
+	{3.494305004259568154, 1.399909901866372864, 5.588700106652763840},
+	{3.003214169499538391, 0.908819067106342928, 5.097609271892733906},
+	{5.930472956509811562, 3.836077854116615875, 1.741682751723420374},
+	{0.138378484090254847, 4.327168688876645809, 2.232773586483450311},
+	{0.448714947059150361, 4.637505151845541521, 2.543110049452346120},
+	{0.158629650112549365, 4.347419854898940135, 2.253024752505744869},
+	{5.891865957979238535, 3.797470855586042958, 1.703075753192847583},
+	{2.711123289609793325, 0.616728187216597771, 4.805518392002988683},
+	{3.294508837434268316, 1.200113735041072948, 5.388903939827463911},
+	{3.804819692245439833, 1.710424589852244509, 5.899214794638635174},
+	{3.664438879055192436, 1.570043776661997111, 5.758833981448388027},
+	{2.361378999196363184, 0.266983896803167583, 4.455774101589558636},
+}
+
+// frame holds the center of a face and the unit vectors pointing north and east from it
+type frame struct {
+	center, north, east sphere.Vector
+}
+
+var frames = newFrames()
+
+func newFrames() [numFaces]frame {
+	var frames [numFaces]frame
+	for f, c := range faceCenters {
+		center := sphere.FromRadians(c[0], c[1])
+		north, east := tangent(center)
+		frames[f] = frame{center: center, north: north, east: east}
+	}
+
+	return frames
+}
+
+func posAngle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+func isClassIII(res int) bool {
+	return res%2 == 1
+}
+
+// nearestFace returns the face whose center is nearest to v
+func nearestFace(v sphere.Vector) int {
+	face, best := 0, math.Inf(-1)
+	for f := range frames {
+		if d := frames[f].center.Dot(v); d > best {
+			face, best = f, d
+		}
+	}
+
+	return face
+}
+
+// toHex2d returns the cartesian coordinates of v in the gnomonic projection of face, in units of the
+// distance between adjacent cell centers of the resolution
+func toHex2d(v sphere.Vector, face, res int) (x, y float64) {
+	fr := frames[face]
+	r := fr.center.Angle(v)
+	if r < epsilon {
+		return 0, 0
+	}
+
+	// θ is the angle counter-clockwise from the i axis
+	θ := posAngle(faceAxes[face][0] - posAngle(math.Atan2(v.Dot(fr.east), v.Dot(fr.north))))
+	if isClassIII(res) {
+		θ = posAngle(θ - ap7Rot)
+	}
+	r = math.Tan(r) / res0UGnomonic * math.Pow(math.Sqrt(7), float64(res))
+	sinθ, cosθ := math.Sincos(θ)
+
+	return r * cosθ, r * sinθ
+}
+
+// fromHex2d returns the unit vector at cartesian coordinates x and y of the gnomonic projection of
+// face. On a substrate grid, coordinates are 3 times finer than the Class II grid of resolution res,
+// or of res+1 for Class III resolutions
+func fromHex2d(x, y float64, face, res int, substrate bool) sphere.Vector {
+	fr := frames[face]
+	r := math.Hypot(x, y)
+	if r < epsilon {
+		return fr.center
+	}
+
+	θ := math.Atan2(y, x)
+	r /= math.Pow(math.Sqrt(7), float64(res))
+	if substrate {
+		r /= 3
+		if isClassIII(res) {
+			r /= math.Sqrt(7)
+		}
+	}
+	r = math.Atan(r * res0UGnomonic)
+	if !substrate && isClassIII(res) {
+		θ = posAngle(θ + ap7Rot)
+	}
+	az := posAngle(faceAxes[face][0] - θ)
+
+	sinaz, cosaz := math.Sincos(az)
+	dir := fr.north.Scale(cosaz).Add(fr.east.Scale(sinaz))
+	sinr, cosr := math.Sincos(r)
+
+	return fr.center.Scale(cosr).Add(dir.Scale(sinr))
+}
+
+// faceIJK represents a hexagon by its coordinates in the grid of a face
+type faceIJK struct {
+	face  int
+	coord coordIJK
+}
+
+// fromVector returns the hexagon of the given resolution containing v, on the face nearest to v
+func fromVector(v sphere.Vector, res int) faceIJK {
+	face := nearestFace(v)
+	x, y := toHex2d(v, face, res)
+
+	return faceIJK{face: face, coord: hex2dToIJK(x, y)}
+}
+
+// vector returns the center of h
+func (h faceIJK) vector(res int) sphere.Vector {
+	x, y := h.coord.hex2d()
+	return fromHex2d(x, y, h.face, res, false)
+}
+
+// Quadrants of the neighbors of a face, across the edges opposite to its k, j and i vertices
+const (
+	ij = 1
+	ki = 2
+	jk = 3
+)
+
+// faceOrient describes the grid of a neighboring face relative to the one of a face: coordinates are
+// rotated by ccwRot60 counter-clockwise 60° steps and then translated by translate, in units of the
+// resolution 0 grid
+type faceOrient struct {
+	face      int
+	translate coordIJK
+	ccwRot60  int
+}
+
+// faceNeighbors holds the neighbors of each face by quadrant, which are computed from the geometry of
+// the icosahedron
+var faceNeighbors = newFaceNeighbors()
+
+// maxDim returns the maximum sum of the normalized coordinates of the hexagons of the Class II grid of
+// a resolution within a face
+func maxDim(res int) int {
+	return 2 * unitScale(res)
+}
+
+// unitScale returns the length of the unit vectors of the resolution 0 grid in the Class II grid of a
+// resolution
+func unitScale(res int) int {
+	scale := 1
+	for r := 0; r+2 <= res; r += 2 {
+		scale *= 7
+	}
+	return scale
+}
+
+type overage int
+
+const (
+	noOverage overage = iota
+	faceEdge
+	newFace
+)
+
+// adjustOverageClassII moves h to the neighboring face if it lies beyond the edges of its face, in the
+// Class II grid of the resolution or in its substrate grid. On substrate grids, it reports whether h
+// lies on an edge of its face. pentagonI reports whether h is a descendant of a pentagon at the i
+// vertex of the face with a leading i axes digit, which is rotated across the gap of the deleted k
+// axes subsequence when it moves beyond the ki edge
+func (h *faceIJK) adjustOverageClassII(res int, pentagonI, substrate bool) overage {
+	dim := maxDim(res)
+	scale := unitScale(res)
+	if substrate {
+		dim *= 3
+		scale *= 3
+	}
+
+	sum := h.coord.i + h.coord.j + h.coord.k
+	if substrate && sum == dim {
+		return faceEdge
+	}
+	if sum <= dim {
+		return noOverage
+	}
+
+	var orient faceOrient
+	switch {
+	case h.coord.k > 0 && h.coord.j > 0:
+		orient = faceNeighbors[h.face][jk]
+	case h.coord.k > 0:
+		orient = faceNeighbors[h.face][ki]
+		if pentagonI {
+			origin := coordIJK{dim, 0, 0}
+			h.coord = h.coord.sub(origin).rotate60cw().add(origin)
+		}
+	default:
+		orient = faceNeighbors[h.face][ij]
+	}
+
+	h.face = orient.face
+	for r := 0; r < orient.ccwRot60; r++ {
+		h.coord = h.coord.rotate60ccw()
+	}
+	h.coord = h.coord.add(orient.translate.scale(scale)).normalize()
+	if substrate && h.coord.i+h.coord.j+h.coord.k == dim {
+		return faceEdge
+	}
+
+	return newFace
+}
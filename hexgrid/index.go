@@ -0,0 +1,484 @@
+// Package hexgrid implements a hierarchical discrete global grid of hexagonal cells, laid out as in
+// the H3 library: the sphere is projected onto the 20 faces of an icosahedron with gnomonic
+// projections, and each face is covered by a grid of hexagons whose resolutions are related by an
+// aperture of 7, so that each cell has 7 children whose centers lie within it. The 12 vertices of
+// the icosahedron are the centers of pentagons, which have 5 neighbors and 6 children.
+//
+// Cells are identified by 64-bit indexes with the layout of H3 cell indexes: a mode of 1, the
+// resolution of the cell from 0 to 15, one of the 122 cells of resolution 0 (base cells), and the
+// digits from 0 to 6 which locate the cell within each of its ancestors.
+//
+// Indexes follow the conventions of H3, including the rotations of the digits of the descendants of
+// pentagons, which skip their deleted k axes subsequence, so they can be exchanged with the H3 library
+package hexgrid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+var (
+	// ErrSyntax is returned when a string is not a cell index
+	ErrSyntax = errors.New("hexgrid: invalid index")
+	// ErrResolution is returned when a resolution is out of range
+	ErrResolution = errors.New("hexgrid: invalid resolution")
+)
+
+const (
+	// MaxResolution is the resolution of the smallest cells, whose area is about 0.9 m²
+	MaxResolution = 15
+
+	modeCell      = 1
+	modeOffset    = 59
+	resOffset     = 52
+	baseCellShift = 45
+	digitBits     = 3
+	digitMask     = 1<<digitBits - 1
+	// emptyIndex is the index of resolution 0 with a cell mode and every digit unused
+	emptyIndex = uint64(modeCell)<<modeOffset | 1<<baseCellShift - 1
+)
+
+// Cell identifies a cell of the grid. The zero Cell is invalid
+type Cell uint64
+
+// FromPoint returns the cell of the given resolution, from 0 to MaxResolution, containing p. If p is
+// invalid or res is out of range, the returned Cell is invalid
+func FromPoint(p geodesy.Point, res int) Cell {
+	if !p.Valid() || res < 0 || res > MaxResolution {
+		return 0
+	}
+
+	return fromVector(sphere.FromPoint(p), res).cell(res)
+}
+
+// Parse returns the cell of an index returned by String, which is case-insensitive
+func Parse(s string) (Cell, error) {
+	if len(s) == 0 || len(s) > 16 {
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	n, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	c := Cell(n)
+	if !c.Valid() {
+		return 0, fmt.Errorf("%w: %q is not a cell", ErrSyntax, s)
+	}
+
+	return c, nil
+}
+
+func newCell(res, baseCell int) Cell {
+	return Cell(emptyIndex | uint64(res)<<resOffset | uint64(baseCell)<<baseCellShift)
+}
+
+func (c Cell) digit(res int) int {
+	return int(uint64(c) >> uint((MaxResolution-res)*digitBits) & digitMask)
+}
+
+func (c Cell) setDigit(res, digit int) Cell {
+	shift := uint((MaxResolution - res) * digitBits)
+	return Cell(uint64(c)&^(digitMask<<shift) | uint64(digit)<<shift)
+}
+
+// leadingDigit returns the first digit of c which is not centerDigit, or centerDigit if there is
+// none
+func (c Cell) leadingDigit() int {
+	for r := 1; r <= c.Resolution(); r++ {
+		if d := c.digit(r); d != centerDigit {
+			return d
+		}
+	}
+	return centerDigit
+}
+
+// rotate60ccw rotates the digits of c by 60° counter-clockwise
+func (c Cell) rotate60ccw() Cell {
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.setDigit(r, rotateDigit60ccw(c.digit(r)))
+	}
+	return c
+}
+
+// rotate60cw rotates the digits of c by 60° clockwise
+func (c Cell) rotate60cw() Cell {
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.setDigit(r, rotateDigit60cw(c.digit(r)))
+	}
+	return c
+}
+
+// rotatePentagon60ccw rotates the digits of c, a descendant of a pentagon, by 60° counter-clockwise, and
+// once more if its leading digit falls on the deleted k axes subsequence
+func (c Cell) rotatePentagon60ccw() Cell {
+	leading := false
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.setDigit(r, rotateDigit60ccw(c.digit(r)))
+		if !leading && c.digit(r) != centerDigit {
+			leading = true
+			if c.leadingDigit() == kAxesDigit {
+				c = c.rotate60ccw()
+			}
+		}
+	}
+	return c
+}
+
+// Valid returns whether c identifies a cell
+func (c Cell) Valid() bool {
+	// The reserved bits between the mode and the resolution must be unset
+	if uint64(c)>>modeOffset != modeCell || uint64(c)>>resOffset&0x7f != uint64(c.Resolution()) {
+		return false
+	}
+	if c.BaseCell() >= numBaseCells {
+		return false
+	}
+	for r := 1; r <= MaxResolution; r++ {
+		if d := c.digit(r); r <= c.Resolution() && d == invalidDigit || r > c.Resolution() && d != invalidDigit {
+			return false
+		}
+	}
+
+	// The k axes subsequence of pentagons is deleted
+	return !baseCells[c.BaseCell()].pentagon || c.leadingDigit() != kAxesDigit
+}
+
+// Resolution returns the resolution of c, from 0 to MaxResolution
+func (c Cell) Resolution() int {
+	return int(uint64(c) >> resOffset & 0xf)
+}
+
+// BaseCell returns the number of the cell of resolution 0 containing c, from 0 to 121
+func (c Cell) BaseCell() int {
+	return int(uint64(c) >> baseCellShift & 0x7f)
+}
+
+// IsPentagon returns whether c is one of the 12 pentagons of its resolution
+func (c Cell) IsPentagon() bool {
+	return c.Valid() && baseCells[c.BaseCell()].pentagon && c.leadingDigit() == centerDigit
+}
+
+// Parent returns the cell of the given resolution containing c, which is invalid if res is greater
+// than the resolution of c
+func (c Cell) Parent(res int) Cell {
+	if !c.Valid() || res < 0 || res > c.Resolution() {
+		return 0
+	}
+	p := newCell(res, c.BaseCell())
+	for r := 1; r <= res; r++ {
+		p = p.setDigit(r, c.digit(r))
+	}
+
+	return p
+}
+
+// Children returns the cells of the given resolution contained by c, in index order: 7 per
+// resolution below c for hexagons, and 6 for pentagons. It returns nil if res is lower than the
+// resolution of c or greater than MaxResolution
+func (c Cell) Children(res int) []Cell {
+	if !c.Valid() || res < c.Resolution() || res > MaxResolution {
+		return nil
+	}
+
+	children := []Cell{c}
+	for r := c.Resolution() + 1; r <= res; r++ {
+		next := make([]Cell, 0, 7*len(children))
+		for _, parent := range children {
+			pentagon := parent.IsPentagon()
+			child := Cell(uint64(parent)&^(0xf<<resOffset) | uint64(r)<<resOffset)
+			for d := centerDigit; d < invalidDigit; d++ {
+				if pentagon && d == kAxesDigit {
+					continue
+				}
+				next = append(next, child.setDigit(r, d))
+			}
+		}
+		children = next
+	}
+
+	return children
+}
+
+// String returns the index of c as lowercase hexadecimal digits, such as "8928308280fffff"
+func (c Cell) String() string {
+	return strconv.FormatUint(uint64(c), 16)
+}
+
+// cell returns the cell of the given resolution at the coordinates of h, which must lie within its
+// face
+func (h faceIJK) cell(res int) Cell {
+	coord := h.coord
+	c := newCell(res, 0)
+	for r := res; r > 0; r-- {
+		child := coord
+		var center coordIJK
+		if isClassIII(r) {
+			coord = coord.upAp7()
+			center = coord.downAp7()
+		} else {
+			coord = coord.upAp7r()
+			center = coord.downAp7r()
+		}
+		c = c.setDigit(r, child.sub(center).digit())
+	}
+
+	fbc, ok := faceBaseCells[faceIJK{face: h.face, coord: coord}]
+	if !ok {
+		return 0
+	}
+	c = Cell(uint64(c) | uint64(fbc.baseCell)<<baseCellShift)
+	if !baseCells[fbc.baseCell].pentagon {
+		for r := 0; r < fbc.ccwRot60; r++ {
+			c = c.rotate60ccw()
+		}
+		return c
+	}
+
+	// Descendants falling on the deleted k axes subsequence belong to the adjacent sector
+	if c.leadingDigit() == kAxesDigit {
+		if fbc.cwOffset {
+			c = c.rotate60cw()
+		} else {
+			c = c.rotate60ccw()
+		}
+	}
+	for r := 0; r < fbc.ccwRot60; r++ {
+		c = c.rotatePentagon60ccw()
+	}
+
+	return c
+}
+
+// homeIJK returns the coordinates of the center of c in the grid of the home face of its base cell,
+// which may lie beyond the face
+func (c Cell) homeIJK() faceIJK {
+	h := baseCells[c.BaseCell()].home
+	for r := 1; r <= c.Resolution(); r++ {
+		if isClassIII(r) {
+			h.coord = h.coord.downAp7()
+		} else {
+			h.coord = h.coord.downAp7r()
+		}
+		h.coord = h.coord.neighbor(c.digit(r))
+	}
+
+	return h
+}
+
+// faceIJK returns the coordinates of the center of c in the grid of the face containing it
+func (c Cell) faceIJK() faceIJK {
+	bc := baseCells[c.BaseCell()]
+	// The ik axes subsequence of pentagons lies beyond their home face, across the gap of the deleted
+	// k axes subsequence
+	if bc.pentagon && c.leadingDigit() == ikAxesDigit {
+		c = c.rotate60cw()
+	}
+	h := c.homeIJK()
+	// The descendants of hexagons centered on a face lie within it
+	if !bc.pentagon && (c.Resolution() == 0 || bc.home.coord == coordIJK{}) {
+		return h
+	}
+
+	// Overages are adjusted in Class II grids
+	coord, res := h.coord, c.Resolution()
+	if isClassIII(res) {
+		h.coord = h.coord.downAp7r()
+		res++
+	}
+	pentagonI := bc.pentagon && c.leadingDigit() == iAxesDigit
+	if h.adjustOverageClassII(res, pentagonI, false) == noOverage {
+		if res != c.Resolution() {
+			h.coord = coord
+		}
+		return h
+	}
+	// Pentagons may need to cross several faces
+	if bc.pentagon {
+		for h.adjustOverageClassII(res, false, false) != noOverage {
+		}
+	}
+	if res != c.Resolution() {
+		h.coord = h.coord.upAp7r()
+	}
+
+	return h
+}
+
+// Center returns the center of c. Cells are hexagons, or pentagons, on the gnomonic projection of the
+// face of the icosahedron containing their center
+func (c Cell) Center() geodesy.Point {
+	if !c.Valid() {
+		return geodesy.Point{math.NaN(), math.NaN()}
+	}
+
+	return c.faceIJK().vector(c.Resolution()).Point()
+}
+
+// Offsets of the vertices of hexagons from their centers, counter-clockwise, in the substrate grid of
+// Class II grids and in the one of the child Class II grid of Class III grids
+var (
+	verticesClassII  = [6]coordIJK{{2, 1, 0}, {1, 2, 0}, {0, 2, 1}, {0, 1, 2}, {1, 0, 2}, {2, 0, 1}}
+	verticesClassIII = [6]coordIJK{{5, 4, 0}, {1, 5, 0}, {0, 5, 4}, {0, 1, 5}, {4, 0, 5}, {5, 0, 1}}
+)
+
+// substrate returns the coordinates of the center of h, a cell of the given resolution, in its
+// substrate grid, along with the resolution of the Class II grid of the substrate and the offsets of
+// the vertices of the cell
+func (h faceIJK) substrate(res int) (coordIJK, int, [6]coordIJK) {
+	center, adjRes, offsets := h.coord, res, verticesClassII
+	if isClassIII(res) {
+		center, adjRes, offsets = center.downAp7r(), res+1, verticesClassIII
+	}
+
+	return center.downAp3().downAp3r(), adjRes, offsets
+}
+
+// edgeCrossing returns the point where the segment between the points p and q of the substrate grid of
+// face crosses the edge of the face shared with neighbor. It returns false if the faces are not
+// adjacent, or the segment crosses the edge at one of its ends
+func edgeCrossing(face, neighbor, adjRes int, p, q [2]float64) (sphere.Vector, bool) {
+	dim := float64(3 * maxDim(adjRes))
+	vi := [2]float64{dim, 0}
+	vj := [2]float64{-dim / 2, dim * math.Sqrt(3) / 2}
+	vk := [2]float64{-dim / 2, -dim * math.Sqrt(3) / 2}
+
+	var e0, e1 [2]float64
+	switch neighbor {
+	case faceNeighbors[face][ij].face:
+		e0, e1 = vi, vj
+	case faceNeighbors[face][jk].face:
+		e0, e1 = vj, vk
+	case faceNeighbors[face][ki].face:
+		e0, e1 = vk, vi
+	default:
+		return sphere.Vector{}, false
+	}
+
+	// Intersect the lines through p and q and through e0 and e1
+	d1 := [2]float64{q[0] - p[0], q[1] - p[1]}
+	d2 := [2]float64{e1[0] - e0[0], e1[1] - e0[1]}
+	den := d1[0]*d2[1] - d1[1]*d2[0]
+	if den == 0 {
+		return sphere.Vector{}, false
+	}
+	t := ((e0[0]-p[0])*d2[1] - (e0[1]-p[1])*d2[0]) / den
+	x, y := p[0]+t*d1[0], p[1]+t*d1[1]
+	// The tolerance of H3 is the machine epsilon of single precision floats, which matters at the
+	// finest resolutions, whose substrate coordinates are in the millions
+	const tolerance = 1.1920929e-7
+	if math.Abs(x-p[0]) < tolerance && math.Abs(y-p[1]) < tolerance ||
+		math.Abs(x-q[0]) < tolerance && math.Abs(y-q[1]) < tolerance {
+		return sphere.Vector{}, false
+	}
+
+	return fromHex2d(x, y, face, adjRes, true), true
+}
+
+// boundary returns the vertices of c counter-clockwise. If distortion is set, the points where the
+// edges of Class III cells cross the edges of the icosahedron are included, since the edges of cells
+// bend there
+func (c Cell) boundary(distortion bool) []sphere.Vector {
+	if c.IsPentagon() {
+		return c.pentagonBoundary(distortion)
+	}
+
+	h := c.faceIJK()
+	center, adjRes, offsets := h.substrate(c.Resolution())
+	distortion = distortion && isClassIII(c.Resolution())
+
+	vertices := make([]sphere.Vector, 0, 12)
+	lastFace, lastOverage := -1, noOverage
+	for k := 0; k <= len(offsets); k++ {
+		v := faceIJK{face: h.face, coord: center.add(offsets[k%len(offsets)]).normalize()}
+		o := v.adjustOverageClassII(adjRes, false, true)
+
+		// Insert the crossing with the edge of the icosahedron between this vertex and the last one
+		if distortion && k > 0 && v.face != lastFace && lastOverage != faceEdge {
+			neighbor := v.face
+			if lastFace != h.face {
+				neighbor = lastFace
+			}
+			p := hex2d(center.add(offsets[k-1]))
+			q := hex2d(center.add(offsets[k%len(offsets)]))
+			if x, ok := edgeCrossing(h.face, neighbor, adjRes, p, q); ok {
+				vertices = append(vertices, x)
+			}
+		}
+		if k < len(offsets) {
+			x, y := v.coord.hex2d()
+			vertices = append(vertices, fromHex2d(x, y, v.face, adjRes, true))
+		}
+		lastFace, lastOverage = v.face, o
+	}
+
+	return vertices
+}
+
+// pentagonBoundary returns the vertices of the pentagon c counter-clockwise, starting from the one
+// along the i axis of its home face, along with the crossings of its edges with the edges of the
+// faces if distortion is set, which all the edges of Class III pentagons have
+func (c Cell) pentagonBoundary(distortion bool) []sphere.Vector {
+	h := c.faceIJK()
+	center, adjRes, offsets := h.substrate(c.Resolution())
+	distortion = distortion && isClassIII(c.Resolution())
+
+	vertices := make([]sphere.Vector, 0, 10)
+	const n = 5
+	var last faceIJK
+	for k := 0; k <= n; k++ {
+		v := faceIJK{face: h.face, coord: center.add(offsets[k%n]).normalize()}
+		for v.adjustOverageClassII(adjRes, false, true) == newFace {
+		}
+
+		// Insert the crossing of the edge from the last vertex, on the grid of the face of the last one
+		if distortion && k > 0 {
+			for q := ij; q <= jk; q++ {
+				orient := faceNeighbors[v.face][q]
+				if orient.face != last.face {
+					continue
+				}
+				w := v.coord
+				for r := 0; r < orient.ccwRot60; r++ {
+					w = w.rotate60ccw()
+				}
+				w = w.add(orient.translate.scale(3 * unitScale(adjRes))).normalize()
+				if x, ok := edgeCrossing(last.face, v.face, adjRes, hex2d(last.coord), hex2d(w)); ok {
+					vertices = append(vertices, x)
+				}
+			}
+		}
+		if k < n {
+			x, y := v.coord.hex2d()
+			vertices = append(vertices, fromHex2d(x, y, v.face, adjRes, true))
+		}
+		last = v
+	}
+
+	return vertices
+}
+
+func hex2d(c coordIJK) [2]float64 {
+	x, y := c.hex2d()
+	return [2]float64{x, y}
+}
+
+// Boundary returns the vertices of c in counter-clockwise order: 6 for hexagons and 5 for pentagons,
+// along with the points where the edges of cells of Class III resolutions (the odd ones) cross the
+// edges of the faces of the icosahedron
+func (c Cell) Boundary() []geodesy.Point {
+	if !c.Valid() {
+		return nil
+	}
+	vertices := c.boundary(true)
+	points := make([]geodesy.Point, len(vertices))
+	for k, v := range vertices {
+		points[k] = v.Point()
+	}
+
+	return points
+}
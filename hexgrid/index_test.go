@@ -0,0 +1,368 @@
+package hexgrid_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/hexgrid"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+// pentagons holds the numbers of the base cells which are pentagons
+var pentagons = []int{4, 14, 24, 38, 49, 58, 63, 72, 83, 97, 107, 117}
+
+var samples = []geodesy.Point{
+	{0, 0}, {51.5074, -0.1278}, {-33.8688, 151.2093}, {89.9, 10}, {-89.9, -170}, {0.5, 179.9}, {35.6762, 139.6503},
+	{-45, 45}, {45, -135}, {12.34, -98.76},
+}
+
+// nearPentagons returns points around the centers of the pentagons
+func nearPentagons(t *testing.T) []geodesy.Point {
+	var points []geodesy.Point
+	for _, bc := range pentagons {
+		c := baseCell(t, bc)
+		center := c.Center()
+		for _, offset := range []geodesy.Point{{0, 0}, {0.3, 0.1}, {-0.2, 0.4}, {0.05, -0.7}, {-1.5, -1}, {2, 3}} {
+			points = append(points, geodesy.Point{
+				math.Max(-90, math.Min(90, center.Lat()+offset.Lat())),
+				math.Remainder(center.Lon()+offset.Lon(), 360),
+			})
+		}
+	}
+
+	return points
+}
+
+// baseCell returns the cell of resolution 0 of the given base cell
+func baseCell(t *testing.T, bc int) hexgrid.Cell {
+	c := hexgrid.Cell(uint64(0x8001fffffffffff) | uint64(bc)<<45)
+	assert.True(t, c.Valid())
+	return c
+}
+
+func TestFromPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		p    geodesy.Point
+		res  int
+		want string
+	}{
+		{name: "OK/res_5", p: geodesy.Point{37.3615593, -122.0553238}, res: 5, want: "85283473fffffff"},
+		{name: "OK/res_9", p: geodesy.Point{37.7752702151959, -122.418307270836}, res: 9, want: "8928308280fffff"},
+		{name: "OK/res_10", p: geodesy.Point{40.689167, -74.044444}, res: 10, want: "8a2a1072b59ffff"},
+		{name: "OK/base_cell", p: geodesy.Point{37.3615593, -122.0553238}, res: 0, want: "8029fffffffffff"},
+		{name: "OK/base_cell_35", p: geodesy.Point{26.030597, -169.635314}, res: 7, want: "8746047a4ffffff"},
+		{name: "OK/base_cell_52", p: geodesy.Point{8.447675, 116.677061}, res: 7, want: "8768043b6ffffff"},
+		{name: "OK/base_cell_54", p: geodesy.Point{8.19153, -96.063858}, res: 7, want: "876c06baaffffff"},
+		{name: "OK/base_cell_68", p: geodesy.Point{-6.749115, -154.342359}, res: 7, want: "878803b14ffffff"},
+		{name: "OK/base_cell_78", p: geodesy.Point{-14.340561, 144.794588}, res: 7, want: "879c03c4bffffff"},
+		{name: "OK/base_cell_81", p: geodesy.Point{-19.412053, 49.125952}, res: 7, want: "87a2040deffffff"},
+		{name: "OK/base_cell_82", p: geodesy.Point{-19.579286, -28.446099}, res: 7, want: "87a406b2affffff"},
+		{name: "OK/base_cell_91", p: geodesy.Point{-27.777304, -94.51191}, res: 7, want: "87b60140effffff"},
+		{name: "OK/base_cell_93", p: geodesy.Point{-32.980012, 178.948483}, res: 7, want: "87ba014a9ffffff"},
+		{name: "OK/base_cell_99", p: geodesy.Point{-38.911144, -136.303249}, res: 7, want: "87c601c42ffffff"},
+		{name: "OK/base_cell_102", p: geodesy.Point{-42.691815, 82.058341}, res: 7, want: "87cc03c1cffffff"},
+		{name: "OK/base_cell_108", p: geodesy.Point{-49.428757, 134.873266}, res: 7, want: "87d803c65ffffff"},
+		{name: "OK/base_cell_110", p: geodesy.Point{-54.519445, -16.944827}, res: 7, want: "87dc03850ffffff"},
+		{name: "OK/base_cell_116", p: geodesy.Point{-63.705597, -90.943931}, res: 7, want: "87e801d75ffffff"},
+		{name: "OK/base_cell_120", p: geodesy.Point{-78.489986, 72.053798}, res: 7, want: "87f003da1ffffff"},
+		{name: "FAIL/invalid_point", p: geodesy.Point{91, 0}, res: 5, want: "0"},
+		{name: "FAIL/res_out_of_range", p: geodesy.Point{0, 0}, res: 16, want: "0"},
+		{name: "FAIL/negative_res", p: geodesy.Point{0, 0}, res: -1, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hexgrid.FromPoint(tt.p, tt.res).String())
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range append(samples, nearPentagons(t)...) {
+			for res := 0; res <= hexgrid.MaxResolution; res++ {
+				c := hexgrid.FromPoint(p, res)
+				assert.True(t, c.Valid())
+				assert.Equal(t, res, c.Resolution())
+				assert.Equal(t, c, hexgrid.FromPoint(c.Center(), res), "%v at %d", p, res)
+				if res <= 12 {
+					assert.True(t, polygon.Contains(c.Boundary(), p), "%v in %v", p, c)
+				}
+			}
+		}
+	})
+}
+
+func TestFromPoint_pentagons(t *testing.T) {
+	// Descendants of the pentagons in each of their sectors, with their centers, from the H3 library
+	tests := []struct {
+		p      geodesy.Point
+		res    int
+		want   string
+		center geodesy.Point
+	}{
+		{p: geodesy.Point{70.239297, 13.433546}, res: 1, want: "81097ffffffffff", center: geodesy.Point{70.405910143889, 8.788269518882}},
+		{p: geodesy.Point{64.740755, 11.243919}, res: 4, want: "8408007ffffffff", center: geodesy.Point{64.700063842843, 11.198646480189}},
+		{p: geodesy.Point{64.6852, 10.55308}, res: 7, want: "870800002ffffff", center: geodesy.Point{64.688305789091, 10.560707481029}},
+		{p: geodesy.Point{64.699383, 10.534703}, res: 10, want: "8a0800000037fff", center: geodesy.Point{64.699513177701, 10.534648664996}},
+		{p: geodesy.Point{64.700023, 10.5361}, res: 13, want: "8d080000000013f", center: geodesy.Point{64.700009747753, 10.536094378816}},
+		{p: geodesy.Point{52.140327, -142.461736}, res: 2, want: "821c27fffffffff", center: geodesy.Point{51.432594098945, -141.059118433956}},
+		{p: geodesy.Point{50.105074, -143.298807}, res: 5, want: "851c0017fffffff", center: geodesy.Point{50.090563212854, -143.308102599614}},
+		{p: geodesy.Point{50.097317, -143.475331}, res: 8, want: "881c000007fffff", center: geodesy.Point{50.097572691847, -143.480368557106}},
+		{p: geodesy.Point{50.102999, -143.478908}, res: 11, want: "8b1c00000002fff", center: geodesy.Point{50.103043876645, -143.478924853799}},
+		{p: geodesy.Point{50.103212, -143.478513}, res: 14, want: "8e1c00000000037", center: geodesy.Point{50.103216822426, -143.478500621387}},
+		{p: geodesy.Point{39.836307, 122.727364}, res: 3, want: "833005fffffffff", center: geodesy.Point{39.507147478753, 123.154385168732}},
+		{p: geodesy.Point{39.095433, 122.355827}, res: 6, want: "86300002fffffff", center: geodesy.Point{39.105838057826, 122.351401338936}},
+		{p: geodesy.Point{39.097699, 122.300631}, res: 9, want: "8930000000bffff", center: geodesy.Point{39.097922279587, 122.298913093090}},
+		{p: geodesy.Point{39.099937, 122.299859}, res: 12, want: "8c30000000005ff", center: geodesy.Point{39.099917930068, 122.299892155540}},
+		{p: geodesy.Point{42.743578, 116.584649}, res: 1, want: "81313ffffffffff", center: geodesy.Point{44.660791846090, 120.363297706416}},
+		{p: geodesy.Point{23.979229, -66.960427}, res: 4, want: "844c009ffffffff", center: geodesy.Point{23.842555542461, -66.854551596308}},
+		{p: geodesy.Point{23.71422, -67.114803}, res: 7, want: "874c00005ffffff", center: geodesy.Point{23.711973981934, -67.116457463477}},
+		{p: geodesy.Point{23.71704, -67.132242}, res: 10, want: "8a4c0000001ffff", center: geodesy.Point{23.717198068662, -67.132745593962}},
+		{p: geodesy.Point{23.717907, -67.132375}, res: 13, want: "8d4c000000000bf", center: geodesy.Point{23.717914410243, -67.132374925874}},
+		{p: geodesy.Point{25.32122, -68.681495}, res: 2, want: "824c37fffffffff", center: geodesy.Point{25.723794540329, -67.452158433556}},
+		{p: geodesy.Point{10.538165, 58.229886}, res: 5, want: "85620017fffffff", center: geodesy.Point{10.495946048761, 58.258092611610}},
+		{p: geodesy.Point{10.445217, 58.163653}, res: 8, want: "886200000bfffff", center: geodesy.Point{10.447613101225, 58.163552859469}},
+		{p: geodesy.Point{10.447009, 58.157694}, res: 11, want: "8b6200000002fff", center: geodesy.Point{10.447061747368, 58.157554019603}},
+		{p: geodesy.Point{10.44734, 58.157688}, res: 14, want: "8e6200000000017", center: geodesy.Point{10.447334702588, 58.157692516475}},
+		{p: geodesy.Point{11.115909, 57.697912}, res: 3, want: "836204fffffffff", center: geodesy.Point{11.215775244067, 58.044513529212}},
+		{p: geodesy.Point{2.331687, -5.21456}, res: 6, want: "867400027ffffff", center: geodesy.Point{2.330139907445, -5.217634892691}},
+		{p: geodesy.Point{2.299814, -5.243293}, res: 9, want: "89740000017ffff", center: geodesy.Point{2.300807249966, -5.243147174099}},
+		{p: geodesy.Point{2.300757, -5.24541}, res: 12, want: "8c74000000007ff", center: geodesy.Point{2.300765640495, -5.245405573707}},
+		{p: geodesy.Point{1.407859, -10.825189}, res: 1, want: "8174bffffffffff", center: geodesy.Point{-0.922697781838, -10.000781576888}},
+		{p: geodesy.Point{2.572593, -5.383977}, res: 4, want: "847400dffffffff", center: geodesy.Point{2.549590197273, -5.380752827937}},
+		{p: geodesy.Point{-2.290745, 174.767596}, res: 7, want: "877e00002ffffff", center: geodesy.Point{-2.289029102940, 174.764914222406}},
+		{p: geodesy.Point{-2.301379, 174.755347}, res: 10, want: "8a7e00000037fff", center: geodesy.Point{-2.301478900788, 174.755175830619}},
+		{p: geodesy.Point{-2.300928, 174.754596}, res: 13, want: "8d7e0000000013f", center: geodesy.Point{-2.300927704266, 174.754605727374}},
+		{p: geodesy.Point{-2.373769, 172.619385}, res: 2, want: "827e2ffffffffff", center: geodesy.Point{-1.929048665383, 172.760852357380}},
+		{p: geodesy.Point{-2.19257, 174.715159}, res: 5, want: "857e000ffffffff", center: geodesy.Point{-2.206586635288, 174.697864627655}},
+		{p: geodesy.Point{-10.44414, -121.83687}, res: 8, want: "8890000005fffff", center: geodesy.Point{-10.447613101225, -121.836447140531}},
+		{p: geodesy.Point{-10.447566, -121.842036}, res: 11, want: "8b9000000006fff", center: geodesy.Point{-10.447594555612, -121.842089659966}},
+		{p: geodesy.Point{-10.447362, -121.842301}, res: 14, want: "8e9000000000027", center: geodesy.Point{-10.447354408450, -121.842308417344}},
+		{p: geodesy.Point{-10.375988, -122.659425}, res: 3, want: "839005fffffffff", center: geodesy.Point{-10.243993205969, -122.604006597667}},
+		{p: geodesy.Point{-10.404896, -121.852258}, res: 6, want: "86900001fffffff", center: geodesy.Point{-10.409626567017, -121.827827430404}},
+		{p: geodesy.Point{-23.716968, 112.870021}, res: 9, want: "89a6000001bffff", center: geodesy.Point{-23.718027806304, 112.870120525644}},
+		{p: geodesy.Point{-23.71802, 112.867766}, res: 12, want: "8ca600000000dff", center: geodesy.Point{-23.717977087096, 112.867788780558}},
+		{p: geodesy.Point{-28.575223, 109.655567}, res: 1, want: "81a77ffffffffff", center: geodesy.Point{-26.784043943018, 107.495613243588}},
+		{p: geodesy.Point{-23.654181, 112.542021}, res: 4, want: "84a600bffffffff", center: geodesy.Point{-23.765936147058, 112.562878481766}},
+		{p: geodesy.Point{-23.701549, 112.865794}, res: 7, want: "87a600002ffffff", center: geodesy.Point{-23.703230557650, 112.873712592403}},
+		{p: geodesy.Point{-39.09974, -57.698904}, res: 10, want: "8ac200000017fff", center: geodesy.Point{-39.100119340112, -57.698951230584}},
+		{p: geodesy.Point{-39.100039, -57.699964}, res: 13, want: "8dc2000000001bf", center: geodesy.Point{-39.100038324149, -57.699967291846}},
+		{p: geodesy.Point{-40.792698, -59.39676}, res: 2, want: "82c227fffffffff", center: geodesy.Point{-40.017476931003, -60.044254968902}},
+		{p: geodesy.Point{-39.06239, -57.840358}, res: 5, want: "85c20017fffffff", center: geodesy.Point{-39.060871683615, -57.832477991025}},
+		{p: geodesy.Point{-39.093777, -57.69986}, res: 8, want: "88c2000007fffff", center: geodesy.Point{-39.094841435289, -57.696708368944}},
+		{p: geodesy.Point{-50.103143, 36.522026}, res: 11, want: "8bd600000006fff", center: geodesy.Point{-50.103302403127, 36.521984017330}},
+		{p: geodesy.Point{-50.103218, 36.521523}, res: 14, want: "8ed600000000037", center: geodesy.Point{-50.103212692646, 36.521529453348}},
+		{p: geodesy.Point{-50.680264, 35.636979}, res: 3, want: "83d605fffffffff", center: geodesy.Point{-50.332706085714, 35.362294356259}},
+		{p: geodesy.Point{-50.084088, 36.460487}, res: 6, want: "86d60002fffffff", center: geodesy.Point{-50.099029580234, 36.458999537163}},
+		{p: geodesy.Point{-50.100872, 36.52202}, res: 9, want: "89d6000000bffff", center: geodesy.Point{-50.101395566709, 36.523582846570}},
+		{p: geodesy.Point{-64.699993, -169.463504}, res: 12, want: "8cea000000009ff", center: geodesy.Point{-64.700000768052, -169.463526060246}},
+		{p: geodesy.Point{-69.877354, -163.579753}, res: 1, want: "81eb7ffffffffff", center: geodesy.Point{-68.702285323196, -158.997436935459}},
+		{p: geodesy.Point{-64.89087, -170.022306}, res: 4, want: "84ea007ffffffff", center: geodesy.Point{-64.864168888833, -170.005133135339}},
+		{p: geodesy.Point{-64.691029, -169.496104}, res: 7, want: "87ea00002ffffff", center: geodesy.Point{-64.696528398649, -169.499627811766}},
+		{p: geodesy.Point{-64.699141, -169.463262}, res: 10, want: "8aea00000037fff", center: geodesy.Point{-64.699219490855, -169.463196396411}},
+	}
+	for _, tt := range tests {
+		t.Run("OK/"+tt.want, func(t *testing.T) {
+			c := hexgrid.FromPoint(tt.p, tt.res)
+			assert.Equal(t, tt.want, c.String())
+			assert.Contains(t, pentagons, c.BaseCell())
+			assert.InDelta(t, tt.center.Lat(), c.Center().Lat(), 1e-9)
+			assert.InDelta(t, tt.center.Lon(), c.Center().Lon(), 1e-9)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    hexgrid.Cell
+		wantErr error
+	}{
+		{name: "OK/base_cell", s: "8001fffffffffff", want: 0x8001fffffffffff},
+		{name: "OK/res_9", s: "8928308280fffff", want: 0x8928308280fffff},
+		{name: "OK/upper_case", s: "8928308280FFFFF", want: 0x8928308280fffff},
+		{name: "FAIL/empty", s: "", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/invalid", s: "8928308280gffff", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/too_long", s: "08928308280fffff0", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/mode", s: "1928308280fffff", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/unused_digit", s: "8928308280ffff0", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/missing_digit", s: "892830828ffffff", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/base_cell_out_of_range", s: "80f5fffffffffff", wantErr: hexgrid.ErrSyntax},
+		{name: "FAIL/pentagon_k_digit", s: "81087ffffffffff", wantErr: hexgrid.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hexgrid.Parse(tt.s)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, strings.ToLower(tt.s), got.String())
+		})
+	}
+}
+
+func TestCell_BaseCell(t *testing.T) {
+	count := 0
+	for bc := 0; bc < 122; bc++ {
+		c := baseCell(t, bc)
+		assert.Equal(t, bc, c.BaseCell())
+		assert.Equal(t, 0, c.Resolution())
+		if c.IsPentagon() {
+			assert.Contains(t, pentagons, bc)
+			count++
+		}
+	}
+	assert.Equal(t, len(pentagons), count)
+
+	// Base cells are numbered from north to south
+	assert.Equal(t, 0, hexgrid.FromPoint(geodesy.Point{90, 0}, 0).BaseCell())
+	assert.Equal(t, 121, hexgrid.FromPoint(geodesy.Point{-90, 0}, 0).BaseCell())
+}
+
+func TestCell_Parent(t *testing.T) {
+	c := hexgrid.Cell(0x8928308280fffff)
+	assert.Equal(t, "85283083fffffff", c.Parent(5).String())
+	assert.Equal(t, c, c.Parent(9))
+	assert.Equal(t, hexgrid.Cell(0), c.Parent(10))
+	assert.Equal(t, hexgrid.Cell(0), c.Parent(-1))
+	assert.Equal(t, hexgrid.Cell(0), hexgrid.Cell(0).Parent(0))
+
+	for _, p := range samples {
+		leaf := hexgrid.FromPoint(p, hexgrid.MaxResolution)
+		for res := 0; res <= hexgrid.MaxResolution; res++ {
+			parent := leaf.Parent(res)
+			assert.Equal(t, res, parent.Resolution())
+			childRes := res + 2
+			if childRes > hexgrid.MaxResolution {
+				childRes = hexgrid.MaxResolution
+			}
+			assert.Contains(t, parent.Children(childRes), leaf.Parent(childRes))
+		}
+	}
+}
+
+func TestCell_Children(t *testing.T) {
+	t.Run("OK/counts", func(t *testing.T) {
+		total := 0
+		for bc := 0; bc < 122; bc++ {
+			c := baseCell(t, bc)
+			children := c.Children(2)
+			if c.IsPentagon() {
+				assert.Len(t, children, 1+5+5*7)
+			} else {
+				assert.Len(t, children, 49)
+			}
+			total += len(children)
+		}
+		assert.Equal(t, 2+120*49, total)
+	})
+
+	t.Run("OK/hierarchy", func(t *testing.T) {
+		c := hexgrid.FromPoint(geodesy.Point{48.8566, 2.3522}, 7)
+		children := c.Children(8)
+		assert.Len(t, children, 7)
+		for k, child := range children {
+			assert.True(t, child.Valid())
+			assert.Equal(t, c, child.Parent(7))
+			assert.Equal(t, child, hexgrid.FromPoint(child.Center(), 8))
+			if k > 0 {
+				assert.Less(t, uint64(children[k-1]), uint64(child))
+			}
+		}
+		// The center child shares the center of its parent
+		assert.InDelta(t, c.Center().Lat(), children[0].Center().Lat(), 1e-9)
+		assert.InDelta(t, c.Center().Lon(), children[0].Center().Lon(), 1e-9)
+
+		assert.Equal(t, []hexgrid.Cell{c}, c.Children(7))
+		assert.Nil(t, c.Children(6))
+		assert.Nil(t, c.Children(16))
+	})
+
+	t.Run("OK/pentagon", func(t *testing.T) {
+		c := baseCell(t, pentagons[0])
+		for res := 1; res <= 3; res++ {
+			children := c.Children(res)
+			pentagonChildren := 0
+			for _, child := range children {
+				assert.True(t, child.Valid())
+				assert.Equal(t, child, hexgrid.FromPoint(child.Center(), res))
+				if child.IsPentagon() {
+					pentagonChildren++
+				}
+			}
+			assert.Equal(t, 1, pentagonChildren)
+		}
+	})
+}
+
+func TestCell_Center(t *testing.T) {
+	center := hexgrid.Cell(0x8928308280fffff).Center()
+	assert.InDelta(t, 37.77670234943567, center.Lat(), 1e-9)
+	assert.InDelta(t, -122.41845932318311, center.Lon(), 1e-9)
+
+	assert.False(t, hexgrid.Cell(0).Center().Valid())
+}
+
+func TestCell_Boundary(t *testing.T) {
+	t.Run("OK/hexagon", func(t *testing.T) {
+		want := []geodesy.Point{
+			{37.271355866731895, -121.91508032705622},
+			{37.353926450852256, -121.86222328902491},
+			{37.42834118609435, -121.9235499963016},
+			{37.42012867767778, -122.0377349642703},
+			{37.33755608435298, -122.09042892904395},
+			{37.26319797461824, -122.02910130919},
+		}
+		got := hexgrid.Cell(0x85283473fffffff).Boundary()
+		assert.Len(t, got, len(want))
+		for k := range want {
+			assert.InDelta(t, want[k].Lat(), got[k].Lat(), 1e-9)
+			assert.InDelta(t, want[k].Lon(), got[k].Lon(), 1e-9)
+		}
+	})
+
+	t.Run("OK/pentagon", func(t *testing.T) {
+		want := []geodesy.Point{
+			{63.095054077525454, -10.444977544778325},
+			{55.706768465152265, 5.523646549290313},
+			{58.4015448703527, 25.082722326707874},
+			{68.92995788193983, 31.83128049908738},
+			{73.31022368544396, 0.32561035194326043},
+		}
+		got := hexgrid.Cell(0x8009fffffffffff).Boundary()
+		assert.Len(t, got, len(want))
+		for k := range want {
+			assert.InDelta(t, want[k].Lat(), got[k].Lat(), 1e-9)
+			assert.InDelta(t, want[k].Lon(), got[k].Lon(), 1e-9)
+		}
+
+		for _, bc := range pentagons {
+			c := baseCell(t, bc)
+			// Class III pentagons have an additional vertex where each edge crosses a face of the icosahedron
+			assert.Len(t, c.Boundary(), 5)
+			assert.Len(t, c.Children(1)[0].Boundary(), 10)
+			assert.Len(t, c.Children(2)[0].Boundary(), 5)
+		}
+	})
+
+	t.Run("OK/orientation", func(t *testing.T) {
+		for _, p := range append(samples, nearPentagons(t)...) {
+			for res := 0; res <= 10; res++ {
+				c := hexgrid.FromPoint(p, res)
+				boundary := c.Boundary()
+				if res > 0 {
+					assert.Equal(t, polygon.CounterClockwise, polygon.Orientation(boundary), "%v", c)
+				}
+				assert.True(t, polygon.Contains(boundary, c.Center()), "%v", c)
+			}
+		}
+	})
+
+	assert.Nil(t, hexgrid.Cell(0).Boundary())
+}
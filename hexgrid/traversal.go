@@ -0,0 +1,256 @@
+package hexgrid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+	"github.com/lggomez/go-geodesy/polygon"
+)
+
+// ErrGridDistance is returned when the grid distance between two cells is undefined or too large to be
+// computed
+var ErrGridDistance = errors.New("hexgrid: undefined grid distance")
+
+// maxSearchDistance is the largest grid distance computed by searching the neighbors of cells, when the
+// cells are not laid out on a common plane
+const maxSearchDistance = 32
+
+// Neighbors returns the cells sharing an edge with c: 6 for hexagons and 5 for pentagons, in
+// counter-clockwise order
+func (c Cell) Neighbors() []Cell {
+	if !c.Valid() {
+		return nil
+	}
+	res := c.Resolution()
+	center := c.faceIJK().vector(res)
+	vertices := c.boundary(false)
+
+	// The centers of neighbors are close to the reflection of the center of c across the midpoints of
+	// its edges
+	neighbors := make([]Cell, 0, len(vertices))
+	for k, v := range vertices {
+		m := v.Add(vertices[(k+1)%len(vertices)])
+		m = m.Scale(1 / math.Sqrt(m.Dot(m)))
+		p := m.Scale(2 * center.Dot(m)).Add(center.Scale(-1))
+		n := fromVector(p, res).cell(res)
+		if n != c && !containsCell(neighbors, n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+
+	return neighbors
+}
+
+func containsCell(cells []Cell, c Cell) bool {
+	for _, o := range cells {
+		if o == c {
+			return true
+		}
+	}
+	return false
+}
+
+// GridDisk returns the cells within k steps of c, including c, sorted by their grid distance to it.
+// There are 1+3k(k+1) of them, or less if k reaches the distortion of pentagons
+func (c Cell) GridDisk(k int) []Cell {
+	if !c.Valid() || k < 0 {
+		return nil
+	}
+	disk := []Cell{c}
+	visited := map[Cell]bool{c: true}
+	ring := disk
+	for n := 0; n < k; n++ {
+		var next []Cell
+		for _, r := range ring {
+			for _, neighbor := range r.Neighbors() {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		disk = append(disk, next...)
+		ring = next
+	}
+
+	return disk
+}
+
+// GridDistance returns the least amount of steps between neighboring cells from c to o, which must
+// share their resolution. Cells whose digits are oriented by the same or adjacent faces of the
+// icosahedron, outside of the base cells which are pentagons, are laid out on a common plane and
+// measured on it. Otherwise, the neighbors of the cells are searched up to a distance of 32, beyond
+// which an error is returned
+func (c Cell) GridDistance(o Cell) (int, error) {
+	if !c.Valid() || !o.Valid() || c.Resolution() != o.Resolution() {
+		return 0, fmt.Errorf("%w: between %v and %v", ErrGridDistance, c, o)
+	}
+	if c == o {
+		return 0, nil
+	}
+	if d, ok := c.planarDistance(o); ok {
+		return d, nil
+	}
+
+	// Search breadth first from both cells, one ring at a time
+	from, to := map[Cell]int{c: 0}, map[Cell]int{o: 0}
+	fromRing, toRing := []Cell{c}, []Cell{o}
+	for n := 0; n < maxSearchDistance; n++ {
+		ring, visited, other := &fromRing, from, to
+		if n%2 == 1 {
+			ring, visited, other = &toRing, to, from
+		}
+		*ring = expand(*ring, visited)
+		for _, r := range *ring {
+			if d, ok := other[r]; ok {
+				return visited[r] + d, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v and %v are too far apart", ErrGridDistance, c, o)
+}
+
+// expand returns the unvisited neighbors of ring, recording their distance to the origin of the search
+func expand(ring []Cell, visited map[Cell]int) []Cell {
+	var next []Cell
+	for _, r := range ring {
+		for _, n := range r.Neighbors() {
+			if _, ok := visited[n]; !ok {
+				visited[n] = visited[r] + 1
+				next = append(next, n)
+			}
+		}
+	}
+
+	return next
+}
+
+// planarDistance returns the grid distance between c and o on the plane of the home face of c, if
+// their home faces are the same or adjacent and their base cells are not pentagons
+func (c Cell) planarDistance(o Cell) (int, bool) {
+	if baseCells[c.BaseCell()].pentagon || baseCells[o.BaseCell()].pentagon {
+		return 0, false
+	}
+	res := c.Resolution()
+	hc, ho := c.homeIJK(), o.homeIJK()
+	if hc.face == ho.face {
+		return hc.coord.distance(ho.coord), true
+	}
+
+	for q := ij; q <= jk; q++ {
+		orient := faceNeighbors[ho.face][q]
+		if orient.face != hc.face {
+			continue
+		}
+		// Translations between faces are exact in Class II grids
+		coord, adjRes := ho.coord, res
+		if isClassIII(res) {
+			coord, adjRes = coord.downAp7r(), res+1
+		}
+		for r := 0; r < orient.ccwRot60; r++ {
+			coord = coord.rotate60ccw()
+		}
+		coord = coord.add(orient.translate.scale(unitScale(adjRes))).normalize()
+		if isClassIII(res) {
+			coord = coord.upAp7r()
+		}
+
+		return hc.coord.distance(coord), true
+	}
+
+	return 0, false
+}
+
+// Polyfill returns the cells of the given resolution whose centers lie within the polygon with the
+// given vertices, and outside of its holes. Edges are great circle arcs, and the interior of each ring
+// is the smaller of the regions it bounds, as in polygon.Contains
+func Polyfill(vertices []geodesy.Point, res int, holes ...[]geodesy.Point) ([]Cell, error) {
+	if res < 0 || res > MaxResolution {
+		return nil, fmt.Errorf("%w: %d", ErrResolution, res)
+	}
+	rings := append([][]geodesy.Point{vertices}, holes...)
+	for _, ring := range rings {
+		if err := polygon.Validate(ring); err != nil {
+			return nil, err
+		}
+	}
+	inside := func(c Cell) bool {
+		center := c.Center()
+		if !polygon.Contains(vertices, center) {
+			return false
+		}
+		for _, hole := range holes {
+			if polygon.Contains(hole, center) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Seed the search with the cells along the rings and their neighbors, which include every cell
+	// crossed by the rings. Any other cell overlapping the polygon lies within it, and is reached from
+	// them through cells whose centers lie within the polygon
+	step := minCellInradius / math.Pow(math.Sqrt(7), float64(res)) / 2
+	visited, seeds := make(map[Cell]bool), make(map[Cell]bool)
+	var queue []Cell
+	for _, ring := range rings {
+		for k := range ring {
+			a, b := sphere.FromPoint(ring[k]), sphere.FromPoint(ring[(k+1)%len(ring)])
+			for _, p := range sampleArc(a, b, step) {
+				for _, c := range fromVector(p, res).cell(res).GridDisk(1) {
+					if !visited[c] {
+						visited[c], seeds[c] = true, true
+						queue = append(queue, c)
+					}
+				}
+			}
+		}
+	}
+
+	var cells []Cell
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if inside(c) {
+			cells = append(cells, c)
+		} else if !seeds[c] {
+			continue
+		}
+		for _, n := range c.Neighbors() {
+			if !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return cells, nil
+}
+
+// minCellInradius is a lower bound of the angular distance from the center of the cells of resolution 0
+// to their edges, in radians, considering the distortion of the gnomonic projection far from the
+// centers of faces
+const minCellInradius = res0UGnomonic / 2 * 0.5
+
+// sampleArc returns points along the great circle arc from a to b, separated by at most step radians
+func sampleArc(a, b sphere.Vector, step float64) []sphere.Vector {
+	θ := a.Angle(b)
+	n := int(math.Ceil(θ/step)) + 1
+	points := make([]sphere.Vector, 0, n+1)
+	for k := 0; k <= n; k++ {
+		t := float64(k) / float64(n)
+		if θ == 0 {
+			points = append(points, a)
+			continue
+		}
+		// Spherical linear interpolation
+		sa, sb := math.Sin((1-t)*θ)/math.Sin(θ), math.Sin(t*θ)/math.Sin(θ)
+		points = append(points, a.Scale(sa).Add(b.Scale(sb)))
+	}
+
+	return points
+}
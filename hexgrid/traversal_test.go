@@ -0,0 +1,196 @@
+package hexgrid_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/hexgrid"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCell_Neighbors(t *testing.T) {
+	t.Run("OK/base_cells", func(t *testing.T) {
+		var neighbors []int
+		for _, n := range baseCell(t, 4).Neighbors() {
+			neighbors = append(neighbors, n.BaseCell())
+		}
+		assert.ElementsMatch(t, []int{0, 3, 8, 12, 15}, neighbors)
+	})
+
+	t.Run("OK/symmetric", func(t *testing.T) {
+		var cells []hexgrid.Cell
+		for bc := 0; bc < 122; bc++ {
+			cells = append(cells, baseCell(t, bc).Children(1)...)
+		}
+		for _, p := range append(samples, nearPentagons(t)...) {
+			for _, res := range []int{2, 5, 8, 11, 15} {
+				cells = append(cells, hexgrid.FromPoint(p, res))
+			}
+		}
+
+		for _, c := range cells {
+			neighbors := c.Neighbors()
+			if c.IsPentagon() {
+				assert.Len(t, neighbors, 5)
+			} else {
+				assert.Len(t, neighbors, 6, "%v", c)
+			}
+			for _, n := range neighbors {
+				assert.Equal(t, c.Resolution(), n.Resolution())
+				assert.Contains(t, n.Neighbors(), c, "%v and %v", c, n)
+			}
+		}
+	})
+
+	assert.Nil(t, hexgrid.Cell(0).Neighbors())
+}
+
+func TestCell_GridDisk(t *testing.T) {
+	c := hexgrid.FromPoint(geodesy.Point{40.4168, -3.7038}, 9)
+	assert.Equal(t, []hexgrid.Cell{c}, c.GridDisk(0))
+	assert.Nil(t, c.GridDisk(-1))
+
+	for k := 1; k <= 5; k++ {
+		disk := c.GridDisk(k)
+		assert.Len(t, disk, 1+3*k*(k+1))
+		last := 0
+		for _, n := range disk {
+			d, err := c.GridDistance(n)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, d, k)
+			assert.GreaterOrEqual(t, d, last)
+			last = d
+		}
+	}
+
+	// Pentagons have one neighbor less
+	pentagon := baseCell(t, pentagons[3]).Children(4)[0]
+	assert.True(t, pentagon.IsPentagon())
+	assert.Len(t, pentagon.GridDisk(1), 6)
+	assert.Len(t, pentagon.GridDisk(2), 16)
+}
+
+func TestCell_GridDistance(t *testing.T) {
+	t.Run("OK/rings", func(t *testing.T) {
+		for _, p := range append(samples, nearPentagons(t)...) {
+			for _, res := range []int{1, 4, 9} {
+				c := hexgrid.FromPoint(p, res)
+				ring := []hexgrid.Cell{c}
+				seen := map[hexgrid.Cell]bool{c: true}
+				for d := 1; d <= 4; d++ {
+					var next []hexgrid.Cell
+					for _, r := range ring {
+						for _, n := range r.Neighbors() {
+							if !seen[n] {
+								seen[n] = true
+								next = append(next, n)
+							}
+						}
+					}
+					for _, n := range next {
+						got, err := c.GridDistance(n)
+						assert.NoError(t, err)
+						assert.Equal(t, d, got, "%v to %v", c, n)
+					}
+					ring = next
+				}
+			}
+		}
+	})
+
+	t.Run("OK/across_faces", func(t *testing.T) {
+		a := hexgrid.FromPoint(geodesy.Point{40.4168, -3.7038}, 5)
+		b := hexgrid.FromPoint(geodesy.Point{48.8566, 2.3522}, 5)
+		d, err := a.GridDistance(b)
+		assert.NoError(t, err)
+		// The centers of cells of resolution 5 lie about 15 km apart, and Madrid lies 1053 km from Paris
+		assert.InDelta(t, 70, d, 10)
+		back, err := b.GridDistance(a)
+		assert.NoError(t, err)
+		assert.Equal(t, d, back)
+	})
+
+	tests := []struct {
+		name string
+		a, b hexgrid.Cell
+	}{
+		{
+			name: "FAIL/different_resolutions",
+			a:    hexgrid.FromPoint(geodesy.Point{0, 0}, 5),
+			b:    hexgrid.FromPoint(geodesy.Point{0, 0}, 6),
+		},
+		{
+			name: "FAIL/invalid",
+			a:    hexgrid.FromPoint(geodesy.Point{0, 0}, 5),
+		},
+		{
+			name: "FAIL/far_from_pentagon",
+			a:    hexgrid.Cell(0x8009fffffffffff).Children(3)[0],
+			b:    hexgrid.FromPoint(geodesy.Point{-40, 100}, 3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.a.GridDistance(tt.b)
+			assert.ErrorIs(t, err, hexgrid.ErrGridDistance)
+		})
+	}
+}
+
+func TestPolyfill(t *testing.T) {
+	// brute returns the cells around seed whose centers lie within the polygon and outside its hole
+	brute := func(vertices, hole []geodesy.Point, seed hexgrid.Cell, k int) []hexgrid.Cell {
+		var cells []hexgrid.Cell
+		for _, c := range seed.GridDisk(k) {
+			center := c.Center()
+			if polygon.Contains(vertices, center) && (hole == nil || !polygon.Contains(hole, center)) {
+				cells = append(cells, c)
+			}
+		}
+		return cells
+	}
+
+	t.Run("OK/concave", func(t *testing.T) {
+		vertices := []geodesy.Point{{40, -4}, {40, -3}, {41, -3}, {41, -3.5}, {40.5, -3.5}, {40.6, -4}}
+		cells, err := hexgrid.Polyfill(vertices, 6)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cells)
+		assert.ElementsMatch(t, brute(vertices, nil, hexgrid.FromPoint(vertices[0], 6), 30), cells)
+	})
+
+	t.Run("OK/hole_across_antimeridian", func(t *testing.T) {
+		vertices := []geodesy.Point{{-1, 179}, {-1, -179}, {1, -179}, {1, 179}}
+		hole := []geodesy.Point{{-0.5, 179.5}, {-0.5, -179.5}, {0.5, -179.5}, {0.5, 179.5}}
+		cells, err := hexgrid.Polyfill(vertices, 5, hole)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cells)
+		assert.ElementsMatch(t, brute(vertices, hole, hexgrid.FromPoint(vertices[0], 5), 40), cells)
+		assert.NotContains(t, cells, hexgrid.FromPoint(geodesy.Point{0, 180}, 5))
+	})
+
+	t.Run("OK/pole", func(t *testing.T) {
+		vertices := []geodesy.Point{{60, 0}, {60, 120}, {60, -120}}
+		cells, err := hexgrid.Polyfill(vertices, 2)
+		assert.NoError(t, err)
+		assert.Contains(t, cells, hexgrid.FromPoint(geodesy.Point{90, 0}, 2))
+		assert.ElementsMatch(t, brute(vertices, nil, hexgrid.FromPoint(vertices[0], 2), 30), cells)
+	})
+
+	t.Run("OK/smaller_than_cell", func(t *testing.T) {
+		vertices := []geodesy.Point{{0.1, 0.1}, {0.1, 0.11}, {0.11, 0.11}}
+		cells, err := hexgrid.Polyfill(vertices, 3)
+		assert.NoError(t, err)
+		assert.Empty(t, cells)
+	})
+
+	t.Run("FAIL/resolution", func(t *testing.T) {
+		_, err := hexgrid.Polyfill([]geodesy.Point{{0, 0}, {0, 1}, {1, 1}}, 16)
+		assert.ErrorIs(t, err, hexgrid.ErrResolution)
+	})
+
+	t.Run("FAIL/too_few_vertices", func(t *testing.T) {
+		_, err := hexgrid.Polyfill([]geodesy.Point{{0, 0}, {0, 1}}, 5)
+		assert.ErrorIs(t, err, polygon.ErrTooFewVertices)
+	})
+}
@@ -0,0 +1,19 @@
+// Package geomath holds the angle conversions and normalizations shared by the geodesy packages
+package geomath
+
+import "math"
+
+// RadToDeg is the factor converting radians to degrees
+const RadToDeg = 180 / math.Pi
+
+// NormalizeLon returns lon reduced to the range [-180, 180]. 180 is kept as is, so callers requiring a
+// half-open range must map it to -180 themselves
+func NormalizeLon(lon float64) float64 {
+	lon = math.Remainder(lon, 360)
+	if lon == 180 {
+		// Keep 180 as is, as math.Remainder may return either 180 or -180
+		return 180
+	}
+
+	return lon
+}
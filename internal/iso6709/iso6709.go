@@ -0,0 +1,272 @@
+// Package iso6709 implements the string representation of point locations defined in Annex H of
+// ISO 6709, shared by the geodesy package and its public iso6709 counterpart
+package iso6709
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrSyntax is returned when a string is not an ISO 6709 point location
+	ErrSyntax = errors.New("iso6709: invalid syntax")
+	// ErrRange is returned when a coordinate, or any of its minutes or seconds, is out of range
+	ErrRange = errors.New("iso6709: value out of range")
+)
+
+// Form represents the notation of the latitude and longitude of a point location
+type Form int
+
+const (
+	// Degrees formats decimal degrees, as in +40.20361-075.00417/
+	Degrees Form = iota
+	// DegreesMinutes formats degrees and decimal minutes, as in +4012.2166-07500.2502/
+	DegreesMinutes
+	// DegreesMinutesSeconds formats degrees, minutes and decimal seconds, as in +401213.0-0750015.0/
+	DegreesMinutesSeconds
+)
+
+// Location represents a point location. Alt is math.NaN() when the altitude is absent, and CRS holds
+// the coordinate reference system identifier following the CRS prefix, if any
+type Location struct {
+	Lat, Lon, Alt float64
+	CRS           string
+}
+
+// Parse parses s as an ISO 6709 point location, such as +40.20361-075.00417+350.517CRSWGS_84/.
+// The form of each coordinate is given by the amount of digits in its integer part, which for latitudes
+// is 2, 4 or 6 (±DD, ±DDMM or ±DDMMSS) and for longitudes 3, 5 or 7 (±DDD, ±DDDMM or ±DDDMMSS).
+// The terminating solidus is optional, as in earlier editions of the standard
+func Parse(s string) (Location, error) {
+	l := Location{Alt: math.NaN()}
+	sc := scanner{s: s}
+
+	var err error
+	if l.Lat, err = sc.coordinate("latitude", 2, 90); err != nil {
+		return Location{}, err
+	}
+	if l.Lon, err = sc.coordinate("longitude", 3, 180); err != nil {
+		return Location{}, err
+	}
+	if sc.peekSign() {
+		start := sc.i
+		sign := sc.sign()
+		integer, fraction := sc.digits(), ""
+		if integer == "" {
+			return Location{}, sc.errorf(ErrSyntax, "missing altitude digits")
+		}
+		if sc.consume('.') {
+			if fraction = sc.digits(); fraction == "" {
+				return Location{}, sc.errorf(ErrSyntax, "missing altitude decimals")
+			}
+		}
+		alt, err := strconv.ParseFloat(integer+"."+fraction+"0", 64)
+		if err != nil {
+			return Location{}, fmt.Errorf("%w: invalid altitude %q in %q", ErrSyntax, s[start:sc.i], s)
+		}
+		l.Alt = sign * alt
+	}
+	if strings.HasPrefix(s[sc.i:], "CRS") {
+		sc.i += len("CRS")
+		end := strings.IndexByte(s[sc.i:], '/')
+		if end < 0 {
+			end = len(s) - sc.i
+		}
+		if l.CRS = s[sc.i : sc.i+end]; l.CRS == "" {
+			return Location{}, sc.errorf(ErrSyntax, "missing CRS identifier")
+		}
+		sc.i += end
+	}
+	sc.consume('/')
+	if sc.i < len(s) {
+		return Location{}, sc.errorf(ErrSyntax, "unexpected %q", s[sc.i:])
+	}
+
+	return l, nil
+}
+
+// scanner reads the components of a point location from s, starting at the offset i in bytes
+type scanner struct {
+	s string
+	i int
+}
+
+func (sc *scanner) errorf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d in %q", err, fmt.Sprintf(format, args...), sc.i, sc.s)
+}
+
+func (sc *scanner) peekSign() bool {
+	return sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-')
+}
+
+func (sc *scanner) sign() float64 {
+	sc.i++
+	if sc.s[sc.i-1] == '-' {
+		return -1
+	}
+	return 1
+}
+
+func (sc *scanner) digits() string {
+	start := sc.i
+	for sc.i < len(sc.s) && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+		sc.i++
+	}
+	return sc.s[start:sc.i]
+}
+
+func (sc *scanner) consume(c byte) bool {
+	if sc.i < len(sc.s) && sc.s[sc.i] == c {
+		sc.i++
+		return true
+	}
+	return false
+}
+
+// coordinate reads a signed latitude or longitude whose degrees have the given amount of digits,
+// followed by optional minutes and seconds, and checks it does not exceed bound
+func (sc *scanner) coordinate(name string, width int, bound float64) (float64, error) {
+	if !sc.peekSign() {
+		return 0, sc.errorf(ErrSyntax, "missing %s sign", name)
+	}
+	start := sc.i
+	sign := sc.sign()
+	integer := sc.digits()
+	fraction := ""
+	if sc.consume('.') {
+		if fraction = sc.digits(); fraction == "" {
+			return 0, sc.errorf(ErrSyntax, "missing %s decimals", name)
+		}
+	}
+
+	var parts []string
+	switch len(integer) {
+	case width:
+		parts = []string{integer}
+	case width + 2:
+		parts = []string{integer[:width], integer[width:]}
+	case width + 4:
+		parts = []string{integer[:width], integer[width : width+2], integer[width+2:]}
+	default:
+		return 0, fmt.Errorf("%w: %s %q must have %d, %d or %d integer digits at offset %d in %q",
+			ErrSyntax, name, sc.s[start:sc.i], width, width+2, width+4, start, sc.s)
+	}
+
+	v := 0.0
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			part += "." + fraction + "0"
+		}
+		x, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid %s %q at offset %d in %q", ErrSyntax, name, sc.s[start:sc.i], start, sc.s)
+		}
+		if i > 0 && x >= 60 {
+			unit := [...]string{"minutes", "seconds"}[i-1]
+			return 0, fmt.Errorf("%w: %s %s of %s %q must be less than 60 at offset %d in %q",
+				ErrRange, unit, parts[i], name, sc.s[start:sc.i], start, sc.s)
+		}
+		v += x / math.Pow(60, float64(i))
+	}
+	if v > bound {
+		return 0, fmt.Errorf("%w: %s %q at offset %d in %q", ErrRange, name, sc.s[start:sc.i], start, sc.s)
+	}
+
+	return sign * v, nil
+}
+
+// Format returns l as an ISO 6709 point location in the given form, with precision decimal places in
+// the last component of its latitude and longitude. Values are rounded as a whole, so that minutes and
+// seconds never reach 60. The altitude, if present, is written in its shortest exact representation
+func Format(l Location, form Form, precision int) string {
+	var b strings.Builder
+	formatCoordinate(&b, l.Lat, 2, form, precision)
+	formatCoordinate(&b, l.Lon, 3, form, precision)
+	formatSuffix(&b, l)
+
+	return b.String()
+}
+
+// FormatExact returns l as an ISO 6709 point location in decimal degrees, with the shortest
+// representation of its latitude and longitude which is decoded back exactly
+func FormatExact(l Location) string {
+	var b strings.Builder
+	for _, c := range []struct {
+		v     float64
+		width int
+	}{{l.Lat, 2}, {l.Lon, 3}} {
+		if c.v < 0 {
+			b.WriteByte('-')
+		} else {
+			b.WriteByte('+')
+		}
+		s := strconv.FormatFloat(math.Abs(c.v), 'f', -1, 64)
+		integer := strings.IndexByte(s, '.')
+		if integer < 0 {
+			integer = len(s)
+		}
+		b.WriteString(strings.Repeat("0", c.width-integer))
+		b.WriteString(s)
+	}
+	formatSuffix(&b, l)
+
+	return b.String()
+}
+
+// formatSuffix writes the altitude and CRS identifier of l, if any, and the terminating solidus
+func formatSuffix(b *strings.Builder, l Location) {
+	if !math.IsNaN(l.Alt) {
+		if l.Alt >= 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(strconv.FormatFloat(l.Alt, 'f', -1, 64))
+	}
+	if l.CRS != "" {
+		b.WriteString("CRS")
+		b.WriteString(l.CRS)
+	}
+	b.WriteByte('/')
+}
+
+func formatCoordinate(b *strings.Builder, v float64, width int, form Form, precision int) {
+	if precision < 0 {
+		precision = 0
+	}
+
+	// The magnitude is rounded to an integer amount of the last unit, scaled by the precision,
+	// and then split into its components
+	perDegree := math.Pow(60, float64(form))
+	scale := math.Pow(10, float64(precision))
+	units := math.Round(math.Abs(v) * perDegree * scale)
+
+	// Values rounding to zero are written as positive
+	if v < 0 && units > 0 {
+		b.WriteByte('-')
+	} else {
+		b.WriteByte('+')
+	}
+
+	whole := math.Floor(units / scale / perDegree)
+	rest := units - whole*perDegree*scale
+	fmt.Fprintf(b, "%0*.0f", width, whole)
+	if form == DegreesMinutesSeconds {
+		minutes := math.Floor(rest / scale / 60)
+		fmt.Fprintf(b, "%02.0f", minutes)
+		rest -= minutes * 60 * scale
+	}
+	if form == Degrees {
+		// The integer part has been written already, and only the decimals remain
+		if precision > 0 {
+			fmt.Fprintf(b, ".%0*.0f", precision, rest)
+		}
+		return
+	}
+	last := strconv.FormatFloat(rest/scale, 'f', precision, 64)
+	if rest/scale < 10 {
+		last = "0" + last
+	}
+	b.WriteString(last)
+}
@@ -0,0 +1,54 @@
+// Package spatial implements the bounds of geodesic distances shared by the spatial indexes of the
+// geodesy packages, which prune their searches by great circle distances on a sphere
+package spatial
+
+import (
+	"reflect"
+
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+const (
+	// SphericalBound is a lower bound of the ratio of geodesic distances on the WGS-84 ellipsoid to
+	// great circle distances on the sphere of its mean radius, which lies above 0.994
+	SphericalBound = 0.99
+	// Roundoff is an upper bound of the error of great circle distances computed from unit vectors or
+	// trigonometric functions, in meters (m)
+	Roundoff = 1e-6
+)
+
+// LowerBound returns a lower bound of the geodesic distance between two points separated by the given
+// central angle, in radians: the great circle distance on the sphere of the mean radius of the WGS-84
+// ellipsoid, scaled by SphericalBound, less Roundoff
+func LowerBound(angle float64) float64 {
+	return SphericalBound*ellipsoids.WGS84_MEAN_RADIUS*angle - Roundoff
+}
+
+// Comparable reports whether v may be compared with == without panicking, which is not the case of
+// slices, maps and functions, nor of the interfaces, structs and arrays holding any of them
+func Comparable(v interface{}) bool {
+	return comparable(reflect.ValueOf(v))
+}
+
+func comparable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	case reflect.Interface:
+		return v.IsNil() || comparable(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !comparable(v.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !comparable(v.Index(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+// Package sphere implements the cartesian vectors used by the geodesy packages to represent points on
+// the unit sphere
+package sphere
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/internal/geomath"
+)
+
+// Vector represents a point by its cartesian coordinates, usually on the unit sphere
+type Vector [3]float64
+
+// FromPoint returns the unit vector of p
+func FromPoint(p geodesy.Point) Vector {
+	return FromRadians(p.LatRadians(), p.LonRadians())
+}
+
+// FromRadians returns the unit vector of the latitude φ and longitude λ, in radians
+func FromRadians(φ, λ float64) Vector {
+	sinφ, cosφ := math.Sincos(φ)
+	sinλ, cosλ := math.Sincos(λ)
+
+	return Vector{cosφ * cosλ, cosφ * sinλ, sinφ}
+}
+
+// Point returns the point in the direction of v
+func (v Vector) Point() geodesy.Point {
+	return geodesy.Point{
+		math.Atan2(v[2], math.Hypot(v[0], v[1])) * geomath.RadToDeg,
+		math.Atan2(v[1], v[0]) * geomath.RadToDeg,
+	}
+}
+
+func (v Vector) Add(w Vector) Vector {
+	return Vector{v[0] + w[0], v[1] + w[1], v[2] + w[2]}
+}
+
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{v[0] - w[0], v[1] - w[1], v[2] - w[2]}
+}
+
+func (v Vector) Scale(k float64) Vector {
+	return Vector{k * v[0], k * v[1], k * v[2]}
+}
+
+func (v Vector) Dot(w Vector) float64 {
+	return v[0]*w[0] + v[1]*w[1] + v[2]*w[2]
+}
+
+func (v Vector) Cross(w Vector) Vector {
+	return Vector{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}
+}
+
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+func (v Vector) Normalize() Vector {
+	return v.Scale(1 / v.Norm())
+}
+
+// Angle returns the angular distance between the unit vectors v and w, in radians, which is accurate
+// for any angle
+func (v Vector) Angle(w Vector) float64 {
+	return math.Atan2(v.Cross(w).Norm(), v.Dot(w))
+}
+
+// ChordAngle returns the angular distance between the unit vectors v and w, in radians, from the chord
+// between them. It is cheaper than Angle, and as accurate except for nearly antipodal vectors
+func (v Vector) ChordAngle(w Vector) float64 {
+	d := v.Sub(w)
+	return 2 * math.Asin(math.Min(d.Norm()/2, 1))
+}
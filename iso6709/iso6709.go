@@ -0,0 +1,61 @@
+// Package iso6709 parses and formats point locations in the string representation defined in
+// Annex H of ISO 6709, such as +40.20361-075.00417+350.517CRSWGS_84/
+package iso6709
+
+import (
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/internal/iso6709"
+)
+
+var (
+	// ErrSyntax is returned when a string is not an ISO 6709 point location
+	ErrSyntax = iso6709.ErrSyntax
+	// ErrRange is returned when a coordinate, or any of its minutes or seconds, is out of range
+	ErrRange = iso6709.ErrRange
+)
+
+// Form represents the notation of the latitude and longitude of a point location
+type Form = iso6709.Form
+
+const (
+	// Degrees formats decimal degrees, as in +40.20361-075.00417/
+	Degrees = iso6709.Degrees
+	// DegreesMinutes formats degrees and decimal minutes, as in +4012.2166-07500.2502/
+	DegreesMinutes = iso6709.DegreesMinutes
+	// DegreesMinutesSeconds formats degrees, minutes and decimal seconds, as in +401213.0-0750015.0/
+	DegreesMinutesSeconds = iso6709.DegreesMinutesSeconds
+)
+
+// Location represents an ISO 6709 point location, whose point holds a math.NaN() height when
+// the altitude is absent. CRS holds the coordinate reference system identifier following the
+// CRS prefix (such as WGS_84 or EPSG:4979), and is empty when absent
+type Location struct {
+	Point geodesy.Point3D
+	CRS   string
+}
+
+// Parse parses s as an ISO 6709 point location. Latitudes and longitudes may be written in any of the
+// forms of the standard, which is given by the amount of digits of their integer part: ±DD, ±DDMM or
+// ±DDMMSS for latitudes and ±DDD, ±DDDMM or ±DDDMMSS for longitudes, each followed by optional decimals.
+// The altitude and the CRS identifier are optional, as is the terminating solidus.
+// The returned errors wrap either ErrSyntax or ErrRange
+func Parse(s string) (Location, error) {
+	l, err := iso6709.Parse(s)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return Location{Point: geodesy.Point3D{l.Lat, l.Lon, l.Alt}, CRS: l.CRS}, nil
+}
+
+// Format returns l as an ISO 6709 point location in the given form, with precision decimal places in
+// the last component of its latitude and longitude. The altitude is written when the height of the
+// point is known. If the point does not constitute a valid geographic coordinate, it returns an
+// empty string
+func Format(l Location, form Form, precision int) string {
+	if !l.Point.Valid() {
+		return ""
+	}
+
+	return iso6709.Format(iso6709.Location{Lat: l.Point.Lat(), Lon: l.Point.Lon(), Alt: l.Point.Height(), CRS: l.CRS}, form, precision)
+}
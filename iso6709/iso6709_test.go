@@ -0,0 +1,112 @@
+package iso6709_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/iso6709"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name  string
+		input string
+		want  geodesy.Point3D
+		crs   string
+	}{
+		{name: "OK/degrees", input: "+40.20361-075.00417/", want: geodesy.Point3D{40.20361, -75.00417, nan}},
+		{name: "OK/degrees_altitude", input: "+40.20361-075.00417+350.517/", want: geodesy.Point3D{40.20361, -75.00417, 350.517}},
+		{name: "OK/degrees_altitude_crs", input: "+40.20361-075.00417+350.517CRSWGS_84/", want: geodesy.Point3D{40.20361, -75.00417, 350.517}, crs: "WGS_84"},
+		{name: "OK/crs_without_altitude", input: "-33.8688+151.2093CRSEPSG:4326/", want: geodesy.Point3D{-33.8688, 151.2093, nan}, crs: "EPSG:4326"},
+		{name: "OK/degrees_minutes", input: "+4012.2166-07500.2502/", want: geodesy.Point3D{40 + 12.2166/60, -(75 + 0.2502/60), nan}},
+		{name: "OK/degrees_minutes_seconds", input: "+401213.1-0750015.1-12/", want: geodesy.Point3D{40 + 12.0/60 + 13.1/3600, -(75 + 15.1/3600), -12}},
+		{name: "OK/integers", input: "+40-075/", want: geodesy.Point3D{40, -75, nan}},
+		{name: "OK/mixed_forms", input: "+4012-075.5/", want: geodesy.Point3D{40.2, -75.5, nan}},
+		{name: "OK/without_solidus", input: "+40.20361-075.00417", want: geodesy.Point3D{40.20361, -75.00417, nan}},
+		{name: "OK/bounds", input: "-90+180/", want: geodesy.Point3D{-90, 180, nan}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := iso6709.Parse(tt.input)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.Lat(), got.Point.Lat(), 1e-12)
+			assert.InDelta(t, tt.want.Lon(), got.Point.Lon(), 1e-12)
+			assert.Equal(t, tt.want.HasHeight(), got.Point.HasHeight())
+			if tt.want.HasHeight() {
+				assert.Equal(t, tt.want.Height(), got.Point.Height())
+			}
+			assert.Equal(t, tt.crs, got.CRS)
+		})
+	}
+
+	failures := []struct {
+		name    string
+		input   string
+		err     error
+		message string
+	}{
+		{name: "FAIL/empty", input: "", err: iso6709.ErrSyntax, message: `iso6709: invalid syntax: missing latitude sign at offset 0 in ""`},
+		{name: "FAIL/unsigned_longitude", input: "+40.2075.0/", err: iso6709.ErrSyntax},
+		{name: "FAIL/latitude_digits", input: "+040.2-075.0/", err: iso6709.ErrSyntax, message: `iso6709: invalid syntax: latitude "+040.2" must have 2, 4 or 6 integer digits at offset 0 in "+040.2-075.0/"`},
+		{name: "FAIL/longitude_digits", input: "+40.2-75.0/", err: iso6709.ErrSyntax},
+		{name: "FAIL/missing_decimals", input: "+40.-075.0/", err: iso6709.ErrSyntax},
+		{name: "FAIL/minutes", input: "+4060-07500/", err: iso6709.ErrRange, message: `iso6709: value out of range: minutes 60 of latitude "+4060" must be less than 60 at offset 0 in "+4060-07500/"`},
+		{name: "FAIL/seconds", input: "+401260.5-0750000/", err: iso6709.ErrRange},
+		{name: "FAIL/latitude_range", input: "+90.1-075.0/", err: iso6709.ErrRange},
+		{name: "FAIL/longitude_range", input: "+40.0+18030/", err: iso6709.ErrRange},
+		{name: "FAIL/missing_crs", input: "+40.0-075.0CRS/", err: iso6709.ErrSyntax},
+		{name: "FAIL/trailing", input: "+40.0-075.0/x", err: iso6709.ErrSyntax, message: `iso6709: invalid syntax: unexpected "x" at offset 12 in "+40.0-075.0/x"`},
+		{name: "FAIL/altitude", input: "+40.0-075.0+.5/", err: iso6709.ErrSyntax},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iso6709.Parse(tt.input)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.message != "" {
+				assert.EqualError(t, err, tt.message)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name      string
+		l         iso6709.Location
+		form      iso6709.Form
+		precision int
+		want      string
+	}{
+		{name: "OK/degrees", l: iso6709.Location{Point: geodesy.Point3D{40.20361, -75.00417, nan}}, form: iso6709.Degrees, precision: 5, want: "+40.20361-075.00417/"},
+		{name: "OK/degrees_altitude_crs", l: iso6709.Location{Point: geodesy.Point3D{40.20361, -75.00417, 350.517}, CRS: "WGS_84"}, form: iso6709.Degrees, precision: 5, want: "+40.20361-075.00417+350.517CRSWGS_84/"},
+		{name: "OK/negative_altitude", l: iso6709.Location{Point: geodesy.Point3D{-5, 5, -10.5}}, form: iso6709.Degrees, precision: 0, want: "-05+005-10.5/"},
+		{name: "OK/degrees_minutes", l: iso6709.Location{Point: geodesy.Point3D{40.5, -7.25, nan}}, form: iso6709.DegreesMinutes, precision: 2, want: "+4030.00-00715.00/"},
+		{name: "OK/degrees_minutes_seconds", l: iso6709.Location{Point: geodesy.Point3D{40 + 12.0/60 + 13.1/3600, -(75 + 15.1/3600), nan}}, form: iso6709.DegreesMinutesSeconds, precision: 1, want: "+401213.1-0750015.1/"},
+		{name: "OK/seconds_carry", l: iso6709.Location{Point: geodesy.Point3D{10.99999999, 0, nan}}, form: iso6709.DegreesMinutesSeconds, precision: 0, want: "+110000+0000000/"},
+		{name: "OK/negative_zero", l: iso6709.Location{Point: geodesy.Point3D{-0.000001, -0.000001, nan}}, form: iso6709.Degrees, precision: 3, want: "+00.000+000.000/"},
+		{name: "FAIL/invalid_point", l: iso6709.Location{Point: geodesy.Point3D{91, 0, nan}}, form: iso6709.Degrees, precision: 1, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, iso6709.Format(tt.l, tt.form, tt.precision))
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		points := []geodesy.Point3D{{0, 0, nan}, {-90, 180, 0}, {45.123456789, -120.987654321, 8848.86}, {-12.000001, 0.5, -430.5}}
+		for _, form := range []iso6709.Form{iso6709.Degrees, iso6709.DegreesMinutes, iso6709.DegreesMinutesSeconds} {
+			for _, p := range points {
+				l, err := iso6709.Parse(iso6709.Format(iso6709.Location{Point: p, CRS: "EPSG:4979"}, form, 6))
+				assert.NoError(t, err)
+				assert.InDelta(t, p.Lat(), l.Point.Lat(), 1e-6)
+				assert.InDelta(t, p.Lon(), l.Point.Lon(), 1e-6)
+				assert.True(t, p.Equals(geodesy.Point3D{p.Lat(), p.Lon(), l.Point.Height()}))
+				assert.Equal(t, "EPSG:4979", l.CRS)
+			}
+		}
+	})
+}
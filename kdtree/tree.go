@@ -0,0 +1,279 @@
+// Package kdtree implements a static spatial index of points for nearest neighbor and radius queries by
+// geodesic distance. Points are indexed as unit vectors in a 3-dimensional k-d tree, which has no
+// singularities at the poles or the antimeridian, and candidates are ranked by their exact geodesic
+// distance on the WGS-84 ellipsoid
+package kdtree
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/internal/spatial"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+// ErrInvalidPoint is returned when an item to be indexed has an invalid point
+var ErrInvalidPoint = errors.New("kdtree: invalid point")
+
+// leafSize is the maximum amount of items of the ranges of the tree which are not split
+const leafSize = 8
+
+// Item is a point indexed by a tree, along with an arbitrary value identifying it
+type Item struct {
+	Point geodesy.Point
+	Value interface{}
+}
+
+// Neighbor is an item found by a query, along with its geodesic distance to the queried point, in
+// meters (m)
+type Neighbor struct {
+	Item
+	Distance float64
+}
+
+// Tree is a k-d tree of items, which is immutable once built and thus safe for concurrent queries
+type Tree struct {
+	items   []Item
+	vectors []sphere.Vector
+}
+
+// New returns a tree of the given items, which are copied and bulk loaded by splitting them at the
+// median of alternating coordinates of their unit vectors
+func New(items []Item) (*Tree, error) {
+	t := &Tree{
+		items:   make([]Item, len(items)),
+		vectors: make([]sphere.Vector, len(items)),
+	}
+	copy(t.items, items)
+	for k, item := range t.items {
+		if !item.Point.Valid() {
+			return nil, fmt.Errorf("%w: %v at index %d", ErrInvalidPoint, item.Point, k)
+		}
+		t.vectors[k] = sphere.FromPoint(item.Point)
+	}
+	t.build(0, len(items), 0)
+
+	return t, nil
+}
+
+// Len returns the amount of items of t
+func (t *Tree) Len() int {
+	return len(t.items)
+}
+
+// build partitions the items in [lo, hi) around their median along the axis of the given depth, and
+// recursively both halves around it
+func (t *Tree) build(lo, hi, depth int) {
+	if hi-lo <= leafSize {
+		return
+	}
+	m := (lo + hi) / 2
+	t.selectNth(lo, hi, m, depth%3)
+	t.build(lo, m, depth+1)
+	t.build(m+1, hi, depth+1)
+}
+
+// selectNth reorders the items in [lo, hi) so that the item at n is the one which would be there if they
+// were sorted along axis, preceded by lower or equal items and followed by greater or equal ones
+func (t *Tree) selectNth(lo, hi, n, axis int) {
+	for hi-lo > 1 {
+		// Partition around the median of three items, by Hoare's scheme
+		mid := lo + (hi-lo)/2
+		a, b, c := t.vectors[lo][axis], t.vectors[mid][axis], t.vectors[hi-1][axis]
+		pivot := math.Max(math.Min(a, b), math.Min(math.Max(a, b), c))
+		i, j := lo, hi-1
+		for i <= j {
+			for t.vectors[i][axis] < pivot {
+				i++
+			}
+			for t.vectors[j][axis] > pivot {
+				j--
+			}
+			if i <= j {
+				t.swap(i, j)
+				i++
+				j--
+			}
+		}
+		switch {
+		case n <= j:
+			hi = j + 1
+		case n >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+func (t *Tree) swap(i, j int) {
+	t.items[i], t.items[j] = t.items[j], t.items[i]
+	t.vectors[i], t.vectors[j] = t.vectors[j], t.vectors[i]
+}
+
+// box is the axis-aligned bounding box of the unit vectors of a range of the tree
+type box struct {
+	min, max sphere.Vector
+}
+
+var unitBox = box{min: sphere.Vector{-1, -1, -1}, max: sphere.Vector{1, 1, 1}}
+
+// lowerBound returns a lower bound of the geodesic distance from the unit vector v to any point within b,
+// in meters (m)
+func (b box) lowerBound(v sphere.Vector) float64 {
+	var d2 float64
+	for axis := 0; axis < 3; axis++ {
+		if d := b.min[axis] - v[axis]; d > 0 {
+			d2 += d * d
+		} else if d := v[axis] - b.max[axis]; d > 0 {
+			d2 += d * d
+		}
+	}
+
+	return lowerBound(math.Sqrt(d2))
+}
+
+// lowerBound returns a lower bound of the geodesic distance between two points whose unit vectors are
+// separated by the given chord, in meters (m)
+func lowerBound(chord float64) float64 {
+	return spatial.LowerBound(2 * math.Asin(math.Min(chord/2, 1)))
+}
+
+// collector gathers the items found by a search
+type collector interface {
+	// bound returns the geodesic distance beyond which items are not collected
+	bound() float64
+	// add considers the item at index k, whose geodesic distance is not lower than lowerBound
+	add(k int, lowerBound float64)
+}
+
+// search passes to c the items in [lo, hi) which may lie within its bound of the unit vector v, nearest
+// ranges first, skipping the ranges whose bounding box lies beyond it
+func (t *Tree) search(lo, hi, depth int, b box, v sphere.Vector, c collector) {
+	if hi <= lo || b.lowerBound(v) > c.bound() {
+		return
+	}
+	if hi-lo <= leafSize {
+		for k := lo; k < hi; k++ {
+			c.add(k, lowerBound(v.Sub(t.vectors[k]).Norm()))
+		}
+		return
+	}
+
+	m := (lo + hi) / 2
+	c.add(m, lowerBound(v.Sub(t.vectors[m]).Norm()))
+	axis := depth % 3
+	split := t.vectors[m][axis]
+	left, right := b, b
+	left.max[axis], right.min[axis] = split, split
+	if v[axis] < split {
+		t.search(lo, m, depth+1, left, v, c)
+		t.search(m+1, hi, depth+1, right, v, c)
+		return
+	}
+	t.search(m+1, hi, depth+1, right, v, c)
+	t.search(lo, m, depth+1, left, v, c)
+}
+
+// Nearest returns the k items of t nearest to p, sorted by their geodesic distance to it. If p is
+// invalid or k is not positive, the returned slice is nil
+func (t *Tree) Nearest(p geodesy.Point, k int) []Neighbor {
+	if !p.Valid() || k <= 0 {
+		return nil
+	}
+
+	c := &nearestCollector{t: t, p: p, k: k, nearest: make(neighborHeap, 0, k)}
+	t.search(0, len(t.items), 0, unitBox, sphere.FromPoint(p), c)
+	sort.Sort(byDistance(c.nearest))
+
+	return c.nearest
+}
+
+// nearestCollector keeps the k nearest items found in a max-heap by distance, whose farthest item bounds
+// the search once there are k of them
+type nearestCollector struct {
+	t       *Tree
+	p       geodesy.Point
+	k       int
+	nearest neighborHeap
+}
+
+func (c *nearestCollector) bound() float64 {
+	if len(c.nearest) < c.k {
+		return math.Inf(1)
+	}
+	return c.nearest[0].Distance
+}
+
+func (c *nearestCollector) add(k int, lowerBound float64) {
+	if lowerBound > c.bound() {
+		return
+	}
+	n := Neighbor{Item: c.t.items[k], Distance: geodesic.WGS84.Inverse(c.p, c.t.items[k].Point).Distance}
+	if len(c.nearest) < c.k {
+		heap.Push(&c.nearest, n)
+	} else if n.Distance < c.nearest[0].Distance {
+		c.nearest[0] = n
+		heap.Fix(&c.nearest, 0)
+	}
+}
+
+// Within returns the items of t within the given geodesic distance of p, in meters (m), sorted by their
+// distance to it. If p is invalid or radius is negative, the returned slice is nil
+func (t *Tree) Within(p geodesy.Point, radius float64) []Neighbor {
+	if !p.Valid() || !(radius >= 0) {
+		return nil
+	}
+
+	c := &withinCollector{t: t, p: p, radius: radius}
+	t.search(0, len(t.items), 0, unitBox, sphere.FromPoint(p), c)
+	sort.Sort(byDistance(c.within))
+
+	return c.within
+}
+
+// withinCollector keeps the items found within a radius
+type withinCollector struct {
+	t      *Tree
+	p      geodesy.Point
+	radius float64
+	within []Neighbor
+}
+
+func (c *withinCollector) bound() float64 {
+	return c.radius
+}
+
+func (c *withinCollector) add(k int, lowerBound float64) {
+	if lowerBound > c.radius {
+		return
+	}
+	if d := geodesic.WGS84.Inverse(c.p, c.t.items[k].Point).Distance; d <= c.radius {
+		c.within = append(c.within, Neighbor{Item: c.t.items[k], Distance: d})
+	}
+}
+
+type byDistance []Neighbor
+
+func (n byDistance) Len() int           { return len(n) }
+func (n byDistance) Less(i, j int) bool { return n[i].Distance < n[j].Distance }
+func (n byDistance) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+// neighborHeap is a max-heap of neighbors by distance
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int            { return len(h) }
+func (h neighborHeap) Less(i, j int) bool  { return h[i].Distance > h[j].Distance }
+func (h neighborHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x interface{}) { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,184 @@
+package kdtree_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/kdtree"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomItems returns n items at random points, clustered around a few centers as well as spread over
+// the whole sphere, whose values are their indexes
+func randomItems(r *rand.Rand, n int) []kdtree.Item {
+	centers := []geodesy.Point{{40.4168, -3.7038}, {89.9, 0}, {-0.5, 179.9}, {-33.8688, 151.2093}}
+	items := make([]kdtree.Item, n)
+	for k := range items {
+		var p geodesy.Point
+		if k%2 == 0 {
+			p = geodesy.Point{math.Asin(2*r.Float64()-1) * 180 / math.Pi, 360*r.Float64() - 180}
+		} else {
+			c := centers[k%len(centers)]
+			p = geodesy.Point{
+				math.Max(-90, math.Min(90, c.Lat()+r.NormFloat64())),
+				math.Remainder(c.Lon()+r.NormFloat64(), 360),
+			}
+		}
+		items[k] = kdtree.Item{Point: p, Value: k}
+	}
+
+	return items
+}
+
+// bruteForce returns the neighbors of p among items, sorted by distance
+func bruteForce(items []kdtree.Item, p geodesy.Point) []kdtree.Neighbor {
+	neighbors := make([]kdtree.Neighbor, len(items))
+	for k, item := range items {
+		neighbors[k] = kdtree.Neighbor{Item: item, Distance: geodesic.WGS84.Inverse(p, item.Point).Distance}
+	}
+	sort.SliceStable(neighbors, func(i, j int) bool { return neighbors[i].Distance < neighbors[j].Distance })
+
+	return neighbors
+}
+
+// queries returns points near and far from the items
+func queries(r *rand.Rand) []geodesy.Point {
+	points := []geodesy.Point{{40.4, -3.7}, {90, 0}, {-90, 0}, {0, 180}, {0, -180}, {-33.9, 151.2}, {10, 10}}
+	for k := 0; k < 20; k++ {
+		points = append(points, geodesy.Point{180*r.Float64() - 90, 360*r.Float64() - 180})
+	}
+
+	return points
+}
+
+func distances(neighbors []kdtree.Neighbor) []float64 {
+	d := make([]float64, len(neighbors))
+	for k, n := range neighbors {
+		d[k] = n.Distance
+	}
+	return d
+}
+
+func TestTree_Nearest(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items := randomItems(r, 5000)
+	tree, err := kdtree.New(items)
+	assert.NoError(t, err)
+	assert.Equal(t, len(items), tree.Len())
+
+	for _, p := range queries(r) {
+		want := bruteForce(items, p)
+		for _, k := range []int{1, 5, 50} {
+			got := tree.Nearest(p, k)
+			assert.Len(t, got, k)
+			assert.Equal(t, distances(want[:k]), distances(got), "%v", p)
+		}
+	}
+
+	t.Run("OK/more_than_len", func(t *testing.T) {
+		tree, err := kdtree.New(items[:10])
+		assert.NoError(t, err)
+		got := tree.Nearest(geodesy.Point{0, 0}, 20)
+		assert.Equal(t, distances(bruteForce(items[:10], geodesy.Point{0, 0})), distances(got))
+	})
+
+	t.Run("OK/empty", func(t *testing.T) {
+		tree, err := kdtree.New(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, tree.Nearest(geodesy.Point{0, 0}, 3))
+	})
+
+	t.Run("OK/duplicates", func(t *testing.T) {
+		dup := make([]kdtree.Item, 100)
+		for k := range dup {
+			dup[k] = kdtree.Item{Point: geodesy.Point{10, 20}, Value: k}
+		}
+		tree, err := kdtree.New(dup)
+		assert.NoError(t, err)
+		got := tree.Nearest(geodesy.Point{10, 20}, 100)
+		assert.Len(t, got, 100)
+		assert.Equal(t, 0.0, got[99].Distance)
+	})
+
+	assert.Nil(t, tree.Nearest(geodesy.Point{91, 0}, 1))
+	assert.Nil(t, tree.Nearest(geodesy.Point{0, 0}, 0))
+}
+
+func TestTree_Within(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	items := randomItems(r, 5000)
+	tree, err := kdtree.New(items)
+	assert.NoError(t, err)
+
+	for _, p := range queries(r) {
+		all := bruteForce(items, p)
+		for _, radius := range []float64{0, 10_000, 150_000, 2_000_000} {
+			var want []kdtree.Neighbor
+			for _, n := range all {
+				if n.Distance <= radius {
+					want = append(want, n)
+				}
+			}
+			got := tree.Within(p, radius)
+			assert.Equal(t, distances(want), distances(got), "%v within %v", p, radius)
+		}
+	}
+
+	assert.Len(t, tree.Within(geodesy.Point{0, 0}, 30_000_000), len(items))
+	assert.Nil(t, tree.Within(geodesy.Point{0, 0}, -1))
+	assert.Nil(t, tree.Within(geodesy.Point{0, 0}, math.NaN()))
+	assert.Nil(t, tree.Within(geodesy.Point{0, 181}, 1))
+}
+
+func TestTree_Concurrent(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	items := randomItems(r, 2000)
+	tree, err := kdtree.New(items)
+	assert.NoError(t, err)
+	points := queries(r)
+
+	want := make([][]kdtree.Neighbor, len(points))
+	for k, p := range points {
+		want[k] = tree.Nearest(p, 10)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k, p := range points {
+				assert.Equal(t, want[k], tree.Nearest(p, 10))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNew(t *testing.T) {
+	items := []kdtree.Item{{Point: geodesy.Point{0, 0}}, {Point: geodesy.Point{95, 0}}}
+	_, err := kdtree.New(items)
+	assert.ErrorIs(t, err, kdtree.ErrInvalidPoint)
+
+	// Items are copied
+	items = []kdtree.Item{{Point: geodesy.Point{0, 0}, Value: "a"}, {Point: geodesy.Point{1, 1}, Value: "b"}}
+	tree, err := kdtree.New(items)
+	assert.NoError(t, err)
+	items[0].Value = "c"
+	assert.Equal(t, "a", tree.Nearest(geodesy.Point{0, 0}, 1)[0].Value)
+}
+
+func BenchmarkTree_Nearest(b *testing.B) {
+	r := rand.New(rand.NewSource(4))
+	tree, _ := kdtree.New(randomItems(r, 500_000))
+	points := queries(r)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Nearest(points[i%len(points)], 1)
+	}
+}
@@ -0,0 +1,103 @@
+// Package maidenhead implements Maidenhead locators, used by amateur radio operators to identify the
+// cells of a grid of latitudes and longitudes, such as "JN58td". Locators are made of pairs of
+// characters encoding the longitude and latitude of fields of 20° by 10° (A to R), squares of 2° by 1°
+// (0 to 9), subsquares of 5' by 2.5' (a to x), extended squares of 30" by 15" (0 to 9) and extended
+// subsquares of 1.25" by 0.625" (a to x)
+package maidenhead
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// ErrSyntax is returned when a string is not a Maidenhead locator
+var ErrSyntax = errors.New("maidenhead: invalid locator")
+
+// MaxLength is the maximum amount of characters of locators
+const MaxLength = 10
+
+// pair describes the characters of each pair of a locator
+type pair struct {
+	first byte
+	base  int
+}
+
+var pairs = [MaxLength / 2]pair{{'A', 18}, {'0', 10}, {'a', 24}, {'0', 10}, {'a', 24}}
+
+// Encode returns the locator of the given even amount of characters, from 2 to MaxLength, of the cell
+// containing p. Letters of fields are upper case, and letters of subsquares are lower case. If p is
+// invalid or length is not valid, the returned locator is empty
+func Encode(p geodesy.Point, length int) string {
+	if !p.Valid() || length < 2 || length > MaxLength || length%2 == 1 {
+		return ""
+	}
+
+	// Longitudes and latitudes are scaled to units of the first pair. Points at 90° belong to the
+	// northernmost cells, and points at 180° to the westernmost ones
+	lon := (p.Lon() - geodesy.LonLowerBound) / 20
+	lat := math.Min((p.Lat()-geodesy.LatLowerBound)/10, math.Nextafter(float64(pairs[0].base), 0))
+	locator := make([]byte, length)
+	for i := 0; i < length/2; i++ {
+		pr := pairs[i]
+		x, y := math.Floor(lon), math.Floor(lat)
+		locator[2*i] = pr.first + byte(int(x)%pr.base)
+		locator[2*i+1] = pr.first + byte(y)
+		if i+1 < len(pairs) {
+			lon = (lon - x) * float64(pairs[i+1].base)
+			lat = (lat - y) * float64(pairs[i+1].base)
+		}
+	}
+
+	return string(locator)
+}
+
+// Bounds returns the bounding box of the cell of the locator, which is case-insensitive
+func Bounds(locator string) (geodesy.BoundingBox, error) {
+	if len(locator) < 2 || len(locator) > MaxLength || len(locator)%2 == 1 {
+		nan := math.NaN()
+		return geodesy.BoundingBox{South: nan, West: nan, North: nan, East: nan},
+			fmt.Errorf("%w: %q has %d characters instead of an even amount from 2 to %d", ErrSyntax, locator, len(locator), MaxLength)
+	}
+
+	west, south := float64(geodesy.LonLowerBound), float64(geodesy.LatLowerBound)
+	width, height := 360.0, 180.0
+	for i := 0; i < len(locator); i++ {
+		pr := pairs[i/2]
+		d := int(upper(locator[i])) - int(upper(pr.first))
+		if d < 0 || d >= pr.base {
+			nan := math.NaN()
+			return geodesy.BoundingBox{South: nan, West: nan, North: nan, East: nan},
+				fmt.Errorf("%w: invalid character %q at offset %d in %q", ErrSyntax, locator[i], i, locator)
+		}
+		if i%2 == 0 {
+			width /= float64(pr.base)
+			west += float64(d) * width
+		} else {
+			height /= float64(pr.base)
+			south += float64(d) * height
+		}
+	}
+
+	return geodesy.BoundingBox{South: south, West: west, North: south + height, East: west + width}, nil
+}
+
+// upper returns the upper case of the letter c, or c otherwise
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
+
+// Decode returns the center of the cell of the locator, which is case-insensitive
+func Decode(locator string) (geodesy.Point, error) {
+	b, err := Bounds(locator)
+	if err != nil {
+		return geodesy.Point{math.NaN(), math.NaN()}, err
+	}
+
+	return geodesy.Point{(b.South + b.North) / 2, (b.West + b.East) / 2}, nil
+}
@@ -0,0 +1,97 @@
+package maidenhead_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/maidenhead"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      geodesy.Point
+		length int
+		want   string
+	}{
+		{name: "OK/munich", p: geodesy.Point{48.14666, 11.60833}, length: 6, want: "JN58td"},
+		{name: "OK/montevideo", p: geodesy.Point{-34.91, -56.21166}, length: 6, want: "GF15vc"},
+		{name: "OK/washington", p: geodesy.Point{38.92, -77.065}, length: 6, want: "FM18lw"},
+		{name: "OK/wellington", p: geodesy.Point{-41.2833, 174.745}, length: 6, want: "RE78ir"},
+		{name: "OK/field", p: geodesy.Point{48.14666, 11.60833}, length: 2, want: "JN"},
+		{name: "OK/extended", p: geodesy.Point{48.14666, 11.60833}, length: 10, want: "JN58td25xe"},
+		{name: "OK/north_east_corner", p: geodesy.Point{90, 179.9999999}, length: 10, want: "RR99xx99xx"},
+		{name: "OK/south_west_corner", p: geodesy.Point{-90, -180}, length: 8, want: "AA00aa00"},
+		{name: "OK/antimeridian", p: geodesy.Point{0, 180}, length: 4, want: "AJ00"},
+		{name: "FAIL/odd_length", p: geodesy.Point{0, 0}, length: 5, want: ""},
+		{name: "FAIL/too_long", p: geodesy.Point{0, 0}, length: 12, want: ""},
+		{name: "FAIL/invalid_point", p: geodesy.Point{0, 181}, length: 6, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, maidenhead.Encode(tt.p, tt.length))
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		locator string
+		want    geodesy.BoundingBox
+		wantErr error
+	}{
+		{name: "OK/field", locator: "JN", want: geodesy.BoundingBox{South: 40, West: 0, North: 50, East: 20}},
+		{name: "OK/square", locator: "JN58", want: geodesy.BoundingBox{South: 48, West: 10, North: 49, East: 12}},
+		{name: "OK/subsquare", locator: "JN58td", want: geodesy.BoundingBox{South: 48.125, West: 11.5833333333, North: 48.1666666667, East: 11.6666666667}},
+		{name: "OK/case_insensitive", locator: "jn58TD", want: geodesy.BoundingBox{South: 48.125, West: 11.5833333333, North: 48.1666666667, East: 11.6666666667}},
+		{name: "OK/extended", locator: "AA00aa00aa", want: geodesy.BoundingBox{South: -90, West: -180, North: -90 + 1.0/24/10/24, East: -180 + 2.0/24/10/24}},
+		{name: "FAIL/empty", locator: "", wantErr: maidenhead.ErrSyntax},
+		{name: "FAIL/odd_length", locator: "JN5", wantErr: maidenhead.ErrSyntax},
+		{name: "FAIL/too_long", locator: "JN58td25ht00", wantErr: maidenhead.ErrSyntax},
+		{name: "FAIL/field_out_of_range", locator: "SN58", wantErr: maidenhead.ErrSyntax},
+		{name: "FAIL/subsquare_out_of_range", locator: "JN58yd", wantErr: maidenhead.ErrSyntax},
+		{name: "FAIL/letter_in_square", locator: "JNA8", wantErr: maidenhead.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := maidenhead.Bounds(tt.locator)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, got.Valid())
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.South, got.South, 1e-9)
+			assert.InDelta(t, tt.want.West, got.West, 1e-9)
+			assert.InDelta(t, tt.want.North, got.North, 1e-9)
+			assert.InDelta(t, tt.want.East, got.East, 1e-9)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	p, err := maidenhead.Decode("JN58td")
+	assert.NoError(t, err)
+	assert.InDelta(t, 48.1458333333, p.Lat(), 1e-9)
+	assert.InDelta(t, 11.625, p.Lon(), 1e-9)
+
+	p, err = maidenhead.Decode("JN5")
+	assert.ErrorIs(t, err, maidenhead.ErrSyntax)
+	assert.False(t, p.Valid())
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range []geodesy.Point{{0.001, 0.001}, {51.5074, -0.1278}, {-33.8688, 151.2093}, {89.99, 179.99}, {-89.99, -180}} {
+			for length := 2; length <= maidenhead.MaxLength; length += 2 {
+				locator := maidenhead.Encode(p, length)
+				b, err := maidenhead.Bounds(locator)
+				assert.NoError(t, err)
+				assert.True(t, b.Contains(p), "%v in %s", p, locator)
+				c, err := maidenhead.Decode(locator)
+				assert.NoError(t, err)
+				assert.Equal(t, locator, maidenhead.Encode(c, length))
+			}
+		}
+	})
+}
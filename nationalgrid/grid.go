@@ -0,0 +1,224 @@
+// Package nationalgrid converts WGS-84 points to and from the eastings, northings and grid references of
+// national grids: the Ordnance Survey National Grid of Great Britain, the Irish Grid, Irish Transverse
+// Mercator and the Swiss LV03 and LV95 grids. The datum of each grid is related to WGS-84 by a Helmert
+// transformation, whose accuracy ranges from about 1 m in Switzerland to 5 m in Great Britain and
+// Ireland, or by the national transformation grids given to WithGridShift for better results
+package nationalgrid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/gridshift"
+	"github.com/lggomez/go-geodesy/projection"
+	"github.com/lggomez/go-geodesy/transform"
+)
+
+var (
+	// ErrSyntax is returned when a string is not a grid reference of a grid
+	ErrSyntax = errors.New("nationalgrid: invalid grid reference")
+	// ErrRange is returned when a point or coordinates fall outside of the extent of a grid
+	ErrRange = errors.New("nationalgrid: out of grid range")
+	// ErrResolution is returned when a resolution is not a power of ten supported by a grid
+	ErrResolution = errors.New("nationalgrid: invalid resolution")
+)
+
+// Grid represents a national grid: a projection of a geodetic datum, whose grid references are either
+// letters identifying squares of 100 km followed by digits, or plain eastings and northings
+type Grid struct {
+	name      string
+	ellipsoid ellipsoids.Ellipsoid
+	fromWGS84 transform.Helmert
+	// shift holds the grids shifting points from the datum of the grid to WGS-84, used instead of
+	// fromWGS84 when set
+	shift      gridshift.GridSet
+	projection projection.Projection
+	// letters is the amount of letters of grid references, which are numeric when 0
+	letters int
+	// minEasting, minNorthing, maxEasting and maxNorthing bound the extent of the grid, in meters (m)
+	minEasting, minNorthing, maxEasting, maxNorthing float64
+}
+
+var (
+	// OSGB is the Ordnance Survey National Grid of Great Britain, on the OSGB36 datum. Grid references
+	// have two letters, such as "TQ 30080 80530"
+	OSGB = Grid{
+		name:      "OSGB",
+		ellipsoid: ellipsoids.Airy1830,
+		// See https://www.ordnancesurvey.co.uk/documents/resources/guide-coordinate-systems-great-britain.pdf
+		fromWGS84: transform.Helmert{
+			T: [3]float64{-446_448, 125_157, -542_060}, D: 20_489.4,
+			R: [3]float64{-150.2, -247.0, -842.1},
+		},
+		projection: projection.TransverseMercator{
+			Ellipsoid: ellipsoids.Airy1830, Origin: geodesy.Point{49, -2}, Scale: 0.9996012717,
+			FalseEasting: 400_000, FalseNorthing: -100_000,
+		},
+		letters:    2,
+		maxEasting: 700_000, maxNorthing: 1_300_000,
+	}
+
+	// IrishGrid is the Irish Grid, on the Ireland 1965 datum. Grid references have one letter, such as
+	// "O 15904 34671"
+	IrishGrid = Grid{
+		name:      "Irish Grid",
+		ellipsoid: ellipsoids.AiryModified,
+		fromWGS84: transform.Helmert{
+			T: [3]float64{-482_530, 130_596, -564_557}, D: -8_150,
+			R: [3]float64{1_042, 214, 631},
+		},
+		projection: projection.TransverseMercator{
+			Ellipsoid: ellipsoids.AiryModified, Origin: geodesy.Point{53.5, -8}, Scale: 1.000035,
+			FalseEasting: 200_000, FalseNorthing: 250_000,
+		},
+		letters:    1,
+		maxEasting: 500_000, maxNorthing: 500_000,
+	}
+
+	// ITM is the Irish Transverse Mercator grid, on the ETRS89 datum, which matches WGS-84 to the meter.
+	// Grid references are eastings and northings, such as "715830, 734697"
+	ITM = Grid{
+		name:      "ITM",
+		ellipsoid: ellipsoids.GRS80,
+		projection: projection.TransverseMercator{
+			Ellipsoid: ellipsoids.GRS80, Origin: geodesy.Point{53.5, -8}, Scale: 0.999820,
+			FalseEasting: 600_000, FalseNorthing: 750_000,
+		},
+		minEasting: 400_000, minNorthing: 500_000, maxEasting: 800_000, maxNorthing: 1_000_000,
+	}
+
+	// LV03 is the Swiss grid on the CH1903 datum. Grid references are eastings and northings, such as
+	// "600000, 200000" for the origin in Bern
+	LV03 = swissGrid("LV03", 600_000, 200_000)
+
+	// LV95 is the Swiss grid on the CH1903+ datum, whose eastings and northings are offset from LV03 by
+	// 2000 km and 1000 km, such as "2600000, 1200000" for the origin in Bern
+	LV95 = swissGrid("LV95", 2_600_000, 1_200_000)
+)
+
+// swissGrid returns a Swiss grid with the given coordinates of the origin in Bern
+func swissGrid(name string, falseEasting, falseNorthing float64) Grid {
+	return Grid{
+		name:      name,
+		ellipsoid: ellipsoids.Bessel1841,
+		// See https://www.swisstopo.admin.ch/en/knowledge-facts/surveying-geodesy/reference-frames.html
+		fromWGS84: transform.Helmert{T: [3]float64{-674_374, -15_056, -405_346}},
+		projection: projection.SwissObliqueMercator{
+			Ellipsoid:    ellipsoids.Bessel1841,
+			Origin:       geodesy.Point{46 + 57.0/60 + 8.66/3600, 7 + 26.0/60 + 22.5/3600},
+			FalseEasting: falseEasting, FalseNorthing: falseNorthing,
+		},
+		minEasting: falseEasting - 120_000, minNorthing: falseNorthing - 130_000,
+		maxEasting: falseEasting + 250_000, maxNorthing: falseNorthing + 100_000,
+	}
+}
+
+// String returns the name of g
+func (g Grid) String() string {
+	return g.name
+}
+
+// contains returns whether the given coordinates fall within the extent of g
+func (g Grid) contains(easting, northing float64) bool {
+	return easting >= g.minEasting && easting < g.maxEasting && northing >= g.minNorthing && northing < g.maxNorthing
+}
+
+// WithGridShift returns g with its datum related to WGS-84 by the grid shifts of gs rather than by a
+// Helmert transformation, such as the NTv2 version of OSTN15 published by the Ordnance Survey for OSGB
+// (OSTN15_NTv2_OSGBtoETRS.gsb). The grids must shift points from the datum of g to ETRS89 or WGS-84,
+// which are taken to be the same, and points outside of them return ErrRange
+func (g Grid) WithGridShift(gs gridshift.GridSet) Grid {
+	g.shift = gs
+	return g
+}
+
+// toLocal converts the WGS-84 point p to the datum of g
+func (g Grid) toLocal(p geodesy.Point) (geodesy.Point, error) {
+	if g.shift != nil {
+		local, err := g.shift.Inverse(p)
+		if errors.Is(err, gridshift.ErrOutsideGrid) {
+			return local, fmt.Errorf("%w: %v lies outside of the grid shifts of %s", ErrRange, p, g)
+		}
+		return local, err
+	}
+
+	c := g.fromWGS84.Apply(transform.ToCartesian(p, 0, ellipsoids.WGS84), g.fromWGS84.Epoch)
+	local, _ := transform.FromCartesian(c, g.ellipsoid)
+
+	return local, nil
+}
+
+// toWGS84 converts the point p on the datum of g to WGS-84
+func (g Grid) toWGS84(p geodesy.Point) (geodesy.Point, error) {
+	if g.shift != nil {
+		wgs84, err := g.shift.Forward(p)
+		if errors.Is(err, gridshift.ErrOutsideGrid) {
+			return wgs84, fmt.Errorf("%w: %v lies outside of the grid shifts of %s", ErrRange, p, g)
+		}
+		return wgs84, err
+	}
+
+	toWGS84 := g.fromWGS84.Inverse()
+	c := toWGS84.Apply(transform.ToCartesian(p, 0, g.ellipsoid), toWGS84.Epoch)
+	wgs84, _ := transform.FromCartesian(c, ellipsoids.WGS84)
+
+	return wgs84, nil
+}
+
+// Project returns the easting and northing in g of the WGS-84 point p, in meters (m)
+func (g Grid) Project(p geodesy.Point) (easting, northing float64, err error) {
+	if !p.Valid() {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: invalid point %v", ErrRange, p)
+	}
+
+	local, err := g.toLocal(p)
+	if err != nil {
+		return math.NaN(), math.NaN(), err
+	}
+	easting, northing = g.projection.Forward(local)
+	if !g.contains(easting, northing) {
+		return math.NaN(), math.NaN(), fmt.Errorf("%w: %v lies outside of %s", ErrRange, p, g)
+	}
+
+	return easting, northing, nil
+}
+
+// Unproject returns the WGS-84 point at the given easting and northing in g, in meters (m)
+func (g Grid) Unproject(easting, northing float64) (geodesy.Point, error) {
+	if !g.contains(easting, northing) {
+		return geodesy.Point{math.NaN(), math.NaN()},
+			fmt.Errorf("%w: %.3f, %.3f lies outside of %s", ErrRange, easting, northing, g)
+	}
+
+	p, err := g.toWGS84(g.projection.Inverse(easting, northing))
+	if err != nil {
+		return geodesy.Point{math.NaN(), math.NaN()}, err
+	}
+
+	return p, nil
+}
+
+// Format returns the grid reference in g of the square of the given resolution containing the WGS-84
+// point p (see FormatReference)
+func (g Grid) Format(p geodesy.Point, resolution float64) (string, error) {
+	easting, northing, err := g.Project(p)
+	if err != nil {
+		return "", err
+	}
+
+	return g.FormatReference(easting, northing, resolution)
+}
+
+// Parse returns the WGS-84 point at the south-west corner of the square of the grid reference s in g
+// (see ParseReference)
+func (g Grid) Parse(s string) (geodesy.Point, error) {
+	easting, northing, _, err := g.ParseReference(s)
+	if err != nil {
+		return geodesy.Point{math.NaN(), math.NaN()}, err
+	}
+
+	return g.Unproject(easting, northing)
+}
@@ -0,0 +1,283 @@
+package nationalgrid_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/gridshift"
+	"github.com/lggomez/go-geodesy/nationalgrid"
+	"github.com/lggomez/go-geodesy/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrid_Project(t *testing.T) {
+	tests := []struct {
+		name                      string
+		grid                      nationalgrid.Grid
+		p                         geodesy.Point
+		wantEasting, wantNorthing float64
+		tolerance                 float64
+		wantErr                   error
+	}{
+		{
+			// Caister water tower, whose OSGB36 coordinates are the worked example of the Ordnance Survey
+			name:        "OK/osgb",
+			grid:        nationalgrid.OSGB,
+			p:           geodesy.Point{52.65798, 1.71605},
+			wantEasting: 651_409.903, wantNorthing: 313_177.270,
+			tolerance: 1,
+		},
+		{
+			name:        "OK/itm",
+			grid:        nationalgrid.ITM,
+			p:           geodesy.Point{53.5, -8},
+			wantEasting: 600_000, wantNorthing: 750_000,
+			tolerance: 1e-3,
+		},
+		{
+			// Example of the swisstopo approximate formulas, whose rigorous solution is exactly 2600000, 1100000
+			name:        "OK/lv95",
+			grid:        nationalgrid.LV95,
+			p:           geodesy.Point{46 + 2/60.0 + 38.87/3600, 8 + 43/60.0 + 49.79/3600},
+			wantEasting: 2_700_000, wantNorthing: 1_100_000,
+			tolerance: 0.5,
+		},
+		{
+			name:        "OK/lv03",
+			grid:        nationalgrid.LV03,
+			p:           geodesy.Point{46 + 2/60.0 + 38.87/3600, 8 + 43/60.0 + 49.79/3600},
+			wantEasting: 700_000, wantNorthing: 100_000,
+			tolerance: 0.5,
+		},
+		{name: "FAIL/outside_grid", grid: nationalgrid.OSGB, p: geodesy.Point{40.4168, -3.7038}, wantErr: nationalgrid.ErrRange},
+		{name: "FAIL/outside_swiss_grid", grid: nationalgrid.LV95, p: geodesy.Point{48.8566, 2.3522}, wantErr: nationalgrid.ErrRange},
+		{name: "FAIL/invalid_point", grid: nationalgrid.ITM, p: geodesy.Point{91, 0}, wantErr: nationalgrid.ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, n, err := tt.grid.Project(tt.p)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantEasting, e, tt.tolerance)
+			assert.InDelta(t, tt.wantNorthing, n, tt.tolerance)
+
+			p, err := tt.grid.Unproject(e, n)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.p.Lat(), p.Lat(), 1e-7)
+			assert.InDelta(t, tt.p.Lon(), p.Lon(), 1e-7)
+		})
+	}
+
+	t.Run("OK/irish_grid_and_itm", func(t *testing.T) {
+		// A point in Dublin, whose Irish Grid and ITM coordinates are published by Ordnance Survey Ireland
+		p, err := nationalgrid.ITM.Unproject(715_830, 734_697)
+		assert.NoError(t, err)
+		e, n, err := nationalgrid.IrishGrid.Project(p)
+		assert.NoError(t, err)
+		assert.InDelta(t, 315_904, e, 1)
+		assert.InDelta(t, 234_671, n, 1)
+	})
+
+	_, err := nationalgrid.ITM.Unproject(0, 0)
+	assert.ErrorIs(t, err, nationalgrid.ErrRange)
+}
+
+func TestGrid_FormatReference(t *testing.T) {
+	tests := []struct {
+		name              string
+		grid              nationalgrid.Grid
+		easting, northing float64
+		resolution        float64
+		want              string
+		wantErr           error
+	}{
+		{name: "OK/osgb", grid: nationalgrid.OSGB, easting: 530_080.7, northing: 180_530.2, resolution: 1, want: "TQ 30080 80530"},
+		{name: "OK/osgb_100m", grid: nationalgrid.OSGB, easting: 530_080.7, northing: 180_530.2, resolution: 100, want: "TQ 300 805"},
+		{name: "OK/osgb_square", grid: nationalgrid.OSGB, easting: 530_080.7, northing: 180_530.2, resolution: 100_000, want: "TQ"},
+		{name: "OK/osgb_false_origin", grid: nationalgrid.OSGB, easting: 0, northing: 0, resolution: 1000, want: "SV 00 00"},
+		{name: "OK/osgb_north", grid: nationalgrid.OSGB, easting: 465_432, northing: 1_210_987, resolution: 10, want: "HP 6543 1098"},
+		{name: "OK/irish_grid", grid: nationalgrid.IrishGrid, easting: 315_904, northing: 234_671, resolution: 1, want: "O 15904 34671"},
+		{name: "OK/irish_grid_south_west", grid: nationalgrid.IrishGrid, easting: 12_345, northing: 67_890, resolution: 10, want: "V 1234 6789"},
+		{name: "OK/itm", grid: nationalgrid.ITM, easting: 715_830.456, northing: 734_697.123, resolution: 0.01, want: "715830.45, 734697.12"},
+		{name: "OK/lv95", grid: nationalgrid.LV95, easting: 2_600_000, northing: 1_200_000, resolution: 1, want: "2600000, 1200000"},
+		{name: "FAIL/resolution", grid: nationalgrid.OSGB, easting: 530_080, northing: 180_530, resolution: 0.1, wantErr: nationalgrid.ErrResolution},
+		{name: "FAIL/not_power_of_ten", grid: nationalgrid.ITM, easting: 715_830, northing: 734_697, resolution: 5, wantErr: nationalgrid.ErrResolution},
+		{name: "FAIL/outside_grid", grid: nationalgrid.IrishGrid, easting: 530_080, northing: 180_530, resolution: 1, wantErr: nationalgrid.ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.grid.FormatReference(tt.easting, tt.northing, tt.resolution)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGrid_ParseReference(t *testing.T) {
+	tests := []struct {
+		name                      string
+		grid                      nationalgrid.Grid
+		s                         string
+		wantEasting, wantNorthing float64
+		wantResolution            float64
+		wantErr                   error
+	}{
+		{name: "OK/osgb", grid: nationalgrid.OSGB, s: "TQ 30080 80530", wantEasting: 530_080, wantNorthing: 180_530, wantResolution: 1},
+		{name: "OK/osgb_compact", grid: nationalgrid.OSGB, s: "tq3008080530", wantEasting: 530_080, wantNorthing: 180_530, wantResolution: 1},
+		{name: "OK/osgb_square", grid: nationalgrid.OSGB, s: " HP ", wantEasting: 400_000, wantNorthing: 1_200_000, wantResolution: 100_000},
+		{name: "OK/osgb_10km", grid: nationalgrid.OSGB, s: "NN 1 7", wantEasting: 210_000, wantNorthing: 770_000, wantResolution: 10_000},
+		{name: "OK/irish_grid", grid: nationalgrid.IrishGrid, s: "O 15904 34671", wantEasting: 315_904, wantNorthing: 234_671, wantResolution: 1},
+		{name: "OK/itm", grid: nationalgrid.ITM, s: "715830.45, 734697.1", wantEasting: 715_830.45, wantNorthing: 734_697.1, wantResolution: 0.01},
+		{name: "OK/lv95", grid: nationalgrid.LV95, s: "2'600'000 / 1'200'000", wantEasting: 2_600_000, wantNorthing: 1_200_000, wantResolution: 1},
+		{name: "OK/lv03", grid: nationalgrid.LV03, s: "600000 200000", wantEasting: 600_000, wantNorthing: 200_000, wantResolution: 1},
+		{name: "FAIL/letter_i", grid: nationalgrid.OSGB, s: "TI 30080 80530", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/odd_digits", grid: nationalgrid.OSGB, s: "TQ 3008080", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/uneven_groups", grid: nationalgrid.OSGB, s: "TQ 3008 80530", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/too_many_digits", grid: nationalgrid.OSGB, s: "TQ 300800 805300", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/not_digits", grid: nationalgrid.IrishGrid, s: "O 1590x 34671", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/missing_letters", grid: nationalgrid.OSGB, s: "T", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/square_outside_grid", grid: nationalgrid.OSGB, s: "AA 00 00", wantErr: nationalgrid.ErrRange},
+		{name: "FAIL/numeric_fields", grid: nationalgrid.ITM, s: "715830", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/numeric_syntax", grid: nationalgrid.ITM, s: "715830, 7346g7", wantErr: nationalgrid.ErrSyntax},
+		{name: "FAIL/lv03_in_lv95", grid: nationalgrid.LV95, s: "600000, 200000", wantErr: nationalgrid.ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, n, res, err := tt.grid.ParseReference(tt.s)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantEasting, e, 1e-9)
+			assert.InDelta(t, tt.wantNorthing, n, 1e-9)
+			assert.InDelta(t, tt.wantResolution, res, 1e-12)
+		})
+	}
+}
+
+func TestGrid_Format(t *testing.T) {
+	s, err := nationalgrid.OSGB.Format(geodesy.Point{52.65798, 1.71605}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "TG 51409 13177", s)
+
+	p, err := nationalgrid.OSGB.Parse(s)
+	assert.NoError(t, err)
+	assert.InDelta(t, 52.65798, p.Lat(), 2e-5)
+	assert.InDelta(t, 1.71605, p.Lon(), 2e-5)
+
+	s, err = nationalgrid.LV95.Format(geodesy.Point{46.9480, 7.4474}, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, "2600000, 1199000", s)
+
+	_, err = nationalgrid.IrishGrid.Format(geodesy.Point{51.5074, -0.1278}, 1)
+	assert.ErrorIs(t, err, nationalgrid.ErrRange)
+
+	p, err = nationalgrid.IrishGrid.Parse("Z")
+	assert.NoError(t, err)
+	assert.True(t, p.Valid())
+
+	p, err = nationalgrid.OSGB.Parse("TQ 300")
+	assert.ErrorIs(t, err, nationalgrid.ErrSyntax)
+	assert.False(t, p.Valid())
+
+	assert.Equal(t, "LV95", nationalgrid.LV95.String())
+}
+
+// ntv2File builds an NTv2 file with a single grid over [50, 58]x[-7, 2] with a 0.25° spacing, shifting
+// OSGB36 points to WGS-84 with the Helmert transformation of the Ordnance Survey
+func ntv2File() []byte {
+	toWGS84 := transform.Helmert{
+		T: [3]float64{-446_448, 125_157, -542_060}, D: 20_489.4,
+		R: [3]float64{-150.2, -247.0, -842.1},
+	}.Inverse()
+
+	buf := &bytes.Buffer{}
+	write := func(key string, value interface{}) {
+		k := []byte("        ")
+		copy(k, key)
+		buf.Write(k)
+		switch v := value.(type) {
+		case int32:
+			_ = binary.Write(buf, binary.LittleEndian, v)
+			buf.Write(make([]byte, 4))
+		case float64:
+			_ = binary.Write(buf, binary.LittleEndian, v)
+		case string:
+			s := []byte("        ")
+			copy(s, v)
+			buf.Write(s)
+		}
+	}
+
+	const s, n, e, w, step = 50.0, 58.0, -2.0, 7.0, 0.25
+	rows, cols := int((n-s)/step)+1, int((w-e)/step)+1
+	for _, r := range []struct {
+		key   string
+		value interface{}
+	}{
+		{"NUM_OREC", int32(11)}, {"NUM_SREC", int32(11)}, {"NUM_FILE", int32(1)},
+		{"GS_TYPE", "SECONDS"}, {"VERSION", "NTv2.0"}, {"SYSTEM_F", "OSGB36"}, {"SYSTEM_T", "ETRS89"},
+		{"MAJOR_F", 6377563.396}, {"MINOR_F", 6356256.909}, {"MAJOR_T", 6378137.0}, {"MINOR_T", 6356752.314},
+		{"SUB_NAME", "OSGB"}, {"PARENT", "NONE"}, {"CREATED", "20211001"}, {"UPDATED", "20211001"},
+		{"S_LAT", s * 3600}, {"N_LAT", n * 3600}, {"E_LONG", e * 3600}, {"W_LONG", w * 3600},
+		{"LAT_INC", step * 3600}, {"LONG_INC", step * 3600}, {"GS_COUNT", int32(rows * cols)},
+	} {
+		write(r.key, r.value)
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			// Nodes go from east to west, with positive west longitudes
+			p := geodesy.Point{s + float64(row)*step, -(e + float64(col)*step)}
+			c := toWGS84.Apply(transform.ToCartesian(p, 0, ellipsoids.Airy1830), toWGS84.Epoch)
+			q, _ := transform.FromCartesian(c, ellipsoids.WGS84)
+			Δφ, Δλ := (q.Lat()-p.Lat())*3600, (q.Lon()-p.Lon())*3600
+			_ = binary.Write(buf, binary.LittleEndian, []float32{float32(Δφ), float32(-Δλ), 0, 0})
+		}
+	}
+	write("END", float64(0))
+
+	return buf.Bytes()
+}
+
+func TestGrid_WithGridShift(t *testing.T) {
+	gs, err := gridshift.ReadNTv2(bytes.NewReader(ntv2File()))
+	assert.NoError(t, err)
+	grid := nationalgrid.OSGB.WithGridShift(gs)
+
+	t.Run("OK/helmert_grid", func(t *testing.T) {
+		// The grid reproduces the Helmert transformation up to its interpolation errors
+		for _, p := range []geodesy.Point{{52.65798, 1.71605}, {51.5074, -0.1278}, {55.9533, -3.1883}, {50.1, -5.5}} {
+			e, n, err := grid.Project(p)
+			assert.NoError(t, err)
+			wantEasting, wantNorthing, err := nationalgrid.OSGB.Project(p)
+			assert.NoError(t, err)
+			assert.InDelta(t, wantEasting, e, 0.01, "%v", p)
+			assert.InDelta(t, wantNorthing, n, 0.01, "%v", p)
+
+			q, err := grid.Unproject(e, n)
+			assert.NoError(t, err)
+			assert.InDelta(t, p.Lat(), q.Lat(), 1e-9)
+			assert.InDelta(t, p.Lon(), q.Lon(), 1e-9)
+		}
+	})
+
+	t.Run("FAIL/outside_grid_shift", func(t *testing.T) {
+		// Lerwick, in the extent of the National Grid but not in the one of the grid shifts
+		_, _, err := grid.Project(geodesy.Point{60.1546, -1.1494})
+		assert.ErrorIs(t, err, nationalgrid.ErrRange)
+
+		_, err = grid.Unproject(446_000, 1_141_000)
+		assert.ErrorIs(t, err, nationalgrid.ErrRange)
+	})
+}
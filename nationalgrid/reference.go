@@ -0,0 +1,208 @@
+package nationalgrid
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// squareSize is the size of the squares identified by the letters of grid references, in meters (m)
+const squareSize = 100_000
+
+// exponent returns the power of ten of resolution, if it is one supported by g: from 1 m to 100 km for
+// grids with letters, and from 1 mm to 100 km otherwise
+func (g Grid) exponent(resolution float64) (int, error) {
+	exp := math.Round(math.Log10(resolution))
+	min := -3.0
+	if g.letters > 0 {
+		min = 0
+	}
+	if resolution <= 0 || math.Abs(math.Pow(10, exp)-resolution) > resolution*1e-9 || exp < min || exp > 5 {
+		return 0, fmt.Errorf("%w: %v m for %s", ErrResolution, resolution, g)
+	}
+
+	return int(exp), nil
+}
+
+// FormatReference returns the grid reference in g of the square of the given resolution, in meters (m),
+// containing the easting and northing. Resolutions are powers of ten, and the digits of grid
+// references with letters are truncated to the south-west corner of the square: "TQ 30080 80530" at
+// 1 m or "TQ 3 8" at 10 km. Numeric grid references have as many decimals as the resolution requires
+func (g Grid) FormatReference(easting, northing, resolution float64) (string, error) {
+	exp, err := g.exponent(resolution)
+	if err != nil {
+		return "", err
+	}
+	if !g.contains(easting, northing) {
+		return "", fmt.Errorf("%w: %.3f, %.3f lies outside of %s", ErrRange, easting, northing, g)
+	}
+
+	if g.letters == 0 {
+		decimals := 0
+		if exp < 0 {
+			decimals = -exp
+		}
+		return fmt.Sprintf("%.*f, %.*f", decimals, math.Floor(easting/resolution)*resolution,
+			decimals, math.Floor(northing/resolution)*resolution), nil
+	}
+
+	e, n := int(easting)/squareSize, int(northing)/squareSize
+	letters := g.squareLetters(e, n)
+	digits := 5 - exp
+	if digits == 0 {
+		return letters, nil
+	}
+	scale := math.Pow(10, float64(exp))
+	de := int(math.Floor((easting - float64(e*squareSize)) / scale))
+	dn := int(math.Floor((northing - float64(n*squareSize)) / scale))
+
+	return fmt.Sprintf("%s %0*d %0*d", letters, digits, de, digits, dn), nil
+}
+
+// ParseReference returns the easting and northing of the south-west corner of the square of the grid
+// reference s in g, and its resolution, in meters (m). Letters are case-insensitive, and the digits of
+// eastings and northings may be separated by spaces. The eastings and northings of numeric grid
+// references may be separated by commas, slashes or spaces, and apostrophes separating thousands are
+// ignored
+func (g Grid) ParseReference(s string) (easting, northing, resolution float64, err error) {
+	nan := math.NaN()
+	if g.letters == 0 {
+		easting, northing, resolution, err = g.parseNumeric(s)
+	} else {
+		easting, northing, resolution, err = g.parseLetters(s)
+	}
+	if err != nil {
+		return nan, nan, nan, err
+	}
+	if !g.contains(easting, northing) {
+		return nan, nan, nan, fmt.Errorf("%w: %q lies outside of %s", ErrRange, s, g)
+	}
+
+	return easting, northing, resolution, nil
+}
+
+func (g Grid) parseNumeric(s string) (easting, northing, resolution float64, err error) {
+	fields := strings.FieldsFunc(strings.Replace(s, "'", "", -1), func(r rune) bool {
+		return r == ',' || r == '/' || unicode.IsSpace(r)
+	})
+	if len(fields) != 2 {
+		return 0, 0, 0, fmt.Errorf("%w: %q must have an easting and a northing", ErrSyntax, s)
+	}
+
+	decimals := 0
+	for k, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, 0, 0, fmt.Errorf("%w: invalid number %q in %q", ErrSyntax, f, s)
+		}
+		if k == 0 {
+			easting = v
+		} else {
+			northing = v
+		}
+		if dot := strings.IndexByte(f, '.'); dot >= 0 && len(f)-dot-1 > decimals {
+			decimals = len(f) - dot - 1
+		}
+	}
+
+	return easting, northing, math.Pow(10, -float64(decimals)), nil
+}
+
+func (g Grid) parseLetters(s string) (easting, northing, resolution float64, err error) {
+	s = strings.TrimSpace(s)
+	if len(s) < g.letters {
+		return 0, 0, 0, fmt.Errorf("%w: %q must start with %d letters", ErrSyntax, s, g.letters)
+	}
+	indexes := make([]int, g.letters)
+	for k := range indexes {
+		if indexes[k] = letterIndex(s[k]); indexes[k] < 0 {
+			return 0, 0, 0, fmt.Errorf("%w: invalid letter %q in %q", ErrSyntax, s[k], s)
+		}
+	}
+	e, n := g.square(indexes)
+
+	// Digits are split evenly between the easting and the northing, unless separated by a space
+	var de, dn string
+	switch fields := strings.Fields(s[g.letters:]); len(fields) {
+	case 0:
+	case 1:
+		half := len(fields[0]) / 2
+		de, dn = fields[0][:half], fields[0][half:]
+	case 2:
+		de, dn = fields[0], fields[1]
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: %q has too many groups of digits", ErrSyntax, s)
+	}
+	if len(de) != len(dn) || len(de) > 5 {
+		return 0, 0, 0, fmt.Errorf("%w: %q must have up to 5 digits for each of its easting and northing", ErrSyntax, s)
+	}
+
+	resolution = math.Pow(10, float64(5-len(de)))
+	easting, northing = float64(e*squareSize), float64(n*squareSize)
+	for k, digits := range []string{de, dn} {
+		if digits == "" {
+			break
+		}
+		v, err := strconv.ParseUint(digits, 10, 32)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("%w: invalid digits %q in %q", ErrSyntax, digits, s)
+		}
+		if k == 0 {
+			easting += float64(v) * resolution
+		} else {
+			northing += float64(v) * resolution
+		}
+	}
+
+	return easting, northing, resolution, nil
+}
+
+// letterIndex returns the index of the case-insensitive letter c in the alphabet without I, or -1 if
+// it is not one of its letters
+func letterIndex(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch {
+	case c < 'A' || c > 'Z' || c == 'I':
+		return -1
+	case c > 'I':
+		return int(c-'A') - 1
+	default:
+		return int(c - 'A')
+	}
+}
+
+// letter returns the letter at index i of the alphabet without I
+func letter(i int) byte {
+	if i >= 'I'-'A' {
+		i++
+	}
+	return byte('A' + i)
+}
+
+// square returns the easting and northing, in squares of 100 km, of the letters with the given indexes.
+// Letters are laid out in rows of 5 from the north-west, and the first of the two letters of OSGB
+// identifies squares of 500 km from the false origin at SV
+func (g Grid) square(indexes []int) (e, n int) {
+	if g.letters == 1 {
+		return indexes[0] % 5, 4 - indexes[0]/5
+	}
+	l1, l2 := indexes[0], indexes[1]
+
+	return (l1+3)%5*5 + l2%5, 19 - l1/5*5 - l2/5
+}
+
+// squareLetters returns the letters of the square at the given easting and northing, in squares of
+// 100 km, reverting square
+func (g Grid) squareLetters(e, n int) string {
+	if g.letters == 1 {
+		return string(letter((4-n)*5 + e))
+	}
+	l1 := (19 - n) - (19-n)%5 + (e+10)/5
+	l2 := (19-n)*5%25 + e%5
+
+	return string([]byte{letter(l1), letter(l2)})
+}
@@ -0,0 +1,340 @@
+// Package olc implements Open Location Codes, also known as Plus Codes, which encode points as strings
+// of base 20 characters identifying the cells of a grid of latitudes and longitudes, such as
+// "8FVC9G8F+6X". Full codes identify a cell of the whole Earth, while short codes omit their leading
+// characters and are recovered from a reference point near them.
+//
+// See https://github.com/google/open-location-code/blob/main/docs/specification.md
+package olc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/internal/geomath"
+)
+
+var (
+	// ErrSyntax is returned when a string is not an Open Location Code
+	ErrSyntax = errors.New("olc: invalid code")
+	// ErrNotFull is returned when a full code is required and a short one is given
+	ErrNotFull = errors.New("olc: not a full code")
+	// ErrNotShortenable is returned when a full code is padded or too short to be shortened
+	ErrNotShortenable = errors.New("olc: code cannot be shortened")
+)
+
+const (
+	// DefaultLength is the usual amount of digits of codes, whose cells measure about 14 by 14 meters
+	DefaultLength = pairCodeLength
+	// MaxLength is the maximum amount of digits of codes, whose cells are smaller than 1 cm
+	MaxLength = 15
+
+	alphabet          = "23456789CFGHJMPQRVWX"
+	separator         = '+'
+	separatorPosition = 8
+	padding           = '0'
+	encodingBase      = 20
+
+	// pairCodeLength is the amount of digits encoding alternating latitudes and longitudes, which
+	// are followed by grid digits encoding both of them
+	pairCodeLength = 10
+	gridRows       = 5
+	gridColumns    = 4
+
+	// pairPrecision is the amount of cells per degree of the last pair of digits
+	pairPrecision = 8000
+	// pairFirstPlaceValue is the amount of cells of the last pair of digits per unit of the first one
+	pairFirstPlaceValue = 160000
+	// gridLatFirstPlaceValue and gridLngFirstPlaceValue are the amount of rows and columns of the cells
+	// of the last grid digit per unit of the first one
+	gridLatFirstPlaceValue = 625
+	gridLngFirstPlaceValue = 256
+	// finalLatPrecision and finalLngPrecision are the amount of rows and columns of the cells of the
+	// last grid digit per degree
+	finalLatPrecision = pairPrecision * gridLatFirstPlaceValue * gridRows
+	finalLngPrecision = pairPrecision * gridLngFirstPlaceValue * gridColumns
+
+	// minTrimmableLength is the minimum amount of digits of the full codes which can be shortened
+	minTrimmableLength = 6
+)
+
+// pairResolutions holds the size in degrees of the cells of each pair of digits
+var pairResolutions = [...]float64{20, 1, .05, .0025, .000125}
+
+// Encode returns the code of the given amount of digits of the cell containing p. Lengths are 2, 4, 6
+// or 8, in which case the code is padded with zeros up to the separator, or 10 up to MaxLength. If p
+// is invalid or length is not valid, the returned code is empty
+func Encode(p geodesy.Point, length int) string {
+	if !p.Valid() || length < 2 || length > MaxLength || length < pairCodeLength && length%2 == 1 {
+		return ""
+	}
+
+	// Work on integers, in units of the cells of the last grid digit, to avoid the accumulation of
+	// floating point errors
+	latVal := int64(math.Round((p.Lat()+90)*finalLatPrecision*1e6) / 1e6)
+	lngVal := int64(math.Round((p.Lon()+180)*finalLngPrecision*1e6) / 1e6)
+	if latVal >= 180*finalLatPrecision {
+		latVal = 180*finalLatPrecision - 1
+	}
+	lngVal %= 360 * finalLngPrecision
+
+	var code [MaxLength]byte
+	for i := MaxLength - 1; i >= pairCodeLength; i-- {
+		code[i] = alphabet[latVal%gridRows*gridColumns+lngVal%gridColumns]
+		latVal /= gridRows
+		lngVal /= gridColumns
+	}
+	for i := pairCodeLength/2 - 1; i >= 0; i-- {
+		code[2*i] = alphabet[latVal%encodingBase]
+		code[2*i+1] = alphabet[lngVal%encodingBase]
+		latVal /= encodingBase
+		lngVal /= encodingBase
+	}
+
+	var b strings.Builder
+	b.Write(code[:min(length, separatorPosition)])
+	for i := length; i < separatorPosition; i++ {
+		b.WriteByte(padding)
+	}
+	b.WriteByte(separator)
+	if length > separatorPosition {
+		b.Write(code[separatorPosition:length])
+	}
+
+	return b.String()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// validate returns an error if code is not a valid full or short code
+func validate(code string) error {
+	sep := strings.IndexByte(code, separator)
+	switch {
+	case sep < 0 || strings.Count(code, string(separator)) != 1:
+		return fmt.Errorf("%w: %q must have a single separator", ErrSyntax, code)
+	case sep > separatorPosition || sep%2 == 1:
+		return fmt.Errorf("%w: %q has a separator at offset %d", ErrSyntax, code, sep)
+	case len(code) == 1:
+		return fmt.Errorf("%w: %q has no digits", ErrSyntax, code)
+	case len(code)-sep-1 == 1:
+		return fmt.Errorf("%w: %q has a single digit after the separator", ErrSyntax, code)
+	}
+
+	if pad := strings.IndexByte(code, padding); pad >= 0 {
+		// Padding follows an even amount of digits, and extends up to the separator of full codes
+		end := pad
+		for end < len(code) && code[end] == padding {
+			end++
+		}
+		if sep < separatorPosition || pad == 0 || pad%2 == 1 || end != sep || sep != len(code)-1 {
+			return fmt.Errorf("%w: %q has invalid padding", ErrSyntax, code)
+		}
+	}
+
+	for i := 0; i < len(code); i++ {
+		if c := code[i]; c != separator && c != padding && value(c) < 0 {
+			return fmt.Errorf("%w: invalid character %q at offset %d in %q", ErrSyntax, c, i, code)
+		}
+	}
+
+	return nil
+}
+
+// value returns the value of the case-insensitive digit c, or -1 if it is not a digit
+func value(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return strings.IndexByte(alphabet, c)
+}
+
+// IsValid returns whether code is a valid full or short code
+func IsValid(code string) bool {
+	return validate(code) == nil
+}
+
+// IsShort returns whether code is a valid short code, whose leading digits are omitted
+func IsShort(code string) bool {
+	return IsValid(code) && strings.IndexByte(code, separator) < separatorPosition
+}
+
+// IsFull returns whether code is a valid full code, which identifies a cell of the whole Earth
+func IsFull(code string) bool {
+	return fullError(code) == nil
+}
+
+// fullError returns an error if code is not a valid full code
+func fullError(code string) error {
+	if err := validate(code); err != nil {
+		return err
+	}
+	if strings.IndexByte(code, separator) < separatorPosition {
+		return fmt.Errorf("%w: %q", ErrNotFull, code)
+	}
+
+	// The first latitude and longitude digits must lie within the ranges of latitudes and longitudes
+	if value(code[0])*encodingBase >= 180 || value(code[1])*encodingBase >= 360 {
+		return fmt.Errorf("%w: %q is out of range", ErrSyntax, code)
+	}
+
+	return nil
+}
+
+// digits returns the digits of the valid code, in upper case and without separator nor padding
+func digits(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.Replace(code, string(separator), "", 1)
+	code = strings.TrimRight(code, string(padding))
+	if len(code) > MaxLength {
+		code = code[:MaxLength]
+	}
+
+	return code
+}
+
+// Bounds returns the bounding box of the cell of the full code
+func Bounds(code string) (geodesy.BoundingBox, error) {
+	if err := fullError(code); err != nil {
+		nan := math.NaN()
+		return geodesy.BoundingBox{South: nan, West: nan, North: nan, East: nan}, err
+	}
+	code = digits(code)
+
+	// Pair digits are added in units of the cells of the last pair, and grid digits in units of the
+	// cells of the last grid digit
+	lat, lng := int64(-90*pairPrecision), int64(-180*pairPrecision)
+	placeValue := int64(pairFirstPlaceValue)
+	for i := 0; i < min(len(code), pairCodeLength); i += 2 {
+		if i > 0 {
+			placeValue /= encodingBase
+		}
+		lat += int64(strings.IndexByte(alphabet, code[i])) * placeValue
+		lng += int64(strings.IndexByte(alphabet, code[i+1])) * placeValue
+	}
+	latSize := float64(placeValue) / pairPrecision
+	lngSize := latSize
+
+	var gridLat, gridLng int64
+	if len(code) > pairCodeLength {
+		rowValue, colValue := int64(gridLatFirstPlaceValue), int64(gridLngFirstPlaceValue)
+		for i := pairCodeLength; i < len(code); i++ {
+			if i > pairCodeLength {
+				rowValue /= gridRows
+				colValue /= gridColumns
+			}
+			d := int64(strings.IndexByte(alphabet, code[i]))
+			gridLat += d / gridColumns * rowValue
+			gridLng += d % gridColumns * colValue
+		}
+		latSize = float64(rowValue) / finalLatPrecision
+		lngSize = float64(colValue) / finalLngPrecision
+	}
+
+	south := float64(lat)/pairPrecision + float64(gridLat)/finalLatPrecision
+	west := float64(lng)/pairPrecision + float64(gridLng)/finalLngPrecision
+
+	return geodesy.BoundingBox{South: south, West: west, North: south + latSize, East: west + lngSize}, nil
+}
+
+// Decode returns the center of the cell of the full code, which is case-insensitive
+func Decode(code string) (geodesy.Point, error) {
+	b, err := Bounds(code)
+	if err != nil {
+		return geodesy.Point{math.NaN(), math.NaN()}, err
+	}
+
+	return center(b), nil
+}
+
+// center returns the center of the cell b, whose latitude is at most 90°
+func center(b geodesy.BoundingBox) geodesy.Point {
+	return geodesy.Point{math.Min((b.South+b.North)/2, geodesy.LatUpperBound), (b.West + b.East) / 2}
+}
+
+// length returns the amount of digits of the valid code
+func length(code string) int {
+	return len(digits(code))
+}
+
+// Shorten returns the short code of the full code, omitting as many leading digits as can be
+// recovered from ref: 2, 4 or 6 digits if ref is within 0.3 times the size of the cells of the first,
+// second or third pair of digits from the center of the code. Padded codes cannot be shortened
+func Shorten(code string, ref geodesy.Point) (string, error) {
+	if err := fullError(code); err != nil {
+		return "", err
+	}
+	if strings.IndexByte(code, padding) >= 0 || length(code) < minTrimmableLength {
+		return "", fmt.Errorf("%w: %q", ErrNotShortenable, code)
+	}
+	if !ref.Valid() {
+		return "", fmt.Errorf("%w: invalid reference point %v", ErrNotShortenable, ref)
+	}
+	code = strings.ToUpper(code)
+	c, _ := Decode(code)
+
+	distance := math.Max(math.Abs(c.Lat()-ref.Lat()), math.Abs(c.Lon()-ref.Lon()))
+	for i := len(pairResolutions) - 2; i > 0; i-- {
+		if distance < pairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+
+	return code, nil
+}
+
+// RecoverNearest returns the full code of the cell nearest to ref matching the short code. Full codes
+// are returned in upper case
+func RecoverNearest(code string, ref geodesy.Point) (string, error) {
+	if IsFull(code) {
+		return strings.ToUpper(code), nil
+	}
+	if !IsShort(code) {
+		return "", fmt.Errorf("%w: %q is neither a full nor a short code", ErrSyntax, code)
+	}
+	if !ref.Valid() {
+		return "", fmt.Errorf("%w: invalid reference point %v", ErrSyntax, ref)
+	}
+	code = strings.ToUpper(code)
+
+	// Take the missing digits from the reference point, and move the resulting cell by the size of the
+	// omitted digits if it lies more than half of it away from the reference point
+	missing := separatorPosition - strings.IndexByte(code, separator)
+	resolution := math.Pow(encodingBase, float64(2-missing/2))
+	half := resolution / 2
+	b, err := Bounds(Encode(ref, DefaultLength)[:missing] + code)
+	if err != nil {
+		return "", err
+	}
+	c := center(b)
+	lat, lon := c.Lat(), c.Lon()
+
+	switch {
+	case ref.Lat()+half < lat && lat-resolution >= geodesy.LatLowerBound:
+		lat -= resolution
+	case ref.Lat()-half > lat && lat+resolution <= geodesy.LatUpperBound:
+		lat += resolution
+	}
+	switch {
+	case ref.Lon()+half < lon:
+		lon -= resolution
+	case ref.Lon()-half > lon:
+		lon += resolution
+	}
+
+	return Encode(geodesy.Point{lat, normalizeLon(lon)}, length(code)+missing), nil
+}
+
+// normalizeLon returns the longitude equivalent to lon within [-180, 180)
+func normalizeLon(lon float64) float64 {
+	if lon = geomath.NormalizeLon(lon); lon == geodesy.LonUpperBound {
+		return geodesy.LonLowerBound
+	}
+	return lon
+}
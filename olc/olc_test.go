@@ -0,0 +1,214 @@
+package olc_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/olc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      geodesy.Point
+		length int
+		want   string
+	}{
+		{name: "OK/padded", p: geodesy.Point{20.375, 2.775}, length: 6, want: "7FG49Q00+"},
+		{name: "OK/default", p: geodesy.Point{20.3700625, 2.7821875}, length: 10, want: "7FG49QCJ+2V"},
+		{name: "OK/grid", p: geodesy.Point{20.3701125, 2.782234375}, length: 11, want: "7FG49QCJ+2VX"},
+		{name: "OK/grid_13", p: geodesy.Point{20.3701135, 2.78223535156}, length: 13, want: "7FG49QCJ+2VXGJ"},
+		{name: "OK/southern_eastern", p: geodesy.Point{-41.2730625, 174.7859375}, length: 10, want: "4VCPPQGP+Q9"},
+		{name: "OK/zurich", p: geodesy.Point{47.365590, 8.524997}, length: 10, want: "8FVC9G8F+6X"},
+		{name: "OK/south_west_corner", p: geodesy.Point{-89.9999375, -179.9999375}, length: 10, want: "22222222+22"},
+		{name: "OK/north_pole", p: geodesy.Point{90, 1}, length: 4, want: "CFX30000+"},
+		{name: "OK/antimeridian", p: geodesy.Point{1, 180}, length: 4, want: "62H20000+"},
+		{name: "FAIL/too_long", p: geodesy.Point{1, 1}, length: 16, want: ""},
+		{name: "FAIL/odd_pair_length", p: geodesy.Point{1, 1}, length: 7, want: ""},
+		{name: "FAIL/too_short", p: geodesy.Point{1, 1}, length: 1, want: ""},
+		{name: "FAIL/invalid_point", p: geodesy.Point{91, 1}, length: 10, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, olc.Encode(tt.p, tt.length))
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    geodesy.BoundingBox
+		wantErr error
+	}{
+		{name: "OK/padded", code: "7FG49Q00+", want: geodesy.BoundingBox{South: 20.35, West: 2.75, North: 20.4, East: 2.8}},
+		{name: "OK/default", code: "7FG49QCJ+2V", want: geodesy.BoundingBox{South: 20.37, West: 2.782125, North: 20.370125, East: 2.78225}},
+		{name: "OK/grid", code: "7FG49QCJ+2VX", want: geodesy.BoundingBox{South: 20.3701, West: 2.78221875, North: 20.370125, East: 2.78225}},
+		{name: "OK/grid_13", code: "7FG49QCJ+2VXGJ", want: geodesy.BoundingBox{South: 20.370113, West: 2.782234375, North: 20.370114, East: 2.78223632813}},
+		{name: "OK/lower_case", code: "4vcppqgp+q9", want: geodesy.BoundingBox{South: -41.273125, West: 174.785875, North: -41.273, East: 174.786}},
+		{name: "OK/north_pole", code: "CFX30000+", want: geodesy.BoundingBox{South: 89, West: 1, North: 90, East: 2}},
+		{name: "OK/antimeridian", code: "6VGX0000+", want: geodesy.BoundingBox{South: 0, West: 179, North: 1, East: 180}},
+		{name: "FAIL/short", code: "9QCJ+2VX", wantErr: olc.ErrNotFull},
+		{name: "FAIL/latitude_out_of_range", code: "F2222222+22", wantErr: olc.ErrSyntax},
+		{name: "FAIL/longitude_out_of_range", code: "2X222222+22", wantErr: olc.ErrSyntax},
+		{name: "FAIL/no_separator", code: "8FVC9G8F6X", wantErr: olc.ErrSyntax},
+		{name: "FAIL/two_separators", code: "8FVC9G8F++6X", wantErr: olc.ErrSyntax},
+		{name: "FAIL/odd_separator", code: "8FVC9G8+F6X", wantErr: olc.ErrSyntax},
+		{name: "FAIL/single_grid_digit", code: "8FVC9G8F+6", wantErr: olc.ErrSyntax},
+		{name: "FAIL/odd_padding", code: "8FVC900+", wantErr: olc.ErrSyntax},
+		{name: "FAIL/digits_after_padding", code: "8FVC0000+6X", wantErr: olc.ErrSyntax},
+		{name: "FAIL/invalid_character", code: "8FVC9G8A+6X", wantErr: olc.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := olc.Bounds(tt.code)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, got.Valid())
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.South, got.South, 1e-10)
+			assert.InDelta(t, tt.want.West, got.West, 1e-10)
+			assert.InDelta(t, tt.want.North, got.North, 1e-10)
+			assert.InDelta(t, tt.want.East, got.East, 1e-10)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	p, err := olc.Decode("8FVC9G8F+6X")
+	assert.NoError(t, err)
+	assert.InDelta(t, 47.3655625, p.Lat(), 1e-10)
+	assert.InDelta(t, 8.5249375, p.Lon(), 1e-10)
+
+	p, err = olc.Decode("9G8F+6X")
+	assert.ErrorIs(t, err, olc.ErrNotFull)
+	assert.False(t, p.Valid())
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range []geodesy.Point{{0.0000001, 0.0000001}, {51.50741, -0.12781}, {-33.86881, 151.20931}, {89.99, 179.99}, {-89.99, -180}} {
+			for _, length := range []int{2, 4, 6, 8, 10, 11, 12, 13, 14, 15} {
+				code := olc.Encode(p, length)
+				assert.True(t, olc.IsFull(code), code)
+				b, err := olc.Bounds(code)
+				assert.NoError(t, err)
+				assert.True(t, b.Contains(p), "%v in %s", p, code)
+				c, err := olc.Decode(code)
+				assert.NoError(t, err)
+				assert.Equal(t, code, olc.Encode(c, length))
+			}
+		}
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		code                 string
+		valid, short, isFull bool
+	}{
+		{code: "8FWC2345+G6", valid: true, isFull: true},
+		{code: "8FWC2345+G6G", valid: true, isFull: true},
+		{code: "8fwc2345+", valid: true, isFull: true},
+		{code: "8FWCX400+", valid: true, isFull: true},
+		{code: "WC2345+G6g", valid: true, short: true},
+		{code: "2345+G6", valid: true, short: true},
+		{code: "45+G6", valid: true, short: true},
+		{code: "+G6", valid: true, short: true},
+		{code: "G+"},
+		{code: "+"},
+		{code: "8FWC2345+G"},
+		{code: "8FWC2_45+G6"},
+		{code: "8FWC2η45+G6"},
+		{code: "8FWC2345+G6+"},
+		{code: "8FWC2345G6+"},
+		{code: "8FWC2300+G6"},
+		{code: "WC2300+G6g"},
+		{code: "WC2345+G"},
+		{code: "WC2300+"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.valid, olc.IsValid(tt.code))
+			assert.Equal(t, tt.short, olc.IsShort(tt.code))
+			assert.Equal(t, tt.isFull, olc.IsFull(tt.code))
+		})
+	}
+}
+
+func TestShorten(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		ref     geodesy.Point
+		want    string
+		wantErr error
+	}{
+		{name: "OK/six_digits", code: "9C3W9QCJ+2VX", ref: geodesy.Point{51.3701125, -1.217765625}, want: "+2VX"},
+		{name: "OK/four_digits_north", code: "9C3W9QCJ+2VX", ref: geodesy.Point{51.3708675, -1.217765625}, want: "CJ+2VX"},
+		{name: "OK/four_digits_west", code: "9C3W9QCJ+2VX", ref: geodesy.Point{51.3701125, -1.218520625}, want: "CJ+2VX"},
+		{name: "OK/two_digits", code: "9C3W9QCJ+2VX", ref: geodesy.Point{51.3852125, -1.217765625}, want: "9QCJ+2VX"},
+		{name: "OK/lower_case", code: "8fvc9g8f+6x", ref: geodesy.Point{47.4, 8.6}, want: "9G8F+6X"},
+		{name: "OK/far", code: "8FVC9G8F+6X", ref: geodesy.Point{-33.8688, 151.2093}, want: "8FVC9G8F+6X"},
+		{name: "FAIL/padded", code: "7FG49Q00+", ref: geodesy.Point{20.375, 2.775}, wantErr: olc.ErrNotShortenable},
+		{name: "FAIL/short", code: "9G8F+6X", ref: geodesy.Point{47.4, 8.6}, wantErr: olc.ErrNotFull},
+		{name: "FAIL/invalid", code: "8FVC9G8F+6", ref: geodesy.Point{47.4, 8.6}, wantErr: olc.ErrSyntax},
+		{name: "FAIL/invalid_reference", code: "8FVC9G8F+6X", ref: geodesy.Point{91, 8.6}, wantErr: olc.ErrNotShortenable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := olc.Shorten(tt.code, tt.ref)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			recovered, err := olc.RecoverNearest(got, tt.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, olc.Encode(mustDecode(t, tt.code), len(tt.code)-1), recovered)
+		})
+	}
+}
+
+func mustDecode(t *testing.T, code string) geodesy.Point {
+	p, err := olc.Decode(code)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestRecoverNearest(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		ref     geodesy.Point
+		want    string
+		wantErr error
+	}{
+		{name: "OK/same_cell", code: "9G8F+6X", ref: geodesy.Point{47.4, 8.6}, want: "8FVC9G8F+6X"},
+		{name: "OK/south", code: "2GGG+GG", ref: geodesy.Point{46.976, 8.526}, want: "8FVC2GGG+GG"},
+		{name: "OK/north", code: "XGGG+GG", ref: geodesy.Point{47.003, 8.526}, want: "8FRCXGGG+GG"},
+		{name: "OK/east", code: "GXGG+GG", ref: geodesy.Point{46.526, 8.026}, want: "8FR9GXGG+GG"},
+		{name: "OK/west", code: "G2GG+GG", ref: geodesy.Point{46.526, 7.976}, want: "8FRCG2GG+GG"},
+		{name: "OK/eight_digits", code: "22+", ref: geodesy.Point{42.899, 9.012}, want: "8FJFW222+"},
+		{name: "OK/eight_digits_south", code: "22+", ref: geodesy.Point{14.95125, -23.5001}, want: "796RXG22+"},
+		{name: "OK/antimeridian", code: "2222+22", ref: geodesy.Point{0.5, 179.99}, want: "62G22222+22"},
+		{name: "OK/full", code: "8fvc9g8f+6x", ref: geodesy.Point{0, 0}, want: "8FVC9G8F+6X"},
+		{name: "FAIL/invalid", code: "9G8F+6", ref: geodesy.Point{47.4, 8.6}, wantErr: olc.ErrSyntax},
+		{name: "FAIL/invalid_reference", code: "9G8F+6X", ref: geodesy.Point{47.4, 181}, wantErr: olc.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := olc.RecoverNearest(tt.code, tt.ref)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package geodesy
+
+import "math"
+
+// Point3D represents a latitude-longitude pair in decimal degrees along with a height in meters.
+// The height is math.NaN() when it is unknown
+type Point3D [3]float64
+
+// Lat returns point p's latitude
+func (p Point3D) Lat() float64 {
+	return p[0]
+}
+
+// Lon returns point p's longitude
+func (p Point3D) Lon() float64 {
+	return p[1]
+}
+
+// Height returns point p's height in meters, or math.NaN() if it is unknown
+func (p Point3D) Height() float64 {
+	return p[2]
+}
+
+// HasHeight returns whether the height of p is known
+func (p Point3D) HasHeight() bool {
+	return !math.IsNaN(p[2])
+}
+
+// Point returns the latitude-longitude pair of p
+func (p Point3D) Point() Point {
+	return Point{p[0], p[1]}
+}
+
+// Equals returns whether p is equal in latitude, longitude and height to p2.
+// Two unknown heights are considered equal
+func (p Point3D) Equals(p2 Point3D) bool {
+	return p.Point().Equals(p2.Point()) && (p[2] == p2[2] || !p.HasHeight() && !p2.HasHeight())
+}
+
+// Valid returns whether p is valid, that is, its latitude and longitude are contained within the
+// valid range of geographic coordinates and its height is either finite or unknown
+func (p Point3D) Valid() bool {
+	return p.Point().Valid() && !math.IsInf(p[2], 0)
+}
@@ -0,0 +1,186 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+)
+
+// Accumulator computes the area and perimeter of a polygon on the ellipsoid incrementally,
+// with its vertices added one at a time and its edges being geodesics.
+// Polygons may cross the antimeridian and enclose a pole, and are implicitly closed
+// (that is, the last vertex must not repeat the first one)
+type Accumulator struct {
+	g *geodesic.Geodesic
+
+	first, last geodesy.Point
+	count       int
+	crossings   int
+	area        sum
+	perimeter   sum
+}
+
+// NewAccumulator returns an empty Accumulator over the ellipsoid of g
+func NewAccumulator(g *geodesic.Geodesic) *Accumulator {
+	return &Accumulator{g: g}
+}
+
+// Reset removes all the vertices of a
+func (a *Accumulator) Reset() {
+	*a = Accumulator{g: a.g}
+}
+
+// Count returns the amount of vertices added to a
+func (a *Accumulator) Count() int {
+	return a.count
+}
+
+// Add adds the vertex p to the polygon, connected to the previous vertex by a geodesic.
+// If p does not constitute a valid geographic coordinate, the area and perimeter of a
+// will be math.NaN() until it is reset
+func (a *Accumulator) Add(p geodesy.Point) {
+	if a.count == 0 {
+		a.first = p
+	} else {
+		s := a.g.Inverse(a.last, p)
+		a.perimeter.add(s.Distance)
+		a.area.add(s.Area)
+		a.crossings += transit(a.last.Lon(), p.Lon())
+	}
+	a.last = p
+	a.count++
+}
+
+// Perimeter returns the perimeter of the closed polygon, in meters (m)
+func (a *Accumulator) Perimeter() float64 {
+	if a.count < 2 {
+		return 0
+	}
+
+	return a.perimeter.value() + a.g.Inverse(a.last, a.first).Distance
+}
+
+// Area returns the signed area of the closed polygon, in square meters (m²).
+// The area is positive if the vertices are traversed counter-clockwise and negative otherwise,
+// and always refers to the smaller of the two regions bounded by the polygon (that is, its
+// magnitude is at most half the area of the ellipsoid)
+func (a *Accumulator) Area() float64 {
+	if a.count < 3 {
+		return 0
+	}
+
+	s := a.g.Inverse(a.last, a.first)
+	area := a.area
+	area.add(s.Area)
+	crossings := a.crossings + transit(a.last.Lon(), a.first.Lon())
+
+	return reduceArea(area, a.g.EllipsoidArea(), crossings)
+}
+
+// Area returns the signed area of the polygon with the given vertices on the WGS-84 ellipsoid,
+// in square meters (m²). The area is positive if the vertices are traversed counter-clockwise and
+// negative otherwise. See Accumulator for details
+func Area(points []geodesy.Point) float64 {
+	area, _ := Measure(geodesic.WGS84, points)
+	return area
+}
+
+// Perimeter returns the perimeter of the polygon with the given vertices on the WGS-84 ellipsoid,
+// in meters (m)
+func Perimeter(points []geodesy.Point) float64 {
+	_, perimeter := Measure(geodesic.WGS84, points)
+	return perimeter
+}
+
+// Measure returns the signed area (in m²) and the perimeter (in m) of the polygon with the
+// given vertices, over the ellipsoid of g
+func Measure(g *geodesic.Geodesic, points []geodesy.Point) (area, perimeter float64) {
+	a := NewAccumulator(g)
+	for _, p := range points {
+		a.Add(p)
+	}
+
+	return a.Area(), a.Perimeter()
+}
+
+// transit returns +1 if the edge from lon1 to lon2 crosses the prime meridian eastwards,
+// −1 if it does westwards and 0 otherwise. Counting crossings allows the detection of
+// polygons that encircle a pole
+func transit(lon1, lon2 float64) int {
+	lon12 := math.Remainder(lon2-lon1, 360)
+	lon1, lon2 = normalize(lon1), normalize(lon2)
+
+	switch {
+	case lon12 > 0 && ((lon1 < 0 && lon2 >= 0) || (lon1 > 0 && lon2 == 0)):
+		return 1
+	case lon12 < 0 && lon1 >= 0 && lon2 < 0:
+		return -1
+	}
+
+	return 0
+}
+
+// reduceArea reduces the accumulated area between the edges and the equator (which is measured
+// clockwise) into the signed area of the polygon, given the total area of the ellipsoid
+func reduceArea(area sum, total float64, crossings int) float64 {
+	v := math.Remainder(area.value(), total)
+	if crossings&1 != 0 {
+		// The polygon encircles a pole
+		if v < 0 {
+			v += total / 2
+		} else {
+			v -= total / 2
+		}
+	}
+	// Convert to the counter-clockwise convention
+	v = -v
+	if v > total/2 {
+		v -= total
+	} else if v <= -total/2 {
+		v += total
+	}
+
+	return 0 + v
+}
+
+// normalize reduces the longitude lon to the range [−180°, 180°], preserving ±180°
+func normalize(lon float64) float64 {
+	v := math.Remainder(lon, 360)
+	if math.Abs(v) == 180 {
+		return math.Copysign(180, lon)
+	}
+
+	return v
+}
+
+// sum is a compensated (double-double) accumulator, which keeps the rounding errors of
+// the additions so that long sums of edges of varying magnitude remain exact
+type sum struct {
+	s, t float64
+}
+
+func (a *sum) add(y float64) {
+	y, u := twoSum(y, a.t)
+	a.s, a.t = twoSum(y, a.s)
+	if a.s == 0 {
+		a.s = u
+	} else {
+		a.t += u
+	}
+}
+
+func (a sum) value() float64 {
+	return a.s + a.t
+}
+
+// twoSum returns u + v along with the rounding error of the sum
+func twoSum(u, v float64) (s, t float64) {
+	s = u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+
+	return s, -(up + vpp)
+}
@@ -0,0 +1,119 @@
+package polygon_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name              string
+		points            []geodesy.Point
+		expectedArea      float64
+		expectedPerimeter float64
+	}{
+		{
+			name:              "OK/north_pole",
+			points:            []geodesy.Point{{89, 0}, {89, 90}, {89, 180}, {89, -90}},
+			expectedArea:      24952305678.0,
+			expectedPerimeter: 631819.8745,
+		},
+		{
+			name:              "OK/south_pole_clockwise",
+			points:            []geodesy.Point{{-89, 0}, {-89, 90}, {-89, 180}, {-89, -90}},
+			expectedArea:      -24952305678.0,
+			expectedPerimeter: 631819.8745,
+		},
+		{
+			name:              "OK/south_pole_counter_clockwise",
+			points:            []geodesy.Point{{-89, -90}, {-89, 180}, {-89, 90}, {-89, 0}},
+			expectedArea:      24952305678.0,
+			expectedPerimeter: 631819.8745,
+		},
+		{
+			name:              "OK/diamond",
+			points:            []geodesy.Point{{0, -1}, {-1, 0}, {0, 1}, {1, 0}},
+			expectedArea:      24619419146.0,
+			expectedPerimeter: 627598.2731,
+		},
+		{
+			name:              "OK/antimeridian",
+			points:            []geodesy.Point{{0, 179}, {-1, 180}, {0, -179}, {1, 180}},
+			expectedArea:      24619419146.0,
+			expectedPerimeter: 627598.2731,
+		},
+		{
+			name:              "OK/octant",
+			points:            []geodesy.Point{{90, 0}, {0, 0}, {0, 90}},
+			expectedArea:      63758202715511.0,
+			expectedPerimeter: 30022685.630,
+		},
+		{
+			name:              "OK/clockwise_octant",
+			points:            []geodesy.Point{{90, 0}, {0, 90}, {0, 0}},
+			expectedArea:      -63758202715511.0,
+			expectedPerimeter: 30022685.630,
+		},
+		{
+			name:              "OK/degenerate",
+			points:            []geodesy.Point{{10, 10}, {20, 20}},
+			expectedArea:      0,
+			expectedPerimeter: 2 * geodesic.WGS84.Inverse(geodesy.Point{10, 10}, geodesy.Point{20, 20}).Distance,
+		},
+		{
+			name:   "OK/empty",
+			points: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area, perimeter := polygon.Measure(geodesic.WGS84, tt.points)
+			assert.InDelta(t, tt.expectedArea, area, 1)
+			assert.InDelta(t, tt.expectedPerimeter, perimeter, 1e-3)
+			assert.Equal(t, area, polygon.Area(tt.points))
+			assert.Equal(t, perimeter, polygon.Perimeter(tt.points))
+		})
+	}
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.True(t, math.IsNaN(polygon.Area([]geodesy.Point{{0, 0}, {0, 1}, {91, 0}})))
+	})
+}
+
+func TestAccumulator(t *testing.T) {
+	a := polygon.NewAccumulator(geodesic.WGS84)
+	assert.Zero(t, a.Area())
+	assert.Zero(t, a.Perimeter())
+
+	// Streamed vertices of a polygon around the south pole, crossing the antimeridian several times
+	points := []geodesy.Point{}
+	for lon := 180.0; lon > -180; lon -= 7.5 {
+		points = append(points, geodesy.Point{-70 + 5*math.Sin(3*lon*math.Pi/180), lon})
+	}
+	for i, p := range points {
+		a.Add(p)
+		assert.Equal(t, i+1, a.Count())
+	}
+	area, perimeter := polygon.Measure(geodesic.WGS84, points)
+	assert.Equal(t, area, a.Area())
+	assert.Equal(t, perimeter, a.Perimeter())
+	// Traversed westwards around the south pole, that is, counter-clockwise
+	assert.Greater(t, area, 0.0)
+	assert.Less(t, area, geodesic.WGS84.EllipsoidArea()/2)
+
+	// Reversing the vertices flips the sign of the area
+	reversed := make([]geodesy.Point, len(points))
+	for i, p := range points {
+		reversed[len(points)-1-i] = p
+	}
+	assert.InDelta(t, -area, polygon.Area(reversed), 1e-3)
+
+	a.Reset()
+	assert.Zero(t, a.Count())
+	assert.Zero(t, a.Area())
+}
@@ -0,0 +1,273 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+// DefaultSegments is a vertex density for circles and buffers which keeps the area of the
+// returned polygons within 0.2% of the exact one
+const DefaultSegments = 64
+
+// Circle returns the polygon approximating the geodesic circle of radius r (in meters) around center,
+// that is, the points at distance r from center on the ellipsoid of g, computed with the direct problem.
+// Its segments vertices are equally spaced in azimuth and listed counter-clockwise, and the polygon is
+// implicitly closed, as for the other functions of this package.
+// The circle may enclose a pole or cross the antimeridian, but r must be less than a quarter of the
+// meridian (about 10000 km) for the circle to be the smaller of the two regions its polygon bounds.
+// If center does not constitute a valid geographic coordinate, r is not positive or segments is
+// less than 3, it returns nil
+func Circle(g *geodesic.Geodesic, center geodesy.Point, r float64, segments int) []geodesy.Point {
+	if !center.Valid() || !(r > 0) || segments < 3 {
+		return nil
+	}
+
+	ring := make([]geodesy.Point, segments)
+	for i := range ring {
+		// Decreasing azimuths sweep the circle counter-clockwise
+		ring[i] = g.Direct(center, -360*float64(i)/float64(segments), r).P2
+	}
+
+	return ring
+}
+
+// Buffer returns the polygon approximating the corridor of points within distance r (in meters)
+// of the polyline path, whose edges are geodesics on the ellipsoid of g.
+// Its boundary is made of the points at distance r from the edges, computed with the direct problem
+// along them, joined by circular arcs around the vertices on the outer side of each turn and at both
+// ends of the path. Arcs have the same vertex density as a Circle with the given segments.
+// The returned ring is counter-clockwise and implicitly closed, and it may enclose a pole or cross
+// the antimeridian. If the path encloses a region narrower than 2r, the ring is its outer boundary
+// (holes in the corridor are not represented).
+// If any point does not constitute a valid geographic coordinate, r is not positive or segments is
+// less than 3, it returns nil
+func Buffer(g *geodesic.Geodesic, path []geodesy.Point, r float64, segments int) []geodesy.Point {
+	if len(path) == 0 || !(r > 0) || segments < 3 {
+		return nil
+	}
+	var dedup []geodesy.Point
+	for i, p := range path {
+		if !p.Valid() {
+			return nil
+		}
+		if i == 0 || sphere.FromPoint(p).Angle(sphere.FromPoint(dedup[len(dedup)-1])) >= coincident {
+			dedup = append(dedup, p)
+		}
+	}
+	if len(dedup) == 1 {
+		return Circle(g, dedup[0], r, segments)
+	}
+
+	reversed := make([]geodesy.Point, len(dedup))
+	for i, p := range dedup {
+		reversed[len(dedup)-1-i] = p
+	}
+
+	// The raw boundary follows the right side of the path forwards and then its left side backwards,
+	// which is the right side of the reversed path. Its inner turns form small clockwise loops,
+	// which are removed afterwards
+	step := 360 / float64(segments)
+	right, end := offset(g, dedup, r, step)
+	left, start := offset(g, reversed, r, step)
+	raw := append(right, arc(g, dedup[len(dedup)-1], end+90, -180, r, step)...)
+	raw = append(raw, left...)
+	raw = append(raw, arc(g, dedup[0], start+90, -180, r, step)...)
+
+	return outerRing(raw)
+}
+
+// offset returns the points at distance r to the right of the geodesic edges of path, densified so that
+// consecutive points are spaced by no more than densifyStep along the path, with arcs around the vertices
+// where the path turns left. It also returns the azimuth of the path at its last vertex
+func offset(g *geodesic.Geodesic, path []geodesy.Point, r, step float64) ([]geodesy.Point, float64) {
+	var points []geodesy.Point
+	var azimuth float64
+	for i := 0; i < len(path)-1; i++ {
+		s := g.Inverse(path[i], path[i+1])
+		if i > 0 {
+			if turn := angleDiff(azimuth, s.Azimuth1); turn < 0 {
+				points = append(points, arc(g, path[i], azimuth+90, turn, r, step)...)
+			}
+		}
+
+		line := g.Line(path[i], s.Azimuth1)
+		n := int(math.Ceil(s.Distance / densifyStep))
+		if n < 1 {
+			n = 1
+		}
+		for j := 0; j <= n; j++ {
+			p := line.Position(s.Distance * float64(j) / float64(n))
+			points = append(points, g.Direct(p.P2, p.Azimuth2+90, r).P2)
+		}
+		azimuth = s.Azimuth2
+	}
+
+	return points, azimuth
+}
+
+// arc returns the intermediate points at distance r from center between azimuths from and from+sweep,
+// spaced by no more than step degrees
+func arc(g *geodesic.Geodesic, center geodesy.Point, from, sweep, r, step float64) []geodesy.Point {
+	n := int(math.Ceil(math.Abs(sweep) / step))
+	points := make([]geodesy.Point, 0, n)
+	for i := 1; i < n; i++ {
+		points = append(points, g.Direct(center, from+sweep*float64(i)/float64(n), r).P2)
+	}
+
+	return points
+}
+
+// angleDiff returns the difference b − a between azimuths, reduced to the range [-180, 180]
+func angleDiff(a, b float64) float64 {
+	return math.Remainder(b-a, 360)
+}
+
+// outerRing splits the ring at its self intersections into simple rings, and returns the
+// counter-clockwise one enclosing the largest area
+func outerRing(ring []geodesy.Point) []geodesy.Point {
+	var outer []sphere.Vector
+	outerArea := 0.0
+	for _, vs := range simpleRings(toVectors(ring)) {
+		if orientation(vs) != CounterClockwise {
+			continue
+		}
+		if area := leftArea(vs); area > outerArea {
+			outer, outerArea = vs, area
+		}
+	}
+
+	points := make([]geodesy.Point, len(outer))
+	for i, v := range outer {
+		points[i] = v.Point()
+	}
+
+	return points
+}
+
+// simpleRings splits the ring vs at its self intersections into simple rings in a single pass.
+// It walks along vs keeping the path walked so far simple: whenever the next edge crosses the path,
+// the loop closed by the crossing nearest to the start of the edge is removed from the path.
+// The edges of the path are looked up in a grid, so each edge is only tested against those near it
+func simpleRings(vs []sphere.Vector) [][]sphere.Vector {
+	grid := newEdgeGrid(vs)
+	path := []sphere.Vector{vs[0]}
+	var ids []int // ids[k] is the grid edge from path[k] to path[k+1]
+	var rings [][]sphere.Vector
+	for i := 1; i <= len(vs); i++ {
+		q := vs[i%len(vs)]
+		closing := i == len(vs)
+		for {
+			a := path[len(path)-1]
+			k, x := grid.nearestCrossing(a, q, ids, closing)
+			if k < 0 {
+				break
+			}
+			rings = append(rings, append([]sphere.Vector{x}, path[k+1:]...))
+			path = append(path[:k+1], x)
+			ids = append(ids[:k], grid.add(path[k], x, k))
+		}
+		if !closing {
+			path = append(path, q)
+			ids = append(ids, grid.add(path[len(path)-2], q, len(ids)))
+		}
+	}
+
+	return append(rings, path)
+}
+
+// edgeGrid indexes edges by the cubic cells of a grid over the unit sphere their arcs go through.
+// Cells are as large as the longest edge, so that each edge only goes through a few of them
+type edgeGrid struct {
+	size  float64
+	cells map[[3]int][]int
+	edges []gridEdge
+	seen  []int
+	query int
+}
+
+// gridEdge is the edge from a to b, which is the k-th edge of the path while it is not truncated
+type gridEdge struct {
+	a, b sphere.Vector
+	k    int
+}
+
+// newEdgeGrid returns an empty grid for the edges of the ring vs and their parts
+func newEdgeGrid(vs []sphere.Vector) *edgeGrid {
+	size := 0.0
+	for i, v := range vs {
+		size = math.Max(size, v.Sub(vs[(i+1)%len(vs)]).Norm())
+	}
+	if size == 0 {
+		size = 1
+	}
+
+	return &edgeGrid{size: size, cells: make(map[[3]int][]int)}
+}
+
+// add indexes the edge from a to b as the k-th edge of the path, and returns its id
+func (g *edgeGrid) add(a, b sphere.Vector, k int) int {
+	id := len(g.edges)
+	g.edges = append(g.edges, gridEdge{a: a, b: b, k: k})
+	g.seen = append(g.seen, 0)
+	g.visit(a, b, func(cell [3]int) {
+		g.cells[cell] = append(g.cells[cell], id)
+	})
+
+	return id
+}
+
+// nearestCrossing returns the index in the path of the edge crossing the arc from a to b nearest to a,
+// and their intersection, or -1 if no edge of the path crosses it. ids are the grid edges of the path,
+// whose last edge ends at a. If closing, b is the start of the path
+func (g *edgeGrid) nearestCrossing(a, b sphere.Vector, ids []int, closing bool) (int, sphere.Vector) {
+	g.query++
+	k, x, nearest := -1, sphere.Vector{}, math.Inf(-1)
+	g.visit(a, b, func(cell [3]int) {
+		for _, id := range g.cells[cell] {
+			if g.seen[id] == g.query {
+				continue
+			}
+			g.seen[id] = g.query
+
+			e := g.edges[id]
+			if e.k >= len(ids) || ids[e.k] != id || e.k == len(ids)-1 || closing && e.k == 0 {
+				// Truncated edges and those sharing a vertex with the arc
+				continue
+			}
+			if !crossing(e.a, e.b, a, b) {
+				continue
+			}
+			y := e.a.Cross(e.b).Cross(a.Cross(b)).Normalize()
+			if y.Dot(a.Add(b)) < 0 {
+				y = y.Scale(-1)
+			}
+			if d := y.Dot(a); d > nearest {
+				k, x, nearest = e.k, y, d
+			}
+		}
+	})
+
+	return k, x
+}
+
+// visit calls f for the cells of the bounding box of the arc from a to b, which is that of its chord
+// enlarged by the sagitta of the arc
+func (g *edgeGrid) visit(a, b sphere.Vector, f func(cell [3]int)) {
+	chord := a.Sub(b).Norm()
+	sagitta := 1 - math.Sqrt(math.Max(0, 1-chord*chord/4))
+	lo, hi := [3]int{}, [3]int{}
+	for i := range lo {
+		lo[i] = int(math.Floor((math.Min(a[i], b[i]) - sagitta) / g.size))
+		hi[i] = int(math.Floor((math.Max(a[i], b[i]) + sagitta) / g.size))
+	}
+	for x := lo[0]; x <= hi[0]; x++ {
+		for y := lo[1]; y <= hi[1]; y++ {
+			for z := lo[2]; z <= hi[2]; z++ {
+				f([3]int{x, y, z})
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package polygon_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircle(t *testing.T) {
+	g := geodesic.WGS84
+	tests := []struct {
+		name   string
+		center geodesy.Point
+		r      float64
+	}{
+		{name: "OK/equator", center: geodesy.Point{0, 0}, r: 100_000},
+		{name: "OK/antimeridian", center: geodesy.Point{-36.8, 180}, r: 250_000},
+		{name: "OK/north_pole", center: geodesy.Point{90, 0}, r: 500_000},
+		{name: "OK/enclosing_south_pole", center: geodesy.Point{-88, 45}, r: 1_000_000},
+		{name: "OK/large", center: geodesy.Point{45, -120}, r: 5_000_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := polygon.Circle(g, tt.center, tt.r, polygon.DefaultSegments)
+			assert.Len(t, ring, polygon.DefaultSegments)
+			assert.NoError(t, polygon.Validate(ring))
+			assert.Equal(t, polygon.CounterClockwise, polygon.Orientation(ring))
+			for _, p := range ring {
+				assert.InDelta(t, tt.r, g.Inverse(tt.center, p).Distance, 1e-6)
+			}
+
+			assert.True(t, polygon.ContainsGeodesic(g, ring, tt.center))
+			for azi := -180.0; azi < 180; azi += 7 {
+				assert.True(t, polygon.ContainsGeodesic(g, ring, g.Direct(tt.center, azi, 0.99*tt.r).P2))
+				assert.False(t, polygon.ContainsGeodesic(g, ring, g.Direct(tt.center, azi, 1.01*tt.r).P2))
+			}
+
+			// The area of the circle is slightly larger than the one of the inscribed polygon
+			exact, _ := polygon.Measure(g, polygon.Circle(g, tt.center, tt.r, 4096))
+			area, _ := polygon.Measure(g, ring)
+			assert.InEpsilon(t, exact, area, 0.002)
+			assert.Less(t, area, exact)
+		})
+	}
+
+	assert.Nil(t, polygon.Circle(g, geodesy.Point{91, 0}, 1, polygon.DefaultSegments))
+	assert.Nil(t, polygon.Circle(g, geodesy.Point{0, 0}, 0, polygon.DefaultSegments))
+	assert.Nil(t, polygon.Circle(g, geodesy.Point{0, 0}, 1, 2))
+}
+
+func TestBuffer(t *testing.T) {
+	g := geodesic.WGS84
+	tests := []struct {
+		name string
+		path []geodesy.Point
+		r    float64
+	}{
+		{name: "OK/single_point", path: []geodesy.Point{{10, 10}}, r: 1000},
+		{name: "OK/segment", path: []geodesy.Point{{0, -1}, {0, 1}}, r: 10_000},
+		{name: "OK/repeated_points", path: []geodesy.Point{{0, -1}, {0, -1}, {0, 1}}, r: 10_000},
+		{name: "OK/zigzag", path: []geodesy.Point{{0, 0}, {1, 0.2}, {0, 0.4}, {1, 0.6}, {-1, 0.8}}, r: 20_000},
+		{name: "OK/sharp_turns", path: []geodesy.Point{{0, 0}, {0, 1}, {0.01, 0}, {0.02, 1}}, r: 5_000},
+		{name: "OK/antimeridian", path: []geodesy.Point{{-17, 178}, {-16, -179}, {-18, -178}}, r: 50_000},
+		{name: "OK/over_the_pole", path: []geodesy.Point{{85, 0}, {85, 180}}, r: 100_000},
+		{name: "OK/long", path: []geodesy.Point{{-34.6, -58.4}, {40.4, -3.7}, {51.5, -0.1}}, r: 200_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := polygon.Buffer(g, tt.path, tt.r, polygon.DefaultSegments)
+			assert.NoError(t, polygon.Validate(ring))
+			assert.Equal(t, polygon.CounterClockwise, polygon.Orientation(ring))
+
+			// Points along the path and at distance r from it on both sides are inside, and the
+			// ones beyond it are outside unless they are close to another edge
+			for i := range tt.path {
+				assert.True(t, polygon.ContainsGeodesic(g, ring, tt.path[i]))
+				if i == len(tt.path)-1 {
+					continue
+				}
+				s := g.Inverse(tt.path[i], tt.path[i+1])
+				line := g.Line(tt.path[i], s.Azimuth1)
+				for f := 0.05; f < 1; f += 0.1 {
+					p := line.Position(f * s.Distance)
+					for _, side := range []float64{-90, 90} {
+						assert.True(t, polygon.ContainsGeodesic(g, ring, g.Direct(p.P2, p.Azimuth2+side, 0.99*tt.r).P2))
+						outside := g.Direct(p.P2, p.Azimuth2+side, 1.01*tt.r).P2
+						if distanceToPath(g, tt.path, outside) > 1.001*tt.r {
+							assert.False(t, polygon.ContainsGeodesic(g, ring, outside))
+						}
+					}
+				}
+			}
+			for azi := -180.0; azi < 180; azi += 15 {
+				for _, end := range []geodesy.Point{tt.path[0], tt.path[len(tt.path)-1]} {
+					assert.True(t, polygon.ContainsGeodesic(g, ring, g.Direct(end, azi, 0.99*tt.r).P2))
+				}
+			}
+		})
+	}
+
+	t.Run("OK/area", func(t *testing.T) {
+		// A corridor along the equator is a rectangle with two half circles at its ends
+		path := []geodesy.Point{{0, -1}, {0, 1}}
+		r := 10_000.0
+		area, _ := polygon.Measure(g, polygon.Buffer(g, path, r, 1024))
+		length := g.Inverse(path[0], path[1]).Distance
+		assert.InEpsilon(t, 2*r*length+math.Pi*r*r, area, 0.001)
+	})
+
+	assert.Nil(t, polygon.Buffer(g, nil, 1, polygon.DefaultSegments))
+	assert.Nil(t, polygon.Buffer(g, []geodesy.Point{{0, 0}, {0, 181}}, 1, polygon.DefaultSegments))
+	assert.Nil(t, polygon.Buffer(g, []geodesy.Point{{0, 0}, {0, 1}}, -1, polygon.DefaultSegments))
+}
+
+// distanceToPath returns an upper bound of the distance from p to the polyline path,
+// sampled along its edges
+func distanceToPath(g *geodesic.Geodesic, path []geodesy.Point, p geodesy.Point) float64 {
+	d := g.Inverse(path[0], p).Distance
+	for i := 0; i < len(path)-1; i++ {
+		s := g.Inverse(path[i], path[i+1])
+		line := g.Line(path[i], s.Azimuth1)
+		for j := 0; j <= 200; j++ {
+			d = math.Min(d, g.Inverse(line.Position(s.Distance*float64(j)/200).P2, p).Distance)
+		}
+	}
+
+	return d
+}
+
+// windingPath returns a path of n edges of 3 km turning alternately by 60° to either side, whose
+// corridors have an inner loop per turn
+func windingPath(n int) []geodesy.Point {
+	path := []geodesy.Point{{40.4168, -3.7038}}
+	azimuth := 45.0
+	for i := 0; i < n; i++ {
+		path = append(path, geodesic.WGS84.Direct(path[i], azimuth, 3_000).P2)
+		azimuth += 60 * float64(1-2*(i%2))
+	}
+
+	return path
+}
+
+func BenchmarkBuffer(b *testing.B) {
+	path := windingPath(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		polygon.Buffer(geodesic.WGS84, path, 1_000, polygon.DefaultSegments)
+	}
+}
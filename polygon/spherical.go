@@ -0,0 +1,320 @@
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+var (
+	// ErrTooFewVertices is returned when a polygon has less than 3 vertices
+	ErrTooFewVertices = errors.New("polygon: less than 3 vertices")
+	// ErrInvalidPoint is returned when a vertex does not constitute a valid geographic coordinate
+	ErrInvalidPoint = errors.New("polygon: invalid vertex")
+	// ErrDuplicateVertex is returned when a polygon repeats a vertex (including a closing vertex
+	// equal to the first one, as polygons are implicitly closed)
+	ErrDuplicateVertex = errors.New("polygon: duplicate vertex")
+	// ErrAntipodalEdge is returned when an edge joins antipodal vertices, so that it is undefined
+	ErrAntipodalEdge = errors.New("polygon: edge between antipodal vertices")
+	// ErrSelfIntersection is returned when two edges of a polygon cross each other
+	ErrSelfIntersection = errors.New("polygon: self intersection")
+)
+
+// Winding represents the orientation of the vertices of a polygon
+type Winding int
+
+const (
+	// Degenerate polygons have less than 3 vertices or enclose no area
+	Degenerate Winding = iota
+	// CounterClockwise polygons have their interior to the left of their edges
+	CounterClockwise
+	// Clockwise polygons have their interior to the right of their edges
+	Clockwise
+)
+
+func (w Winding) String() string {
+	switch w {
+	case CounterClockwise:
+		return "CounterClockwise"
+	case Clockwise:
+		return "Clockwise"
+	}
+
+	return "Degenerate"
+}
+
+const (
+	// coincident is the angular distance (in radians, ~6 µm) below which vertices are considered equal
+	coincident = 1e-12
+	// densifyStep is the maximum length of the great circle arcs approximating a geodesic edge,
+	// which keeps the deviation between them under 1 cm
+	densifyStep = 10_000
+)
+
+// crossing returns whether the great circle arcs ab and cd cross at a point interior to both.
+// See Rubin (2012), "S2 Geometry", S2EdgeCrossings::SimpleCrossing
+func crossing(a, b, c, d sphere.Vector) bool {
+	ab := a.Cross(b)
+	acb := -ab.Dot(c)
+	bda := ab.Dot(d)
+	if acb*bda <= 0 {
+		return false
+	}
+
+	cd := c.Cross(d)
+	cbd := -cd.Dot(b)
+	dac := cd.Dot(a)
+
+	return acb*cbd > 0 && acb*dac > 0
+}
+
+// arcCrossing is like crossing, but a vertex c or d lying exactly on the great circle through a and b
+// is consistently considered to lie on its left. Therefore, when counting the crossings of ab with the
+// edges of a polygon, an arc passing through a vertex crosses either both or none of its edges
+func arcCrossing(a, b, c, d sphere.Vector) bool {
+	ab := a.Cross(b)
+	side := func(v sphere.Vector) float64 {
+		if ab.Dot(v) >= 0 {
+			return 1
+		}
+		return -1
+	}
+	acb := -side(c)
+	bda := side(d)
+	if acb*bda <= 0 {
+		return false
+	}
+
+	cd := c.Cross(d)
+	cbd := -cd.Dot(b)
+	dac := cd.Dot(a)
+
+	return acb*cbd > 0 && acb*dac > 0
+}
+
+func toVectors(points []geodesy.Point) []sphere.Vector {
+	vs := make([]sphere.Vector, len(points))
+	for i, p := range points {
+		vs[i] = sphere.FromPoint(p)
+	}
+
+	return vs
+}
+
+// leftArea returns the area of the region to the left of the edges of the polygon vs on the unit
+// sphere, in steradians, given by the Gauss-Bonnet theorem as 2π minus the sum of the turning angles
+func leftArea(vs []sphere.Vector) float64 {
+	turning := 0.0
+	for i := range vs {
+		a, b, c := vs[i], vs[(i+1)%len(vs)], vs[(i+2)%len(vs)]
+		in := a.Cross(b).Cross(b)
+		out := b.Cross(c).Cross(b)
+		turning += math.Atan2(b.Dot(in.Cross(out)), in.Dot(out))
+	}
+
+	return 2*math.Pi - turning
+}
+
+// orientation returns the winding of the polygon vs, considering its interior to be the smaller
+// of the two regions it bounds
+func orientation(vs []sphere.Vector) Winding {
+	if len(vs) < 3 {
+		return Degenerate
+	}
+
+	area := leftArea(vs)
+	switch {
+	case math.IsNaN(area) || math.Abs(math.Remainder(area, 4*math.Pi)) < coincident:
+		return Degenerate
+	case area < 2*math.Pi:
+		return CounterClockwise
+	}
+
+	return Clockwise
+}
+
+// Orientation returns the winding of the polygon with the given vertices, with great circle edges.
+// The interior of a polygon is considered to be the smaller of the two regions it bounds
+// on the sphere, so that it is consistent with the sign of its area
+func Orientation(points []geodesy.Point) Winding {
+	for _, p := range points {
+		if !p.Valid() {
+			return Degenerate
+		}
+	}
+
+	return orientation(toVectors(points))
+}
+
+// Contains returns whether p lies inside the polygon with the given vertices, whose edges are
+// great circle arcs on the sphere. The interior of the polygon is the smaller of the two regions
+// it bounds regardless of its orientation, and it may cross the antimeridian or enclose a pole.
+// Points lying exactly on an edge may be reported either as inside or outside.
+// If p or any vertex does not constitute a valid geographic coordinate, it returns false
+func Contains(points []geodesy.Point, p geodesy.Point) bool {
+	if !p.Valid() {
+		return false
+	}
+	for _, v := range points {
+		if !v.Valid() {
+			return false
+		}
+	}
+
+	return contains(toVectors(points), sphere.FromPoint(p))
+}
+
+// ContainsGeodesic returns whether p lies inside the polygon with the given vertices, whose edges
+// are geodesics on the ellipsoid of g. See Contains for details
+func ContainsGeodesic(g *geodesic.Geodesic, points []geodesy.Point, p geodesy.Point) bool {
+	if !p.Valid() {
+		return false
+	}
+	for _, v := range points {
+		if !v.Valid() {
+			return false
+		}
+	}
+
+	return contains(toVectors(densify(g, points)), sphere.FromPoint(p))
+}
+
+func contains(vs []sphere.Vector, p sphere.Vector) bool {
+	winding := orientation(vs)
+	if winding == Degenerate {
+		return false
+	}
+
+	// Count the crossings with the edges of an arc from p to a reference point placed just to the
+	// left of the midpoint of the first edge, so that p is to the left of the edges (that is, in the
+	// counter-clockwise interior) iff they are even
+	a, b := vs[0], vs[1]
+	ref := a.Add(b).Normalize().Add(a.Cross(b).Normalize().Scale(1e-9)).Normalize()
+
+	left := true
+	for i := range vs {
+		if arcCrossing(ref, p, vs[i], vs[(i+1)%len(vs)]) {
+			left = !left
+		}
+	}
+
+	return left == (winding == CounterClockwise)
+}
+
+// densify approximates the geodesic edges of a polygon with great circle arcs,
+// returning the vertices along with the intermediate points of each edge
+func densify(g *geodesic.Geodesic, points []geodesy.Point) []geodesy.Point {
+	dense := make([]geodesy.Point, 0, len(points))
+	for i, p := range points {
+		dense = append(dense, p)
+
+		s := g.Inverse(p, points[(i+1)%len(points)])
+		n := int(math.Ceil(s.Distance / densifyStep))
+		if n < 2 {
+			continue
+		}
+		line := g.Line(p, s.Azimuth1)
+		for j := 1; j < n; j++ {
+			dense = append(dense, line.Position(s.Distance*float64(j)/float64(n)).P2)
+		}
+	}
+
+	return dense
+}
+
+// Centroid returns the centroid of the surface of the polygon with the given vertices on the sphere,
+// with great circle edges, projected onto the sphere. It is computed from the exact integral
+//
+//	∫ x dA = ½ Σ θi (vi × vi+1) / |vi × vi+1|
+//
+// where θi is the length of the edge from vi to vi+1, for the interior to the left of the edges.
+// If the polygon is degenerate or any vertex does not constitute a valid geographic coordinate,
+// the coordinates of the returned point will be math.NaN()
+func Centroid(points []geodesy.Point) geodesy.Point {
+	nan := geodesy.Point{math.NaN(), math.NaN()}
+	for _, p := range points {
+		if !p.Valid() {
+			return nan
+		}
+	}
+
+	vs := toVectors(points)
+	winding := orientation(vs)
+	if winding == Degenerate {
+		return nan
+	}
+
+	var sum sphere.Vector
+	for i := range vs {
+		a, b := vs[i], vs[(i+1)%len(vs)]
+		n := a.Cross(b)
+		if norm := n.Norm(); norm > 0 {
+			sum = sum.Add(n.Scale(a.Angle(b) / norm / 2))
+		}
+	}
+	if winding == Clockwise {
+		// The integral over the right region is the opposite, as it vanishes over the whole sphere
+		sum = sum.Scale(-1)
+	}
+	if sum.Norm() == 0 {
+		return nan
+	}
+
+	return sum.Normalize().Point()
+}
+
+// Validate checks that the polygon with the given vertices is simple, that is, it has at least 3
+// valid and distinct vertices, and its great circle edges do not intersect each other.
+// It returns nil for valid polygons, and an error wrapping one of ErrTooFewVertices,
+// ErrInvalidPoint, ErrDuplicateVertex, ErrAntipodalEdge or ErrSelfIntersection otherwise
+func Validate(points []geodesy.Point) error {
+	if len(points) < 3 {
+		return fmt.Errorf("%w: got %d", ErrTooFewVertices, len(points))
+	}
+	for i, p := range points {
+		if !p.Valid() {
+			return fmt.Errorf("%w: vertex %d (%v)", ErrInvalidPoint, i, p)
+		}
+	}
+
+	vs := toVectors(points)
+	for i := range vs {
+		for j := i + 1; j < len(vs); j++ {
+			if vs[i].Angle(vs[j]) < coincident {
+				return fmt.Errorf("%w: vertices %d and %d (%v)", ErrDuplicateVertex, i, j, points[i])
+			}
+		}
+		if vs[i].Angle(vs[(i+1)%len(vs)]) > math.Pi-coincident {
+			return fmt.Errorf("%w: vertices %d and %d", ErrAntipodalEdge, i, (i+1)%len(vs))
+		}
+	}
+
+	n := len(vs)
+	for i := range vs {
+		// Consecutive edges overlap if the polygon turns back at their shared vertex
+		a, b, c := vs[i], vs[(i+1)%n], vs[(i+2)%n]
+		in := a.Cross(b).Cross(b).Normalize()
+		out := b.Cross(c).Cross(b).Normalize()
+		if in.Dot(out) < -1+coincident {
+			return fmt.Errorf("%w: edges %d and %d overlap", ErrSelfIntersection, i, (i+1)%n)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		// Adjacent edges share a vertex, so that only edges which are at least 2 positions apart are checked
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			if crossing(vs[i], vs[i+1], vs[j], vs[(j+1)%n]) {
+				return fmt.Errorf("%w: edges %d and %d", ErrSelfIntersection, i, j)
+			}
+		}
+	}
+
+	return nil
+}
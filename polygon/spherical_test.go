@@ -0,0 +1,145 @@
+package polygon_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/polygon"
+	"github.com/stretchr/testify/assert"
+)
+
+func reverse(points []geodesy.Point) []geodesy.Point {
+	reversed := make([]geodesy.Point, len(points))
+	for i, p := range points {
+		reversed[len(points)-1-i] = p
+	}
+
+	return reversed
+}
+
+func TestContains(t *testing.T) {
+	square := []geodesy.Point{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}}
+	antimeridian := []geodesy.Point{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}}
+	northCap := []geodesy.Point{{80, 0}, {80, 120}, {80, -120}}
+	// A ring along the parallel -10° splits the sphere into a northern region larger than
+	// a hemisphere, and a smaller southern cap which is the interior
+	var ring []geodesy.Point
+	for lon := -180.0; lon < 180; lon += 30 {
+		ring = append(ring, geodesy.Point{-10, lon})
+	}
+
+	tests := []struct {
+		name     string
+		points   []geodesy.Point
+		p        geodesy.Point
+		expected bool
+	}{
+		{name: "OK/square_inside", points: square, p: geodesy.Point{0, 0}, expected: true},
+		{name: "OK/square_outside", points: square, p: geodesy.Point{0, 11}, expected: false},
+		{name: "OK/square_antipode", points: square, p: geodesy.Point{0, 180}, expected: false},
+		{name: "OK/square_great_circle_edge", points: square, p: geodesy.Point{10.1, 0}, expected: true},
+		{name: "OK/antimeridian_inside", points: antimeridian, p: geodesy.Point{5, 179.9}, expected: true},
+		{name: "OK/antimeridian_inside_west", points: antimeridian, p: geodesy.Point{-5, -175}, expected: true},
+		{name: "OK/antimeridian_outside", points: antimeridian, p: geodesy.Point{0, 0}, expected: false},
+		{name: "OK/north_pole", points: northCap, p: geodesy.Point{90, 0}, expected: true},
+		{name: "OK/north_cap_inside", points: northCap, p: geodesy.Point{85, 60}, expected: true},
+		{name: "OK/north_cap_outside", points: northCap, p: geodesy.Point{79, 0}, expected: false},
+		{name: "OK/ring_south_cap", points: ring, p: geodesy.Point{-80, 33}, expected: true},
+		{name: "OK/ring_north", points: ring, p: geodesy.Point{0, 0}, expected: false},
+		{name: "FAIL/invalid_point", points: square, p: geodesy.Point{0, 200}, expected: false},
+		{name: "FAIL/too_few_vertices", points: square[:2], p: geodesy.Point{0, 0}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, polygon.Contains(tt.points, tt.p))
+			assert.Equal(t, tt.expected, polygon.Contains(reverse(tt.points), tt.p), "reversed")
+		})
+	}
+
+	t.Run("OK/geodesic_edges", func(t *testing.T) {
+		edge := []geodesy.Point{{40, -60}, {40, 60}, {-40, 60}, {-40, -60}}
+
+		// Both the geodesic and the great circle arc between two points on the same parallel
+		// reach their northernmost point at the central meridian, but at different latitudes
+		s := geodesic.WGS84.Inverse(edge[0], edge[1])
+		geodesicVertex := geodesic.WGS84.Line(edge[0], s.Azimuth1).Position(s.Distance / 2).P2.Lat()
+		greatCircleVertex := math.Atan(math.Tan(40*math.Pi/180)/math.Cos(60*math.Pi/180)) * 180 / math.Pi
+		assert.Greater(t, math.Abs(geodesicVertex-greatCircleVertex), 0.01)
+
+		between := geodesy.Point{(geodesicVertex + greatCircleVertex) / 2, 0}
+		assert.NotEqual(t, polygon.Contains(edge, between), polygon.ContainsGeodesic(geodesic.WGS84, edge, between))
+		assert.True(t, polygon.ContainsGeodesic(geodesic.WGS84, edge, geodesy.Point{geodesicVertex - 1e-4, 0}))
+		assert.False(t, polygon.ContainsGeodesic(geodesic.WGS84, edge, geodesy.Point{geodesicVertex + 1e-4, 0}))
+		assert.True(t, polygon.Contains(edge, geodesy.Point{greatCircleVertex - 1e-4, 0}))
+		assert.False(t, polygon.Contains(edge, geodesy.Point{greatCircleVertex + 1e-4, 0}))
+	})
+}
+
+func TestOrientation(t *testing.T) {
+	square := []geodesy.Point{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}}
+	assert.Equal(t, polygon.CounterClockwise, polygon.Orientation(square))
+	assert.Equal(t, polygon.Clockwise, polygon.Orientation(reverse(square)))
+	assert.Equal(t, polygon.Degenerate, polygon.Orientation(square[:2]))
+	assert.Equal(t, polygon.Degenerate, polygon.Orientation([]geodesy.Point{{0, 0}, {0, 10}, {0, 20}}))
+	assert.Equal(t, polygon.Degenerate, polygon.Orientation([]geodesy.Point{{0, 0}, {0, 10}, {95, 20}}))
+	assert.Equal(t, "Clockwise", polygon.Clockwise.String())
+
+	// Consistent with the sign of the area
+	ring := []geodesy.Point{{-60, 0}, {-60, 120}, {-60, -120}}
+	assert.Equal(t, polygon.Clockwise, polygon.Orientation(ring))
+	assert.Less(t, polygon.Area(ring), 0.0)
+}
+
+func TestCentroid(t *testing.T) {
+	antimeridian := []geodesy.Point{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}}
+	for _, points := range [][]geodesy.Point{antimeridian, reverse(antimeridian)} {
+		c := polygon.Centroid(points)
+		assert.InDelta(t, 0, c.Lat(), 1e-9)
+		assert.InDelta(t, 180, math.Abs(c.Lon()), 1e-9)
+	}
+
+	northCap := []geodesy.Point{{80, 10}, {80, 130}, {80, -110}}
+	assert.InDelta(t, 90, polygon.Centroid(northCap).Lat(), 1e-9)
+
+	// The centroid of a spherical triangle lies poleward of the mean of its vertices
+	triangle := []geodesy.Point{{0, 0}, {0, 40}, {60, 20}}
+	c := polygon.Centroid(triangle)
+	assert.InDelta(t, 20, c.Lon(), 1e-9)
+	assert.Greater(t, c.Lat(), 20.0)
+	assert.True(t, polygon.Contains(triangle, c))
+
+	assert.True(t, math.IsNaN(polygon.Centroid(triangle[:2]).Lat()))
+	assert.True(t, math.IsNaN(polygon.Centroid([]geodesy.Point{{0, 0}, {0, 1}, {-91, 0}}).Lat()))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		points   []geodesy.Point
+		expected error
+	}{
+		{name: "OK/square", points: []geodesy.Point{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}}},
+		{name: "OK/antimeridian", points: []geodesy.Point{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}}},
+		{name: "OK/concave", points: []geodesy.Point{{0, 0}, {0, 10}, {5, 5}, {10, 10}, {10, 0}}},
+		{name: "FAIL/too_few_vertices", points: []geodesy.Point{{0, 0}, {1, 1}}, expected: polygon.ErrTooFewVertices},
+		{name: "FAIL/invalid_point", points: []geodesy.Point{{0, 0}, {1, 1}, {0, 190}}, expected: polygon.ErrInvalidPoint},
+		{name: "FAIL/duplicate", points: []geodesy.Point{{0, 0}, {0, 10}, {10, 10}, {0, 10}}, expected: polygon.ErrDuplicateVertex},
+		{name: "FAIL/closing_vertex", points: []geodesy.Point{{0, 0}, {0, 10}, {10, 10}, {0, 0}}, expected: polygon.ErrDuplicateVertex},
+		{name: "FAIL/same_point_antimeridian", points: []geodesy.Point{{5, 180}, {0, 10}, {10, 10}, {5, -180}}, expected: polygon.ErrDuplicateVertex},
+		{name: "FAIL/antipodal_edge", points: []geodesy.Point{{10, 20}, {-10, -160}, {50, 50}}, expected: polygon.ErrAntipodalEdge},
+		{name: "FAIL/bow_tie", points: []geodesy.Point{{0, 0}, {10, 10}, {0, 10}, {10, 0}}, expected: polygon.ErrSelfIntersection},
+		{name: "FAIL/spike", points: []geodesy.Point{{0, 0}, {0, 10}, {0, 5}, {10, 5}}, expected: polygon.ErrSelfIntersection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := polygon.Validate(tt.points)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
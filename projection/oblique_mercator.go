@@ -0,0 +1,99 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+// SwissObliqueMercator represents the oblique Mercator projection of the Swiss national grids, which
+// projects the ellipsoid conformally onto a sphere tangent at the origin, and the sphere onto a
+// cylinder tangent along the great circle through the origin perpendicular to its meridian.
+//
+// See https://www.swisstopo.admin.ch/en/knowledge-facts/surveying-geodesy/reference-frames.html
+// ("Formulas and constants for the calculation of the Swiss conformal cylindrical projection")
+type SwissObliqueMercator struct {
+	Ellipsoid ellipsoids.Ellipsoid
+	// Origin is the point of tangency of the sphere, and of the cylinder
+	Origin geodesy.Point
+	// FalseEasting and FalseNorthing are the coordinates of the origin, in meters (m)
+	FalseEasting, FalseNorthing float64
+}
+
+// sphere holds the constants of the conformal projection of the ellipsoid onto the sphere
+type sphere struct {
+	e float64
+	// r is the radius of the sphere, and α the ratio of spherical to ellipsoidal longitudes
+	r, α float64
+	// b0 is the spherical latitude of the origin, and k the constant of the latitude conversion
+	b0, k float64
+}
+
+func (om SwissObliqueMercator) sphere() sphere {
+	e2 := om.Ellipsoid.EccentricitySquared()
+	e := math.Sqrt(e2)
+	φ0 := om.Origin.LatRadians()
+	sinφ0, cosφ0 := math.Sincos(φ0)
+
+	α := math.Sqrt(1 + e2/(1-e2)*math.Pow(cosφ0, 4))
+	b0 := math.Asin(sinφ0 / α)
+
+	return sphere{
+		e:  e,
+		r:  om.Ellipsoid.SemiMajorAxis * math.Sqrt(1-e2) / (1 - e2*sinφ0*sinφ0),
+		α:  α,
+		b0: b0,
+		k: math.Log(math.Tan(math.Pi/4+b0/2)) - α*math.Log(math.Tan(math.Pi/4+φ0/2)) +
+			α*e/2*math.Log((1+e*sinφ0)/(1-e*sinφ0)),
+	}
+}
+
+// Forward returns the easting and northing of p
+func (om SwissObliqueMercator) Forward(p geodesy.Point) (easting, northing float64) {
+	s := om.sphere()
+	φ := p.LatRadians()
+	sinφ := math.Sin(φ)
+
+	// Spherical latitude and longitude
+	S := s.α*math.Log(math.Tan(math.Pi/4+φ/2)) - s.α*s.e/2*math.Log((1+s.e*sinφ)/(1-s.e*sinφ)) + s.k
+	b := 2 * (math.Atan(math.Exp(S)) - math.Pi/4)
+	l := s.α * remainder(p.Lon()-om.Origin.Lon()) * degToRad
+
+	// Latitude and longitude in the oblique system, whose equator is the axis of the cylinder
+	sinb0, cosb0 := math.Sincos(s.b0)
+	sinb, cosb := math.Sincos(b)
+	sinl, cosl := math.Sincos(l)
+	lo := math.Atan2(sinl, sinb0*math.Tan(b)+cosb0*cosl)
+	bo := math.Asin(cosb0*sinb - sinb0*cosb*cosl)
+
+	return om.FalseEasting + s.r*lo, om.FalseNorthing + s.r/2*math.Log((1+math.Sin(bo))/(1-math.Sin(bo)))
+}
+
+// Inverse returns the point at the given easting and northing
+func (om SwissObliqueMercator) Inverse(easting, northing float64) geodesy.Point {
+	s := om.sphere()
+	lo := (easting - om.FalseEasting) / s.r
+	bo := 2 * (math.Atan(math.Exp((northing-om.FalseNorthing)/s.r)) - math.Pi/4)
+
+	sinb0, cosb0 := math.Sincos(s.b0)
+	sinbo, cosbo := math.Sincos(bo)
+	sinlo, coslo := math.Sincos(lo)
+	b := math.Asin(cosb0*sinbo + sinb0*cosbo*coslo)
+	l := math.Atan2(sinlo, cosb0*coslo-sinb0*math.Tan(bo))
+
+	// Solve the ellipsoidal latitude iteratively
+	lnb := (math.Log(math.Tan(math.Pi/4+b/2)) - s.k) / s.α
+	φ := b
+	for i := 0; i < 20; i++ {
+		S := lnb + s.e*math.Log(math.Tan(math.Pi/4+math.Asin(s.e*math.Sin(φ))/2))
+		next := 2*math.Atan(math.Exp(S)) - math.Pi/2
+		if math.Abs(next-φ) < 1e-14 {
+			φ = next
+			break
+		}
+		φ = next
+	}
+
+	return geodesy.Point{φ * radToDeg, remainder(om.Origin.Lon() + l/s.α*radToDeg)}
+}
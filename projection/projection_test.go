@@ -0,0 +1,100 @@
+package projection_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/projection"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	nationalGrid = projection.TransverseMercator{
+		Ellipsoid: ellipsoids.Airy1830, Origin: geodesy.Point{49, -2}, Scale: 0.9996012717,
+		FalseEasting: 400_000, FalseNorthing: -100_000,
+	}
+	utm31 = projection.TransverseMercator{
+		Ellipsoid: ellipsoids.WGS84, Origin: geodesy.Point{0, 3}, Scale: 0.9996, FalseEasting: 500_000,
+	}
+	swiss = projection.SwissObliqueMercator{
+		Ellipsoid:    ellipsoids.Bessel1841,
+		Origin:       geodesy.Point{46 + 57.0/60 + 8.66/3600, 7 + 26.0/60 + 22.5/3600},
+		FalseEasting: 2_600_000, FalseNorthing: 1_200_000,
+	}
+)
+
+func TestTransverseMercator(t *testing.T) {
+	tests := []struct {
+		name                      string
+		tm                        projection.TransverseMercator
+		p                         geodesy.Point
+		wantEasting, wantNorthing float64
+	}{
+		{
+			// Worked example of the Ordnance Survey guide to coordinate systems in Great Britain
+			name:        "OK/national_grid",
+			tm:          nationalGrid,
+			p:           geodesy.Point{52 + 39.0/60 + 27.2531/3600, 1 + 43.0/60 + 4.5177/3600},
+			wantEasting: 651_409.903, wantNorthing: 313_177.270,
+		},
+		{
+			name:        "OK/true_origin",
+			tm:          nationalGrid,
+			p:           geodesy.Point{49, -2},
+			wantEasting: 400_000, wantNorthing: -100_000,
+		},
+		{
+			name:        "OK/equator",
+			tm:          utm31,
+			p:           geodesy.Point{0, 3},
+			wantEasting: 500_000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, n := tt.tm.Forward(tt.p)
+			assert.InDelta(t, tt.wantEasting, e, 1e-3)
+			assert.InDelta(t, tt.wantNorthing, n, 1e-3)
+
+			p := tt.tm.Inverse(tt.wantEasting, tt.wantNorthing)
+			assert.InDelta(t, tt.p.Lat(), p.Lat(), 1e-8)
+			assert.InDelta(t, tt.p.Lon(), p.Lon(), 1e-8)
+		})
+	}
+
+	t.Run("OK/round_trip", func(t *testing.T) {
+		for _, p := range []geodesy.Point{{0, 33}, {-60, -20}, {84, 3}, {45, 178}, {-10, 4}, {70, 30}} {
+			e, n := utm31.Forward(p)
+			back := utm31.Inverse(e, n)
+			assert.InDelta(t, p.Lat(), back.Lat(), 1e-10, "%v", p)
+			assert.InDelta(t, p.Lon(), back.Lon(), 1e-10, "%v", p)
+		}
+	})
+
+	t.Run("OK/symmetry", func(t *testing.T) {
+		e1, n1 := utm31.Forward(geodesy.Point{40, 0})
+		e2, n2 := utm31.Forward(geodesy.Point{-40, 6})
+		assert.InDelta(t, 1_000_000-e1, e2, 1e-6)
+		assert.InDelta(t, -n1, n2, 1e-6)
+	})
+}
+
+func TestSwissObliqueMercator(t *testing.T) {
+	e, n := swiss.Forward(swiss.Origin)
+	assert.InDelta(t, 2_600_000, e, 1e-6)
+	assert.InDelta(t, 1_200_000, n, 1e-6)
+
+	for _, p := range []geodesy.Point{{46.0453330062, 8.7316273516}, {47.8, 6}, {45.8, 10.5}, {46.5, 7.4}} {
+		e, n := swiss.Forward(p)
+		back := swiss.Inverse(e, n)
+		assert.InDelta(t, p.Lat(), back.Lat(), 1e-10, "%v", p)
+		assert.InDelta(t, p.Lon(), back.Lon(), 1e-10, "%v", p)
+	}
+
+	// Northings grow northwards along the meridian of the origin, and eastings eastwards along its parallel
+	_, north := swiss.Forward(geodesy.Point{47.5, swiss.Origin.Lon()})
+	east, _ := swiss.Forward(geodesy.Point{swiss.Origin.Lat(), 8.5})
+	assert.Greater(t, north, 1_200_000.0)
+	assert.Greater(t, east, 2_600_000.0)
+}
@@ -0,0 +1,148 @@
+// Package projection implements map projections of geodetic points over an ellipsoid onto planar
+// eastings and northings, as used by national and regional grids
+package projection
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+const (
+	degToRad = math.Pi / 180
+	radToDeg = 180 / math.Pi
+)
+
+// Projection converts geodetic points to and from eastings and northings, defined in meters (m)
+type Projection interface {
+	Forward(p geodesy.Point) (easting, northing float64)
+	Inverse(easting, northing float64) geodesy.Point
+}
+
+// TransverseMercator represents a transverse Mercator projection, computed with the series by
+// Krüger (1912) to the sixth order of the third flattening as given by Karney (2011), which are
+// accurate to the nanometer within 3900 km of the central meridian.
+//
+// See https://arxiv.org/abs/1002.1417 ("Transverse Mercator with an accuracy of a few nanometers")
+type TransverseMercator struct {
+	Ellipsoid ellipsoids.Ellipsoid
+	// Origin is the true origin, whose longitude is the central meridian
+	Origin geodesy.Point
+	// Scale is the scale factor k0 on the central meridian
+	Scale float64
+	// FalseEasting and FalseNorthing are the coordinates of the true origin, in meters (m)
+	FalseEasting, FalseNorthing float64
+}
+
+// series holds the coefficients of the Krüger series of an ellipsoid
+type series struct {
+	e float64
+	// a is the radius of the rectifying sphere, whose meridian length matches the ellipsoid's
+	a    float64
+	α, β [6]float64
+}
+
+func newSeries(el ellipsoids.Ellipsoid) series {
+	n := el.Flattening / (2 - el.Flattening)
+	n2, n3 := n*n, n*n*n
+	n4, n5, n6 := n3*n, n3*n2, n3*n3
+
+	return series{
+		e: math.Sqrt(el.EccentricitySquared()),
+		a: el.SemiMajorAxis / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+		α: [6]float64{
+			n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+			13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+			61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+			49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+			34729*n5/80640 - 3418889*n6/1995840,
+			212378941 * n6 / 319334400,
+		},
+		β: [6]float64{
+			n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+			n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+			17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+			4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+			4583*n5/161280 - 108847*n6/3991680,
+			20648693 * n6 / 638668800,
+		},
+	}
+}
+
+// conformal returns the tangent τ' of the conformal latitude of the latitude whose tangent is τ
+func (s series) conformal(τ float64) float64 {
+	σ := math.Sinh(s.e * math.Atanh(s.e*τ/math.Sqrt(1+τ*τ)))
+	return τ*math.Sqrt(1+σ*σ) - σ*math.Sqrt(1+τ*τ)
+}
+
+// forward returns the northing ξ and easting η on the rectifying sphere of unit radius of the point at
+// latitude φ and longitude λ from the central meridian, in radians
+func (s series) forward(φ, λ float64) (ξ, η float64) {
+	τp := s.conformal(math.Tan(φ))
+	sinλ, cosλ := math.Sincos(λ)
+	ξp := math.Atan2(τp, cosλ)
+	ηp := math.Asinh(sinλ / math.Sqrt(τp*τp+cosλ*cosλ))
+
+	ξ, η = ξp, ηp
+	for j, α := range s.α {
+		k := float64(2 * (j + 1))
+		ξ += α * math.Sin(k*ξp) * math.Cosh(k*ηp)
+		η += α * math.Cos(k*ξp) * math.Sinh(k*ηp)
+	}
+
+	return ξ, η
+}
+
+// inverse reverts forward, returning the latitude and the longitude from the central meridian
+func (s series) inverse(ξ, η float64) (φ, λ float64) {
+	ξp, ηp := ξ, η
+	for j, β := range s.β {
+		k := float64(2 * (j + 1))
+		ξp -= β * math.Sin(k*ξ) * math.Cosh(k*η)
+		ηp -= β * math.Cos(k*ξ) * math.Sinh(k*η)
+	}
+
+	sinhηp := math.Sinh(ηp)
+	sinξp, cosξp := math.Sincos(ξp)
+	τp := sinξp / math.Sqrt(sinhηp*sinhηp+cosξp*cosξp)
+
+	// Solve the conformal latitude for the geodetic one with Newton's method
+	e2 := s.e * s.e
+	τ := τp
+	for i := 0; i < 10; i++ {
+		τi := s.conformal(τ)
+		δτ := (τp - τi) / math.Sqrt(1+τi*τi) * (1 + (1-e2)*τ*τ) / ((1 - e2) * math.Sqrt(1+τ*τ))
+		τ += δτ
+		if math.Abs(δτ) < 1e-12 {
+			break
+		}
+	}
+
+	return math.Atan(τ), math.Atan2(sinhηp, cosξp)
+}
+
+// Forward returns the easting and northing of p
+func (tm TransverseMercator) Forward(p geodesy.Point) (easting, northing float64) {
+	s := newSeries(tm.Ellipsoid)
+	ξ, η := s.forward(p.LatRadians(), remainder(p.Lon()-tm.Origin.Lon())*degToRad)
+	ξ0, _ := s.forward(tm.Origin.LatRadians(), 0)
+	k := tm.Scale * s.a
+
+	return tm.FalseEasting + k*η, tm.FalseNorthing + k*(ξ-ξ0)
+}
+
+// Inverse returns the point at the given easting and northing
+func (tm TransverseMercator) Inverse(easting, northing float64) geodesy.Point {
+	s := newSeries(tm.Ellipsoid)
+	ξ0, _ := s.forward(tm.Origin.LatRadians(), 0)
+	k := tm.Scale * s.a
+	φ, λ := s.inverse((northing-tm.FalseNorthing)/k+ξ0, (easting-tm.FalseEasting)/k)
+
+	return geodesy.Point{φ * radToDeg, remainder(tm.Origin.Lon() + λ*radToDeg)}
+}
+
+// remainder returns the longitude equivalent to lon within [-180, 180]
+func remainder(lon float64) float64 {
+	return math.Remainder(lon, 360)
+}
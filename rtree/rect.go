@@ -0,0 +1,167 @@
+package rtree
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+)
+
+// rect is a region bounded by two parallels and two meridians which does not cross the antimeridian,
+// in decimal degrees
+type rect struct {
+	south, west, north, east float64
+}
+
+// rects returns the rects covering b, which are its halves on either side of the antimeridian if it
+// crosses it
+func rects(b geodesy.BoundingBox) []rect {
+	switch {
+	case b.Width() == 360:
+		return []rect{{b.South, geodesy.LonLowerBound, b.North, geodesy.LonUpperBound}}
+	case b.CrossesAntimeridian():
+		return []rect{
+			{b.South, b.West, b.North, geodesy.LonUpperBound},
+			{b.South, geodesy.LonLowerBound, b.North, b.East},
+		}
+	}
+
+	return []rect{{b.South, b.West, b.North, b.East}}
+}
+
+// queryRects returns the rects covering b, along with the edges at ±180° of longitude of those touching
+// the antimeridian mirrored to the other side of it, so that they intersect the rects touching it there
+func queryRects(b geodesy.BoundingBox) []rect {
+	parts := rects(b)
+	for _, r := range parts {
+		if r.west == geodesy.LonLowerBound {
+			parts = append(parts, rect{r.south, geodesy.LonUpperBound, r.north, geodesy.LonUpperBound})
+		}
+		if r.east == geodesy.LonUpperBound {
+			parts = append(parts, rect{r.south, geodesy.LonLowerBound, r.north, geodesy.LonLowerBound})
+		}
+	}
+
+	return parts
+}
+
+// bounds returns the smallest rect containing the rects of nodes, or a zero rect if there are none
+func bounds[T comparable](nodes []*node[T]) rect {
+	if len(nodes) == 0 {
+		return rect{}
+	}
+
+	r := nodes[0].rect
+	for _, n := range nodes[1:] {
+		r = r.union(n.rect)
+	}
+
+	return r
+}
+
+// low returns the lower bound of r along axis, which is 0 for longitudes and 1 for latitudes
+func (r rect) low(axis int) float64 {
+	if axis == 0 {
+		return r.west
+	}
+	return r.south
+}
+
+// high returns the upper bound of r along axis, which is 0 for longitudes and 1 for latitudes
+func (r rect) high(axis int) float64 {
+	if axis == 0 {
+		return r.east
+	}
+	return r.north
+}
+
+func (r rect) union(o rect) rect {
+	return rect{
+		south: math.Min(r.south, o.south), west: math.Min(r.west, o.west),
+		north: math.Max(r.north, o.north), east: math.Max(r.east, o.east),
+	}
+}
+
+func (r rect) intersects(o rect) bool {
+	return r.south <= o.north && o.south <= r.north && r.west <= o.east && o.west <= r.east
+}
+
+func (r rect) contains(o rect) bool {
+	return r.south <= o.south && o.north <= r.north && r.west <= o.west && o.east <= r.east
+}
+
+// area returns the area of r in the plane of its coordinates, in square degrees
+func (r rect) area() float64 {
+	return (r.north - r.south) * (r.east - r.west)
+}
+
+// margin returns the half perimeter of r in the plane of its coordinates, in degrees
+func (r rect) margin() float64 {
+	return (r.north - r.south) + (r.east - r.west)
+}
+
+// overlap returns the area of the intersection of r and o, in square degrees
+func (r rect) overlap(o rect) float64 {
+	height := math.Min(r.north, o.north) - math.Max(r.south, o.south)
+	width := math.Min(r.east, o.east) - math.Max(r.west, o.west)
+	if height <= 0 || width <= 0 {
+		return 0
+	}
+
+	return height * width
+}
+
+// target is a point to which the distances of rects are measured, along with the sine and cosine of its
+// latitude
+type target struct {
+	geodesy.Point
+	sinφ, cosφ float64
+}
+
+func newTarget(p geodesy.Point) target {
+	t := target{Point: p}
+	t.sinφ, t.cosφ = math.Sincos(p.LatRadians())
+
+	return t
+}
+
+// closest returns the point of r nearest to t on a sphere, and the angle between them, in radians.
+// If the longitude of t lies within r, it is the point of its meridian at the nearest latitude of r.
+// Otherwise it lies on the meridians bounding r, either at one of their ends or at the foot of the
+// perpendicular from t to them, which are compared by the cosines of their angles to t
+func (r rect) closest(t target) (geodesy.Point, float64) {
+	if t.Lon() >= r.west && t.Lon() <= r.east {
+		lat := math.Max(r.south, math.Min(r.north, t.Lat()))
+		return geodesy.Point{lat, t.Lon()}, math.Abs(t.Lat()-lat) * math.Pi / 180
+	}
+
+	var q geodesy.Point
+	best := math.Inf(-1)
+	sinS, cosS := math.Sincos(r.south * math.Pi / 180)
+	sinN, cosN := math.Sincos(r.north * math.Pi / 180)
+	for _, lon := range []float64{r.west, r.east} {
+		cosΔλ := math.Cos((t.Lon() - lon) * math.Pi / 180)
+		if c := t.sinφ*sinS + t.cosφ*cosS*cosΔλ; c > best {
+			q, best = geodesy.Point{r.south, lon}, c
+		}
+		if c := t.sinφ*sinN + t.cosφ*cosN*cosΔλ; c > best {
+			q, best = geodesy.Point{r.north, lon}, c
+		}
+		x := t.cosφ * cosΔλ
+		if foot := math.Atan2(t.sinφ, x) * 180 / math.Pi; foot > r.south && foot < r.north {
+			if c := math.Hypot(x, t.sinφ); c > best {
+				q, best = geodesy.Point{foot, lon}, c
+			}
+		}
+	}
+
+	return q, t.angle(q)
+}
+
+// angle returns the great circle angle between t and q, in radians, by the haversine formula
+func (t target) angle(q geodesy.Point) float64 {
+	φ := q.LatRadians()
+	sinΔφ, sinΔλ := math.Sin((φ-t.LatRadians())/2), math.Sin((q.LonRadians()-t.LonRadians())/2)
+	h := sinΔφ*sinΔφ + t.cosφ*math.Cos(φ)*sinΔλ*sinΔλ
+
+	return 2 * math.Asin(math.Min(math.Sqrt(h), 1))
+}
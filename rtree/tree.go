@@ -0,0 +1,515 @@
+// Package rtree implements a dynamic spatial index of bounding boxes for intersection, containment and
+// nearest neighbor queries by geodesic distance. Boxes crossing the antimeridian are indexed as their two
+// halves on either side of it, in an R-tree which is bulk loaded by sort-tile-recursive packing and whose
+// overflowing nodes are split as in the R*-tree
+package rtree
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/internal/spatial"
+)
+
+var (
+	// ErrInvalidBox is returned when an item to be indexed has an invalid bounding box
+	ErrInvalidBox = errors.New("rtree: invalid bounding box")
+	// ErrUncomparable is returned when an item to be indexed has a value which cannot be compared with ==,
+	// such as a slice or a map held by a value of an interface type
+	ErrUncomparable = errors.New("rtree: uncomparable value")
+)
+
+const (
+	// maxEntries is the maximum amount of children of a node, beyond which it is split
+	maxEntries = 16
+	// minEntries is the minimum amount of children of each node resulting from a split, below which
+	// nodes are removed on deletion and their entries inserted again
+	minEntries = 6
+)
+
+// Item is a bounding box indexed by a tree, such as that of a geometry given by geometry.Bounds, along
+// with a value identifying it, which is compared with == on deletion
+type Item[T comparable] struct {
+	Box   geodesy.BoundingBox
+	Value T
+}
+
+// Neighbor is an item found by a query, along with its geodesic distance to the queried point, in
+// meters (m)
+type Neighbor[T comparable] struct {
+	Item[T]
+	Distance float64
+}
+
+// Tree is an R-tree of items with values of type T, created by New. Its methods are safe for concurrent
+// use, as queries share a read lock and insertions and deletions take an exclusive one
+type Tree[T comparable] struct {
+	mu   sync.RWMutex
+	root *node[T]
+	len  int
+}
+
+// record is an indexed item, shared by the entries of both halves of boxes crossing the antimeridian
+type record[T comparable] struct {
+	item  Item[T]
+	rects []rect
+}
+
+// node is a node of a tree, whose rect bounds those of its children. The children of leaves are entries,
+// which have a record instead of children
+type node[T comparable] struct {
+	rect     rect
+	leaf     bool
+	children []*node[T]
+	record   *record[T]
+}
+
+// New returns a tree of the given items, which are bulk loaded by sort-tile-recursive packing. Items with
+// an invalid box or an uncomparable value are rejected with ErrInvalidBox or ErrUncomparable
+func New[T comparable](items []Item[T]) (*Tree[T], error) {
+	var entries []*node[T]
+	for k, item := range items {
+		if err := item.validate(); err != nil {
+			return nil, fmt.Errorf("%w at index %d", err, k)
+		}
+		entries = append(entries, newEntries(item)...)
+	}
+
+	return &Tree[T]{root: pack(entries, true), len: len(items)}, nil
+}
+
+func (item Item[T]) validate() error {
+	if !item.Box.Valid() {
+		return fmt.Errorf("%w: %v", ErrInvalidBox, item.Box)
+	}
+	if !spatial.Comparable(item.Value) {
+		return fmt.Errorf("%w: %T", ErrUncomparable, item.Value)
+	}
+
+	return nil
+}
+
+// newEntries returns the entries of a new record of item
+func newEntries[T comparable](item Item[T]) []*node[T] {
+	r := &record[T]{item: item, rects: rects(item.Box)}
+	entries := make([]*node[T], len(r.rects))
+	for k := range r.rects {
+		entries[k] = &node[T]{rect: r.rects[k], record: r}
+	}
+
+	return entries
+}
+
+func newNode[T comparable](children []*node[T], leaf bool) *node[T] {
+	n := &node[T]{leaf: leaf, children: make([]*node[T], len(children), maxEntries+1)}
+	copy(n.children, children)
+	n.rect = bounds(n.children)
+
+	return n
+}
+
+// pack returns the root of a tree whose lowest level has the given children, which are sorted by the
+// longitude of their centers into vertical slices and then by latitude into nodes of maxEntries
+// children. Upper levels are packed likewise, until a single node remains
+func pack[T comparable](children []*node[T], leaf bool) *node[T] {
+	if len(children) <= maxEntries {
+		return newNode(children, leaf)
+	}
+
+	count := (len(children) + maxEntries - 1) / maxEntries
+	sliceSize := int(math.Ceil(math.Sqrt(float64(count)))) * maxEntries
+	sortByCenter(children, 0)
+	parents := make([]*node[T], 0, count)
+	for lo := 0; lo < len(children); lo += sliceSize {
+		slice := children[lo:min(lo+sliceSize, len(children))]
+		sortByCenter(slice, 1)
+		for k := 0; k < len(slice); k += maxEntries {
+			parents = append(parents, newNode(slice[k:min(k+maxEntries, len(slice))], leaf))
+		}
+	}
+
+	return pack(parents, false)
+}
+
+func sortByCenter[T comparable](nodes []*node[T], axis int) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].rect.low(axis)+nodes[i].rect.high(axis) < nodes[j].rect.low(axis)+nodes[j].rect.high(axis)
+	})
+}
+
+// Len returns the amount of items of t
+func (t *Tree[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.len
+}
+
+// Insert adds item to t, rejecting it as New does
+func (t *Tree[T]) Insert(item Item[T]) error {
+	if err := item.validate(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range newEntries(item) {
+		t.insert(e)
+	}
+	t.len++
+
+	return nil
+}
+
+// insert adds the entry e to t, growing it by a level if its root is split
+func (t *Tree[T]) insert(e *node[T]) {
+	if len(t.root.children) == 0 {
+		// Empty roots are leaves, so that no entry is inserted below a missing subtree
+		t.root = newNode[T](nil, true)
+	}
+	if sibling := insert(t.root, e); sibling != nil {
+		t.root = newNode([]*node[T]{t.root, sibling}, false)
+	}
+}
+
+// insert adds the entry e to the leaf below n whose rect needs the least enlargement to contain it,
+// returning the node split from n if it overflows
+func insert[T comparable](n, e *node[T]) *node[T] {
+	if len(n.children) == 0 {
+		n.rect = e.rect
+	} else {
+		n.rect = n.rect.union(e.rect)
+	}
+
+	if n.leaf {
+		n.children = append(n.children, e)
+	} else if sibling := insert(chooseSubtree(n, e.rect), e); sibling != nil {
+		n.children = append(n.children, sibling)
+	}
+	if len(n.children) > maxEntries {
+		return split(n)
+	}
+
+	return nil
+}
+
+// chooseSubtree returns the child of n whose rect needs the least enlargement to contain r, or the
+// smallest one among those that need the same
+func chooseSubtree[T comparable](n *node[T], r rect) *node[T] {
+	var best *node[T]
+	bestEnlargement, bestArea := math.Inf(1), math.Inf(1)
+	for _, child := range n.children {
+		area := child.rect.area()
+		enlargement := child.rect.union(r).area() - area
+		if enlargement < bestEnlargement || enlargement == bestEnlargement && area < bestArea {
+			best, bestEnlargement, bestArea = child, enlargement, area
+		}
+	}
+
+	return best
+}
+
+// split moves the children of n past a split point to a new node, which is returned. As in the R*-tree,
+// children are sorted along the axis whose distributions have the least total margin, and split where
+// the rects of both groups overlap the least, or else have the least total area
+func split[T comparable](n *node[T]) *node[T] {
+	children := n.children
+	axis, bestMargin := 0, math.Inf(1)
+	for a := 0; a < 2; a++ {
+		var margin float64
+		for _, byHigh := range []bool{false, true} {
+			sortByBound(children, a, byHigh)
+			for k := minEntries; k <= len(children)-minEntries; k++ {
+				margin += bounds(children[:k]).margin() + bounds(children[k:]).margin()
+			}
+		}
+		if margin < bestMargin {
+			axis, bestMargin = a, margin
+		}
+	}
+
+	bestK, bestByHigh := 0, false
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+	for _, byHigh := range []bool{false, true} {
+		sortByBound(children, axis, byHigh)
+		for k := minEntries; k <= len(children)-minEntries; k++ {
+			r1, r2 := bounds(children[:k]), bounds(children[k:])
+			overlap, area := r1.overlap(r2), r1.area()+r2.area()
+			if overlap < bestOverlap || overlap == bestOverlap && area < bestArea {
+				bestK, bestByHigh, bestOverlap, bestArea = k, byHigh, overlap, area
+			}
+		}
+	}
+
+	sortByBound(children, axis, bestByHigh)
+	sibling := newNode(children[bestK:], n.leaf)
+	n.children = append(make([]*node[T], 0, maxEntries+1), children[:bestK]...)
+	n.rect = bounds(n.children)
+
+	return sibling
+}
+
+func sortByBound[T comparable](nodes []*node[T], axis int, byHigh bool) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if byHigh {
+			return nodes[i].rect.high(axis) < nodes[j].rect.high(axis)
+		}
+		return nodes[i].rect.low(axis) < nodes[j].rect.low(axis)
+	})
+}
+
+// Delete removes an item of t whose box and value are equal to those of item, reporting whether there
+// was one. Values are compared with ==, which is safe as uncomparable values are never indexed
+func (t *Tree[T]) Delete(item Item[T]) bool {
+	if item.validate() != nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var r *record[T]
+	for _, part := range rects(item.Box) {
+		t.root.search(part, func(e *node[T]) bool {
+			if e.record.item.Box == item.Box && e.record.item.Value == item.Value {
+				r = e.record
+				return false
+			}
+			return true
+		})
+		if r != nil {
+			break
+		}
+	}
+	if r == nil {
+		return false
+	}
+
+	// Nodes left underfull are removed, and their entries other than those of r inserted again
+	var orphans []*node[T]
+	for _, part := range r.rects {
+		remove(t.root, part, r, &orphans)
+	}
+	// The halves of r may lie in different children of the root, each of which may be removed, so that
+	// the root is either collapsed to its only child or reset to an empty leaf
+	for !t.root.leaf && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+	}
+	if len(t.root.children) == 0 {
+		t.root = newNode[T](nil, true)
+	}
+	for _, e := range orphans {
+		if e.record != r {
+			t.insert(e)
+		}
+	}
+	t.len--
+
+	return true
+}
+
+// remove removes the entry of r with the given rect below n, reporting whether it was found. Children of
+// n left with less than minEntries children are removed as well, and their entries appended to orphans
+func remove[T comparable](n *node[T], part rect, r *record[T], orphans *[]*node[T]) bool {
+	if len(n.children) == 0 || !n.rect.contains(part) {
+		return false
+	}
+
+	for k, child := range n.children {
+		if n.leaf {
+			if child.record != r || child.rect != part {
+				continue
+			}
+		} else if !remove(child, part, r, orphans) {
+			continue
+		} else if len(child.children) >= minEntries {
+			n.rect = bounds(n.children)
+			return true
+		} else {
+			*orphans = child.entries(*orphans)
+		}
+		n.children = append(n.children[:k], n.children[k+1:]...)
+		n.rect = bounds(n.children)
+		return true
+	}
+
+	return false
+}
+
+// entries appends the entries below n to dst
+func (n *node[T]) entries(dst []*node[T]) []*node[T] {
+	if n.leaf {
+		return append(dst, n.children...)
+	}
+	for _, child := range n.children {
+		dst = child.entries(dst)
+	}
+
+	return dst
+}
+
+// search calls f with the entries below n whose rects intersect r, until it returns false, reporting
+// whether it never did
+func (n *node[T]) search(r rect, f func(e *node[T]) bool) bool {
+	for _, child := range n.children {
+		switch {
+		case !child.rect.intersects(r):
+		case n.leaf:
+			if !f(child) {
+				return false
+			}
+		case !child.search(r, f):
+			return false
+		}
+	}
+
+	return true
+}
+
+// Intersects returns the items of t whose boxes intersect b, in no particular order. If b is invalid,
+// the returned slice is nil
+func (t *Tree[T]) Intersects(b geodesy.BoundingBox) []Item[T] {
+	return t.query(b, func(item Item[T]) bool { return item.Box.Intersects(b) })
+}
+
+// Contains returns the items of t whose boxes contain b, in no particular order, such as the boxes of the
+// geofences which may contain a point given as a box of zero size. If b is invalid, the returned slice
+// is nil
+func (t *Tree[T]) Contains(b geodesy.BoundingBox) []Item[T] {
+	return t.query(b, func(item Item[T]) bool { return item.Box.ContainsBox(b) })
+}
+
+// Within returns the items of t whose boxes lie within b, in no particular order. If b is invalid, the
+// returned slice is nil
+func (t *Tree[T]) Within(b geodesy.BoundingBox) []Item[T] {
+	return t.query(b, func(item Item[T]) bool { return b.ContainsBox(item.Box) })
+}
+
+// query returns the items of t whose boxes may intersect b and satisfy match, each of them once
+func (t *Tree[T]) query(b geodesy.BoundingBox, match func(Item[T]) bool) []Item[T] {
+	if !b.Valid() {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var items []Item[T]
+	var seen map[*record[T]]bool
+	parts := queryRects(b)
+	for _, part := range parts {
+		t.root.search(part, func(e *node[T]) bool {
+			r := e.record
+			if len(parts) > 1 || len(r.rects) > 1 {
+				if seen[r] {
+					return true
+				}
+				if seen == nil {
+					seen = make(map[*record[T]]bool)
+				}
+				seen[r] = true
+			}
+			if match(r.item) {
+				items = append(items, r.item)
+			}
+			return true
+		})
+	}
+
+	return items
+}
+
+// Nearest returns the k items of t nearest to p, sorted by their geodesic distance to it. Distances are
+// zero for the boxes containing p, and are otherwise measured to the point of each box nearest to p on
+// a sphere, which for boxes of zero size is their exact geodesic distance. If p is invalid or k is not
+// positive, the returned slice is nil
+func (t *Tree[T]) Nearest(p geodesy.Point, k int) []Neighbor[T] {
+	if !p.Valid() || k <= 0 {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var nearest []Neighbor[T]
+	to := newTarget(p)
+	seen := make(map[*record[T]]bool)
+	queue := candidateQueue[T]{{node: t.root}}
+	for len(queue) > 0 && len(nearest) < k {
+		c := heap.Pop(&queue).(candidate[T])
+		switch {
+		case c.node == nil:
+			// Candidates are popped by increasing lower bounds, so that no item is nearer than this one
+			nearest = append(nearest, Neighbor[T]{Item: c.record.item, Distance: c.distance})
+		case c.node.record != nil:
+			if r := c.node.record; !seen[r] {
+				seen[r] = true
+				heap.Push(&queue, candidate[T]{distance: r.distance(to), record: r})
+			}
+		default:
+			for _, child := range c.node.children {
+				heap.Push(&queue, candidate[T]{distance: lowerBound(to, child.rect), node: child})
+			}
+		}
+	}
+
+	return nearest
+}
+
+// distance returns the geodesic distance from t to the box of r, measured to its point nearest to t on
+// a sphere
+func (r *record[T]) distance(t target) float64 {
+	if r.item.Box.Contains(t.Point) {
+		return 0
+	}
+
+	var q geodesy.Point
+	best := math.Inf(1)
+	for _, part := range r.rects {
+		if c, d := part.closest(t); d < best {
+			q, best = c, d
+		}
+	}
+
+	return geodesic.WGS84.Inverse(t.Point, q).Distance
+}
+
+// lowerBound returns a lower bound of the geodesic distance from t to any point within r, in meters (m):
+// the great circle distance on the sphere of the mean radius of the WGS-84 ellipsoid, scaled by
+// spatial.SphericalBound, less its round-off
+func lowerBound(t target, r rect) float64 {
+	_, d := r.closest(t)
+	return spatial.LowerBound(d)
+}
+
+// candidate is either a node to be searched or an entry, whose distance is a lower bound of those of the
+// items below it, or a record whose exact distance is known, if node is nil
+type candidate[T comparable] struct {
+	distance float64
+	node     *node[T]
+	record   *record[T]
+}
+
+// candidateQueue is a min-heap of candidates by distance
+type candidateQueue[T comparable] []candidate[T]
+
+func (q candidateQueue[T]) Len() int            { return len(q) }
+func (q candidateQueue[T]) Less(i, j int) bool  { return q[i].distance < q[j].distance }
+func (q candidateQueue[T]) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *candidateQueue[T]) Push(x interface{}) { *q = append(*q, x.(candidate[T])) }
+func (q *candidateQueue[T]) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
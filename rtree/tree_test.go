@@ -0,0 +1,324 @@
+package rtree_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/rtree"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomBox returns a box of random size at a random position, which may cross the antimeridian, reach a
+// pole or span all longitudes
+func randomBox(r *rand.Rand) geodesy.BoundingBox {
+	switch r.Intn(20) {
+	case 0:
+		lat := 180*r.Float64() - 90
+		return geodesy.BoundingBox{South: lat, West: -180, North: math.Min(lat+5, 90), East: 180}
+	case 1:
+		return geodesy.BoundingBox{South: 80 + 10*r.Float64(), West: 360*r.Float64() - 180, North: 90, East: 360*r.Float64() - 180}
+	case 2:
+		lat := 180*r.Float64() - 90
+		return geodesy.BoundingBox{South: lat, West: 175 + 5*r.Float64(), North: lat, East: -180 + 5*r.Float64()}
+	}
+
+	lat, lon := 170*r.Float64()-85, 360*r.Float64()-180
+	h, w := 5*r.ExpFloat64(), 10*r.ExpFloat64()
+	if r.Intn(3) == 0 {
+		h, w = 0, 0
+	}
+	return geodesy.BoundingBox{
+		South: lat, West: lon,
+		North: math.Min(lat+h, 90), East: math.Remainder(lon+math.Min(w, 359), 360),
+	}
+}
+
+func randomItems(r *rand.Rand, n int) []rtree.Item[int] {
+	items := make([]rtree.Item[int], n)
+	for k := range items {
+		items[k] = rtree.Item[int]{Box: randomBox(r), Value: k}
+	}
+
+	return items
+}
+
+// queries returns boxes of random size, along with boxes of zero size at points on the antimeridian and
+// the poles
+func queries(r *rand.Rand) []geodesy.BoundingBox {
+	boxes := []geodesy.BoundingBox{
+		{South: 10, West: 180, North: 10, East: 180},
+		{South: 10, West: -180, North: 10, East: -180},
+		{South: 90, West: 0, North: 90, East: 0},
+		{South: -90, West: -180, North: 90, East: 180},
+		{South: -10, West: 170, North: 10, East: -170},
+	}
+	for k := 0; k < 100; k++ {
+		boxes = append(boxes, randomBox(r))
+	}
+
+	return boxes
+}
+
+func values(items []rtree.Item[int]) []int {
+	v := make([]int, len(items))
+	for k, item := range items {
+		v[k] = item.Value
+	}
+	sort.Ints(v)
+
+	return v
+}
+
+func filter(items []rtree.Item[int], match func(rtree.Item[int]) bool) []int {
+	var matched []rtree.Item[int]
+	for _, item := range items {
+		if match(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	return values(matched)
+}
+
+// assertQueries compares the queries of tree with those of a linear scan of items
+func assertQueries(t *testing.T, tree *rtree.Tree[int], items []rtree.Item[int], boxes []geodesy.BoundingBox) {
+	assert.Equal(t, len(items), tree.Len())
+	for _, b := range boxes {
+		assert.Equal(t, filter(items, func(item rtree.Item[int]) bool { return item.Box.Intersects(b) }), values(tree.Intersects(b)), "%v", b)
+		assert.Equal(t, filter(items, func(item rtree.Item[int]) bool { return item.Box.ContainsBox(b) }), values(tree.Contains(b)), "%v", b)
+		assert.Equal(t, filter(items, func(item rtree.Item[int]) bool { return b.ContainsBox(item.Box) }), values(tree.Within(b)), "%v", b)
+	}
+}
+
+func TestTree_Queries(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items := randomItems(r, 3000)
+	boxes := queries(r)
+
+	t.Run("OK/bulk_loaded", func(t *testing.T) {
+		tree, err := rtree.New(items)
+		assert.NoError(t, err)
+		assertQueries(t, tree, items, boxes)
+	})
+
+	t.Run("OK/inserted", func(t *testing.T) {
+		tree, err := rtree.New[int](nil)
+		assert.NoError(t, err)
+		for _, item := range items {
+			assert.NoError(t, tree.Insert(item))
+		}
+		assertQueries(t, tree, items, boxes)
+	})
+
+	t.Run("OK/deleted", func(t *testing.T) {
+		tree, err := rtree.New(items)
+		assert.NoError(t, err)
+		var kept []rtree.Item[int]
+		for k, item := range items {
+			if k%3 == 0 {
+				kept = append(kept, item)
+			} else {
+				assert.True(t, tree.Delete(item))
+			}
+		}
+		assertQueries(t, tree, kept, boxes)
+
+		for _, item := range kept {
+			assert.True(t, tree.Delete(item))
+		}
+		assertQueries(t, tree, nil, boxes)
+		assert.NoError(t, tree.Insert(items[0]))
+		assertQueries(t, tree, items[:1], boxes)
+	})
+
+	t.Run("OK/geofence", func(t *testing.T) {
+		tree, err := rtree.New([]rtree.Item[string]{
+			{Box: geodesy.BoundingBox{South: -21, West: 177, North: -12, East: -178}, Value: "fiji"},
+			{Box: geodesy.BoundingBox{South: 40.3, West: -3.9, North: 40.6, East: -3.5}, Value: "madrid"},
+		})
+		assert.NoError(t, err)
+		p := geodesy.BoundingBox{South: -18, West: -179.5, North: -18, East: -179.5}
+		assert.Equal(t, []rtree.Item[string]{{Box: geodesy.BoundingBox{South: -21, West: 177, North: -12, East: -178}, Value: "fiji"}}, tree.Contains(p))
+	})
+
+	assert.Nil(t, (&rtree.Tree[int]{}).Intersects(geodesy.BoundingBox{South: 1, West: 0, North: 0, East: 0}))
+}
+
+func TestTree_Delete(t *testing.T) {
+	b := geodesy.BoundingBox{South: 0, West: 170, North: 10, East: -170}
+	tree, err := rtree.New([]rtree.Item[string]{{Box: b, Value: "a"}, {Box: b, Value: "b"}})
+	assert.NoError(t, err)
+
+	assert.False(t, tree.Delete(rtree.Item[string]{Box: b, Value: "c"}))
+	assert.False(t, tree.Delete(rtree.Item[string]{Box: geodesy.BoundingBox{South: 0, West: 170, North: 10, East: 180}, Value: "a"}))
+	assert.False(t, tree.Delete(rtree.Item[string]{Box: geodesy.BoundingBox{South: 91}, Value: "a"}))
+	assert.True(t, tree.Delete(rtree.Item[string]{Box: b, Value: "a"}))
+	assert.False(t, tree.Delete(rtree.Item[string]{Box: b, Value: "a"}))
+	assert.Equal(t, 1, tree.Len())
+	assert.Equal(t, []rtree.Item[string]{{Box: b, Value: "b"}}, tree.Intersects(b))
+
+	// Uncomparable values held by interfaces are neither indexed nor compared
+	type key struct {
+		id   interface{}
+		name string
+	}
+	keys, err := rtree.New([]rtree.Item[interface{}]{{Box: b, Value: "b"}})
+	assert.NoError(t, err)
+	assert.NoError(t, keys.Insert(rtree.Item[interface{}]{Box: b, Value: key{id: 1, name: "c"}}))
+	assert.False(t, keys.Delete(rtree.Item[interface{}]{Box: b, Value: []string{"b"}}))
+	assert.False(t, keys.Delete(rtree.Item[interface{}]{Box: b, Value: key{id: []int{1}, name: "c"}}))
+	assert.True(t, keys.Delete(rtree.Item[interface{}]{Box: b, Value: key{id: 1, name: "c"}}))
+	assert.True(t, keys.Delete(rtree.Item[interface{}]{Box: b, Value: "b"}))
+}
+
+func TestTree_InsertDelete(t *testing.T) {
+	// Random insertions and deletions of boxes crossing the antimeridian, whose halves may lie in
+	// different children of the root, compared with a linear scan
+	boxes := queries(rand.New(rand.NewSource(5)))
+	for seed := int64(0); seed < 300; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		tree, err := rtree.New[int](nil)
+		assert.NoError(t, err)
+		var items []rtree.Item[int]
+		for op := 0; op < 200; op++ {
+			if len(items) > 0 && r.Intn(2) == 0 {
+				k := r.Intn(len(items))
+				assert.True(t, tree.Delete(items[k]), "seed %d", seed)
+				items = append(items[:k], items[k+1:]...)
+				continue
+			}
+			lat := 170*r.Float64() - 85
+			item := rtree.Item[int]{
+				Box:   geodesy.BoundingBox{South: lat, West: 170 + 10*r.Float64(), North: lat + r.Float64(), East: -180 + 10*r.Float64()},
+				Value: op,
+			}
+			assert.NoError(t, tree.Insert(item))
+			items = append(items, item)
+		}
+		assertQueries(t, tree, items, boxes)
+	}
+}
+
+func TestTree_Nearest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	items := make([]rtree.Item[int], 3000)
+	for k := range items {
+		p := geodesy.Point{math.Asin(2*r.Float64()-1) * 180 / math.Pi, 360*r.Float64() - 180}
+		items[k] = rtree.Item[int]{Box: geodesy.NewBoundingBox(p), Value: k}
+	}
+	tree, err := rtree.New(items)
+	assert.NoError(t, err)
+
+	points := []geodesy.Point{{0, 180}, {0, -180}, {90, 0}, {-90, 0}, {40.4168, -3.7038}}
+	for k := 0; k < 20; k++ {
+		points = append(points, geodesy.Point{180*r.Float64() - 90, 360*r.Float64() - 180})
+	}
+	for _, p := range points {
+		want := make([]float64, len(items))
+		for k, item := range items {
+			want[k] = geodesic.WGS84.Inverse(p, geodesy.Point{item.Box.South, item.Box.West}).Distance
+		}
+		sort.Float64s(want)
+		got := tree.Nearest(p, 20)
+		assert.Len(t, got, 20)
+		for k, n := range got {
+			assert.Equal(t, want[k], n.Distance, "%v", p)
+		}
+	}
+
+	t.Run("OK/boxes", func(t *testing.T) {
+		tree, err := rtree.New([]rtree.Item[string]{
+			{Box: geodesy.BoundingBox{South: -10, West: 170, North: 10, East: -170}, Value: "antimeridian"},
+			{Box: geodesy.BoundingBox{South: 20, West: -160, North: 30, East: -150}, Value: "north"},
+			{Box: geodesy.BoundingBox{South: -5, West: -165, North: 5, East: -160}, Value: "east"},
+		})
+		assert.NoError(t, err)
+		got := tree.Nearest(geodesy.Point{0, -175}, 3)
+		assert.Len(t, got, 3)
+		assert.Equal(t, "antimeridian", got[0].Value)
+		assert.Equal(t, 0.0, got[0].Distance)
+		assert.Equal(t, "east", got[1].Value)
+		assert.InDelta(t, geodesic.WGS84.Inverse(geodesy.Point{0, -175}, geodesy.Point{0, -165}).Distance, got[1].Distance, 1e-6)
+		assert.Equal(t, "north", got[2].Value)
+		assert.InDelta(t, geodesic.WGS84.Inverse(geodesy.Point{0, -175}, geodesy.Point{20, -160}).Distance, got[2].Distance, 1e-6)
+
+		got = tree.Nearest(geodesy.Point{0, 160}, 1)
+		assert.Equal(t, "antimeridian", got[0].Value)
+		assert.InDelta(t, geodesic.WGS84.Inverse(geodesy.Point{0, 160}, geodesy.Point{0, 170}).Distance, got[0].Distance, 1e-6)
+	})
+
+	t.Run("OK/more_than_len", func(t *testing.T) {
+		tree, err := rtree.New(items[:10])
+		assert.NoError(t, err)
+		assert.Len(t, tree.Nearest(geodesy.Point{0, 0}, 20), 10)
+	})
+
+	assert.Nil(t, tree.Nearest(geodesy.Point{91, 0}, 1))
+	assert.Nil(t, tree.Nearest(geodesy.Point{0, 0}, 0))
+}
+
+func TestTree_Concurrent(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	items := randomItems(r, 2000)
+	tree, err := rtree.New(items[:1000])
+	assert.NoError(t, err)
+	boxes := queries(r)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, item := range items[1000:] {
+			assert.NoError(t, tree.Insert(item))
+			assert.True(t, tree.Delete(item))
+		}
+	}()
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, b := range boxes {
+				for _, item := range tree.Intersects(b) {
+					assert.True(t, item.Box.Intersects(b))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assertQueries(t, tree, items[:1000], boxes)
+}
+
+func TestNew(t *testing.T) {
+	_, err := rtree.New([]rtree.Item[int]{{Box: geodesy.BoundingBox{South: 0, West: 0, North: 1, East: 1}}, {Box: geodesy.BoundingBox{South: 1, West: 0, North: 0, East: 0}}})
+	assert.ErrorIs(t, err, rtree.ErrInvalidBox)
+
+	tree, err := rtree.New[interface{}](nil)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, tree.Insert(rtree.Item[interface{}]{Box: geodesy.BoundingBox{South: 0, West: 0, North: 0, East: 181}}), rtree.ErrInvalidBox)
+	assert.ErrorIs(t, tree.Insert(rtree.Item[interface{}]{Box: geodesy.BoundingBox{South: 0, West: 0, North: 1, East: 1}, Value: map[string]int{}}), rtree.ErrUncomparable)
+	assert.ErrorIs(t, tree.Insert(rtree.Item[interface{}]{Box: geodesy.BoundingBox{South: 0, West: 0, North: 1, East: 1}, Value: [1]interface{}{func() {}}}), rtree.ErrUncomparable)
+
+	_, err = rtree.New([]rtree.Item[interface{}]{{Box: geodesy.BoundingBox{South: 0, West: 0, North: 1, East: 1}, Value: []int{1}}})
+	assert.EqualError(t, err, "rtree: uncomparable value: []int at index 0")
+	assert.Equal(t, 0, tree.Len())
+	assert.Empty(t, tree.Nearest(geodesy.Point{0, 0}, 1))
+}
+
+func BenchmarkTree_Contains(b *testing.B) {
+	r := rand.New(rand.NewSource(4))
+	tree, _ := rtree.New(randomItems(r, 100_000))
+	points := make([]geodesy.BoundingBox, 100)
+	for k := range points {
+		p := geodesy.Point{180*r.Float64() - 90, 360*r.Float64() - 180}
+		points[k] = geodesy.NewBoundingBox(p)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Contains(points[i%len(points)])
+	}
+}
@@ -0,0 +1,235 @@
+// Package simplify implements the simplification of polylines on the ellipsoid, with tolerances
+// expressed in meters or square meters so that they behave consistently at any latitude
+package simplify
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/internal/sphere"
+)
+
+const (
+	// interceptTolerance is the change in the along-track position (in meters) below which the
+	// foot of the perpendicular from a point to a geodesic is considered found
+	interceptTolerance = 1e-4
+	// interceptIterations bounds the iterations of the search of the foot of the perpendicular,
+	// which usually converges in 2 to 4 of them
+	interceptIterations = 20
+	// sphereRadius is the radius of the sphere on which distances are first estimated
+	sphereRadius = ellipsoids.WGS84_MEAN_RADIUS
+)
+
+// DouglasPeucker simplifies the polyline points with the Ramer-Douglas-Peucker algorithm, keeping the
+// smallest subset of its points such that every removed point lies within tolerance meters of the
+// geodesic between the kept points surrounding it. Distances are cross-track geodesic distances
+// on the ellipsoid of g, measured to the nearest point of each geodesic segment.
+// The first and last points are always kept, and the returned slice does not share memory with points.
+// If any point does not constitute a valid geographic coordinate, it returns nil
+func DouglasPeucker(g *geodesic.Geodesic, points []geodesy.Point, tolerance float64) []geodesy.Point {
+	for _, p := range points {
+		if !p.Valid() {
+			return nil
+		}
+	}
+	if len(points) < 3 {
+		return append([]geodesy.Point(nil), points...)
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	vectors := make([]sphere.Vector, len(points))
+	for i, p := range points {
+		vectors[i] = sphere.FromPoint(p)
+	}
+
+	// Ranges are processed from an explicit stack, as recursion depth may reach the amount of points
+	type span struct{ first, last int }
+	type candidate struct {
+		index    int
+		estimate float64
+	}
+	var candidates []candidate
+	stack := []span{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if s.last-s.first < 2 {
+			continue
+		}
+
+		// Distances are estimated on the sphere first, so that the ones on the ellipsoid are only
+		// computed for the points which may be the farthest one beyond the tolerance. Long segments
+		// are approximated by several great circle arcs, as they depart from the geodesic
+		seg := newSegment(g, points[s.first], points[s.last])
+		arcs := int(math.Ceil(seg.length / arcLength(tolerance)))
+		if arcs < 1 {
+			arcs = 1
+		}
+		vertices := make([]sphere.Vector, arcs+1)
+		vertices[0], vertices[arcs] = vectors[s.first], vectors[s.last]
+		for j := 1; j < arcs; j++ {
+			vertices[j] = sphere.FromPoint(seg.line.Position(seg.length * float64(j) / float64(arcs)).P2)
+		}
+		great := make([]greatArc, arcs)
+		for j := range great {
+			great[j] = newGreatArc(vertices[j], vertices[j+1])
+		}
+		margin := func(e float64) float64 {
+			return estimateError(e, seg.length/float64(arcs))
+		}
+
+		// The candidate with the largest estimate is measured first, which usually leaves no other
+		// candidate whose estimate could exceed its distance
+		candidates = candidates[:0]
+		top := -1
+		for i := s.first + 1; i < s.last; i++ {
+			if e := pathDistance(great, vectors[i]); e+margin(e) > tolerance {
+				if top < 0 || e > candidates[top].estimate {
+					top = len(candidates)
+				}
+				candidates = append(candidates, candidate{i, e})
+			}
+		}
+		if top < 0 {
+			continue
+		}
+		candidates[0], candidates[top] = candidates[top], candidates[0]
+
+		farthest, distance := -1, tolerance
+		for _, c := range candidates {
+			if c.estimate+margin(c.estimate) <= distance {
+				continue
+			}
+			if d := seg.distance(points[c.index]); d > distance {
+				farthest, distance = c.index, d
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+
+		keep[farthest] = true
+		stack = append(stack, span{s.first, farthest}, span{farthest, s.last})
+	}
+
+	var simplified []geodesy.Point
+	for i, p := range points {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+
+	return simplified
+}
+
+// segment represents a geodesic segment, for the computation of the distance from points to it
+type segment struct {
+	g      *geodesic.Geodesic
+	a, b   geodesy.Point
+	line   *geodesic.Line
+	length float64
+}
+
+func newSegment(g *geodesic.Geodesic, a, b geodesy.Point) segment {
+	s := g.Inverse(a, b)
+	return segment{g: g, a: a, b: b, line: g.Line(a, s.Azimuth1), length: s.Distance}
+}
+
+// distance returns the geodesic distance in meters from p to the nearest point of s.
+//
+// The foot of the perpendicular from p to the geodesic is found iteratively: given a point x along it,
+// the along-track distance to the foot is the one of the spherical right triangle with hypotenuse xp
+// and angle Δα at x, between the geodesic and xp, and the process is repeated from the new point.
+// See Baselga & Martínez-Llario (2018), "Intersection and point-to-line solutions for geodesics on the
+// ellipsoid", Studia Geophysica et Geodaetica 62
+func (s segment) distance(p geodesy.Point) float64 {
+	if s.length == 0 {
+		return s.g.Inverse(s.a, p).Distance
+	}
+
+	const r = sphereRadius
+	along := 0.0
+	for i := 0; i < interceptIterations; i++ {
+		x := s.line.Position(along)
+		xp := s.g.Inverse(x.P2, p)
+		σ := xp.Distance / r
+		Δα := (xp.Azimuth1 - x.Azimuth2) * math.Pi / 180
+
+		// The nearest point lies at an endpoint when the foot falls outside the segment
+		next := math.Min(math.Max(along+r*math.Atan2(math.Sin(σ)*math.Cos(Δα), math.Cos(σ)), 0), s.length)
+		if math.IsNaN(next) {
+			break
+		}
+		step := next - along
+		along = next
+		if math.Abs(step) < interceptTolerance {
+			break
+		}
+	}
+
+	return s.g.Inverse(s.line.Position(along).P2, p).Distance
+}
+
+// arcLength returns the length of the great circle arcs approximating a geodesic segment when estimating
+// distances to it, so that the error of the estimates stays within a tenth of the tolerance
+func arcLength(tolerance float64) float64 {
+	return math.Min(math.Max(math.Sqrt(0.1*tolerance/2e-10), 10_000), 1_000_000)
+}
+
+// estimateError bounds the difference between the distance d (in meters) from a point to a segment
+// of the given length estimated on the sphere and the one on the ellipsoid. It accounts for the
+// difference in scale between them (below 0.7%) and the separation between the great circle and the
+// geodesic joining the same endpoints, which grows with the square of the length of the segment
+// (about 1 cm for 10 km, and 65 m for 1000 km)
+func estimateError(d, length float64) float64 {
+	return 0.01*d + 2e-10*length*length + 1e-3
+}
+
+// greatArc represents a great circle arc ab on the sphere, along with the normals used to compute
+// the distance from points to it
+type greatArc struct {
+	a, b sphere.Vector
+	// n holds the unit normal of the plane of the arc, and na and bn the normals of the planes
+	// perpendicular to it through a and b, which bound the points whose nearest point is interior
+	n, na, bn  sphere.Vector
+	degenerate bool
+}
+
+func newGreatArc(a, b sphere.Vector) greatArc {
+	n := a.Cross(b)
+	norm := math.Sqrt(n.Dot(n))
+	if norm == 0 {
+		return greatArc{a: a, b: b, degenerate: true}
+	}
+	n = sphere.Vector{n[0] / norm, n[1] / norm, n[2] / norm}
+
+	return greatArc{a: a, b: b, n: n, na: n.Cross(a), bn: b.Cross(n)}
+}
+
+// pathDistance returns the distance in meters from p to the path made by the consecutive arcs on the
+// sphere, which is either the distance to the nearest vertex or the one to an arc whose interior holds
+// the foot of the perpendicular from p. Candidates are compared by their cosines, so that a single
+// inverse trigonometric function is evaluated
+func pathDistance(arcs []greatArc, p sphere.Vector) float64 {
+	perpendicular := math.Inf(1)
+	nearest, vertex := p.Dot(arcs[len(arcs)-1].b), arcs[len(arcs)-1].b
+	for _, arc := range arcs {
+		if !arc.degenerate && p.Dot(arc.na) >= 0 && p.Dot(arc.bn) >= 0 {
+			perpendicular = math.Min(perpendicular, math.Abs(p.Dot(arc.n)))
+		}
+		if d := p.Dot(arc.a); d > nearest {
+			nearest, vertex = d, arc.a
+		}
+	}
+
+	angle := vertex.ChordAngle(p)
+	if perpendicular <= 1 {
+		angle = math.Min(angle, math.Asin(perpendicular))
+	}
+
+	return angle * sphereRadius
+}
@@ -0,0 +1,85 @@
+package simplify_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/simplify"
+	"github.com/stretchr/testify/assert"
+)
+
+// zigzag returns a polyline along the geodesic from p with azimuth azi, with n points spaced by step
+// meters and alternately displaced by offset meters to each side of it
+func zigzag(p geodesy.Point, azi, step, offset float64, n int) []geodesy.Point {
+	line := geodesic.WGS84.Line(p, azi)
+	points := make([]geodesy.Point, n)
+	for i := range points {
+		s := line.Position(step * float64(i))
+		side := 90.0
+		if i%2 == 1 {
+			side = -90
+		}
+		if i == 0 || i == n-1 {
+			points[i] = s.P2
+			continue
+		}
+		points[i] = geodesic.WGS84.Direct(s.P2, s.Azimuth2+side, offset).P2
+	}
+
+	return points
+}
+
+func TestDouglasPeucker(t *testing.T) {
+	starts := []struct {
+		name string
+		p    geodesy.Point
+		azi  float64
+	}{
+		{name: "equator", p: geodesy.Point{0, 10}, azi: 90},
+		{name: "high_latitude", p: geodesy.Point{80, 10}, azi: 45},
+		{name: "antimeridian", p: geodesy.Point{-30, 179.9}, azi: 100},
+		{name: "meridian", p: geodesy.Point{-50, -70}, azi: 0},
+	}
+	for _, start := range starts {
+		t.Run("OK/"+start.name, func(t *testing.T) {
+			points := zigzag(start.p, start.azi, 1000, 50, 101)
+
+			// The displacement of every point is the same in meters regardless of the latitude
+			assert.Len(t, simplify.DouglasPeucker(geodesic.WGS84, points, 50.01), 2)
+			assert.Len(t, simplify.DouglasPeucker(geodesic.WGS84, points, 49.99), 101)
+
+			simplified := simplify.DouglasPeucker(geodesic.WGS84, points, 100)
+			assert.Equal(t, []geodesy.Point{points[0], points[100]}, simplified)
+		})
+	}
+
+	t.Run("OK/corner", func(t *testing.T) {
+		// Two legs of 100 km meeting at a right angle, with intermediate points every km
+		legA := zigzag(geodesy.Point{60, 0}, 90, 1000, 1, 101)
+		legB := zigzag(legA[100], geodesic.WGS84.Inverse(legA[99], legA[100]).Azimuth2+90, 1000, 1, 101)
+		points := append(legA, legB[1:]...)
+
+		simplified := simplify.DouglasPeucker(geodesic.WGS84, points, 10)
+		assert.Equal(t, []geodesy.Point{points[0], points[100], points[200]}, simplified)
+	})
+
+	t.Run("OK/beyond_endpoints", func(t *testing.T) {
+		// Points behind the start of a segment are measured to its endpoint, not to the extended geodesic
+		points := []geodesy.Point{{0, 0}, {0, -0.001}, {0, 1}}
+		assert.Len(t, simplify.DouglasPeucker(geodesic.WGS84, points, 100), 3)
+		assert.Len(t, simplify.DouglasPeucker(geodesic.WGS84, points, 112), 2)
+	})
+
+	t.Run("OK/short", func(t *testing.T) {
+		points := []geodesy.Point{{0, 0}, {1, 1}}
+		simplified := simplify.DouglasPeucker(geodesic.WGS84, points, 100)
+		assert.Equal(t, points, simplified)
+		simplified[0] = geodesy.Point{2, 2}
+		assert.Equal(t, geodesy.Point{0, 0}, points[0])
+	})
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.Nil(t, simplify.DouglasPeucker(geodesic.WGS84, []geodesy.Point{{0, 0}, {91, 0}, {1, 1}}, 100))
+	})
+}
@@ -0,0 +1,115 @@
+package simplify
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/polygon"
+)
+
+// Visvalingam simplifies the polyline points with the Visvalingam-Whyatt algorithm, which repeatedly
+// removes the point whose triangle with its neighbours has the smallest effective area, as long as it
+// is below tolerance square meters. Areas are the ones of geodesic triangles on the ellipsoid of g.
+// As in the original algorithm, the effective area of a point is never less than the one of a point
+// removed before it, so that the order of removal follows the significance of the points.
+// The first and last points are always kept, and the returned slice does not share memory with points.
+// If any point does not constitute a valid geographic coordinate, it returns nil
+func Visvalingam(g *geodesic.Geodesic, points []geodesy.Point, tolerance float64) []geodesy.Point {
+	for _, p := range points {
+		if !p.Valid() {
+			return nil
+		}
+	}
+	if len(points) < 3 {
+		return append([]geodesy.Point(nil), points...)
+	}
+
+	// Points form a doubly linked list, and the ones between the endpoints are ranked in a min-heap
+	vertices := make([]vertex, len(points))
+	h := make(vertexHeap, 0, len(points)-2)
+	for i := range vertices {
+		vertices[i] = vertex{index: i, prev: i - 1, next: i + 1}
+		if i > 0 && i < len(points)-1 {
+			vertices[i].area = triangleArea(g, points[i-1], points[i], points[i+1])
+			vertices[i].position = len(h)
+			h = append(h, &vertices[i])
+		}
+	}
+	heap.Init(&h)
+
+	removed := make([]bool, len(points))
+	for h.Len() > 0 {
+		v := heap.Pop(&h).(*vertex)
+		if v.area >= tolerance {
+			break
+		}
+		removed[v.index] = true
+		vertices[v.prev].next = v.next
+		vertices[v.next].prev = v.prev
+
+		for _, n := range []*vertex{&vertices[v.prev], &vertices[v.next]} {
+			if n.prev < 0 || n.next >= len(points) {
+				continue
+			}
+			n.area = math.Max(triangleArea(g, points[n.prev], points[n.index], points[n.next]), v.area)
+			heap.Fix(&h, n.position)
+		}
+	}
+
+	var simplified []geodesy.Point
+	for i, p := range points {
+		if !removed[i] {
+			simplified = append(simplified, p)
+		}
+	}
+
+	return simplified
+}
+
+// triangleArea returns the unsigned area of the geodesic triangle abc, in square meters
+func triangleArea(g *geodesic.Geodesic, a, b, c geodesy.Point) float64 {
+	area, _ := polygon.Measure(g, []geodesy.Point{a, b, c})
+	return math.Abs(area)
+}
+
+// vertex represents a point of a polyline being simplified, along with its effective area
+type vertex struct {
+	index, prev, next int
+	area              float64
+	// position holds the index of the vertex within the heap
+	position int
+}
+
+// vertexHeap implements heap.Interface over vertices, ordered by their effective area
+type vertexHeap []*vertex
+
+func (h vertexHeap) Len() int {
+	return len(h)
+}
+
+func (h vertexHeap) Less(i, j int) bool {
+	return h[i].area < h[j].area
+}
+
+func (h vertexHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].position = i
+	h[j].position = j
+}
+
+func (h *vertexHeap) Push(x interface{}) {
+	v := x.(*vertex)
+	v.position = len(*h)
+	*h = append(*h, v)
+}
+
+func (h *vertexHeap) Pop() interface{} {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	v.position = -1
+
+	return v
+}
@@ -0,0 +1,63 @@
+package simplify_test
+
+import (
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/geodesic"
+	"github.com/lggomez/go-geodesy/simplify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisvalingam(t *testing.T) {
+	starts := []struct {
+		name string
+		p    geodesy.Point
+		azi  float64
+	}{
+		{name: "equator", p: geodesy.Point{0, 10}, azi: 90},
+		{name: "high_latitude", p: geodesy.Point{80, 10}, azi: 45},
+		{name: "antimeridian", p: geodesy.Point{-30, 179.9}, azi: 100},
+	}
+	for _, start := range starts {
+		t.Run("OK/"+start.name, func(t *testing.T) {
+			// Each displaced point forms a triangle of at least 1000 m × 75 m / 2 = 37500 m² with its neighbours
+			points := zigzag(start.p, start.azi, 500, 50, 101)
+
+			assert.Len(t, simplify.Visvalingam(geodesic.WGS84, points, 30_000), 101)
+			simplified := simplify.Visvalingam(geodesic.WGS84, points, 1e12)
+			assert.Equal(t, []geodesy.Point{points[0], points[100]}, simplified)
+		})
+	}
+
+	t.Run("OK/significance", func(t *testing.T) {
+		// A single large bump survives the removal of the small ones around it
+		line := geodesic.WGS84.Line(geodesy.Point{45, 5}, 60)
+		points := make([]geodesy.Point, 5)
+		for i := range points {
+			s := line.Position(5000 * float64(i))
+			offset := 1.0
+			if i == 2 {
+				offset = 2000
+			}
+			points[i] = geodesic.WGS84.Direct(s.P2, s.Azimuth2+90, offset).P2
+		}
+		points[0], points[4] = line.Position(0).P2, line.Position(20_000).P2
+
+		// The small bumps have areas of about 10000 m × 1000 m / 2 = 5e6 m², which are the first to be
+		// removed, and then the large one has an area of about 20000 m × 2000 m / 2 = 2e7 m²
+		simplified := simplify.Visvalingam(geodesic.WGS84, points, 1.5e7)
+		assert.Equal(t, []geodesy.Point{points[0], points[2], points[4]}, simplified)
+		assert.Len(t, simplify.Visvalingam(geodesic.WGS84, points, 2.1e7), 2)
+		assert.Len(t, simplify.Visvalingam(geodesic.WGS84, points, 4e6), 5)
+	})
+
+	t.Run("OK/short", func(t *testing.T) {
+		points := []geodesy.Point{{0, 0}, {1, 1}}
+		assert.Equal(t, points, simplify.Visvalingam(geodesic.WGS84, points, 100))
+	})
+
+	t.Run("FAIL/invalid_point", func(t *testing.T) {
+		assert.Nil(t, simplify.Visvalingam(geodesic.WGS84, []geodesy.Point{{0, 0}, {0, 181}, {1, 1}}, 100))
+	})
+}
@@ -0,0 +1,235 @@
+// Package gpx reads and writes GPS Exchange Format (GPX 1.1) documents as streams of track fixes
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+)
+
+// ErrInvalid is returned when a document is not valid GPX, or it holds invalid coordinates or values
+var ErrInvalid = errors.New("gpx: invalid document")
+
+// Reader reads the fixes of a GPX document, which are its waypoints, route points and track points in
+// document order
+type Reader struct {
+	d *xml.Decoder
+}
+
+// NewReader returns a Reader reading a GPX document from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{d: xml.NewDecoder(r)}
+}
+
+// Read returns the next fix of the document, or io.EOF when there are no more fixes.
+//
+// Besides the elements of GPX 1.1, the speed and course of points are read from the speed and course
+// elements of GPX 1.0 and of the Garmin TrackPointExtension, found among the extensions of points
+func (r *Reader) Read() (track.Fix, error) {
+	for {
+		tok, err := r.d.Token()
+		if err == io.EOF {
+			return track.Fix{}, io.EOF
+		}
+		if err != nil {
+			return track.Fix{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "wpt", "rtept", "trkpt":
+				return r.point(start)
+			}
+		}
+	}
+}
+
+func (r *Reader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalid, fmt.Sprintf(format, args...), r.d.InputOffset())
+}
+
+// point reads the point element started by start, along with its children
+func (r *Reader) point(start xml.StartElement) (track.Fix, error) {
+	var lat, lon string
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "lat":
+			lat = attr.Value
+		case "lon":
+			lon = attr.Value
+		}
+	}
+	φ, errLat := strconv.ParseFloat(lat, 64)
+	λ, errLon := strconv.ParseFloat(lon, 64)
+	p := geodesy.Point{φ, λ}
+	if errLat != nil || errLon != nil || !p.Valid() {
+		return track.Fix{}, r.errorf("%s coordinates lat=%q lon=%q", start.Name.Local, lat, lon)
+	}
+	f := track.NewFix(p)
+
+	for depth := 1; depth > 0; {
+		tok, err := r.d.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return track.Fix{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			depth--
+		case xml.StartElement:
+			if !isValue(tok.Name.Local) {
+				// Other elements are walked through, as the extensions holding speeds and courses
+				depth++
+				continue
+			}
+			if err := r.value(&f, tok); err != nil {
+				return track.Fix{}, err
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// isValue returns whether the element name holds a value of a fix
+func isValue(name string) bool {
+	switch name {
+	case "ele", "time", "name", "sat", "hdop", "speed", "course":
+		return true
+	}
+	return false
+}
+
+// value reads the value element started by start into f
+func (r *Reader) value(f *track.Fix, start xml.StartElement) error {
+	name := start.Name.Local
+	var text string
+	if err := r.d.DecodeElement(&text, &start); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	text = strings.TrimSpace(text)
+
+	var err error
+	switch name {
+	case "name":
+		f.Name = text
+	case "time":
+		if f.Time, err = time.Parse(time.RFC3339Nano, text); err == nil {
+			f.Time = f.Time.UTC()
+		}
+	case "sat":
+		f.Satellites, err = strconv.Atoi(text)
+	default:
+		var v float64
+		if v, err = strconv.ParseFloat(text, 64); err != nil {
+			break
+		}
+		switch name {
+		case "ele":
+			f.Point[2] = v
+		case "hdop":
+			f.HDOP = v
+		case "speed":
+			f.Speed = v
+		case "course":
+			f.Course = v
+		}
+	}
+	if err != nil {
+		return r.errorf("invalid %s %q", name, text)
+	}
+
+	return nil
+}
+
+// Writer writes fixes as the points of a single track segment of a GPX document
+type Writer struct {
+	w       io.Writer
+	started bool
+	err     error
+}
+
+// NewWriter returns a Writer writing a GPX document to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+const header = xml.Header + `<gpx version="1.1" creator="go-geodesy" xmlns="http://www.topografix.com/GPX/1/1"` +
+	` xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2">` + "\n<trk><trkseg>\n"
+
+// Write writes f as a track point. Its speed and course are written in a Garmin TrackPointExtension,
+// as GPX 1.1 has no elements for them. If the position of f is invalid, it returns an error wrapping
+// ErrInvalid
+func (w *Writer) Write(f track.Fix) error {
+	if !f.Point.Valid() {
+		return fmt.Errorf("%w: invalid point %v", ErrInvalid, f.Point)
+	}
+	if !w.started {
+		w.write(header)
+		w.started = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<trkpt lat="%s" lon="%s">`, format(f.Point.Lat()), format(f.Point.Lon()))
+	if f.Point.HasHeight() {
+		b.WriteString("<ele>" + format(f.Point.Height()) + "</ele>")
+	}
+	if !f.Time.IsZero() {
+		b.WriteString("<time>" + f.Time.UTC().Format(time.RFC3339Nano) + "</time>")
+	}
+	if f.Name != "" {
+		b.WriteString("<name>")
+		_ = xml.EscapeText(&b, []byte(f.Name))
+		b.WriteString("</name>")
+	}
+	if f.Satellites > 0 {
+		b.WriteString("<sat>" + strconv.Itoa(f.Satellites) + "</sat>")
+	}
+	if !math.IsNaN(f.HDOP) {
+		b.WriteString("<hdop>" + format(f.HDOP) + "</hdop>")
+	}
+	if !math.IsNaN(f.Speed) || !math.IsNaN(f.Course) {
+		b.WriteString("<extensions><gpxtpx:TrackPointExtension>")
+		if !math.IsNaN(f.Speed) {
+			b.WriteString("<gpxtpx:speed>" + format(f.Speed) + "</gpxtpx:speed>")
+		}
+		if !math.IsNaN(f.Course) {
+			b.WriteString("<gpxtpx:course>" + format(f.Course) + "</gpxtpx:course>")
+		}
+		b.WriteString("</gpxtpx:TrackPointExtension></extensions>")
+	}
+	b.WriteString("</trkpt>\n")
+	w.write(b.String())
+
+	return w.err
+}
+
+// Close completes the document, and returns the first error found writing it
+func (w *Writer) Close() error {
+	if !w.started {
+		w.write(header)
+		w.started = true
+	}
+	w.write("</trkseg></trk>\n</gpx>\n")
+
+	return w.err
+}
+
+func (w *Writer) write(s string) {
+	if w.err == nil {
+		_, w.err = io.WriteString(w.w, s)
+	}
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
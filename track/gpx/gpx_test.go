@@ -0,0 +1,135 @@
+package gpx_test
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+	"github.com/lggomez/go-geodesy/track/gpx"
+	"github.com/stretchr/testify/assert"
+)
+
+const document = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1"
+	xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2">
+	<metadata><name>Morning run</name></metadata>
+	<wpt lat="-34.6037" lon="-58.3816"><name>Obelisco &amp; co</name></wpt>
+	<trk>
+		<name>Track</name>
+		<trkseg>
+			<trkpt lat="-34.6040" lon="-58.3820">
+				<ele>25.5</ele>
+				<time>2021-05-01T10:00:00Z</time>
+				<sat>9</sat>
+				<hdop>0.8</hdop>
+				<extensions>
+					<gpxtpx:TrackPointExtension>
+						<gpxtpx:speed>3.2</gpxtpx:speed>
+						<gpxtpx:course>45</gpxtpx:course>
+					</gpxtpx:TrackPointExtension>
+				</extensions>
+			</trkpt>
+			<trkpt lat="-34.6045" lon="-58.3825"><time>2021-05-01T07:00:05.5-03:00</time></trkpt>
+		</trkseg>
+	</trk>
+</gpx>`
+
+func TestReader(t *testing.T) {
+	t.Run("OK/document", func(t *testing.T) {
+		fixes, err := track.ReadAll(gpx.NewReader(strings.NewReader(document)))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 3)
+
+		assert.Equal(t, geodesy.Point{-34.6037, -58.3816}, fixes[0].Point.Point())
+		assert.Equal(t, "Obelisco & co", fixes[0].Name)
+		assert.True(t, fixes[0].Time.IsZero())
+
+		f := fixes[1]
+		assert.Equal(t, geodesy.Point3D{-34.6040, -58.3820, 25.5}, f.Point)
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), f.Time)
+		assert.Equal(t, 9, f.Satellites)
+		assert.Equal(t, 0.8, f.HDOP)
+		assert.Equal(t, 3.2, f.Speed)
+		assert.Equal(t, 45.0, f.Course)
+		assert.Empty(t, f.Name)
+
+		f = fixes[2]
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 5, 5e8, time.UTC), f.Time)
+		assert.False(t, f.Point.HasHeight())
+		assert.True(t, math.IsNaN(f.Speed))
+	})
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/coordinates", input: `<gpx><wpt lat="95" lon="0"/></gpx>`, message: `gpx: invalid document: wpt coordinates lat="95" lon="0" at offset 28`},
+		{name: "FAIL/missing_coordinates", input: `<gpx><trkpt lat="1"/></gpx>`, message: `gpx: invalid document: trkpt coordinates lat="1" lon="" at offset 21`},
+		{name: "FAIL/value", input: `<gpx><trkpt lat="1" lon="2"><ele>high</ele></trkpt></gpx>`, message: `gpx: invalid document: invalid ele "high" at offset 43`},
+		{name: "FAIL/time", input: `<gpx><trkpt lat="1" lon="2"><time>today</time></trkpt></gpx>`, message: `gpx: invalid document: invalid time "today" at offset 46`},
+		{name: "FAIL/syntax", input: `<gpx><trkpt lat="1" lon="2">`, message: "gpx: invalid document: XML syntax error on line 1: unexpected EOF"},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gpx.NewReader(strings.NewReader(tt.input)).Read()
+			assert.ErrorIs(t, err, gpx.ErrInvalid)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+
+	t.Run("OK/empty", func(t *testing.T) {
+		_, err := gpx.NewReader(strings.NewReader(`<gpx/>`)).Read()
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("OK/round_trip", func(t *testing.T) {
+		f := track.NewFix(geodesy.Point{-34.6040, -58.3820})
+		f.Point[2] = 25.5
+		f.Time = time.Date(2021, 5, 1, 10, 0, 0, 250e6, time.UTC)
+		f.Speed = 3.2
+		f.Course = 45
+		f.HDOP = 0.8
+		f.Satellites = 9
+		f.Name = "<start>"
+		fixes := []track.Fix{f, track.NewFix(geodesy.Point{-34.6045, -58.3825})}
+
+		var b bytes.Buffer
+		w := gpx.NewWriter(&b)
+		for _, f := range fixes {
+			assert.NoError(t, w.Write(f))
+		}
+		assert.NoError(t, w.Close())
+		assert.Contains(t, b.String(), `<trkpt lat="-34.604" lon="-58.382"><ele>25.5</ele><time>2021-05-01T10:00:00.25Z</time>`+
+			`<name>&lt;start&gt;</name><sat>9</sat><hdop>0.8</hdop><extensions><gpxtpx:TrackPointExtension>`+
+			`<gpxtpx:speed>3.2</gpxtpx:speed><gpxtpx:course>45</gpxtpx:course></gpxtpx:TrackPointExtension></extensions></trkpt>`)
+
+		got, err := track.ReadAll(gpx.NewReader(&b))
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, fixes[0], got[0])
+		assert.Equal(t, fixes[1].Point.Point(), got[1].Point.Point())
+	})
+
+	t.Run("OK/empty", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, gpx.NewWriter(&b).Close())
+		fixes, err := track.ReadAll(gpx.NewReader(&b))
+		assert.NoError(t, err)
+		assert.Empty(t, fixes)
+	})
+
+	t.Run("FAIL/invalid", func(t *testing.T) {
+		var b bytes.Buffer
+		err := gpx.NewWriter(&b).Write(track.NewFix(geodesy.Point{0, 181}))
+		assert.ErrorIs(t, err, gpx.ErrInvalid)
+		assert.Zero(t, b.Len())
+	})
+}
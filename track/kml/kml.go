@@ -0,0 +1,354 @@
+// Package kml reads and writes the placemarks of Keyhole Markup Language (KML 2.2) documents, and of
+// their zipped KMZ form, as streams of track fixes
+package kml
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+)
+
+// ErrInvalid is returned when a document is not valid KML or KMZ, or it holds invalid coordinates or
+// values
+var ErrInvalid = errors.New("kml: invalid document")
+
+// Reader reads the fixes of the placemarks of a KML document in document order. Each point yields a fix
+// timestamped by the TimeStamp of its placemark, each vertex of line strings and rings an untimed fix,
+// and each coordinate of tracks (gx:Track) a fix timestamped by its when element. Fixes are named after
+// their placemark
+type Reader struct {
+	d *xml.Decoder
+	// closer closes the document of a KMZ archive, and is nil for plain documents
+	closer io.Closer
+	// stack holds the local names of the elements being read
+	stack   []string
+	name    string
+	when    time.Time
+	pending []track.Fix
+}
+
+// NewReader returns a Reader reading a KML document from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{d: xml.NewDecoder(r)}
+}
+
+// NewKMZReader returns a Reader reading the KML document of the KMZ archive in r, which is its doc.kml
+// file or, if missing, its first file with the .kml extension. The Reader must be closed once done
+func NewKMZReader(r io.ReaderAt, size int64) (*Reader, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var doc *zip.File
+	for _, f := range z.File {
+		if f.Name == "doc.kml" {
+			doc = f
+			break
+		}
+		if doc == nil && strings.EqualFold(path.Ext(f.Name), ".kml") {
+			doc = f
+		}
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("%w: KMZ archive without KML documents", ErrInvalid)
+	}
+	rc, err := doc.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	kr := NewReader(rc)
+	kr.closer = rc
+
+	return kr, nil
+}
+
+// Close closes the document of a KMZ archive read by r. It does nothing for plain KML documents
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	err := r.closer.Close()
+	r.closer = nil
+
+	return err
+}
+
+func (r *Reader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalid, fmt.Sprintf(format, args...), r.d.InputOffset())
+}
+
+func (r *Reader) parent() string {
+	if len(r.stack) == 0 {
+		return ""
+	}
+	return r.stack[len(r.stack)-1]
+}
+
+// Read returns the next fix of the document, or io.EOF when there are no more fixes
+func (r *Reader) Read() (track.Fix, error) {
+	for len(r.pending) == 0 {
+		tok, err := r.d.Token()
+		if err == io.EOF {
+			return track.Fix{}, io.EOF
+		}
+		if err != nil {
+			return track.Fix{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			r.stack = r.stack[:len(r.stack)-1]
+		case xml.StartElement:
+			if err := r.element(tok); err != nil {
+				return track.Fix{}, err
+			}
+		}
+	}
+
+	f := r.pending[0]
+	r.pending = r.pending[1:]
+
+	return f, nil
+}
+
+// element reads the element started by start, either whole when it holds a value or a track, or as
+// a parent of the elements read next
+func (r *Reader) element(start xml.StartElement) error {
+	name := start.Name.Local
+	switch {
+	case name == "Placemark":
+		r.name, r.when = "", time.Time{}
+	case name == "name" && r.parent() == "Placemark":
+		text, err := r.text(start)
+		r.name = text
+		return err
+	case name == "when" && r.parent() == "TimeStamp":
+		text, err := r.text(start)
+		if err != nil {
+			return err
+		}
+		r.when, err = r.time(text)
+		return err
+	case name == "coordinates":
+		return r.coordinates(start)
+	case name == "Track":
+		return r.track(start)
+	}
+	r.stack = append(r.stack, name)
+
+	return nil
+}
+
+func (r *Reader) text(start xml.StartElement) (string, error) {
+	var text string
+	if err := r.d.DecodeElement(&text, &start); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// time parses a KML time, which may be a full dateTime or a date
+func (r *Reader) time(text string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, r.errorf("invalid time %q", text)
+}
+
+// fix returns the fix of the tuple made of longitude, latitude and an optional altitude
+func (r *Reader) fix(values []string) (track.Fix, error) {
+	if len(values) != 2 && len(values) != 3 {
+		return track.Fix{}, r.errorf("coordinate %q", strings.Join(values, ","))
+	}
+	v := make([]float64, len(values))
+	for i, s := range values {
+		var err error
+		if v[i], err = strconv.ParseFloat(s, 64); err != nil {
+			return track.Fix{}, r.errorf("coordinate %q", strings.Join(values, ","))
+		}
+	}
+	p := geodesy.Point{v[1], v[0]}
+	if !p.Valid() {
+		return track.Fix{}, r.errorf("coordinate %q out of range", strings.Join(values, ","))
+	}
+
+	f := track.NewFix(p)
+	if len(v) == 3 {
+		f.Point[2] = v[2]
+	}
+	f.Name = r.name
+
+	return f, nil
+}
+
+// coordinates reads the comma separated tuples of a coordinates element
+func (r *Reader) coordinates(start xml.StartElement) error {
+	point := r.parent() == "Point"
+	text, err := r.text(start)
+	if err != nil {
+		return err
+	}
+	for _, tuple := range strings.Fields(text) {
+		f, err := r.fix(strings.Split(tuple, ","))
+		if err != nil {
+			return err
+		}
+		if point {
+			f.Time = r.when
+		}
+		r.pending = append(r.pending, f)
+	}
+
+	return nil
+}
+
+// track reads a gx:Track element, pairing its when and gx:coord children in order
+func (r *Reader) track(start xml.StartElement) error {
+	var times []time.Time
+	var fixes []track.Fix
+	for depth := 1; depth > 0; {
+		tok, err := r.d.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			depth--
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "when":
+				text, err := r.text(tok)
+				if err != nil {
+					return err
+				}
+				t, err := r.time(text)
+				if err != nil {
+					return err
+				}
+				times = append(times, t)
+			case "coord":
+				text, err := r.text(tok)
+				if err != nil {
+					return err
+				}
+				f, err := r.fix(strings.Fields(text))
+				if err != nil {
+					return err
+				}
+				fixes = append(fixes, f)
+			default:
+				depth++
+			}
+		}
+	}
+	if len(times) != len(fixes) {
+		return r.errorf("track with %d times and %d coordinates", len(times), len(fixes))
+	}
+	for i := range fixes {
+		fixes[i].Time = times[i]
+	}
+	r.pending = append(r.pending, fixes...)
+
+	return nil
+}
+
+// Writer writes fixes as point placemarks of a KML document, timestamped by their times
+type Writer struct {
+	w       io.Writer
+	zip     *zip.Writer
+	started bool
+	err     error
+}
+
+// NewWriter returns a Writer writing a KML document to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// NewKMZWriter returns a Writer writing a KMZ archive to w, holding the document as its doc.kml file
+func NewKMZWriter(w io.Writer) (*Writer, error) {
+	z := zip.NewWriter(w)
+	doc, err := z.Create("doc.kml")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: doc, zip: z}, nil
+}
+
+const header = xml.Header + `<kml xmlns="http://www.opengis.net/kml/2.2">` + "\n<Document>\n"
+
+// Write writes f as a point placemark, with its altitude relative to sea level. The speed, course,
+// dilution of precision and satellite count of f are not written, as KML has no elements for them.
+// If the position of f is invalid, it returns an error wrapping ErrInvalid
+func (w *Writer) Write(f track.Fix) error {
+	if !f.Point.Valid() {
+		return fmt.Errorf("%w: invalid point %v", ErrInvalid, f.Point)
+	}
+	if !w.started {
+		w.write(header)
+		w.started = true
+	}
+
+	var b strings.Builder
+	b.WriteString("<Placemark>")
+	if f.Name != "" {
+		b.WriteString("<name>")
+		_ = xml.EscapeText(&b, []byte(f.Name))
+		b.WriteString("</name>")
+	}
+	if !f.Time.IsZero() {
+		b.WriteString("<TimeStamp><when>" + f.Time.UTC().Format(time.RFC3339Nano) + "</when></TimeStamp>")
+	}
+	b.WriteString("<Point>")
+	coordinates := format(f.Point.Lon()) + "," + format(f.Point.Lat())
+	if f.Point.HasHeight() {
+		b.WriteString("<altitudeMode>absolute</altitudeMode>")
+		coordinates += "," + format(f.Point.Height())
+	}
+	b.WriteString("<coordinates>" + coordinates + "</coordinates></Point></Placemark>\n")
+	w.write(b.String())
+
+	return w.err
+}
+
+// Close completes the document and the KMZ archive holding it, if any, and returns the first error
+// found writing them
+func (w *Writer) Close() error {
+	if !w.started {
+		w.write(header)
+		w.started = true
+	}
+	w.write("</Document>\n</kml>\n")
+	if w.zip != nil && w.err == nil {
+		w.err = w.zip.Close()
+	}
+
+	return w.err
+}
+
+func (w *Writer) write(s string) {
+	if w.err == nil {
+		_, w.err = io.WriteString(w.w, s)
+	}
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
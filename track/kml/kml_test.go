@@ -0,0 +1,154 @@
+package kml_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+	"github.com/lggomez/go-geodesy/track/kml"
+	"github.com/stretchr/testify/assert"
+)
+
+const document = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2" xmlns:gx="http://www.google.com/kml/ext/2.2">
+<Document>
+	<name>Fleet</name>
+	<Folder>
+		<Placemark>
+			<name>Depot</name>
+			<TimeStamp><when>2021-05-01T10:00:00Z</when></TimeStamp>
+			<Point><coordinates>-58.3816,-34.6037,25</coordinates></Point>
+		</Placemark>
+		<Placemark>
+			<name>Route</name>
+			<LineString>
+				<coordinates>
+					-58.3816,-34.6037 -58.3820,-34.6040
+				</coordinates>
+			</LineString>
+		</Placemark>
+		<Placemark>
+			<gx:Track>
+				<when>2021-05-01T10:00:00Z</when>
+				<when>2021-05-01T10:00:05Z</when>
+				<gx:coord>-58.3816 -34.6037 25</gx:coord>
+				<gx:coord>-58.3820 -34.6040 26</gx:coord>
+			</gx:Track>
+		</Placemark>
+	</Folder>
+</Document>
+</kml>`
+
+func TestReader(t *testing.T) {
+	t.Run("OK/document", func(t *testing.T) {
+		fixes, err := track.ReadAll(kml.NewReader(strings.NewReader(document)))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 5)
+
+		assert.Equal(t, geodesy.Point3D{-34.6037, -58.3816, 25}, fixes[0].Point)
+		assert.Equal(t, "Depot", fixes[0].Name)
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), fixes[0].Time)
+
+		assert.Equal(t, geodesy.Point{-34.6040, -58.3820}, fixes[2].Point.Point())
+		assert.Equal(t, "Route", fixes[2].Name)
+		assert.True(t, fixes[2].Time.IsZero())
+		assert.False(t, fixes[2].Point.HasHeight())
+
+		assert.Equal(t, geodesy.Point3D{-34.6040, -58.3820, 26}, fixes[4].Point)
+		assert.Equal(t, time.Date(2021, 5, 1, 10, 0, 5, 0, time.UTC), fixes[4].Time)
+		assert.Empty(t, fixes[4].Name)
+	})
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/coordinates", input: `<kml><Placemark><Point><coordinates>1</coordinates></Point></Placemark></kml>`, message: `kml: invalid document: coordinate "1" at offset 51`},
+		{name: "FAIL/range", input: `<kml><Placemark><Point><coordinates>0,91</coordinates></Point></Placemark></kml>`, message: `kml: invalid document: coordinate "0,91" out of range at offset 54`},
+		{name: "FAIL/time", input: `<kml><Placemark><TimeStamp><when>noon</when></TimeStamp></Placemark></kml>`, message: `kml: invalid document: invalid time "noon" at offset 44`},
+		{name: "FAIL/track", input: `<kml><Track><when>2021-05-01</when></Track></kml>`, message: `kml: invalid document: track with 1 times and 0 coordinates at offset 43`},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := kml.NewReader(strings.NewReader(tt.input)).Read()
+			assert.ErrorIs(t, err, kml.ErrInvalid)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestKMZ(t *testing.T) {
+	t.Run("OK/round_trip", func(t *testing.T) {
+		f := track.NewFix(geodesy.Point{-34.6037, -58.3816})
+		f.Point[2] = 25
+		f.Time = time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+		f.Name = "Depot & yard"
+		fixes := []track.Fix{f, track.NewFix(geodesy.Point{-34.6040, -58.3820})}
+
+		var b bytes.Buffer
+		w, err := kml.NewKMZWriter(&b)
+		assert.NoError(t, err)
+		for _, f := range fixes {
+			assert.NoError(t, w.Write(f))
+		}
+		assert.NoError(t, w.Close())
+
+		r, err := kml.NewKMZReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		assert.NoError(t, err)
+		got, err := track.ReadAll(r)
+		assert.NoError(t, err)
+		assert.NoError(t, r.Close())
+		assert.NoError(t, r.Close())
+		assert.Len(t, got, 2)
+		for i := range fixes {
+			// Unknown values are NaN, which are not equal to themselves
+			assert.True(t, fixes[i].Point.Equals(got[i].Point))
+			assert.Equal(t, fixes[i].Time, got[i].Time)
+			assert.Equal(t, fixes[i].Name, got[i].Name)
+		}
+	})
+
+	t.Run("OK/other_document_name", func(t *testing.T) {
+		var b bytes.Buffer
+		z := zip.NewWriter(&b)
+		doc, err := z.Create("files/track.KML")
+		assert.NoError(t, err)
+		_, err = doc.Write([]byte(document))
+		assert.NoError(t, err)
+		assert.NoError(t, z.Close())
+
+		r, err := kml.NewKMZReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		assert.NoError(t, err)
+		fixes, err := track.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 5)
+		assert.NoError(t, r.Close())
+	})
+
+	t.Run("FAIL/archive", func(t *testing.T) {
+		_, err := kml.NewKMZReader(strings.NewReader(document), int64(len(document)))
+		assert.ErrorIs(t, err, kml.ErrInvalid)
+
+		var b bytes.Buffer
+		assert.NoError(t, zip.NewWriter(&b).Close())
+		_, err = kml.NewKMZReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		assert.EqualError(t, err, "kml: invalid document: KMZ archive without KML documents")
+	})
+}
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := kml.NewWriter(&b)
+	f := track.NewFix(geodesy.Point{-34.6037, -58.3816})
+	f.Point[2] = 25
+	assert.NoError(t, w.Write(f))
+	assert.ErrorIs(t, w.Write(track.NewFix(geodesy.Point{-95, 0})), kml.ErrInvalid)
+	assert.NoError(t, w.Close())
+	assert.Contains(t, b.String(), "<Placemark><Point><altitudeMode>absolute</altitudeMode>"+
+		"<coordinates>-58.3816,-34.6037,25</coordinates></Point></Placemark>\n</Document>\n</kml>\n")
+}
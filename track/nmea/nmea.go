@@ -0,0 +1,452 @@
+// Package nmea reads and writes logs of NMEA 0183 sentences as streams of track fixes
+package nmea
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+)
+
+var (
+	// ErrSyntax is returned when a line is not a valid NMEA 0183 sentence
+	ErrSyntax = errors.New("nmea: invalid sentence")
+	// ErrChecksum is returned when the checksum of a sentence does not match its contents
+	ErrChecksum = errors.New("nmea: checksum mismatch")
+)
+
+const (
+	// knot is the speed of a knot in meters per second
+	knot = 1852.0 / 3600
+	// kmh is the speed of a kilometer per hour in meters per second
+	kmh = 1000.0 / 3600
+)
+
+// Reader reads the fixes of a log of NMEA 0183 sentences, one per line.
+//
+// The GGA, RMC, GSA and VTG sentences of any talker are merged into a fix per epoch, which ends when a
+// sentence reports another UTC time or repeats a GGA or RMC sentence of the epoch. Multi-constellation
+// receivers send several GSA sentences per epoch, whose satellites are merged. Epochs without a
+// valid position, as the ones whose GGA fix quality is 0 or whose RMC status is void, are skipped. The
+// date of fixes is given by the latest RMC sentence, advanced when times wrap around midnight, and fixes
+// read before any RMC sentence are dated January 1 of year 1.
+//
+// Checksums are verified when present. Other sentences, blank lines and proprietary sentences are
+// ignored
+type Reader struct {
+	s    *bufio.Scanner
+	line int
+
+	// date holds the midnight of the current day, and clock the time of the current epoch since then
+	date     time.Time
+	clock    time.Duration
+	hasClock bool
+
+	fix   track.Fix
+	valid bool
+	seen  map[string]bool
+	// satellites holds the satellites used by the epoch as reported by its GSA sentences, identified by
+	// their system and PRN
+	satellites map[string]bool
+	// ready holds the fixes of the epochs ended, until they are returned
+	ready []track.Fix
+}
+
+// NewReader returns a Reader reading a log of sentences from r
+func NewReader(r io.Reader) *Reader {
+	rd := &Reader{s: bufio.NewScanner(r)}
+	rd.reset()
+
+	return rd
+}
+
+func (r *Reader) reset() {
+	r.fix = track.NewFix(geodesy.Point{})
+	r.valid, r.hasClock = false, false
+	r.seen = map[string]bool{}
+	r.satellites = map[string]bool{}
+}
+
+// flush ends the current epoch, keeping its fix if it has a valid position
+func (r *Reader) flush() {
+	if r.valid {
+		f := r.fix
+		if f.Satellites == 0 {
+			f.Satellites = len(r.satellites)
+		}
+		if r.hasClock {
+			f.Time = r.date.Add(r.clock)
+		}
+		r.ready = append(r.ready, f)
+	}
+	r.reset()
+}
+
+// Read returns the next fix of the log, or io.EOF when there are no more fixes. After an error wrapping
+// ErrSyntax or ErrChecksum, Read may be called again to skip the offending sentence
+func (r *Reader) Read() (track.Fix, error) {
+	for len(r.ready) == 0 {
+		if !r.s.Scan() {
+			if err := r.s.Err(); err != nil {
+				return track.Fix{}, err
+			}
+			r.flush()
+			if len(r.ready) == 0 {
+				return track.Fix{}, io.EOF
+			}
+			break
+		}
+		r.line++
+		if err := r.sentence(strings.TrimSpace(r.s.Text())); err != nil {
+			return track.Fix{}, err
+		}
+	}
+
+	f := r.ready[0]
+	r.ready = r.ready[1:]
+
+	return f, nil
+}
+
+func (r *Reader) errorf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at line %d", err, fmt.Sprintf(format, args...), r.line)
+}
+
+// sentence merges the sentence s into the current epoch, ending the previous one if s starts a new one
+func (r *Reader) sentence(s string) error {
+	if s == "" {
+		return nil
+	}
+	if s[0] != '$' {
+		return r.errorf(ErrSyntax, "missing '$' in %q", s)
+	}
+	body := s[1:]
+	if star := strings.LastIndexByte(body, '*'); star >= 0 {
+		want, err := strconv.ParseUint(body[star+1:], 16, 8)
+		if err != nil || len(body)-star-1 != 2 {
+			return r.errorf(ErrSyntax, "invalid checksum in %q", s)
+		}
+		body = body[:star]
+		if got := checksum(body); uint64(got) != want {
+			return r.errorf(ErrChecksum, "%02X instead of %02X in %q", got, want, s)
+		}
+	}
+
+	fields := strings.Split(body, ",")
+	address := fields[0]
+	if len(address) < 5 || address[0] == 'P' {
+		return nil
+	}
+	kind := address[len(address)-3:]
+	var minFields int
+	switch kind {
+	case "GGA":
+		minFields = 15
+	case "RMC":
+		minFields = 12
+	case "GSA":
+		minFields = 18
+	case "VTG":
+		minFields = 9
+	default:
+		return nil
+	}
+	if len(fields) < minFields {
+		return r.errorf(ErrSyntax, "%s with %d fields instead of at least %d in %q", kind, len(fields), minFields, s)
+	}
+
+	// A sentence reporting another time, or repeating a GGA or RMC sentence, starts a new epoch
+	var clock time.Duration
+	hasClock := false
+	if kind == "GGA" || kind == "RMC" {
+		var err error
+		if clock, hasClock, err = r.clockOf(fields[1]); err != nil {
+			return err
+		}
+	}
+	if (kind == "GGA" || kind == "RMC") && r.seen[kind] || hasClock && r.hasClock && clock != r.clock {
+		previous, hadClock := r.clock, r.hasClock
+		r.flush()
+		if hasClock && hadClock && clock < previous && !r.date.IsZero() {
+			r.date = r.date.AddDate(0, 0, 1)
+		}
+	}
+	r.seen[kind] = true
+	if hasClock {
+		r.clock, r.hasClock = clock, true
+	}
+
+	switch kind {
+	case "GGA":
+		return r.gga(fields)
+	case "RMC":
+		return r.rmc(fields)
+	case "GSA":
+		return r.gsa(fields)
+	}
+	return r.vtg(fields)
+}
+
+// checksum returns the exclusive or of the bytes of body
+func checksum(body string) byte {
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+	return sum
+}
+
+// clockOf parses a hhmmss.ss time, which may be empty
+func (r *Reader) clockOf(s string) (time.Duration, bool, error) {
+	if s == "" {
+		return 0, false, nil
+	}
+	if len(s) < 6 {
+		return 0, false, r.errorf(ErrSyntax, "invalid time %q", s)
+	}
+	h, errH := strconv.Atoi(s[:2])
+	m, errM := strconv.Atoi(s[2:4])
+	sec, errS := strconv.ParseFloat(s[4:], 64)
+	if errH != nil || errM != nil || errS != nil || h > 23 || m > 59 || sec < 0 || sec >= 61 {
+		return 0, false, r.errorf(ErrSyntax, "invalid time %q", s)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(math.Round(sec*1e9)), true, nil
+}
+
+// position parses a ddmm.mm latitude or dddmm.mm longitude along with its hemisphere, which may both be
+// empty
+func (r *Reader) position(lat, ns, lon, ew string) (geodesy.Point, bool, error) {
+	if lat == "" && lon == "" {
+		return geodesy.Point{}, false, nil
+	}
+	φ, errLat := parseAngle(lat, ns, "N", "S")
+	λ, errLon := parseAngle(lon, ew, "E", "W")
+	p := geodesy.Point{φ, λ}
+	if errLat != nil || errLon != nil || !p.Valid() {
+		return geodesy.Point{}, false, r.errorf(ErrSyntax, "invalid position %s,%s,%s,%s", lat, ns, lon, ew)
+	}
+
+	return p, true, nil
+}
+
+// parseAngle parses a ddmm.mm or dddmm.mm angle, which is positive in the given hemisphere
+func parseAngle(s, hemisphere, positive, negative string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, ErrSyntax
+	}
+	degrees := math.Floor(v / 100)
+	minutes := v - 100*degrees
+	if minutes >= 60 {
+		return 0, ErrSyntax
+	}
+	v = degrees + minutes/60
+
+	switch hemisphere {
+	case positive:
+		return v, nil
+	case negative:
+		return -v, nil
+	}
+	return 0, ErrSyntax
+}
+
+// number parses an optional number, returning math.NaN() if s is empty
+func (r *Reader) number(s, name string) (float64, error) {
+	if s == "" {
+		return math.NaN(), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN(), r.errorf(ErrSyntax, "invalid %s %q", name, s)
+	}
+	return v, nil
+}
+
+// gga merges a GGA sentence, holding the position, quality, satellites in use, HDOP and altitude
+func (r *Reader) gga(fields []string) error {
+	p, ok, err := r.position(fields[2], fields[3], fields[4], fields[5])
+	if err != nil {
+		return err
+	}
+	if ok && fields[6] != "" && fields[6] != "0" {
+		r.fix.Point[0], r.fix.Point[1] = p.Lat(), p.Lon()
+		r.valid = true
+	}
+	if fields[7] != "" {
+		if r.fix.Satellites, err = strconv.Atoi(fields[7]); err != nil {
+			return r.errorf(ErrSyntax, "invalid satellite count %q", fields[7])
+		}
+	}
+	if hdop, err := r.number(fields[8], "HDOP"); err != nil {
+		return err
+	} else if !math.IsNaN(hdop) {
+		r.fix.HDOP = hdop
+	}
+	alt, err := r.number(fields[9], "altitude")
+	if err != nil {
+		return err
+	}
+	if !math.IsNaN(alt) {
+		r.fix.Point[2] = alt
+	}
+
+	return nil
+}
+
+// rmc merges an RMC sentence, holding the status, position, speed, course and date
+func (r *Reader) rmc(fields []string) error {
+	p, ok, err := r.position(fields[3], fields[4], fields[5], fields[6])
+	if err != nil {
+		return err
+	}
+	if ok && fields[2] == "A" {
+		r.fix.Point[0], r.fix.Point[1] = p.Lat(), p.Lon()
+		r.valid = true
+	}
+	if err := r.motion(fields[7], knot, fields[8]); err != nil {
+		return err
+	}
+	if date := fields[9]; date != "" {
+		d, err := time.Parse("020106", date)
+		if err != nil {
+			return r.errorf(ErrSyntax, "invalid date %q", date)
+		}
+		r.date = d
+	}
+
+	return nil
+}
+
+// motion merges a speed given in unit meters per second and a course, which may be empty
+func (r *Reader) motion(speed string, unit float64, course string) error {
+	v, err := r.number(speed, "speed")
+	if err != nil {
+		return err
+	}
+	if !math.IsNaN(v) {
+		r.fix.Speed = v * unit
+	}
+	c, err := r.number(course, "course")
+	if err != nil {
+		return err
+	}
+	if !math.IsNaN(c) {
+		r.fix.Course = c
+	}
+
+	return nil
+}
+
+// gsa merges a GSA sentence, holding the satellites used and the dilutions of precision. The satellite
+// count and HDOP of GGA sentences take precedence, as they refer to the fix itself. Satellites are
+// identified by the system ID of NMEA 0183 4.10, if any, as PRNs are numbered per system
+func (r *Reader) gsa(fields []string) error {
+	system := ""
+	if len(fields) > 18 {
+		system = fields[18]
+	}
+	for _, prn := range fields[3:15] {
+		if prn != "" {
+			r.satellites[system+":"+prn] = true
+		}
+	}
+	hdop, err := r.number(fields[16], "HDOP")
+	if err != nil {
+		return err
+	}
+	if !math.IsNaN(hdop) && math.IsNaN(r.fix.HDOP) {
+		r.fix.HDOP = hdop
+	}
+
+	return nil
+}
+
+// vtg merges a VTG sentence, holding the course and the speed in knots and kilometers per hour
+func (r *Reader) vtg(fields []string) error {
+	if fields[7] != "" {
+		return r.motion(fields[7], kmh, fields[1])
+	}
+	return r.motion(fields[5], knot, fields[1])
+}
+
+// Writer writes fixes as pairs of GGA and RMC sentences of the GP talker
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+// NewWriter returns a Writer writing sentences to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes f as a GGA sentence, holding its altitude, HDOP and satellite count, followed by an RMC
+// sentence, holding its speed, course and date. Times are rounded to hundredths of a second, positions
+// to hundred-thousandths of a minute, and dilutions of precision, altitudes, speeds and courses to a
+// tenth. If the position of f is invalid, it returns an error wrapping ErrSyntax
+func (w *Writer) Write(f track.Fix) error {
+	if !f.Point.Valid() {
+		return fmt.Errorf("%w: invalid point %v", ErrSyntax, f.Point)
+	}
+
+	var clock, date string
+	if !f.Time.IsZero() {
+		t := f.Time.UTC().Round(10 * time.Millisecond)
+		clock = fmt.Sprintf("%02d%02d%02d.%02d", t.Hour(), t.Minute(), t.Second(), t.Nanosecond()/1e7)
+		date = t.Format("020106")
+	}
+	lat := angle(f.Point.Lat(), 2, "N", "S")
+	lon := angle(f.Point.Lon(), 3, "E", "W")
+
+	satellites := ""
+	if f.Satellites > 0 {
+		satellites = fmt.Sprintf("%02d", f.Satellites)
+	}
+	w.write("GPGGA," + clock + "," + lat + "," + lon + ",1," + satellites + "," + optional(f.HDOP) + "," +
+		optional(f.Point.Height()) + ",M,,M,,")
+	w.write("GPRMC," + clock + ",A," + lat + "," + lon + "," + optional(f.Speed/knot) + "," + optional(f.Course) + "," +
+		date + ",,,A")
+
+	return w.err
+}
+
+// Close returns the first error found writing sentences
+func (w *Writer) Close() error {
+	return w.err
+}
+
+// write writes the sentence with the given body, along with its checksum
+func (w *Writer) write(body string) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, "$%s*%02X\r\n", body, checksum(body))
+	}
+}
+
+// angle formats v as degrees of the given width and minutes, followed by its hemisphere
+func angle(v float64, width int, positive, negative string) string {
+	hemisphere := positive
+	if v < 0 {
+		hemisphere = negative
+	}
+	units := int64(math.Round(math.Abs(v) * 60 * 1e5))
+	degrees, minutes := units/(60*1e5), units%(60*1e5)
+
+	return fmt.Sprintf("%0*d%02d.%05d,%s", width, degrees, minutes/1e5, minutes%1e5, hemisphere)
+}
+
+// optional formats v with a decimal, or as an empty field if it is math.NaN()
+func optional(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
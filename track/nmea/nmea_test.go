@@ -0,0 +1,197 @@
+package nmea_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/track"
+	"github.com/lggomez/go-geodesy/track/nmea"
+	"github.com/stretchr/testify/assert"
+)
+
+const log = `$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39
+$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47
+$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A
+$PGRME,15.0,M,45.0,M,25.0,M*1C
+
+$GPGGA,123520,4807.040,N,01131.010,E,1,09,1.0,546.0,M,46.9,M,,*4D
+$GPGGA,123521,,,,,0,00,,,M,,M,,*60
+$GNRMC,235959.50,A,4807.038,S,01131.000,W,0.0,,311299,,,A*66
+$GNGGA,000000.50,4807.038,S,01131.000,W,2,10,0.8,10.0,M,,M,,*5F
+`
+
+func TestReader(t *testing.T) {
+	t.Run("OK/log", func(t *testing.T) {
+		fixes, err := track.ReadAll(nmea.NewReader(strings.NewReader(log)))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 4)
+
+		f := fixes[0]
+		assert.InDelta(t, 48.1173, f.Point.Lat(), 1e-12)
+		assert.InDelta(t, 11.516666666666667, f.Point.Lon(), 1e-12)
+		assert.Equal(t, 545.4, f.Point.Height())
+		assert.Equal(t, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC), f.Time)
+		assert.InDelta(t, 22.4*1852/3600, f.Speed, 1e-12)
+		assert.Equal(t, 84.4, f.Course)
+		assert.Equal(t, 0.9, f.HDOP)
+		assert.Equal(t, 8, f.Satellites)
+
+		f = fixes[1]
+		assert.Equal(t, time.Date(1994, 3, 23, 12, 35, 20, 0, time.UTC), f.Time)
+		assert.Equal(t, 9, f.Satellites)
+		assert.True(t, math.IsNaN(f.Speed))
+
+		// The epoch without a fix is skipped, and the date advances past midnight
+		f = fixes[2]
+		assert.InDelta(t, -48.1173, f.Point.Lat(), 1e-12)
+		assert.InDelta(t, -11.516666666666667, f.Point.Lon(), 1e-12)
+		assert.Equal(t, time.Date(1999, 12, 31, 23, 59, 59, 5e8, time.UTC), f.Time)
+		assert.False(t, f.Point.HasHeight())
+		assert.Zero(t, f.Speed)
+		assert.True(t, math.IsNaN(f.Course))
+
+		f = fixes[3]
+		assert.Equal(t, time.Date(2000, 1, 1, 0, 0, 0, 5e8, time.UTC), f.Time)
+		assert.Equal(t, 10.0, f.Point.Height())
+		assert.Equal(t, 10, f.Satellites)
+	})
+
+	t.Run("OK/vtg", func(t *testing.T) {
+		r := nmea.NewReader(strings.NewReader("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,\n" +
+			"$GPVTG,054.7,T,034.4,M,005.5,N,010.2,K*48\n"))
+		f, err := r.Read()
+		assert.NoError(t, err)
+		assert.InDelta(t, 10.2/3.6, f.Speed, 1e-12)
+		assert.Equal(t, 54.7, f.Course)
+		assert.True(t, f.Time.Equal(time.Date(1, 1, 1, 12, 35, 19, 0, time.UTC)))
+
+		_, err = r.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("OK/gsa_hdop", func(t *testing.T) {
+		// The HDOP of GGA sentences takes precedence over the one of GSA sentences, in either order
+		fixes, err := track.ReadAll(nmea.NewReader(strings.NewReader(
+			"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,\n" +
+				"$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1\n" +
+				"$GPGGA,123520,4807.038,N,01131.000,E,1,08,,545.4,M,46.9,M,,\n" +
+				"$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.4,2.1\n")))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 2)
+		assert.Equal(t, 0.9, fixes[0].HDOP)
+		assert.Equal(t, 1.4, fixes[1].HDOP)
+	})
+
+	t.Run("OK/multi_gsa", func(t *testing.T) {
+		// Multi-constellation receivers send a GSA sentence per system, whose PRNs may coincide
+		fixes, err := track.ReadAll(nmea.NewReader(strings.NewReader(
+			"$GNGGA,123519,4807.038,N,01131.000,E,1,,0.9,545.4,M,46.9,M,,\n" +
+				"$GNGSA,A,3,04,05,09,,,,,,,,,,2.5,1.3,2.1,1\n" +
+				"$GNGSA,A,3,04,11,,,,,,,,,,,2.5,1.3,2.1,3\n" +
+				"$GNRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W\n" +
+				"$GNGGA,123520,4807.040,N,01131.010,E,1,09,1.0,546.0,M,46.9,M,,\n" +
+				"$GNGSA,A,3,04,05,09,,,,,,,,,,2.5,1.3,2.1,1\n" +
+				"$GNGSA,A,3,04,11,,,,,,,,,,,2.5,1.3,2.1,3\n")))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 2)
+
+		f := fixes[0]
+		assert.Equal(t, time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC), f.Time)
+		assert.Equal(t, 545.4, f.Point.Height())
+		assert.InDelta(t, 22.4*1852/3600, f.Speed, 1e-12)
+		assert.Equal(t, 5, f.Satellites)
+		assert.Equal(t, 0.9, f.HDOP)
+
+		assert.Equal(t, time.Date(1994, 3, 23, 12, 35, 20, 0, time.UTC), fixes[1].Time)
+		assert.Equal(t, 9, fixes[1].Satellites)
+	})
+
+	t.Run("OK/untimed", func(t *testing.T) {
+		// Repeated sentence types delimit the epochs
+		fixes, err := track.ReadAll(nmea.NewReader(strings.NewReader(
+			"$GPGGA,,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,\n$GPGGA,,4807.040,N,01131.010,E,1,09,1.0,546.0,M,46.9,M,,\n")))
+		assert.NoError(t, err)
+		assert.Len(t, fixes, 2)
+		assert.True(t, fixes[0].Time.IsZero())
+	})
+
+	t.Run("FAIL/checksum", func(t *testing.T) {
+		r := nmea.NewReader(strings.NewReader("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48\n" +
+			"$GPGGA,123520,4807.040,N,01131.010,E,1,09,1.0,546.0,M,46.9,M,,*4D\n"))
+		_, err := r.Read()
+		assert.ErrorIs(t, err, nmea.ErrChecksum)
+		assert.EqualError(t, err, `nmea: checksum mismatch: 47 instead of 48 in "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48" at line 1`)
+
+		// The offending sentence is skipped
+		f, err := r.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, 9, f.Satellites)
+	})
+
+	failures := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "FAIL/prefix", input: "GPGGA,123519", message: `nmea: invalid sentence: missing '$' in "GPGGA,123519" at line 1`},
+		{name: "FAIL/checksum_syntax", input: "$GPGGA,123519*4", message: `nmea: invalid sentence: invalid checksum in "$GPGGA,123519*4" at line 1`},
+		{name: "FAIL/fields", input: "$GPGGA,123519", message: `nmea: invalid sentence: GGA with 2 fields instead of at least 15 in "$GPGGA,123519" at line 1`},
+		{name: "FAIL/time", input: "$GPGGA,1235,,,,,0,,,,M,,M,,", message: `nmea: invalid sentence: invalid time "1235" at line 1`},
+		{name: "FAIL/position", input: "$GPGGA,123519,4867.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,", message: "nmea: invalid sentence: invalid position 4867.038,N,01131.000,E at line 1"},
+		{name: "FAIL/hemisphere", input: "$GPRMC,123519,A,4807.038,E,01131.000,E,022.4,084.4,230394,003.1,W", message: "nmea: invalid sentence: invalid position 4807.038,E,01131.000,E at line 1"},
+		{name: "FAIL/date", input: "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,320394,003.1,W", message: `nmea: invalid sentence: invalid date "320394" at line 1`},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := nmea.NewReader(strings.NewReader(tt.input)).Read()
+			assert.ErrorIs(t, err, nmea.ErrSyntax)
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("OK/sentences", func(t *testing.T) {
+		f := track.NewFix(geodesy.Point{48.1173, -11.516666666666667})
+		f.Point[2] = 545.4
+		f.Time = time.Date(1994, 3, 23, 12, 35, 19, 996e6, time.UTC)
+		f.Speed = 22.4 * 1852 / 3600
+		f.Course = 84.4
+		f.HDOP = 0.9
+		f.Satellites = 8
+
+		var b bytes.Buffer
+		w := nmea.NewWriter(&b)
+		assert.NoError(t, w.Write(f))
+		assert.NoError(t, w.Close())
+		assert.Equal(t, "$GPGGA,123520.00,4807.03800,N,01131.00000,W,1,08,0.9,545.4,M,,M,,*64\r\n"+
+			"$GPRMC,123520.00,A,4807.03800,N,01131.00000,W,22.4,84.4,230394,,,A*4A\r\n", b.String())
+
+		got, err := nmea.NewReader(&b).Read()
+		assert.NoError(t, err)
+		assert.InDelta(t, f.Point.Lat(), got.Point.Lat(), 1e-9)
+		assert.InDelta(t, f.Point.Lon(), got.Point.Lon(), 1e-9)
+		assert.Equal(t, f.Time.Round(10*time.Millisecond), got.Time)
+		assert.InDelta(t, f.Speed, got.Speed, 1e-9)
+		assert.Equal(t, f.HDOP, got.HDOP)
+		assert.Equal(t, f.Satellites, got.Satellites)
+	})
+
+	t.Run("OK/unknown_values", func(t *testing.T) {
+		var b bytes.Buffer
+		assert.NoError(t, nmea.NewWriter(&b).Write(track.NewFix(geodesy.Point{-0.5, 179.99999999})))
+		assert.Equal(t, "$GPGGA,,0030.00000,S,18000.00000,E,1,,,,M,,M,,*4B\r\n"+
+			"$GPRMC,,A,0030.00000,S,18000.00000,E,,,,,,A*67\r\n", b.String())
+	})
+
+	t.Run("FAIL/invalid", func(t *testing.T) {
+		err := nmea.NewWriter(ioutil.Discard).Write(track.NewFix(geodesy.Point{91, 0}))
+		assert.ErrorIs(t, err, nmea.ErrSyntax)
+	})
+}
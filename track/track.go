@@ -0,0 +1,87 @@
+// Package track defines the timestamped position fixes read and written by the track formats of its
+// subpackages, such as GPX, KML and NMEA 0183, along with the operations on sequences of fixes
+package track
+
+import (
+	"io"
+	"math"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/geometry"
+)
+
+// Fix represents a position report of a receiver. Unknown heights, speeds, courses and dilutions of
+// precision are math.NaN(), an unknown time is the zero time.Time and an unknown satellite count is 0
+type Fix struct {
+	// Point holds the position of the fix, with its altitude above mean sea level in meters
+	Point geodesy.Point3D
+	// Time is the time of the fix in UTC
+	Time time.Time
+	// Speed is the speed over ground in meters per second
+	Speed float64
+	// Course is the course over ground in degrees clockwise from true north
+	Course float64
+	// HDOP is the horizontal dilution of precision
+	HDOP float64
+	// Satellites is the amount of satellites used for the fix
+	Satellites int
+	// Name is the name of the waypoint or placemark the fix belongs to, if any
+	Name string
+}
+
+// NewFix returns a fix at p, with its altitude and every other measurement unknown
+func NewFix(p geodesy.Point) Fix {
+	return Fix{
+		Point:  geodesy.Point3D{p.Lat(), p.Lon(), math.NaN()},
+		Speed:  math.NaN(),
+		Course: math.NaN(),
+		HDOP:   math.NaN(),
+	}
+}
+
+// Reader is the interface implemented by the readers of track formats. Read returns the next fix,
+// or io.EOF when there are no more fixes
+type Reader interface {
+	Read() (Fix, error)
+}
+
+// Writer is the interface implemented by the writers of track formats. Close completes the written
+// document, and does not close the underlying io.Writer
+type Writer interface {
+	Write(f Fix) error
+	Close() error
+}
+
+// ReadAll reads every fix from r until io.EOF, which is not reported as an error
+func ReadAll(r Reader) ([]Fix, error) {
+	var fixes []Fix
+	for {
+		f, err := r.Read()
+		if err == io.EOF {
+			return fixes, nil
+		}
+		if err != nil {
+			return fixes, err
+		}
+		fixes = append(fixes, f)
+	}
+}
+
+// LineString returns the polyline joining the positions of fixes, in order
+func LineString(fixes []Fix) geometry.LineString {
+	l := make(geometry.LineString, len(fixes))
+	for i, f := range fixes {
+		l[i] = f.Point.Point()
+	}
+
+	return l
+}
+
+// Length returns the length of the track followed by fixes in meters, as the sum of the distances
+// between consecutive positions given by method, such as distance.Haversine or distance.Vincenty, which
+// adapts VincentyInverse. Altitudes are ignored
+func Length(fixes []Fix, method distance.Method) float64 {
+	return LineString(fixes).Length(method)
+}
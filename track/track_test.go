@@ -0,0 +1,74 @@
+package track_test
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/distance"
+	"github.com/lggomez/go-geodesy/track"
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceReader reads the fixes of a slice, and then err
+type sliceReader struct {
+	fixes []track.Fix
+	err   error
+}
+
+func (r *sliceReader) Read() (track.Fix, error) {
+	if len(r.fixes) == 0 {
+		return track.Fix{}, r.err
+	}
+	f := r.fixes[0]
+	r.fixes = r.fixes[1:]
+	return f, nil
+}
+
+func TestNewFix(t *testing.T) {
+	f := track.NewFix(geodesy.Point{10, 20})
+	assert.Equal(t, geodesy.Point{10, 20}, f.Point.Point())
+	assert.False(t, f.Point.HasHeight())
+	assert.True(t, math.IsNaN(f.Speed))
+	assert.True(t, math.IsNaN(f.Course))
+	assert.True(t, math.IsNaN(f.HDOP))
+	assert.Zero(t, f.Satellites)
+	assert.True(t, f.Time.IsZero())
+}
+
+func TestReadAll(t *testing.T) {
+	fixes := []track.Fix{track.NewFix(geodesy.Point{0, 0}), track.NewFix(geodesy.Point{0, 1})}
+
+	t.Run("OK/eof", func(t *testing.T) {
+		got, err := track.ReadAll(&sliceReader{fixes: fixes, err: io.EOF})
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("FAIL/error", func(t *testing.T) {
+		failure := errors.New("failure")
+		got, err := track.ReadAll(&sliceReader{fixes: fixes, err: failure})
+		assert.Equal(t, failure, err)
+		assert.Len(t, got, 2)
+	})
+}
+
+func TestLength(t *testing.T) {
+	fixes := []track.Fix{
+		track.NewFix(geodesy.Point{0, 0}),
+		track.NewFix(geodesy.Point{0, 1}),
+		track.NewFix(geodesy.Point{1, 1}),
+	}
+	want := distance.Haversine(geodesy.Point{0, 0}, geodesy.Point{0, 1}) + distance.Haversine(geodesy.Point{0, 1}, geodesy.Point{1, 1})
+	assert.Equal(t, want, track.Length(fixes, distance.Haversine))
+	assert.Zero(t, track.Length(nil, distance.Haversine))
+
+	want = 0
+	for k := 1; k < len(fixes); k++ {
+		d, _, _ := distance.VincentyInverse(fixes[k-1].Point.Point(), fixes[k].Point.Point(), -1, false)
+		want += d
+	}
+	assert.Equal(t, want, track.Length(fixes, distance.Vincenty))
+}
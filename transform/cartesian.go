@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/internal/geomath"
+)
+
+// Cartesian represents an earth-centered, earth-fixed (ECEF) position, defined in meters (m)
+type Cartesian struct {
+	X, Y, Z float64
+}
+
+// ToCartesian converts the geodetic point p with ellipsoidal height h (in meters)
+// into earth-centered, earth-fixed cartesian coordinates over the ellipsoid e
+func ToCartesian(p geodesy.Point, h float64, e ellipsoids.Ellipsoid) Cartesian {
+	a := e.SemiMajorAxis
+	e2 := e.EccentricitySquared()
+
+	sinφ, cosφ := math.Sincos(p.LatRadians())
+	sinλ, cosλ := math.Sincos(p.LonRadians())
+
+	ν := a / math.Sqrt(1-e2*sinφ*sinφ) // Radius of curvature in the prime vertical
+
+	return Cartesian{
+		X: (ν + h) * cosφ * cosλ,
+		Y: (ν + h) * cosφ * sinλ,
+		Z: (ν*(1-e2) + h) * sinφ,
+	}
+}
+
+// FromCartesian converts the earth-centered, earth-fixed coordinates c into a geodetic
+// point and its ellipsoidal height in meters over the ellipsoid e.
+// It uses the closed form solution by Vermeille (2002), which is exact for any
+// position outside the evolute of the ellipsoid (that is, farther than ~43 km from
+// the center of the earth)
+func FromCartesian(c Cartesian, e ellipsoids.Ellipsoid) (geodesy.Point, float64) {
+	a := e.SemiMajorAxis
+	e2 := e.EccentricitySquared()
+	e4 := e2 * e2
+
+	ρ2 := c.X*c.X + c.Y*c.Y
+	ρ := math.Sqrt(ρ2)
+	λ := math.Atan2(c.Y, c.X)
+
+	if ρ == 0 {
+		// Position on the polar axis
+		b := e.SemiMinorAxis()
+		if c.Z < 0 {
+			return geodesy.Point{-90, 0}, -c.Z - b
+		}
+		return geodesy.Point{90, 0}, c.Z - b
+	}
+
+	p := ρ2 / (a * a)
+	q := (1 - e2) / (a * a) * c.Z * c.Z
+	r := (p + q - e4) / 6
+	s := e4 * p * q / (4 * r * r * r)
+	t := math.Cbrt(1 + s + math.Sqrt(s*(2+s)))
+	u := r * (1 + t + 1/t)
+	v := math.Sqrt(u*u + e4*q)
+	w := e2 * (u + v - q) / (2 * v)
+	k := math.Sqrt(u+v+w*w) - w
+	D := k * ρ / (k + e2)
+	hyp := math.Sqrt(D*D + c.Z*c.Z)
+
+	φ := 2 * math.Atan2(c.Z, D+hyp)
+	h := (k + e2 - 1) / k * hyp
+
+	return geodesy.Point{φ * geomath.RadToDeg, λ * geomath.RadToDeg}, h
+}
@@ -0,0 +1,25 @@
+package transform
+
+import (
+	"math"
+	"time"
+)
+
+// DecimalYear returns the coordinate epoch of t expressed as a decimal year
+// (e.g. 2020-07-02T12:00:00Z is 2020.5), as used by the IERS conventions
+func DecimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	return float64(t.Year()) + float64(t.Sub(start))/float64(end.Sub(start))
+}
+
+// EpochTime returns the UTC instant represented by the decimal year epoch
+func EpochTime(epoch float64) time.Time {
+	year := math.Floor(epoch)
+	start := time.Date(int(year), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	return start.Add(time.Duration((epoch - year) * float64(end.Sub(start))))
+}
@@ -0,0 +1,163 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+)
+
+// Frame identifies a terrestrial reference frame realization
+type Frame string
+
+const (
+	ITRF2020   Frame = "ITRF2020"
+	ITRF2014   Frame = "ITRF2014"
+	ITRF2008   Frame = "ITRF2008"
+	ITRF2005   Frame = "ITRF2005"
+	ITRF2000   Frame = "ITRF2000"
+	ETRF2000   Frame = "ETRF2000"
+	GDA2020    Frame = "GDA2020"
+	NAD83_2011 Frame = "NAD83(2011)"
+)
+
+// ErrNoTransformation is returned when there is no known transformation path between two frames
+var ErrNoTransformation = errors.New("transform: no transformation between frames")
+
+// Published parameter sets between a source and a target frame
+var parameters = []struct {
+	from, to Frame
+	h        Helmert
+}{
+	// ITRF2020 to past ITRFs, see https://itrf.ign.fr/en/solutions/transformations
+	{ITRF2020, ITRF2014, Helmert{
+		T: [3]float64{-1.4, -0.9, 1.4}, D: -0.42,
+		TRate: [3]float64{0, -0.1, 0.2},
+		Epoch: 2015,
+	}},
+	{ITRF2020, ITRF2008, Helmert{
+		T: [3]float64{0.2, 1.0, 3.3}, D: -0.29,
+		TRate: [3]float64{0, -0.1, 0.1}, DRate: 0.03,
+		Epoch: 2015,
+	}},
+	{ITRF2020, ITRF2005, Helmert{
+		T: [3]float64{2.7, 0.1, -1.4}, D: 0.65,
+		TRate: [3]float64{0.3, -0.1, 0.1}, DRate: 0.03,
+		Epoch: 2015,
+	}},
+	{ITRF2020, ITRF2000, Helmert{
+		T: [3]float64{-0.2, 0.8, -34.2}, D: 2.25,
+		TRate: [3]float64{0.1, 0, -1.7}, DRate: 0.11,
+		Epoch: 2015,
+	}},
+	// ITRF2014 to ITRF2008, see https://itrf.ign.fr/en/solutions/transformations
+	{ITRF2014, ITRF2008, Helmert{
+		T: [3]float64{1.6, 1.9, 2.4}, D: -0.02,
+		TRate: [3]float64{0, 0, -0.1}, DRate: 0.03,
+		Epoch: 2010,
+	}},
+	// ITRF2014 to ETRF2000, see EUREF Technical Note 1 (Altamimi, 2018)
+	{ITRF2014, ETRF2000, Helmert{
+		T: [3]float64{54.7, 52.2, -74.1}, D: 2.12,
+		R:     [3]float64{1.701, 10.290, -16.632},
+		TRate: [3]float64{0.1, 0.1, -1.9}, DRate: 0.11,
+		RRate: [3]float64{0.081, 0.490, -0.792},
+		Epoch: 2010,
+	}},
+	// ITRF2014 to GDA2020 (Australian plate motion model), see the GDA2020 Technical Manual.
+	// Published in the coordinate frame convention, so rotation rates are negated
+	{ITRF2014, GDA2020, Helmert{
+		RRate: [3]float64{-1.50379, -1.18346, -1.20716},
+		Epoch: 2020,
+	}},
+	// ITRF2008 to NAD83(2011), see Pearson & Snay (2013) "Introducing HTDP 3.1".
+	// Published in the coordinate frame convention, so rotations and their rates are negated
+	{ITRF2008, NAD83_2011, Helmert{
+		T: [3]float64{993.43, -1_903.31, -526.55}, D: 1.71504,
+		R:     [3]float64{-25.91467, -9.42645, -11.59935},
+		TRate: [3]float64{0.79, -0.60, -1.34}, DRate: -0.10201,
+		RRate: [3]float64{-0.06667, 0.75744, 0.05133},
+		Epoch: 1997,
+	}},
+}
+
+// Lookup returns the transformation from one frame to another, composing the published
+// parameter sets (or their inverses) along the shortest available path between them
+func Lookup(from, to Frame) (Helmert, error) {
+	if from == to {
+		return Helmert{}, nil
+	}
+
+	// Breadth-first search over the frames graph
+	previous := map[Frame]Frame{from: from}
+	steps := map[Frame]Helmert{}
+	queue := []Frame{from}
+	for len(queue) > 0 && !hasFrame(previous, to) {
+		current := queue[0]
+		queue = queue[1:]
+		for _, p := range parameters {
+			var next Frame
+			h := p.h
+			switch current {
+			case p.from:
+				next = p.to
+			case p.to:
+				next, h = p.from, h.Inverse()
+			default:
+				continue
+			}
+			if hasFrame(previous, next) {
+				continue
+			}
+			previous[next] = current
+			steps[next] = h
+			queue = append(queue, next)
+		}
+	}
+
+	if !hasFrame(previous, to) {
+		return Helmert{}, fmt.Errorf("%w: %s to %s", ErrNoTransformation, from, to)
+	}
+
+	// Walk the path back from the target frame, composing each step
+	path := []Helmert{}
+	for f := to; f != from; f = previous[f] {
+		path = append([]Helmert{steps[f]}, path...)
+	}
+	h := path[0]
+	for _, step := range path[1:] {
+		h = h.Then(step)
+	}
+
+	return h, nil
+}
+
+// Transform converts the position c, given at the coordinate epoch (as a decimal year),
+// from one frame to another
+func Transform(c Cartesian, from, to Frame, epoch float64) (Cartesian, error) {
+	h, err := Lookup(from, to)
+	if err != nil {
+		return Cartesian{}, err
+	}
+
+	return h.Apply(c, epoch), nil
+}
+
+// TransformPoint converts the geodetic point p with ellipsoidal height h (in meters), given
+// at the coordinate epoch (as a decimal year), from one frame to another over the GRS-80 ellipsoid
+func TransformPoint(p geodesy.Point, h float64, from, to Frame, epoch float64) (geodesy.Point, float64, error) {
+	c, err := Transform(ToCartesian(p, h, ellipsoids.GRS80), from, to, epoch)
+	if err != nil {
+		return geodesy.Point{}, 0, err
+	}
+
+	p2, h2 := FromCartesian(c, ellipsoids.GRS80)
+
+	return p2, h2, nil
+}
+
+func hasFrame(m map[Frame]Frame, f Frame) bool {
+	_, ok := m[f]
+	return ok
+}
@@ -0,0 +1,104 @@
+package transform
+
+import "math"
+
+const (
+	mmToMeters  = 1e-3
+	ppbToScale  = 1e-9
+	masToRadian = math.Pi / (180 * 3_600 * 1_000)
+)
+
+// Helmert represents a time-dependent 14-parameter similarity transformation between two
+// terrestrial reference frames, expressed in the units and sign convention published by the IERS:
+//
+//	X2 = X1 + T + D·X1 + R·X1
+//
+//	    | 0   -R3   R2 |
+//	R = | R3   0   -R1 |
+//	    | -R2  R1   0  |
+//
+// Each parameter P is evaluated at the coordinate epoch t as P(t) = P + Ṗ·(t − Epoch). Rotations
+// follow the position vector convention; parameters published in the coordinate frame convention
+// must have their rotations (and rotation rates) negated.
+type Helmert struct {
+	// T holds the translations T1, T2 and T3, defined in millimeters (mm)
+	T [3]float64
+	// D is the scale difference, defined in parts per billion (ppb)
+	D float64
+	// R holds the rotations R1, R2 and R3, defined in milliarcseconds (mas)
+	R [3]float64
+
+	// TRate holds the translation rates, defined in millimeters per year (mm/yr)
+	TRate [3]float64
+	// DRate is the scale rate, defined in parts per billion per year (ppb/yr)
+	DRate float64
+	// RRate holds the rotation rates, defined in milliarcseconds per year (mas/yr)
+	RRate [3]float64
+
+	// Epoch is the reference epoch of the parameters, as a decimal year
+	Epoch float64
+}
+
+// At returns the parameters of h propagated to epoch, which becomes
+// the reference epoch of the returned transformation
+func (h Helmert) At(epoch float64) Helmert {
+	Δt := epoch - h.Epoch
+	at := h
+	for i := 0; i < 3; i++ {
+		at.T[i] += h.TRate[i] * Δt
+		at.R[i] += h.RRate[i] * Δt
+	}
+	at.D += h.DRate * Δt
+	at.Epoch = epoch
+
+	return at
+}
+
+// Inverse returns the transformation reverting h. As the rotations and scale are
+// small, the inverse is approximated to first order by negating every parameter
+func (h Helmert) Inverse() Helmert {
+	inv := h
+	for i := 0; i < 3; i++ {
+		inv.T[i] = -h.T[i]
+		inv.R[i] = -h.R[i]
+		inv.TRate[i] = -h.TRate[i]
+		inv.RRate[i] = -h.RRate[i]
+	}
+	inv.D = -h.D
+	inv.DRate = -h.DRate
+
+	return inv
+}
+
+// Then returns the transformation equivalent to applying h followed by next,
+// approximated to first order by adding both parameter sets at h's reference epoch
+func (h Helmert) Then(next Helmert) Helmert {
+	next = next.At(h.Epoch)
+	sum := h
+	for i := 0; i < 3; i++ {
+		sum.T[i] += next.T[i]
+		sum.R[i] += next.R[i]
+		sum.TRate[i] += next.TRate[i]
+		sum.RRate[i] += next.RRate[i]
+	}
+	sum.D += next.D
+	sum.DRate += next.DRate
+
+	return sum
+}
+
+// Apply transforms the position c, given at the coordinate epoch (as a decimal year),
+// from the source to the target frame of h. The coordinate epoch is preserved
+func (h Helmert) Apply(c Cartesian, epoch float64) Cartesian {
+	p := h.At(epoch)
+
+	t1, t2, t3 := p.T[0]*mmToMeters, p.T[1]*mmToMeters, p.T[2]*mmToMeters
+	d := p.D * ppbToScale
+	r1, r2, r3 := p.R[0]*masToRadian, p.R[1]*masToRadian, p.R[2]*masToRadian
+
+	return Cartesian{
+		X: c.X + t1 + d*c.X - r3*c.Y + r2*c.Z,
+		Y: c.Y + t2 + r3*c.X + d*c.Y - r1*c.Z,
+		Z: c.Z + t3 - r2*c.X + r1*c.Y + d*c.Z,
+	}
+}
@@ -0,0 +1,240 @@
+package transform_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lggomez/go-geodesy"
+	"github.com/lggomez/go-geodesy/ellipsoids"
+	"github.com/lggomez/go-geodesy/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartesian(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        geodesy.Point
+		h        float64
+		expected transform.Cartesian
+	}{
+		{
+			name:     "OK/equator_prime_meridian",
+			p:        geodesy.Point{0, 0},
+			h:        0,
+			expected: transform.Cartesian{X: ellipsoids.GRS80_SEMI_MAJOR_AXIS},
+		},
+		{
+			name:     "OK/north_pole",
+			p:        geodesy.Point{90, 0},
+			h:        100,
+			expected: transform.Cartesian{Z: ellipsoids.GRS80.SemiMinorAxis() + 100},
+		},
+		{
+			name:     "OK/south_pole",
+			p:        geodesy.Point{-90, 0},
+			h:        0,
+			expected: transform.Cartesian{Z: -ellipsoids.GRS80.SemiMinorAxis()},
+		},
+		{
+			name:     "OK/equator_east",
+			p:        geodesy.Point{0, 90},
+			h:        -50,
+			expected: transform.Cartesian{Y: ellipsoids.GRS80_SEMI_MAJOR_AXIS - 50},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := transform.ToCartesian(tt.p, tt.h, ellipsoids.GRS80)
+			assert.InDelta(t, tt.expected.X, c.X, 1e-6)
+			assert.InDelta(t, tt.expected.Y, c.Y, 1e-6)
+			assert.InDelta(t, tt.expected.Z, c.Z, 1e-6)
+
+			p, h := transform.FromCartesian(c, ellipsoids.GRS80)
+			assert.InDelta(t, tt.p.Lat(), p.Lat(), 1e-10)
+			assert.InDelta(t, tt.h, h, 1e-6)
+		})
+	}
+}
+
+func TestCartesianRoundtrip(t *testing.T) {
+	for lat := -89.5; lat <= 89.5; lat += 7.25 {
+		for lon := -179.5; lon <= 180; lon += 13.5 {
+			for _, h := range []float64{-400, 0, 8_848, 35_786_000} {
+				p := geodesy.Point{lat, lon}
+				p2, h2 := transform.FromCartesian(transform.ToCartesian(p, h, ellipsoids.WGS84), ellipsoids.WGS84)
+				assert.InDelta(t, lat, p2.Lat(), 1e-11)
+				assert.InDelta(t, lon, p2.Lon(), 1e-11)
+				assert.InDelta(t, h, h2, 1e-6)
+			}
+		}
+	}
+}
+
+func TestDecimalYear(t *testing.T) {
+	assert.EqualValues(t, 2020, transform.DecimalYear(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.EqualValues(t, 2021.5, transform.DecimalYear(time.Date(2021, time.July, 2, 12, 0, 0, 0, time.UTC)))
+
+	ts := time.Date(2024, time.March, 17, 8, 30, 0, 0, time.UTC)
+	assert.WithinDuration(t, ts, transform.EpochTime(transform.DecimalYear(ts)), time.Microsecond)
+}
+
+// published is a parameter set as published by its source, in millimeters, parts per billion and
+// milliarcseconds, applied to first order with the rotation matrix of its own convention
+type published struct {
+	t, tRate        [3]float64
+	d, dRate        float64
+	r, rRate        [3]float64
+	epoch           float64
+	coordinateFrame bool
+}
+
+func (p published) apply(c transform.Cartesian, epoch float64) transform.Cartesian {
+	dt := epoch - p.epoch
+	var t, r [3]float64
+	for i := range t {
+		t[i] = (p.t[i] + p.tRate[i]*dt) * 1e-3
+		r[i] = (p.r[i] + p.rRate[i]*dt) * math.Pi / (180 * 3_600 * 1_000)
+	}
+	d := (p.d + p.dRate*dt) * 1e-9
+
+	x := [3]float64{c.X, c.Y, c.Z}
+	rotation := [3][3]float64{{0, -r[2], r[1]}, {r[2], 0, -r[0]}, {-r[1], r[0], 0}}
+	if p.coordinateFrame {
+		rotation = [3][3]float64{{0, r[2], -r[1]}, {-r[2], 0, r[0]}, {r[1], -r[0], 0}}
+	}
+	var out [3]float64
+	for i := range out {
+		out[i] = x[i] + t[i] + d*x[i]
+		for j := range x {
+			out[i] += rotation[i][j] * x[j]
+		}
+	}
+
+	return transform.Cartesian{X: out[0], Y: out[1], Z: out[2]}
+}
+
+// inverse returns the first order inverse of p
+func (p published) inverse() published {
+	inv := p
+	for i := range p.t {
+		inv.t[i], inv.tRate[i], inv.r[i], inv.rRate[i] = -p.t[i], -p.tRate[i], -p.r[i], -p.rRate[i]
+	}
+	inv.d, inv.dRate = -p.d, -p.dRate
+
+	return inv
+}
+
+var (
+	// IERS, https://itrf.ign.fr/en/solutions/transformations
+	itrf2020ToITRF2014 = published{t: [3]float64{-1.4, -0.9, 1.4}, d: -0.42, tRate: [3]float64{0, -0.1, 0.2}, epoch: 2015}
+	itrf2020ToITRF2008 = published{t: [3]float64{0.2, 1.0, 3.3}, d: -0.29, tRate: [3]float64{0, -0.1, 0.1}, dRate: 0.03, epoch: 2015}
+	itrf2020ToITRF2000 = published{t: [3]float64{-0.2, 0.8, -34.2}, d: 2.25, tRate: [3]float64{0.1, 0, -1.7}, dRate: 0.11, epoch: 2015}
+	// EUREF Technical Note 1 (Altamimi, 2018), in the convention of the IERS
+	itrf2014ToETRF2000 = published{
+		t: [3]float64{54.7, 52.2, -74.1}, d: 2.12, r: [3]float64{1.701, 10.290, -16.632},
+		tRate: [3]float64{0.1, 0.1, -1.9}, dRate: 0.11, rRate: [3]float64{0.081, 0.490, -0.792},
+		epoch: 2010,
+	}
+	// GDA2020 Technical Manual, in the coordinate frame convention
+	itrf2014ToGDA2020 = published{rRate: [3]float64{1.50379, 1.18346, 1.20716}, epoch: 2020, coordinateFrame: true}
+	// Pearson & Snay (2013), in meters and in the coordinate frame convention
+	itrf2008ToNAD83 = published{
+		t: [3]float64{993.43, -1_903.31, -526.55}, d: 1.71504, r: [3]float64{25.91467, 9.42645, 11.59935},
+		tRate: [3]float64{0.79, -0.60, -1.34}, dRate: -0.10201, rRate: [3]float64{0.06667, -0.75744, -0.05133},
+		epoch: 1997, coordinateFrame: true,
+	}
+)
+
+func TestLookup(t *testing.T) {
+	canberra := transform.ToCartesian(geodesy.Point{-35.343, 149.160}, 760, ellipsoids.GRS80)
+	wettzell := transform.ToCartesian(geodesy.Point{49.144, 12.879}, 666, ellipsoids.GRS80)
+	boulder := transform.ToCartesian(geodesy.Point{39.991, -105.261}, 1_650, ellipsoids.GRS80)
+
+	tests := []struct {
+		name    string
+		station transform.Cartesian
+		from    transform.Frame
+		to      transform.Frame
+		epoch   float64
+		steps   []published // the published parameter sets along the path, applied in order
+		err     error
+	}{
+		{name: "OK/identity", station: canberra, from: transform.ITRF2014, to: transform.ITRF2014, epoch: 2020},
+		{name: "OK/ITRF2020_ITRF2014", station: canberra, from: transform.ITRF2020, to: transform.ITRF2014, epoch: 2030, steps: []published{itrf2020ToITRF2014}},
+		{name: "OK/ITRF2014_ITRF2020", station: canberra, from: transform.ITRF2014, to: transform.ITRF2020, epoch: 2000, steps: []published{itrf2020ToITRF2014.inverse()}},
+		{name: "OK/ITRF2000_ITRF2014", station: canberra, from: transform.ITRF2000, to: transform.ITRF2014, epoch: 2005, steps: []published{itrf2020ToITRF2000.inverse(), itrf2020ToITRF2014}},
+		{name: "OK/ITRF2014_ETRF2000", station: wettzell, from: transform.ITRF2014, to: transform.ETRF2000, epoch: 2025, steps: []published{itrf2014ToETRF2000}},
+		{name: "OK/ITRF2020_ETRF2000", station: wettzell, from: transform.ITRF2020, to: transform.ETRF2000, epoch: 2020, steps: []published{itrf2020ToITRF2014, itrf2014ToETRF2000}},
+		{name: "OK/ITRF2008_NAD83(2011)", station: boulder, from: transform.ITRF2008, to: transform.NAD83_2011, epoch: 2010, steps: []published{itrf2008ToNAD83}},
+		{name: "OK/ITRF2020_NAD83(2011)", station: boulder, from: transform.ITRF2020, to: transform.NAD83_2011, epoch: 2024, steps: []published{itrf2020ToITRF2008, itrf2008ToNAD83}},
+		{name: "OK/ITRF2014_GDA2020", station: canberra, from: transform.ITRF2014, to: transform.GDA2020, epoch: 2030, steps: []published{itrf2014ToGDA2020}},
+		{name: "OK/ITRF2014_GDA2020_reference_epoch", station: canberra, from: transform.ITRF2014, to: transform.GDA2020, epoch: 2020},
+		{name: "FAIL/unknown_frame", station: canberra, from: transform.ITRF2014, to: transform.Frame("WGS84(G730)"), err: transform.ErrNoTransformation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := transform.Transform(tt.station, tt.from, tt.to, tt.epoch)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			// The composed parameters must agree with the published ones applied in turn, to a tenth of a
+			// millimeter, which any sign error in a translation, rotation or rate exceeds
+			want := tt.station
+			for _, step := range tt.steps {
+				want = step.apply(want, tt.epoch)
+			}
+			assert.InDelta(t, want.X, c.X, 1e-4)
+			assert.InDelta(t, want.Y, c.Y, 1e-4)
+			assert.InDelta(t, want.Z, c.Z, 1e-4)
+
+			// Reverting the transformation must yield the original position
+			back, err := transform.Transform(c, tt.to, tt.from, tt.epoch)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.station.X, back.X, 1e-4)
+			assert.InDelta(t, tt.station.Y, back.Y, 1e-4)
+			assert.InDelta(t, tt.station.Z, back.Z, 1e-4)
+		})
+	}
+}
+
+func TestHelmertApply(t *testing.T) {
+	h := transform.Helmert{
+		T:     [3]float64{-1.4, -0.9, 1.4},
+		D:     -0.42,
+		TRate: [3]float64{0, -0.1, 0.2},
+		Epoch: 2015,
+	}
+	c := transform.Cartesian{X: 1e6, Y: 2e6, Z: 3e6}
+
+	// At the reference epoch only the static parameters apply
+	out := h.Apply(c, 2015)
+	assert.InDelta(t, 1e6-0.0014-0.00042, out.X, 1e-9)
+	assert.InDelta(t, 2e6-0.0009-0.00084, out.Y, 1e-9)
+	assert.InDelta(t, 3e6+0.0014-0.00126, out.Z, 1e-9)
+
+	// Rates are applied 10 years later
+	out = h.Apply(c, 2025)
+	assert.InDelta(t, 2e6-0.0019-0.00084, out.Y, 1e-9)
+	assert.InDelta(t, 3e6+0.0034-0.00126, out.Z, 1e-9)
+}
+
+func TestPlateMotion(t *testing.T) {
+	// A position fixed on the Australian plate, observed in ITRF2014 at successive
+	// epochs, must stay (nearly) still in GDA2020
+	station := transform.ToCartesian(geodesy.Point{-35.343, 149.160}, 760, ellipsoids.GRS80)
+	v := transform.AustralianPlate.Velocity(station)
+
+	speed := math.Sqrt(v.VX*v.VX + v.VY*v.VY + v.VZ*v.VZ)
+	assert.InDelta(t, 0.067, speed, 0.01)
+
+	moved := transform.Propagate(station, v, 2020, 2030)
+	gda, err := transform.Transform(moved, transform.ITRF2014, transform.GDA2020, 2030)
+	assert.NoError(t, err)
+	assert.InDelta(t, station.X, gda.X, 0.01)
+	assert.InDelta(t, station.Y, gda.Y, 0.01)
+	assert.InDelta(t, station.Z, gda.Z, 0.01)
+}
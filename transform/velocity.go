@@ -0,0 +1,53 @@
+package transform
+
+// Velocity represents the linear velocity of an earth-centered, earth-fixed position,
+// defined in meters per year (m/yr)
+type Velocity struct {
+	VX, VY, VZ float64
+}
+
+// EulerPole represents the angular velocity of a tectonic plate as the cartesian
+// components of its rotation vector, defined in milliarcseconds per year (mas/yr)
+type EulerPole struct {
+	WX, WY, WZ float64
+}
+
+// Plate rotation poles of the ITRF2014 plate motion model, see Altamimi et al. (2017)
+// "ITRF2014 plate motion model", Geophysical Journal International 209(3)
+var (
+	AntarcticPlate    = EulerPole{-0.248, -0.324, 0.675}
+	ArabianPlate      = EulerPole{1.154, -0.136, 1.444}
+	AustralianPlate   = EulerPole{1.510, 1.182, 1.215}
+	EurasianPlate     = EulerPole{-0.085, -0.531, 0.770}
+	IndianPlate       = EulerPole{1.154, -0.005, 1.454}
+	NazcaPlate        = EulerPole{-0.333, -1.544, 1.623}
+	NorthAmericaPlate = EulerPole{0.024, -0.694, -0.063}
+	NubianPlate       = EulerPole{0.099, -0.614, 0.733}
+	PacificPlate      = EulerPole{-0.409, 1.047, -2.169}
+	SouthAmericaPlate = EulerPole{-0.270, -0.301, -0.140}
+	SomalianPlate     = EulerPole{-0.121, -0.794, 0.884}
+)
+
+// Velocity returns the horizontal velocity induced by the rotation of the plate
+// at the position c, as the cross product of the rotation vector and c
+func (e EulerPole) Velocity(c Cartesian) Velocity {
+	ωx, ωy, ωz := e.WX*masToRadian, e.WY*masToRadian, e.WZ*masToRadian
+
+	return Velocity{
+		VX: ωy*c.Z - ωz*c.Y,
+		VY: ωz*c.X - ωx*c.Z,
+		VZ: ωx*c.Y - ωy*c.X,
+	}
+}
+
+// Propagate moves the position c with velocity v from one coordinate epoch
+// to another (both as decimal years), within the same reference frame
+func Propagate(c Cartesian, v Velocity, from, to float64) Cartesian {
+	Δt := to - from
+
+	return Cartesian{
+		X: c.X + v.VX*Δt,
+		Y: c.Y + v.VY*Δt,
+		Z: c.Z + v.VZ*Δt,
+	}
+}